@@ -31,6 +31,14 @@ rules:
   - update
   - patch
   - delete
+- apiGroups:
+  - fission.io
+  resources:
+  - functions/status
+  verbs:
+  - get
+  - update
+  - patch
 {{- end }}
 {{- define "kubewatcher-rules" }}
 rules:
@@ -29,6 +29,22 @@ rules:
   - list
   - create
   - delete
+- apiGroups:
+  - batch
+  resources:
+  - jobs
+  verbs:
+  - create
+  - delete
+  - deletecollection
+- apiGroups:
+  - ""
+  resources:
+  - persistentvolumeclaims
+  verbs:
+  - get
+  - create
+  - delete
 - apiGroups:
   - apiextensions.k8s.io
   resources:
@@ -119,6 +135,16 @@ rules:
   verbs:
   - create
 {{- end }}      
+{{- if .Values.executor.leaderElection.enabled }}
+- apiGroups:
+  - coordination.k8s.io
+  resources:
+  - leases
+  verbs:
+  - create
+  - get
+  - update
+{{- end }}
 - apiGroups:
   - apps
   resources:
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
@@ -45,6 +46,9 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 	specializePayload := flag.String("specialize-request", "", "JSON payload for specialize request")
 	secretDir := flag.String("secret-dir", "", "Path to shared secrets directory")
 	configDir := flag.String("cfgmap-dir", "", "Path to shared configmap directory")
+	packageCacheDir := flag.String("package-cache-dir", "", "Path to node-local package cache directory, disabled if empty")
+	packageCacheSize := flag.Int64("package-cache-size", 0, "Maximum size of the package cache in bytes, 0 means unlimited")
+	plainHTTPRegistries := flag.String("oci-plain-http-registries", "", "Comma separated registries of OCI archives reached over plain HTTP")
 
 	flag.Parse()
 	if flag.NArg() == 0 {
@@ -78,6 +82,15 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 	if err != nil {
 		logger.Fatal("error making fetcher", zap.Error(err))
 	}
+	if len(*packageCacheDir) > 0 {
+		err = f.EnablePackageCache(*packageCacheDir, *packageCacheSize)
+		if err != nil {
+			logger.Fatal("error enabling package cache", zap.Error(err))
+		}
+	}
+	if len(*plainHTTPRegistries) > 0 {
+		f.SetPlainHTTPRegistries(strings.Split(*plainHTTPRegistries, ","))
+	}
 
 	// do specialization in other goroutine to prevent blocking in newdeploy
 	mgr.Add(ctx, func(_ context.Context) {
@@ -89,7 +102,7 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 				logger.Fatal("error decoding specialize request", zap.Error(err))
 			}
 
-			err = f.SpecializePod(ctx, specializeReq.FetchReq, specializeReq.LoadReq)
+			err = f.SpecializeOnStartup(ctx, specializeReq)
 			if err != nil {
 				logger.Fatal("error specializing function pod", zap.Error(err))
 			}
@@ -100,6 +113,8 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 	mux := http.NewServeMux()
 	mux.HandleFunc("/fetch", f.FetchHandler)
 	mux.HandleFunc("/specialize", f.SpecializeHandler)
+	mux.HandleFunc("/specialize/phases", f.SpecializePhasesHandler)
+	mux.HandleFunc("/refresh", f.RefreshHandler)
 	mux.HandleFunc("/upload", f.UploadHandler)
 	mux.HandleFunc("/version", f.VersionHandler)
 	mux.HandleFunc("/wsevent/start", f.WsStartHandler)
@@ -125,5 +140,5 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 }
 
 func fetcherUsage() {
-	fmt.Println("Usage: fetcher [-specialize-on-startup] [-specialize-request <json>] [-secret-dir <string>] [-cfgmap-dir <string>] <shared volume path>")
+	fmt.Println("Usage: fetcher [-specialize-on-startup] [-specialize-request <json>] [-secret-dir <string>] [-cfgmap-dir <string>] [-package-cache-dir <string>] [-package-cache-size <bytes>] [-oci-plain-http-registries <registries>] <shared volume path>")
 }
@@ -41,6 +41,14 @@ const (
 
 	// ArchiveTypeUrl means the package contents are at the specified URL.
 	ArchiveTypeUrl ArchiveType = "url"
+
+	// ArchiveTypeOCI means the package contents are an OCI artifact
+	// referenced by digest, like oci://registry/repository@sha256:...
+	ArchiveTypeOCI ArchiveType = "oci"
+
+	// ArchiveTypeGit means the package source is checked out from the git
+	// repository at the specified URL.
+	ArchiveTypeGit ArchiveType = "git"
 )
 
 const (
@@ -66,6 +74,28 @@ const (
 	StrategyTypeExecution = "execution"
 )
 
+const (
+	ScalingModeCPU         ScalingMode = "cpu"
+	ScalingModeConcurrency ScalingMode = "concurrency"
+)
+
+const (
+	ConfigUpdateRecycle ConfigUpdatePolicy = "Recycle"
+	ConfigUpdateReload  ConfigUpdatePolicy = "Reload"
+)
+
+const (
+	UpdateStrategyRolling   UpdateStrategyType = "Rolling"
+	UpdateStrategyBlueGreen UpdateStrategyType = "BlueGreen"
+)
+
+const (
+	RolloutProgressing RolloutPhase = "Progressing"
+	RolloutSucceeded   RolloutPhase = "Succeeded"
+	RolloutRolledBack  RolloutPhase = "RolledBack"
+	RolloutFailed      RolloutPhase = "Failed"
+)
+
 const (
 	RuntimePodSpecPath = "/etc/fission/runtime-podspec-patch.yaml"
 	BuilderPodSpecPath = "/etc/fission/builder-podspec-patch.yaml"
@@ -78,6 +108,8 @@ const (
 	SharedVolumeConfigmaps = "configmaps"
 	PodInfoVolume          = "podinfo"
 	PodInfoMount           = "/etc/podinfo"
+	PackageCacheVolume     = "package-cache"
+	PackageCacheMount      = "/package-cache"
 )
 
 const (
@@ -133,12 +165,38 @@ const (
 	FUNCTION_RESOURCE_VERSION = "functionResourceVersion"
 	EXECUTOR_TYPE             = "executorType"
 	MANAGED                   = "managed"
+	POOL_CLASS                = "poolClass"
+	ROLLOUT_TRACK             = "rolloutTrack"
 )
 
 const (
 	ANNOTATION_SVC_HOST = "svcHost"
+	// ANNOTATION_LAST_ACCESS_TIME records when a function service was last
+	// used, so that executor replicas can share access times.
+	ANNOTATION_LAST_ACCESS_TIME = "lastAccessTime"
+	// ANNOTATION_SPECIALIZATION_TIME records when a pool pod was specialized,
+	// so that executor replicas agree on the age of the function service.
+	ANNOTATION_SPECIALIZATION_TIME = "specializationTime"
+	// ANNOTATION_ACTIVE_REQUESTS_PREFIX prefixes the annotations recording the
+	// in-flight requests of a function observed by each executor replica.
+	ANNOTATION_ACTIVE_REQUESTS_PREFIX = "activeRequests."
+	// ANNOTATION_DRAIN_DEADLINE records that a specialized pod is drained,
+	// and until when its in-flight requests are waited for.
+	ANNOTATION_DRAIN_DEADLINE = "drainDeadline"
 )
 
+// namespace annotation keys limiting the resources used by the functions of a namespace
+const (
+	ANNOTATION_QUOTA_SPECIALIZED_PODS = "quota.fission.io/specialized-pods"
+	ANNOTATION_QUOTA_REPLICAS         = "quota.fission.io/replicas"
+	ANNOTATION_QUOTA_CPU              = "quota.fission.io/cpu"
+	ANNOTATION_QUOTA_MEMORY           = "quota.fission.io/memory"
+)
+
+// ANNOTATION_IDLE_REAP_POLICY is the namespace annotation setting the idle
+// reap policy, in JSON, of the functions of the namespace which have none.
+const ANNOTATION_IDLE_REAP_POLICY = "reaper.fission.io/idle-policy"
+
 const (
 	ArchiveLiteralSizeLimit int64 = 256 * 1024
 )
@@ -170,4 +228,8 @@ const (
 
 const (
 	BuilderContainerName = "builder"
+
+	// DefaultBuilderDependencyCacheMountPath is where the dependency cache
+	// volume is mounted in the builder container by default.
+	DefaultBuilderDependencyCacheMountPath = "/build-cache"
 )
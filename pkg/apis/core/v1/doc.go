@@ -26,4 +26,5 @@ package v1
 const (
 	CRD_VERSION          = "fission.io/v1"
 	CRD_NAME_ENVIRONMENT = "Environment"
+	CRD_NAME_FUNCTION    = "Function"
 )
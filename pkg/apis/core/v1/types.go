@@ -17,9 +17,13 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	asv2 "k8s.io/api/autoscaling/v2"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -75,6 +79,10 @@ type (
 		metav1.TypeMeta   `json:",inline"`
 		metav1.ObjectMeta `json:"metadata"`
 		Spec              FunctionSpec `json:"spec"`
+
+		// Status reports the state of the function's resources.
+		//+optional
+		Status FunctionStatus `json:"status,omitzero"`
 	}
 
 	// FunctionList is a list of Functions.
@@ -221,10 +229,13 @@ type (
 	// Archive contains or references a collection of sources or
 	// binary files.
 	Archive struct {
-		// Type defines how the package is specified: literal or URL.
+		// Type defines how the package is specified: literal, URL, OCI
+		// artifact or git repository.
 		// Available value:
 		//  - literal
 		//  - url
+		//  - oci
+		//  - git
 		// +optional
 		Type ArchiveType `json:"type,omitempty"`
 
@@ -241,6 +252,40 @@ type (
 		// referenced by URL. Ignored for literals.
 		// +optional
 		Checksum Checksum `json:"checksum,omitempty"`
+
+		// PullSecret is the name of an image pull secret, in the namespace
+		// of the package, with the credentials of the registry of an OCI
+		// archive. The image pull secrets of the function and its
+		// environment are used as well.
+		// +optional
+		PullSecret string `json:"pullSecret,omitempty"`
+
+		// Git is the revision and directory of the git repository at the
+		// URL of git archives, which are only supported as package sources.
+		// +optional
+		Git *GitSource `json:"git,omitempty"`
+	}
+
+	// GitSource references the source of a package in a git repository.
+	GitSource struct {
+		// Ref is the branch, tag or commit to check out. The default branch
+		// of the repository is checked out if empty.
+		// +optional
+		Ref string `json:"ref,omitempty"`
+
+		// SubPath is the directory of the repository holding the source of
+		// the package, the root of the repository if empty.
+		// +optional
+		SubPath string `json:"subPath,omitempty"`
+
+		// AuthSecret is the name of a secret, in the namespace of the
+		// package, with the credentials of the repository: a token or
+		// password in the password key, with an optional username key, for
+		// HTTPS repositories, or a private key in the ssh-privatekey key and
+		// the host keys of the server in the known_hosts key for SSH
+		// repositories.
+		// +optional
+		AuthSecret string `json:"authSecret,omitempty"`
 	}
 
 	// EnvironmentReference is a reference to an environment.
@@ -253,12 +298,48 @@ type (
 	SecretReference struct {
 		Namespace string `json:"namespace"`
 		Name      string `json:"name"`
+
+		// Env projects the data of the secret into environment variables of the function,
+		// in addition to the files under the secrets directory.
+		// +optional
+		Env *EnvProjection `json:"env,omitempty"`
 	}
 
 	// ConfigMapReference is a reference to a kubernetes configmap.
 	ConfigMapReference struct {
 		Namespace string `json:"namespace"`
 		Name      string `json:"name"`
+
+		// Env projects the data of the configmap into environment variables of the function,
+		// in addition to the files under the configmaps directory.
+		// +optional
+		Env *EnvProjection `json:"env,omitempty"`
+	}
+
+	// EnvProjection projects the data of a secret or configmap into environment variables.
+	// Newdeploy and container functions get them as environment variables of their
+	// pods, while poolmgr functions get them in the specialize request of the environment,
+	// for runtimes which support it.
+	EnvProjection struct {
+		// Prefix is prepended to the names of the environment variables, e.g. "DB_".
+		// +optional
+		Prefix string `json:"prefix,omitempty"`
+
+		// Items maps keys to environment variables. If empty, every key is
+		// projected to an environment variable of the same name.
+		// +optional
+		Items []EnvKeyMapping `json:"items,omitempty"`
+	}
+
+	// EnvKeyMapping maps a key of a secret or configmap to an environment variable.
+	EnvKeyMapping struct {
+		// Key is the key of the secret or configmap.
+		Key string `json:"key"`
+
+		// Name is the name of the environment variable, before the prefix.
+		// Defaults to the key.
+		// +optional
+		Name string `json:"name,omitempty"`
 	}
 
 	// BuildStatus indicates the current build status of a package.
@@ -283,6 +364,11 @@ type (
 		// +optional
 		BuildCommand string `json:"buildcmd,omitempty"`
 
+		// BuildTimeout is the maximum duration in seconds of a build of the
+		// package. It overrides the build timeout of the environment builder.
+		// +optional
+		BuildTimeout int `json:"buildTimeout,omitempty"`
+
 		// In the future, we can have a debug build here too
 	}
 
@@ -299,6 +385,28 @@ type (
 		// +optional
 		BuildLog string `json:"buildlog,omitempty"` // output of the build (errors etc)
 
+		// BuildLogArchiveID is the ID of the storagesvc archive holding the full
+		// build log, when it's too long to be kept in the package. BuildLog then
+		// only holds the tail of the build log.
+		// +optional
+		BuildLogArchiveID string `json:"buildlogArchiveID,omitempty"`
+
+		// BuildCacheKey is the hash of the inputs of the build: the source
+		// archive, the build command and the builder image digest. A later
+		// build with the same key may reuse the deployment archive of this one.
+		// +optional
+		BuildCacheKey string `json:"buildCacheKey,omitempty"`
+
+		// QueuePosition is the position of the package in the build queue of
+		// its environment while it waits for a build to start, 1 being next.
+		// +optional
+		QueuePosition int `json:"queuePosition,omitempty"`
+
+		// Provenance records what went into the deployment archive of the
+		// last successful build.
+		// +optional
+		Provenance *BuildProvenance `json:"provenance,omitempty"`
+
 		// LastUpdateTimestamp will store the timestamp the package was last updated
 		// metav1.Time is a wrapper around time.Time which supports correct marshaling to YAML and JSON.
 		// https://github.com/kubernetes/apimachinery/blob/44bd77c24ef93cd3a5eb6fef64e514025d10d44e/pkg/apis/meta/v1/time.go#L26-L35
@@ -307,6 +415,59 @@ type (
 		LastUpdateTimestamp metav1.Time `json:"lastUpdateTimestamp,omitempty"`
 	}
 
+	// BuildProvenance records the inputs and the outputs of a package build.
+	BuildProvenance struct {
+		// SourceChecksum is the checksum of the source archive, if known.
+		// +optional
+		SourceChecksum Checksum `json:"sourceChecksum,omitempty"`
+
+		// SourceCommit is the commit checked out for git sources, resolved
+		// from their ref at build time.
+		// +optional
+		SourceCommit string `json:"sourceCommit,omitempty"`
+
+		// DeploymentChecksum is the checksum of the deployment archive.
+		// +optional
+		DeploymentChecksum Checksum `json:"deploymentChecksum,omitempty"`
+
+		// BuilderImage is the image of the environment builder.
+		// +optional
+		BuilderImage string `json:"builderImage,omitempty"`
+
+		// BuilderImageDigest is the digest of the builder image the build
+		// ran with, as reported by the container runtime.
+		// +optional
+		BuilderImageDigest string `json:"builderImageDigest,omitempty"`
+
+		// BuildCommand is the command the builder ran.
+		// +optional
+		BuildCommand string `json:"buildCommand,omitempty"`
+
+		// EnvironmentVersion is the interface version of the environment.
+		// +optional
+		EnvironmentVersion int `json:"environmentVersion,omitempty"`
+
+		// EnvironmentResourceVersion is the resource version of the
+		// environment the build ran with.
+		// +optional
+		EnvironmentResourceVersion string `json:"environmentResourceVersion,omitempty"`
+
+		// StartTime is when the build started.
+		// +optional
+		// +nullable
+		StartTime metav1.Time `json:"startTime,omitempty"`
+
+		// EndTime is when the build ended.
+		// +optional
+		// +nullable
+		EndTime metav1.Time `json:"endTime,omitempty"`
+
+		// SBOM references the software bill of materials emitted by the
+		// build script, if any.
+		// +optional
+		SBOM *Archive `json:"sbom,omitempty"`
+	}
+
 	// PackageRef is a reference to the package.
 	PackageRef struct {
 		// +optional
@@ -362,6 +523,20 @@ type (
 		// +nullable
 		ConfigMaps []ConfigMapReference `json:"configmaps,omitempty"`
 
+		// ConfigUpdatePolicy is what happens to function pods when a referenced secret
+		// or configmap changes.
+		//
+		// Available value:
+		//  - Recycle: function pods are replaced by new ones
+		//  - Reload: the fetcher of function pods updates the secret and configmap files
+		//    in place and calls the optional reload endpoint of the environment,
+		//    so that in-flight requests and warm state are kept. Not supported by
+		//    executor type container.
+		//
+		// This is optional. If not specified default value will be taken as Recycle.
+		// +optional
+		ConfigUpdatePolicy ConfigUpdatePolicy `json:"configUpdatePolicy,omitempty"`
+
 		// cpu and memory resources as per K8S standards
 		// This is only for newdeploy to set up resource limitation
 		// when creating deployment for a function.
@@ -384,6 +559,12 @@ type (
 		// +optional
 		IdleTimeout *int `json:"idletimeout,omitempty"`
 
+		// IdleReapPolicy refines when idle function pods are reaped, in addition to
+		// the idle timeout. If not specified, the policy set by the annotation
+		// reaper.fission.io/idle-policy of the function namespace applies, if enabled.
+		// +optional
+		IdleReapPolicy *IdleReapPolicy `json:"idleReapPolicy,omitempty"`
+
 		// Maximum number of pods to be specialized which will serve requests
 		// This is optional. If not specified default value will be taken as 500
 		// +optional
@@ -404,6 +585,25 @@ type (
 		// +optional
 		RetainPods int `json:"retainPods,omitempty"`
 
+		// MaxPodLifetime specifies the maximum age in seconds of a specialized pod.
+		// Older pods stop receiving new requests and are deleted once their in-flight
+		// requests finish, so that fresh pods take over. Only valid for executor type poolmgr.
+		// This is optional. If not specified pods are not recycled by age.
+		// +optional
+		MaxPodLifetime int `json:"maxPodLifetime,omitempty"`
+
+		// MaxPodRequests specifies the number of requests a specialized pod serves before
+		// it is recycled the same way. Only valid for executor type poolmgr.
+		// This is optional. If not specified pods are not recycled by number of requests.
+		// +optional
+		MaxPodRequests int `json:"maxPodRequests,omitempty"`
+
+		// PoolClass is the name of the pool class of the environment to specialize
+		// pods from. Only valid for executor type poolmgr.
+		// This is optional. If not specified the default pool of the environment is used.
+		// +optional
+		PoolClass string `json:"poolClass,omitempty"`
+
 		// Podspec specifies podspec to use for executor type container based functions
 		// Different arguments mentioned for container based function are populated inside a pod.
 		// +optional
@@ -481,6 +681,200 @@ type (
 		// Applicable for executor type newdeploy and container.
 		// +optional
 		Behavior *asv2.HorizontalPodAutoscalerBehavior `json:"hpaBehavior,omitempty"`
+
+		// ScalingMode is the autoscaling mode of the function deployment. Defaults to "cpu".
+		// Applicable for executor type newdeploy.
+		//
+		// Available value:
+		//  - cpu: the deployment is scaled by a HPA on hpaMetrics
+		//  - concurrency: the deployment is scaled by the executor on the in-flight requests
+		//    per pod reported by the routers
+		// +optional
+		ScalingMode ScalingMode `json:"scalingMode,omitempty"`
+
+		// ConcurrencyScaling configures the concurrency scaling mode.
+		// +optional
+		ConcurrencyScaling *ConcurrencyScaling `json:"concurrencyScaling,omitempty"`
+
+		// UpdateStrategy configures how updates of the function are rolled out to its deployment.
+		// Applicable for executor type newdeploy and container.
+		// +optional
+		UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty"`
+
+		// ScalingSchedules override the scale of the function during recurring time windows,
+		// e.g. to keep pods warm during business hours. The first active window applies.
+		// +optional
+		ScalingSchedules []ScalingSchedule `json:"scalingSchedules,omitempty"`
+	}
+
+	// ScalingSchedule overrides the scale of a function during a recurring time window.
+	ScalingSchedule struct {
+		// Name identifies the window, e.g. "business-hours".
+		Name string `json:"name"`
+
+		// Schedule is the cron spec of the window starts, e.g. "0 9 * * 1-5".
+		Schedule string `json:"schedule"`
+
+		// Duration is how long the window lasts after each start, e.g. "8h".
+		Duration metav1.Duration `json:"duration"`
+
+		// TimeZone is the IANA time zone of the schedule, e.g. "Europe/Berlin". Defaults to UTC.
+		// +optional
+		TimeZone string `json:"timeZone,omitempty"`
+
+		// MinScale overrides the minimum replicas of the function deployment during the window.
+		// Applicable for executor type newdeploy and container.
+		// +optional
+		MinScale *int `json:"minScale,omitempty"`
+
+		// MaxScale overrides the maximum replicas of the function deployment during the window.
+		// Applicable for executor type newdeploy and container.
+		// +optional
+		MaxScale *int `json:"maxScale,omitempty"`
+
+		// WarmPods overrides the number of specialized pods retained after serving
+		// requests during the window. Pods are specialized ahead of requests
+		// when the window starts.
+		// Applicable for executor type poolmgr.
+		// +optional
+		WarmPods *int `json:"warmPods,omitempty"`
+	}
+
+	// IdleReapPolicy refines when idle function pods are reaped.
+	IdleReapPolicy struct {
+		// KeepAliveWindows keep idle pods for at least their keep-alive during
+		// recurring time windows, e.g. to avoid cold starts during business hours.
+		// The longest keep-alive of the active windows applies.
+		// +optional
+		KeepAliveWindows []KeepAliveWindow `json:"keepAliveWindows,omitempty"`
+
+		// ReapOnPackageChange reaps idle specialized pods of a previous package
+		// of the function right away instead of after the idle timeout.
+		// Applicable for executor type poolmgr; newdeploy and container functions
+		// replace their pods on package changes anyway.
+		// +optional
+		ReapOnPackageChange bool `json:"reapOnPackageChange,omitempty"`
+	}
+
+	// KeepAliveWindow keeps idle function pods during a recurring time window.
+	KeepAliveWindow struct {
+		// Schedule is the cron spec of the window starts, e.g. "0 9 * * 1-5".
+		Schedule string `json:"schedule"`
+
+		// Duration is how long the window lasts after each start, e.g. "8h".
+		Duration metav1.Duration `json:"duration"`
+
+		// TimeZone is the IANA time zone of the schedule, e.g. "Europe/Berlin". Defaults to UTC.
+		// +optional
+		TimeZone string `json:"timeZone,omitempty"`
+
+		// KeepAlive is the minimum length of time function pods are idle before they
+		// are reaped during the window, e.g. "30m".
+		KeepAlive metav1.Duration `json:"keepAlive"`
+	}
+
+	// ConfigUpdatePolicy is how function pods pick up changes of the secrets
+	// and configmaps they reference.
+	ConfigUpdatePolicy string
+
+	// UpdateStrategyType is the way function updates are rolled out.
+	UpdateStrategyType string
+
+	// UpdateStrategy configures the rollout of function updates.
+	UpdateStrategy struct {
+		// Type is the rollout strategy. Defaults to "Rolling".
+		//
+		// Available value:
+		//  - Rolling: pods are replaced gradually, bounded by maxSurge and maxUnavailable
+		//  - BlueGreen: the service switches to the new pods once all of them are ready
+		// +optional
+		Type UpdateStrategyType `json:"type,omitempty"`
+
+		// MaxSurge is the number or percentage of pods created above the desired
+		// replicas during a rolling update. Defaults to 20%.
+		// +optional
+		MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+		// MaxUnavailable is the number or percentage of pods which can be unavailable
+		// during a rolling update. Defaults to 20%.
+		// +optional
+		MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+		// RollbackTimeout is the number of seconds the new pods have to become ready.
+		// The previous version of the function is restored if they don't. Defaults to 0,
+		// which disables the rollback.
+		// +optional
+		RollbackTimeout int `json:"rollbackTimeout,omitempty"`
+	}
+
+	// FunctionStatus is the observed state of a function.
+	FunctionStatus struct {
+		// Rollout is the result of the last update rollout of the function deployment.
+		// +optional
+		Rollout *RolloutStatus `json:"rollout,omitempty"`
+	}
+
+	// RolloutPhase is the state of a rollout.
+	RolloutPhase string
+
+	// RolloutStatus describes the rollout of a function update.
+	RolloutStatus struct {
+		// Phase is the state of the rollout.
+		//
+		// Available value:
+		//  - Progressing
+		//  - Succeeded
+		//  - RolledBack
+		//  - Failed
+		Phase RolloutPhase `json:"phase"`
+
+		// FunctionResourceVersion is the resource version of the function being rolled out.
+		// +optional
+		FunctionResourceVersion string `json:"functionResourceVersion,omitempty"`
+
+		// Message explains failed rollouts.
+		// +optional
+		Message string `json:"message,omitempty"`
+
+		// LastUpdateTimestamp is the time the phase changed.
+		// +optional
+		LastUpdateTimestamp metav1.Time `json:"lastUpdateTimestamp,omitempty"`
+	}
+
+	// ScalingMode is the autoscaling mode of a function
+	ScalingMode string
+
+	// ConcurrencyScaling configures scaling a function on the number of in-flight requests per pod.
+	ConcurrencyScaling struct {
+		// TargetConcurrency is the number of in-flight requests each pod should serve. Defaults to 10.
+		// +optional
+		TargetConcurrency int `json:"targetConcurrency,omitempty"`
+
+		// StableWindowSeconds is the period the in-flight requests are averaged over
+		// to make scaling decisions. Defaults to 60.
+		// +optional
+		StableWindowSeconds int `json:"stableWindowSeconds,omitempty"`
+
+		// PanicWindowSeconds is the shorter period the in-flight requests are averaged
+		// over to detect bursts of traffic. Defaults to 6.
+		// +optional
+		PanicWindowSeconds int `json:"panicWindowSeconds,omitempty"`
+
+		// PanicThresholdPercent is the ratio of desired to ready pods over the panic window,
+		// in percent, above which the function scales up immediately and doesn't scale down
+		// until the burst has ended for a stable window. Defaults to 200.
+		// +optional
+		PanicThresholdPercent int `json:"panicThresholdPercent,omitempty"`
+
+		// ScaleUpStabilizationSeconds is the period over which the lowest recommendation
+		// is used when scaling up. Defaults to 0.
+		// +optional
+		ScaleUpStabilizationSeconds *int32 `json:"scaleUpStabilizationSeconds,omitempty"`
+
+		// ScaleDownStabilizationSeconds is the period over which the highest recommendation
+		// is used when scaling down. Defaults to 60.
+		// +optional
+		ScaleDownStabilizationSeconds *int32 `json:"scaleDownStabilizationSeconds,omitempty"`
 	}
 
 	// FunctionReferenceType refers to type of Function
@@ -580,6 +974,49 @@ type (
 
 		// PodSpec will store the spec of the pod that will be applied to the pod created for the builder
 		PodSpec *apiv1.PodSpec `json:"podspec,omitempty"`
+
+		// (Optional) DependencyCache mounts a persistent volume into the builder
+		// to keep the caches of package managers, like pip, npm or maven,
+		// between builds.
+		// +optional
+		DependencyCache *BuilderDependencyCache `json:"dependencyCache,omitempty"`
+
+		// (Optional) BuildTimeout is the maximum duration in seconds of a build
+		// of the packages of the environment, defaults to the build timeout of
+		// buildermgr.
+		// +optional
+		BuildTimeout int `json:"buildTimeout,omitempty"`
+
+		// (Optional) MaxParallelBuilds is the maximum number of builds of the
+		// packages of the environment running at the same time, defaults to
+		// the limit of buildermgr. The other builds wait in a queue.
+		// +optional
+		MaxParallelBuilds int `json:"maxParallelBuilds,omitempty"`
+	}
+
+	// BuilderDependencyCache is the persistent volume keeping the caches of
+	// package managers between builds of an environment.
+	BuilderDependencyCache struct {
+		// Size of the volume. It's only used when the volume is created.
+		Size resource.Quantity `json:"size"`
+
+		// (Optional) StorageClassName of the volume, defaults to the default
+		// storage class of the cluster.
+		// +optional
+		StorageClassName *string `json:"storageClassName,omitempty"`
+
+		// (Optional) MountPath of the volume in the builder container,
+		// defaults to /build-cache.
+		// +optional
+		MountPath string `json:"mountPath,omitempty"`
+
+		// (Optional) AccessMode of the volume, defaults to ReadWriteMany when
+		// the builds run in jobs, since concurrent build jobs of the
+		// environment may run on different nodes, and to ReadWriteOnce
+		// otherwise.
+		// +optional
+		// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany;ReadWriteOncePod
+		AccessMode apiv1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
 	}
 
 	// EnvironmentSpec contains with builder, runtime and some other related environment settings.
@@ -630,6 +1067,14 @@ type (
 		// +optional
 		Poolsize int `json:"poolsize,omitempty"`
 
+		// PoolClasses are additional named pools of the environment, functions
+		// choose one of them with their PoolClass. Each class has its own pool
+		// of pre-warmed pods next to the default pool.
+		// (Optional) defaults to no additional pools.
+		// +optional
+		// +nullable
+		PoolClasses []PoolClass `json:"poolClasses,omitempty"`
+
 		// The grace time for pod to perform connection draining before termination. The unit is in seconds.
 		// (Optional) defaults to 360 seconds
 		// +optional
@@ -646,6 +1091,32 @@ type (
 		// +optional
 		ImagePullSecret string `json:"imagepullsecret"`
 	}
+
+	// PoolClass is a named pool of pre-warmed pods of an environment, with its
+	// own resources, scheduling constraints and pool size.
+	PoolClass struct {
+		// Name of the pool class, referenced by functions.
+		Name string `json:"name"`
+
+		// The request and limit CPU/MEM resource setting of pods in the pool,
+		// replacing the resources of the environment.
+		// +optional
+		Resources apiv1.ResourceRequirements `json:"resources"`
+
+		// The pool size of the class.
+		// +optional
+		Poolsize int `json:"poolsize,omitempty"`
+
+		// NodeSelector is added to the node selector of pods in the pool.
+		// +optional
+		NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+		// Tolerations are added to the tolerations of pods in the pool.
+		// +optional
+		// +nullable
+		Tolerations []apiv1.Toleration `json:"tolerations,omitempty"`
+	}
+
 	// AllowedFunctionsPerContainer defaults to 'single'. Related to Fission Workflows
 	AllowedFunctionsPerContainer string
 
@@ -896,9 +1367,106 @@ func (fn Function) GetRetainPods() int {
 	return fn.Spec.RetainPods
 }
 
+func (fn Function) GetMaxPodLifetime() int {
+	return fn.Spec.MaxPodLifetime
+}
+
+func (fn Function) GetMaxPodRequests() int {
+	return fn.Spec.MaxPodRequests
+}
+
+// IsActive returns true if t falls into a window of the scaling schedule.
+// Invalid schedules are never active.
+func (s ScalingSchedule) IsActive(t time.Time) bool {
+	return ParseWindowSchedule(s.Schedule, s.TimeZone).IsActive(s.Duration.Duration, t)
+}
+
+// IsActive returns true if t falls into a window of the keep-alive window.
+// Invalid schedules are never active.
+func (w KeepAliveWindow) IsActive(t time.Time) bool {
+	return ParseWindowSchedule(w.Schedule, w.TimeZone).IsActive(w.Duration.Duration, t)
+}
+
+// KeepAliveAt returns the longest keep-alive of the windows active at t,
+// or 0 if there is none.
+func (p IdleReapPolicy) KeepAliveAt(t time.Time) time.Duration {
+	var keepAlive time.Duration
+	for _, w := range p.KeepAliveWindows {
+		if w.IsActive(t) {
+			keepAlive = max(keepAlive, w.KeepAlive.Duration)
+		}
+	}
+	return keepAlive
+}
+
+// ActiveScalingSchedule returns the first scaling schedule whose window
+// contains t, or nil if there is none.
+func (es ExecutionStrategy) ActiveScalingSchedule(t time.Time) *ScalingSchedule {
+	for i := range es.ScalingSchedules {
+		if es.ScalingSchedules[i].IsActive(t) {
+			return &es.ScalingSchedules[i]
+		}
+	}
+	return nil
+}
+
+// ScaledAt returns the execution strategy with the min and max scale of the
+// scaling schedule active at t.
+func (es ExecutionStrategy) ScaledAt(t time.Time) ExecutionStrategy {
+	s := es.ActiveScalingSchedule(t)
+	if s == nil {
+		return es
+	}
+	if s.MinScale != nil {
+		es.MinScale = *s.MinScale
+	}
+	if s.MaxScale != nil {
+		es.MaxScale = *s.MaxScale
+	}
+	return es
+}
+
+// GetPoolClass returns the pool class with the name, or nil if the
+// environment has no such class.
+func (env Environment) GetPoolClass(name string) *PoolClass {
+	for i := range env.Spec.PoolClasses {
+		if env.Spec.PoolClasses[i].Name == name {
+			return &env.Spec.PoolClasses[i]
+		}
+	}
+	return nil
+}
+
 func (fn Function) GetRequestPerPod() int {
 	if fn.Spec.RequestsPerPod == 0 {
 		return DefaultRequestsPerPod
 	}
 	return fn.Spec.RequestsPerPod
 }
+
+// EnvName returns the name of the environment variable of the key mapping,
+// prefix included.
+func (p EnvProjection) EnvName(m EnvKeyMapping) string {
+	if len(m.Name) > 0 {
+		return p.Prefix + m.Name
+	}
+	return p.Prefix + m.Key
+}
+
+// Project returns the environment variables projected from the data of a
+// secret or configmap. Keys missing from the data are skipped.
+func (p EnvProjection) Project(data map[string][]byte) map[string]string {
+	env := make(map[string]string)
+	if len(p.Items) == 0 {
+		for key, val := range data {
+			env[p.Prefix+key] = string(val)
+		}
+		return env
+	}
+	for _, item := range p.Items {
+		if val, ok := data[item.Key]; ok {
+			env[p.EnvName(item)] = string(val)
+		}
+	}
+	return env
+}
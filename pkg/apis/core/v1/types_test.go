@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func businessHours(minScale int) ScalingSchedule {
+	return ScalingSchedule{
+		Name:     "business-hours",
+		Schedule: "0 9 * * 1-5",
+		Duration: metav1.Duration{Duration: 8 * time.Hour},
+		TimeZone: "Europe/Berlin",
+		MinScale: &minScale,
+	}
+}
+
+func TestScalingScheduleIsActive(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	s := businessHours(3)
+
+	for _, tc := range []struct {
+		name   string
+		t      time.Time
+		active bool
+	}{
+		{"window start", time.Date(2026, 10, 19, 9, 0, 0, 0, berlin), true},
+		{"during window", time.Date(2026, 10, 21, 13, 30, 0, 0, berlin), true},
+		{"during window in UTC", time.Date(2026, 10, 21, 14, 30, 0, 0, time.UTC), true},
+		{"window end", time.Date(2026, 10, 19, 17, 0, 0, 0, berlin), false},
+		{"overnight", time.Date(2026, 10, 20, 3, 0, 0, 0, berlin), false},
+		{"weekend", time.Date(2026, 10, 24, 10, 0, 0, 0, berlin), false},
+	} {
+		if active := s.IsActive(tc.t); active != tc.active {
+			t.Errorf("%s: expected active %v, got %v", tc.name, tc.active, active)
+		}
+	}
+
+	s.Schedule = "not a cron spec"
+	if s.IsActive(time.Date(2026, 10, 19, 10, 0, 0, 0, berlin)) {
+		t.Error("expected invalid schedule to never be active")
+	}
+}
+
+func TestParseWindowSchedule(t *testing.T) {
+	ws := ParseWindowSchedule("0 9 * * 1-5", "")
+	if ws.sched == nil || ws.loc != time.UTC {
+		t.Fatal("expected schedule in UTC")
+	}
+	if ws := ParseWindowSchedule("0 9 * * 1-5", "Not/AZone"); ws.sched != nil {
+		t.Error("expected invalid time zone to invalidate the schedule")
+	}
+	if !ws.IsActive(time.Hour, time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)) {
+		t.Error("expected schedule to be active")
+	}
+	invalid := ParseWindowSchedule("not a cron spec", "")
+	if invalid.sched != nil {
+		t.Error("expected invalid schedule")
+	}
+	if invalid.IsActive(time.Hour, time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)) {
+		t.Error("expected invalid schedule to never be active")
+	}
+}
+
+func TestExecutionStrategyScaledAt(t *testing.T) {
+	es := ExecutionStrategy{
+		ExecutorType:     ExecutorTypeNewdeploy,
+		MinScale:         0,
+		MaxScale:         5,
+		ScalingSchedules: []ScalingSchedule{businessHours(3)},
+	}
+
+	scaled := es.ScaledAt(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC))
+	if scaled.MinScale != 3 || scaled.MaxScale != 5 {
+		t.Errorf("expected scale 3-5 during the window, got %d-%d", scaled.MinScale, scaled.MaxScale)
+	}
+	scaled = es.ScaledAt(time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC))
+	if scaled.MinScale != 0 || scaled.MaxScale != 5 {
+		t.Errorf("expected scale 0-5 outside the window, got %d-%d", scaled.MinScale, scaled.MaxScale)
+	}
+}
+
+func TestScalingScheduleValidate(t *testing.T) {
+	es := ExecutionStrategy{
+		ExecutorType:     ExecutorTypeNewdeploy,
+		MaxScale:         5,
+		ScalingSchedules: []ScalingSchedule{businessHours(3)},
+	}
+	if err := es.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+
+	es.ScalingSchedules = []ScalingSchedule{businessHours(6)}
+	if err := es.Validate(); err == nil {
+		t.Error("expected error for minimum scale above the maximum scale")
+	}
+
+	warmPods := 2
+	s := businessHours(1)
+	s.WarmPods = &warmPods
+	es.ScalingSchedules = []ScalingSchedule{s}
+	if err := es.Validate(); err == nil {
+		t.Error("expected error for warm pods with executor type newdeploy")
+	}
+
+	for _, s := range []ScalingSchedule{
+		{Name: "bad-cron", Schedule: "every day", Duration: metav1.Duration{Duration: time.Hour}},
+		{Name: "no-duration", Schedule: "0 9 * * *"},
+		{Name: "bad-tz", Schedule: "0 9 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+	} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("expected validation error for schedule %s", s.Name)
+		}
+	}
+}
+
+func TestIdleReapPolicyKeepAliveAt(t *testing.T) {
+	p := IdleReapPolicy{
+		KeepAliveWindows: []KeepAliveWindow{
+			{
+				Schedule:  "0 9 * * 1-5",
+				Duration:  metav1.Duration{Duration: 8 * time.Hour},
+				KeepAlive: metav1.Duration{Duration: 10 * time.Minute},
+			},
+			{
+				Schedule:  "0 12 * * *",
+				Duration:  metav1.Duration{Duration: time.Hour},
+				KeepAlive: metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		t         time.Time
+		keepAlive time.Duration
+	}{
+		{"business hours", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), 10 * time.Minute},
+		{"overlapping windows", time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC), 30 * time.Minute},
+		{"weekend noon", time.Date(2026, 10, 24, 12, 30, 0, 0, time.UTC), 30 * time.Minute},
+		{"no active window", time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC), 0},
+	} {
+		if keepAlive := p.KeepAliveAt(tc.t); keepAlive != tc.keepAlive {
+			t.Errorf("%s: expected keep-alive %v, got %v", tc.name, tc.keepAlive, keepAlive)
+		}
+	}
+}
+
+func TestConfigUpdatePolicyValidate(t *testing.T) {
+	spec := FunctionSpec{ConfigUpdatePolicy: ConfigUpdateReload}
+	if err := spec.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+
+	spec.InvokeStrategy = InvokeStrategy{
+		StrategyType:      StrategyTypeExecution,
+		ExecutionStrategy: ExecutionStrategy{ExecutorType: ExecutorTypeContainer, MaxScale: 1},
+	}
+	spec.PodSpec = &apiv1.PodSpec{}
+	spec.ConfigUpdatePolicy = ConfigUpdateRecycle
+	if err := spec.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+	spec.ConfigUpdatePolicy = ConfigUpdateReload
+	if err := spec.Validate(); err == nil {
+		t.Error("expected error for reloading secrets and configmaps of executor type container")
+	}
+
+	spec = FunctionSpec{ConfigUpdatePolicy: "Restart"}
+	if err := spec.Validate(); err == nil {
+		t.Error("expected error for unknown config update policy")
+	}
+}
+
+func TestEnvProjectionProject(t *testing.T) {
+	data := map[string][]byte{"user": []byte("alice"), "password": []byte("secret")}
+
+	env := EnvProjection{Prefix: "DB_"}.Project(data)
+	if len(env) != 2 || env["DB_user"] != "alice" || env["DB_password"] != "secret" {
+		t.Errorf("unexpected projection of all keys: %v", env)
+	}
+
+	env = EnvProjection{Items: []EnvKeyMapping{{Key: "password", Name: "DB_PASSWORD"}, {Key: "missing"}}}.Project(data)
+	if len(env) != 1 || env["DB_PASSWORD"] != "secret" {
+		t.Errorf("unexpected projection of mapped keys: %v", env)
+	}
+
+	if err := (EnvProjection{Prefix: "1BAD"}).Validate("Env"); err == nil {
+		t.Error("expected error for invalid prefix")
+	}
+	if err := (EnvProjection{Items: []EnvKeyMapping{{Key: ""}}}).Validate("Env"); err == nil {
+		t.Error("expected error for empty key")
+	}
+	if err := (EnvProjection{Prefix: "DB_", Items: []EnvKeyMapping{{Key: "user.name", Name: "USER"}}}).Validate("Env"); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestArchiveValidateOCI(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("0a", 32)
+	archive := Archive{Type: ArchiveTypeOCI, URL: "oci://registry:5000/functions/hello@" + digest, PullSecret: "registry-creds"}
+	if err := archive.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+
+	for _, url := range []string{
+		"oci://registry:5000/functions/hello:v1",
+		"oci://registry:5000/functions/hello@sha256:0a",
+		"https://registry:5000/functions/hello@" + digest,
+	} {
+		archive.URL = url
+		if err := archive.Validate(); err == nil {
+			t.Errorf("expected error for OCI archive not referenced by digest: %s", url)
+		}
+	}
+
+	archive = Archive{Type: ArchiveTypeUrl, URL: "http://example.com/hello.zip", PullSecret: "registry-creds"}
+	if err := archive.Validate(); err == nil {
+		t.Error("expected error for pull secret of URL archive")
+	}
+}
+
+func TestArchiveValidateGit(t *testing.T) {
+	spec := PackageSpec{
+		Environment: EnvironmentReference{Name: "go", Namespace: "default"},
+		Source: Archive{
+			Type: ArchiveTypeGit,
+			URL:  "https://github.com/fission/examples.git",
+			Git:  &GitSource{Ref: "v1.2.0", SubPath: "go/hello", AuthSecret: "github-token"},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+
+	for name, git := range map[string]GitSource{
+		"ref":         {Ref: "main..dev"},
+		"subpath":     {SubPath: "../outside"},
+		"abs subpath": {SubPath: "/etc"},
+		"secret":      {AuthSecret: "Not_A_Name"},
+	} {
+		invalid := spec.DeepCopy()
+		invalid.Source.Git = &git
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected error for invalid %s", name)
+		}
+	}
+
+	invalid := spec.DeepCopy()
+	invalid.Source.URL = ""
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected error for git archive without URL")
+	}
+	invalid = spec.DeepCopy()
+	invalid.Deployment = invalid.Source
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected error for git deployment archive")
+	}
+	invalid = spec.DeepCopy()
+	invalid.Source.Type = ArchiveTypeUrl
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected error for git source of URL archive")
+	}
+}
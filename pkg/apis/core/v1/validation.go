@@ -20,13 +20,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/robfig/cron/v3"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/fission/fission/pkg/mqtrigger/validator"
@@ -168,12 +173,46 @@ func ValidateKubeReference(refName string, name string, namespace string) error
 	return result.ErrorOrNil()
 }
 
+var cronSpecParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 func IsValidCronSpec(spec string) error {
-	cronSpecParser := cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
 	_, err := cronSpecParser.Parse(spec)
 	return err
 }
 
+// WindowSchedule is the parsed cron schedule and time zone of a time window.
+// +k8s:deepcopy-gen=false
+type WindowSchedule struct {
+	// sched is nil if the schedule or time zone is invalid
+	sched cron.Schedule
+	loc   *time.Location
+}
+
+// ParseWindowSchedule parses the cron schedule and time zone of a window.
+// The time zone defaults to UTC.
+func ParseWindowSchedule(schedule, timeZone string) WindowSchedule {
+	ws := WindowSchedule{loc: time.UTC}
+	sched, err := cronSpecParser.Parse(schedule)
+	if err == nil && timeZone != "" {
+		ws.loc, err = time.LoadLocation(timeZone)
+	}
+	if err == nil {
+		ws.sched = sched
+	}
+	return ws
+}
+
+// IsActive returns true if t falls into one of the windows of length d
+// starting at the times of the schedule. Invalid schedules are never active.
+func (ws WindowSchedule) IsActive(d time.Duration, t time.Time) bool {
+	if ws.sched == nil {
+		return false
+	}
+	// the only window which can contain t is the first one starting after t - duration
+	start := ws.sched.Next(t.Add(-d).In(ws.loc))
+	return !start.After(t)
+}
+
 /* Resource validation function */
 
 func (checksum Checksum) Validate() error {
@@ -188,17 +227,45 @@ func (checksum Checksum) Validate() error {
 	return result.ErrorOrNil()
 }
 
+// ociArchiveURLRegexp matches references to OCI artifacts pinned by digest.
+var ociArchiveURLRegexp = regexp.MustCompile(`^oci://[^/@\s]+/[^@\s]+@sha256:[a-f0-9]{64}$`)
+
 func (archive Archive) Validate() error {
 	result := &multierror.Error{}
 
 	if len(archive.Type) > 0 {
 		switch archive.Type {
 		case ArchiveTypeLiteral, ArchiveTypeUrl: // no op
+		case ArchiveTypeOCI:
+			if !ociArchiveURLRegexp.MatchString(archive.URL) {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.URL", archive.URL,
+					"OCI archives must be referenced by digest, like oci://registry/repository@sha256:<digest>"))
+			}
+		case ArchiveTypeGit:
+			if len(archive.URL) == 0 {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.URL", archive.URL, "git archives must have the URL of the repository"))
+			}
 		default:
 			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "Archive.Type", archive.Type, "not a valid archive type"))
 		}
 	}
 
+	if len(archive.PullSecret) > 0 {
+		if archive.Type != ArchiveTypeOCI {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.PullSecret", archive.PullSecret, "pull secrets are only used by OCI archives"))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(archive.PullSecret) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.PullSecret", archive.PullSecret, msg))
+		}
+	}
+
+	if archive.Git != nil {
+		if archive.Type != ArchiveTypeGit {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.Git", archive.Git, "only used by git archives"))
+		}
+		result = multierror.Append(result, archive.Git.Validate())
+	}
+
 	if archive.Checksum != (Checksum{}) {
 		result = multierror.Append(result, archive.Checksum.Validate())
 	}
@@ -206,6 +273,24 @@ func (archive Archive) Validate() error {
 	return result.ErrorOrNil()
 }
 
+func (git GitSource) Validate() error {
+	result := &multierror.Error{}
+
+	if strings.ContainsAny(git.Ref, " \t\n:~^?*[\\") || strings.Contains(git.Ref, "..") {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "GitSource.Ref", git.Ref, "not a valid git ref"))
+	}
+	if len(git.SubPath) > 0 && (path.IsAbs(git.SubPath) || !filepath.IsLocal(git.SubPath)) {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "GitSource.SubPath", git.SubPath, "must be a relative path within the repository"))
+	}
+	if len(git.AuthSecret) > 0 {
+		for _, msg := range validation.IsDNS1123Subdomain(git.AuthSecret) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "GitSource.AuthSecret", git.AuthSecret, msg))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 func (ref EnvironmentReference) Validate() error {
 	result := &multierror.Error{}
 	result = multierror.Append(result, ValidateKubeReference("EnvironmentReference", ref.Name, ref.Namespace))
@@ -215,12 +300,40 @@ func (ref EnvironmentReference) Validate() error {
 func (ref SecretReference) Validate() error {
 	result := &multierror.Error{}
 	result = multierror.Append(result, ValidateKubeReference("SecretReference", ref.Name, ref.Namespace))
+	if ref.Env != nil {
+		result = multierror.Append(result, ref.Env.Validate("SecretReference.Env"))
+	}
 	return result.ErrorOrNil()
 }
 
 func (ref ConfigMapReference) Validate() error {
 	result := &multierror.Error{}
 	result = multierror.Append(result, ValidateKubeReference("ConfigMapReference", ref.Name, ref.Namespace))
+	if ref.Env != nil {
+		result = multierror.Append(result, ref.Env.Validate("ConfigMapReference.Env"))
+	}
+	return result.ErrorOrNil()
+}
+
+func (p EnvProjection) Validate(field string) error {
+	result := &multierror.Error{}
+
+	if len(p.Prefix) > 0 {
+		for _, msg := range validation.IsEnvVarName(p.Prefix) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, field+".Prefix", p.Prefix, msg))
+		}
+	}
+	for _, item := range p.Items {
+		if len(item.Key) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, field+".Items.Key", item.Key, "key must not be empty"))
+			continue
+		}
+		name := p.EnvName(item)
+		for _, msg := range validation.IsEnvVarName(name) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, field+".Items.Name", name, msg))
+		}
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -230,10 +343,17 @@ func (spec PackageSpec) Validate() error {
 	result = multierror.Append(result, spec.Environment.Validate())
 
 	for _, r := range []Archive{spec.Source, spec.Deployment} {
-		if len(r.URL) > 0 || len(r.Literal) > 0 {
+		if len(r.URL) > 0 || len(r.Literal) > 0 || r.Type == ArchiveTypeOCI || r.Type == ArchiveTypeGit {
 			result = multierror.Append(result, r.Validate())
 		}
 	}
+	if spec.Deployment.Type == ArchiveTypeGit {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PackageSpec.Deployment.Type", spec.Deployment.Type, "git archives are only supported as package sources"))
+	}
+
+	if spec.BuildTimeout < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PackageSpec.BuildTimeout", spec.BuildTimeout, "must not be negative"))
+	}
 
 	return result.ErrorOrNil()
 }
@@ -280,6 +400,16 @@ func (spec FunctionSpec) Validate() error {
 		result = multierror.Append(result, c.Validate())
 	}
 
+	switch spec.ConfigUpdatePolicy {
+	case "", ConfigUpdateRecycle: // no op
+	case ConfigUpdateReload:
+		if spec.InvokeStrategy.ExecutionStrategy.ExecutorType == ExecutorTypeContainer {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.ConfigUpdatePolicy", spec.ConfigUpdatePolicy, "reloading secrets and configmaps is not supported by executor type container"))
+		}
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "FunctionSpec.ConfigUpdatePolicy", spec.ConfigUpdatePolicy, "not a valid config update policy"))
+	}
+
 	if !reflect.DeepEqual(spec.InvokeStrategy, InvokeStrategy{}) {
 		result = multierror.Append(result, spec.InvokeStrategy.Validate())
 	}
@@ -288,6 +418,28 @@ func (spec FunctionSpec) Validate() error {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "FunctionSpec.PodSpec", "", "executor type container requires a pod spec"))
 	}
 
+	if spec.MaxPodLifetime < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.MaxPodLifetime", spec.MaxPodLifetime, "max pod lifetime must be greater than or equal to 0"))
+	}
+	if spec.MaxPodRequests < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.MaxPodRequests", spec.MaxPodRequests, "max pod requests must be greater than or equal to 0"))
+	}
+	if (spec.MaxPodLifetime > 0 || spec.MaxPodRequests > 0) &&
+		spec.InvokeStrategy.ExecutionStrategy.ExecutorType != "" && spec.InvokeStrategy.ExecutionStrategy.ExecutorType != ExecutorTypePoolmgr {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.MaxPodLifetime", spec.InvokeStrategy.ExecutionStrategy.ExecutorType, "recycling specialized pods is only supported by executor type poolmgr"))
+	}
+
+	if spec.IdleReapPolicy != nil {
+		result = multierror.Append(result, spec.IdleReapPolicy.Validate())
+	}
+
+	if len(spec.PoolClass) > 0 {
+		result = multierror.Append(result, ValidateKubeName("FunctionSpec.PoolClass", spec.PoolClass))
+		if spec.InvokeStrategy.ExecutionStrategy.ExecutorType != "" && spec.InvokeStrategy.ExecutionStrategy.ExecutorType != ExecutorTypePoolmgr {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.PoolClass", spec.InvokeStrategy.ExecutionStrategy.ExecutorType, "pool classes are only supported by executor type poolmgr"))
+		}
+	}
+
 	// TODO Add below validation warning
 	/*if spec.FunctionTimeout <= 0 {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionTimeout value", spec.FunctionTimeout, "not a valid value. Should always be more than 0"))
@@ -336,15 +488,182 @@ func (es ExecutionStrategy) Validate() error {
 			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.TargetCPUPercent", es.TargetCPUPercent, "TargetCPUPercent must be a value between 1 - 100"))
 		}
 
+		switch es.ScalingMode {
+		case "", ScalingModeCPU: // no op
+		case ScalingModeConcurrency:
+			if es.ExecutorType != ExecutorTypeNewdeploy {
+				result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ExecutionStrategy.ScalingMode", es.ScalingMode, "concurrency scaling is only supported by executor type newdeploy"))
+			}
+		default:
+			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ExecutionStrategy.ScalingMode", es.ScalingMode, "not a valid scaling mode"))
+		}
+
+		if es.ConcurrencyScaling != nil {
+			result = multierror.Append(result, es.ConcurrencyScaling.Validate())
+		}
+
+		if es.UpdateStrategy != nil {
+			result = multierror.Append(result, es.UpdateStrategy.Validate())
+		}
+
+		for _, s := range es.ScalingSchedules {
+			if s.WarmPods != nil {
+				result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ScalingSchedule.WarmPods", *s.WarmPods, "warm pods are only supported by executor type poolmgr"))
+			}
+			minScale, maxScale := es.MinScale, es.MaxScale
+			if s.MinScale != nil {
+				minScale = *s.MinScale
+			}
+			if s.MaxScale != nil {
+				maxScale = *s.MaxScale
+			}
+			if maxScale < minScale {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.MaxScale", maxScale, fmt.Sprintf("maximum scale of window %s must be greater than or equal to its minimum scale", s.Name)))
+			}
+		}
+
 		// TODO Add validation warning
 		// if es.SpecializationTimeout < 120 {
 		//	result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.SpecializationTimeout", es.SpecializationTimeout, "SpecializationTimeout must be a value equal to or greater than 120"))
 		//}
+	} else {
+		for _, s := range es.ScalingSchedules {
+			if s.MinScale != nil || s.MaxScale != nil {
+				result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ScalingSchedule", s.Name, "min and max scale are only supported by executor type newdeploy and container"))
+			}
+		}
+	}
+
+	names := make(map[string]struct{}, len(es.ScalingSchedules))
+	for _, s := range es.ScalingSchedules {
+		if _, ok := names[s.Name]; ok {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.Name", s.Name, "duplicate scaling schedule name"))
+		}
+		names[s.Name] = struct{}{}
+		result = multierror.Append(result, s.Validate())
 	}
 
 	return result.ErrorOrNil()
 }
 
+func (p IdleReapPolicy) Validate() error {
+	var result *multierror.Error
+
+	for _, w := range p.KeepAliveWindows {
+		if err := IsValidCronSpec(w.Schedule); err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KeepAliveWindow.Schedule", w.Schedule, "not a valid cron spec"))
+		}
+		if w.Duration.Duration <= 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KeepAliveWindow.Duration", w.Duration.Duration, "duration must be greater than 0"))
+		}
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KeepAliveWindow.TimeZone", w.TimeZone, "not a valid time zone"))
+		}
+		if w.KeepAlive.Duration <= 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "KeepAliveWindow.KeepAlive", w.KeepAlive.Duration, "keep-alive must be greater than 0"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (s ScalingSchedule) Validate() error {
+	result := &multierror.Error{}
+
+	if len(s.Name) == 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.Name", s.Name, "name must not be empty"))
+	}
+	if err := IsValidCronSpec(s.Schedule); err != nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.Schedule", s.Schedule, "not a valid cron spec"))
+	}
+	if s.Duration.Duration <= 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.Duration", s.Duration.Duration, "duration must be greater than 0"))
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.TimeZone", s.TimeZone, "not a valid time zone"))
+	}
+	if s.MinScale != nil && *s.MinScale < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.MinScale", *s.MinScale, "minimum scale must be greater than or equal to 0"))
+	}
+	if s.MaxScale != nil && *s.MaxScale <= 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.MaxScale", *s.MaxScale, "maximum scale must be greater than 0"))
+	}
+	if s.WarmPods != nil && *s.WarmPods < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ScalingSchedule.WarmPods", *s.WarmPods, "warm pods must be greater than or equal to 0"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (cs ConcurrencyScaling) Validate() error {
+	result := &multierror.Error{}
+
+	if cs.TargetConcurrency < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ConcurrencyScaling.TargetConcurrency", cs.TargetConcurrency, "target concurrency must be greater than or equal to 0"))
+	}
+	if cs.StableWindowSeconds < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ConcurrencyScaling.StableWindowSeconds", cs.StableWindowSeconds, "stable window must be greater than or equal to 0"))
+	}
+	if cs.PanicWindowSeconds < 0 || (cs.StableWindowSeconds > 0 && cs.PanicWindowSeconds > cs.StableWindowSeconds) {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ConcurrencyScaling.PanicWindowSeconds", cs.PanicWindowSeconds, "panic window must be between 0 and the stable window"))
+	}
+	if cs.PanicThresholdPercent != 0 && cs.PanicThresholdPercent <= 100 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ConcurrencyScaling.PanicThresholdPercent", cs.PanicThresholdPercent, "panic threshold must be greater than 100"))
+	}
+	if cs.ScaleUpStabilizationSeconds != nil && *cs.ScaleUpStabilizationSeconds < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ConcurrencyScaling.ScaleUpStabilizationSeconds", *cs.ScaleUpStabilizationSeconds, "stabilization window must be greater than or equal to 0"))
+	}
+	if cs.ScaleDownStabilizationSeconds != nil && *cs.ScaleDownStabilizationSeconds < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ConcurrencyScaling.ScaleDownStabilizationSeconds", *cs.ScaleDownStabilizationSeconds, "stabilization window must be greater than or equal to 0"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (us UpdateStrategy) Validate() error {
+	result := &multierror.Error{}
+
+	switch us.Type {
+	case "", UpdateStrategyRolling, UpdateStrategyBlueGreen: // no op
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "UpdateStrategy.Type", us.Type, "not a valid update strategy"))
+	}
+
+	surge, err := validateIntOrPercent(us.MaxSurge)
+	if err != nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "UpdateStrategy.MaxSurge", us.MaxSurge.String(), err.Error()))
+	}
+	unavailable, err := validateIntOrPercent(us.MaxUnavailable)
+	if err != nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "UpdateStrategy.MaxUnavailable", us.MaxUnavailable.String(), err.Error()))
+	}
+	if us.MaxSurge != nil && us.MaxUnavailable != nil && surge == 0 && unavailable == 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "UpdateStrategy.MaxUnavailable", us.MaxUnavailable.String(), "may not be 0 when maxSurge is 0"))
+	}
+
+	if us.RollbackTimeout < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "UpdateStrategy.RollbackTimeout", us.RollbackTimeout, "rollback timeout must be greater than or equal to 0"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validateIntOrPercent checks the value is a non-negative number or a percentage
+// and returns its value scaled to 100.
+func validateIntOrPercent(v *intstr.IntOrString) (int, error) {
+	if v == nil {
+		return 0, nil
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(v, 100, true)
+	if err != nil {
+		return 0, err
+	}
+	if scaled < 0 {
+		return 0, errors.New("must be greater than or equal to 0")
+	}
+	return scaled, nil
+}
+
 func (ref FunctionReference) Validate() error {
 	result := &multierror.Error{}
 
@@ -377,8 +696,31 @@ func (runtime Runtime) Validate() error {
 }
 
 func (builder Builder) Validate() error {
-	// do nothing for now
-	return nil
+	result := &multierror.Error{}
+
+	if builder.BuildTimeout < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Builder.BuildTimeout", builder.BuildTimeout, "must not be negative"))
+	}
+
+	if builder.MaxParallelBuilds < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Builder.MaxParallelBuilds", builder.MaxParallelBuilds, "must not be negative"))
+	}
+
+	if builder.DependencyCache != nil {
+		if builder.DependencyCache.Size.Sign() <= 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Builder.DependencyCache.Size", builder.DependencyCache.Size.String(), "must be greater than 0"))
+		}
+		if mountPath := builder.DependencyCache.MountPath; len(mountPath) > 0 && !path.IsAbs(mountPath) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Builder.DependencyCache.MountPath", mountPath, "must be an absolute path"))
+		}
+		switch accessMode := builder.DependencyCache.AccessMode; accessMode {
+		case "", apiv1.ReadWriteOnce, apiv1.ReadWriteMany, apiv1.ReadWriteOncePod:
+		default:
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Builder.DependencyCache.AccessMode", accessMode, "must be one of ReadWriteOnce, ReadWriteMany or ReadWriteOncePod"))
+		}
+	}
+
+	return result.ErrorOrNil()
 }
 
 func (spec EnvironmentSpec) Validate() error {
@@ -410,6 +752,40 @@ func (spec EnvironmentSpec) Validate() error {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "EnvironmentSpec.TerminationGracePeriod", spec.TerminationGracePeriod, "must be greater than or equal to 0"))
 	}
 
+	classes := make(map[string]struct{})
+	for _, class := range spec.PoolClasses {
+		result = multierror.Append(result, class.Validate())
+		if _, ok := classes[class.Name]; ok {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "EnvironmentSpec.PoolClasses", class.Name, "duplicate pool class name"))
+		}
+		classes[class.Name] = struct{}{}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (class PoolClass) Validate() error {
+	result := &multierror.Error{}
+
+	// the class name is part of the pool deployment name
+	if len(class.Name) > 15 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PoolClass.Name", class.Name, "must be no more than 15 characters"))
+	}
+	result = multierror.Append(result, ValidateKubeName("PoolClass.Name", class.Name))
+
+	if class.Poolsize < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PoolClass.Poolsize", class.Poolsize, "must be greater than or equal to 0"))
+	}
+
+	for k, v := range class.NodeSelector {
+		if e := validation.IsQualifiedName(k); len(e) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PoolClass.NodeSelector.Key", k, e...))
+		}
+		if e := validation.IsValidLabelValue(v); len(e) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PoolClass.NodeSelector.Value", v, e...))
+		}
+	}
+
 	return result.ErrorOrNil()
 }
 
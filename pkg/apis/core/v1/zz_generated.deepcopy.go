@@ -24,6 +24,7 @@ import (
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -35,6 +36,11 @@ func (in *Archive) DeepCopyInto(out *Archive) {
 		copy(*out, *in)
 	}
 	out.Checksum = in.Checksum
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Archive.
@@ -62,6 +68,30 @@ func (in *AuthLogin) DeepCopy() *AuthLogin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildProvenance) DeepCopyInto(out *BuildProvenance) {
+	*out = *in
+	out.SourceChecksum = in.SourceChecksum
+	out.DeploymentChecksum = in.DeploymentChecksum
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.SBOM != nil {
+		in, out := &in.SBOM, &out.SBOM
+		*out = new(Archive)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildProvenance.
+func (in *BuildProvenance) DeepCopy() *BuildProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Builder) DeepCopyInto(out *Builder) {
 	*out = *in
@@ -75,6 +105,11 @@ func (in *Builder) DeepCopyInto(out *Builder) {
 		*out = new(corev1.PodSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependencyCache != nil {
+		in, out := &in.DependencyCache, &out.DependencyCache
+		*out = new(BuilderDependencyCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Builder.
@@ -87,6 +122,27 @@ func (in *Builder) DeepCopy() *Builder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuilderDependencyCache) DeepCopyInto(out *BuilderDependencyCache) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderDependencyCache.
+func (in *BuilderDependencyCache) DeepCopy() *BuilderDependencyCache {
+	if in == nil {
+		return nil
+	}
+	out := new(BuilderDependencyCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CanaryConfig) DeepCopyInto(out *CanaryConfig) {
 	*out = *in
@@ -191,9 +247,39 @@ func (in *Checksum) DeepCopy() *Checksum {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyScaling) DeepCopyInto(out *ConcurrencyScaling) {
+	*out = *in
+	if in.ScaleUpStabilizationSeconds != nil {
+		in, out := &in.ScaleUpStabilizationSeconds, &out.ScaleUpStabilizationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownStabilizationSeconds != nil {
+		in, out := &in.ScaleDownStabilizationSeconds, &out.ScaleDownStabilizationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyScaling.
+func (in *ConcurrencyScaling) DeepCopy() *ConcurrencyScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
 	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(EnvProjection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
@@ -206,6 +292,41 @@ func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvKeyMapping) DeepCopyInto(out *EnvKeyMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvKeyMapping.
+func (in *EnvKeyMapping) DeepCopy() *EnvKeyMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvKeyMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvProjection) DeepCopyInto(out *EnvProjection) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EnvKeyMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvProjection.
+func (in *EnvProjection) DeepCopy() *EnvProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Environment) DeepCopyInto(out *Environment) {
 	*out = *in
@@ -285,6 +406,13 @@ func (in *EnvironmentSpec) DeepCopyInto(out *EnvironmentSpec) {
 	in.Runtime.DeepCopyInto(&out.Runtime)
 	in.Builder.DeepCopyInto(&out.Builder)
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.PoolClasses != nil {
+		in, out := &in.PoolClasses, &out.PoolClasses
+		*out = make([]PoolClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSpec.
@@ -312,6 +440,23 @@ func (in *ExecutionStrategy) DeepCopyInto(out *ExecutionStrategy) {
 		*out = new(v2.HorizontalPodAutoscalerBehavior)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConcurrencyScaling != nil {
+		in, out := &in.ConcurrencyScaling, &out.ConcurrencyScaling
+		*out = new(ConcurrencyScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScalingSchedules != nil {
+		in, out := &in.ScalingSchedules, &out.ScalingSchedules
+		*out = make([]ScalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionStrategy.
@@ -330,6 +475,7 @@ func (in *Function) DeepCopyInto(out *Function) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Function.
@@ -428,12 +574,16 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]SecretReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ConfigMaps != nil {
 		in, out := &in.ConfigMaps, &out.ConfigMaps
 		*out = make([]ConfigMapReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	in.InvokeStrategy.DeepCopyInto(&out.InvokeStrategy)
@@ -442,6 +592,11 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.IdleReapPolicy != nil {
+		in, out := &in.IdleReapPolicy, &out.IdleReapPolicy
+		*out = new(IdleReapPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodSpec != nil {
 		in, out := &in.PodSpec, &out.PodSpec
 		*out = new(corev1.PodSpec)
@@ -459,6 +614,41 @@ func (in *FunctionSpec) DeepCopy() *FunctionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionStatus) DeepCopyInto(out *FunctionStatus) {
+	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionStatus.
+func (in *FunctionStatus) DeepCopy() *FunctionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSource) DeepCopyInto(out *GitSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSource.
+func (in *GitSource) DeepCopy() *GitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
 	*out = *in
@@ -544,6 +734,26 @@ func (in *HTTPTriggerSpec) DeepCopy() *HTTPTriggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleReapPolicy) DeepCopyInto(out *IdleReapPolicy) {
+	*out = *in
+	if in.KeepAliveWindows != nil {
+		in, out := &in.KeepAliveWindows, &out.KeepAliveWindows
+		*out = make([]KeepAliveWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleReapPolicy.
+func (in *IdleReapPolicy) DeepCopy() *IdleReapPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleReapPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
 	*out = *in
@@ -582,6 +792,23 @@ func (in *InvokeStrategy) DeepCopy() *InvokeStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeepAliveWindow) DeepCopyInto(out *KeepAliveWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	out.KeepAlive = in.KeepAlive
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeepAliveWindow.
+func (in *KeepAliveWindow) DeepCopy() *KeepAliveWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(KeepAliveWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesWatchTrigger) DeepCopyInto(out *KubernetesWatchTrigger) {
 	*out = *in
@@ -864,6 +1091,11 @@ func (in *PackageSpec) DeepCopy() *PackageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
 	*out = *in
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(BuildProvenance)
+		(*in).DeepCopyInto(*out)
+	}
 	in.LastUpdateTimestamp.DeepCopyInto(&out.LastUpdateTimestamp)
 }
 
@@ -877,6 +1109,52 @@ func (in *PackageStatus) DeepCopy() *PackageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolClass) DeepCopyInto(out *PoolClass) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolClass.
+func (in *PoolClass) DeepCopy() *PoolClass {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	in.LastUpdateTimestamp.DeepCopyInto(&out.LastUpdateTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouterAuthToken) DeepCopyInto(out *RouterAuthToken) {
 	*out = *in
@@ -917,9 +1195,45 @@ func (in *Runtime) DeepCopy() *Runtime {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingSchedule) DeepCopyInto(out *ScalingSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MinScale != nil {
+		in, out := &in.MinScale, &out.MinScale
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxScale != nil {
+		in, out := &in.MaxScale, &out.MaxScale
+		*out = new(int)
+		**out = **in
+	}
+	if in.WarmPods != nil {
+		in, out := &in.WarmPods, &out.WarmPods
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingSchedule.
+func (in *ScalingSchedule) DeepCopy() *ScalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(EnvProjection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
@@ -1006,6 +1320,31 @@ func (in *TimeTriggerSpec) DeepCopy() *TimeTriggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateStrategy) DeepCopyInto(out *UpdateStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategy.
+func (in *UpdateStrategy) DeepCopy() *UpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidationError) DeepCopyInto(out *ValidationError) {
 	*out = *in
@@ -11,11 +11,13 @@ package v1
 // Those methods can be generated by using hack/update-swagger-docs.sh
 // AUTO-GENERATED FUNCTIONS START HERE
 var map_Archive = map[string]string{
-	"":         "Archive contains or references a collection of sources or binary files.",
-	"type":     "Type defines how the package is specified: literal or URL. Available value:\n - literal\n - url",
-	"literal":  "Literal contents of the package. Can be used for encoding packages below TODO (256 KB?) size.",
-	"url":      "URL references a package.",
-	"checksum": "Checksum ensures the integrity of packages referenced by URL. Ignored for literals.",
+	"":           "Archive contains or references a collection of sources or binary files.",
+	"type":       "Type defines how the package is specified: literal, URL, OCI artifact or git repository. Available value:\n - literal\n - url\n - oci\n - git",
+	"literal":    "Literal contents of the package. Can be used for encoding packages below TODO (256 KB?) size.",
+	"url":        "URL references a package.",
+	"checksum":   "Checksum ensures the integrity of packages referenced by URL. Ignored for literals.",
+	"pullSecret": "PullSecret is the name of an image pull secret, in the namespace of the package, with the credentials of the registry of an OCI archive. The image pull secrets of the function and its environment are used as well.",
+	"git":        "Git is the revision and directory of the git repository at the URL of git archives, which are only supported as package sources.",
 }
 
 func (Archive) SwaggerDoc() map[string]string {
@@ -30,18 +32,52 @@ func (AuthLogin) SwaggerDoc() map[string]string {
 	return map_AuthLogin
 }
 
+var map_BuildProvenance = map[string]string{
+	"":                           "BuildProvenance records the inputs and the outputs of a package build.",
+	"sourceChecksum":             "SourceChecksum is the checksum of the source archive, if known.",
+	"sourceCommit":               "SourceCommit is the commit checked out for git sources, resolved from their ref at build time.",
+	"deploymentChecksum":         "DeploymentChecksum is the checksum of the deployment archive.",
+	"builderImage":               "BuilderImage is the image of the environment builder.",
+	"builderImageDigest":         "BuilderImageDigest is the digest of the builder image the build ran with, as reported by the container runtime.",
+	"buildCommand":               "BuildCommand is the command the builder ran.",
+	"environmentVersion":         "EnvironmentVersion is the interface version of the environment.",
+	"environmentResourceVersion": "EnvironmentResourceVersion is the resource version of the environment the build ran with.",
+	"startTime":                  "StartTime is when the build started.",
+	"endTime":                    "EndTime is when the build ended.",
+	"sbom":                       "SBOM references the software bill of materials emitted by the build script, if any.",
+}
+
+func (BuildProvenance) SwaggerDoc() map[string]string {
+	return map_BuildProvenance
+}
+
 var map_Builder = map[string]string{
-	"":          "Builder is the setting for environment builder.",
-	"image":     "Image for containing the language compilation environment.",
-	"command":   "(Optional) Default build command to run for this build environment.",
-	"container": "(Optional) Container allows the modification of the deployed builder container using the Kubernetes Container spec. Fission overrides the following fields: - Name - Image; set to the Builder.Image - Command; set to the Builder.Command - TerminationMessagePath - ImagePullPolicy - ReadinessProbe",
-	"podspec":   "PodSpec will store the spec of the pod that will be applied to the pod created for the builder",
+	"":                  "Builder is the setting for environment builder.",
+	"image":             "Image for containing the language compilation environment.",
+	"command":           "(Optional) Default build command to run for this build environment.",
+	"container":         "(Optional) Container allows the modification of the deployed builder container using the Kubernetes Container spec. Fission overrides the following fields: - Name - Image; set to the Builder.Image - Command; set to the Builder.Command - TerminationMessagePath - ImagePullPolicy - ReadinessProbe",
+	"podspec":           "PodSpec will store the spec of the pod that will be applied to the pod created for the builder",
+	"dependencyCache":   "(Optional) DependencyCache mounts a persistent volume into the builder to keep the caches of package managers, like pip, npm or maven, between builds.",
+	"buildTimeout":      "(Optional) BuildTimeout is the maximum duration in seconds of a build of the packages of the environment, defaults to the build timeout of buildermgr.",
+	"maxParallelBuilds": "(Optional) MaxParallelBuilds is the maximum number of builds of the packages of the environment running at the same time, defaults to the limit of buildermgr. The other builds wait in a queue.",
 }
 
 func (Builder) SwaggerDoc() map[string]string {
 	return map_Builder
 }
 
+var map_BuilderDependencyCache = map[string]string{
+	"":                 "BuilderDependencyCache is the persistent volume keeping the caches of package managers between builds of an environment.",
+	"size":             "Size of the volume. It's only used when the volume is created.",
+	"storageClassName": "(Optional) StorageClassName of the volume, defaults to the default storage class of the cluster.",
+	"mountPath":        "(Optional) MountPath of the volume in the builder container, defaults to /build-cache.",
+	"accessMode":       "(Optional) AccessMode of the volume, defaults to ReadWriteMany when the builds run in jobs, since concurrent build jobs of the environment may run on different nodes, and to ReadWriteOnce otherwise.",
+}
+
+func (BuilderDependencyCache) SwaggerDoc() map[string]string {
+	return map_BuilderDependencyCache
+}
+
 var map_CanaryConfig = map[string]string{
 	"": "CanaryConfig is for canary deployment of two functions.",
 }
@@ -88,14 +124,49 @@ func (Checksum) SwaggerDoc() map[string]string {
 	return map_Checksum
 }
 
+var map_ConcurrencyScaling = map[string]string{
+	"":                              "ConcurrencyScaling configures scaling a function on the number of in-flight requests per pod.",
+	"targetConcurrency":             "TargetConcurrency is the number of in-flight requests each pod should serve. Defaults to 10.",
+	"stableWindowSeconds":           "StableWindowSeconds is the period the in-flight requests are averaged over to make scaling decisions. Defaults to 60.",
+	"panicWindowSeconds":            "PanicWindowSeconds is the shorter period the in-flight requests are averaged over to detect bursts of traffic. Defaults to 6.",
+	"panicThresholdPercent":         "PanicThresholdPercent is the ratio of desired to ready pods over the panic window, in percent, above which the function scales up immediately and doesn't scale down until the burst has ended for a stable window. Defaults to 200.",
+	"scaleUpStabilizationSeconds":   "ScaleUpStabilizationSeconds is the period over which the lowest recommendation is used when scaling up. Defaults to 0.",
+	"scaleDownStabilizationSeconds": "ScaleDownStabilizationSeconds is the period over which the highest recommendation is used when scaling down. Defaults to 60.",
+}
+
+func (ConcurrencyScaling) SwaggerDoc() map[string]string {
+	return map_ConcurrencyScaling
+}
+
 var map_ConfigMapReference = map[string]string{
-	"": "ConfigMapReference is a reference to a kubernetes configmap.",
+	"":    "ConfigMapReference is a reference to a kubernetes configmap.",
+	"env": "Env projects the data of the configmap into environment variables of the function, in addition to the files under the configmaps directory.",
 }
 
 func (ConfigMapReference) SwaggerDoc() map[string]string {
 	return map_ConfigMapReference
 }
 
+var map_EnvKeyMapping = map[string]string{
+	"":     "EnvKeyMapping maps a key of a secret or configmap to an environment variable.",
+	"key":  "Key is the key of the secret or configmap.",
+	"name": "Name is the name of the environment variable, before the prefix. Defaults to the key.",
+}
+
+func (EnvKeyMapping) SwaggerDoc() map[string]string {
+	return map_EnvKeyMapping
+}
+
+var map_EnvProjection = map[string]string{
+	"":       "EnvProjection projects the data of a secret or configmap into environment variables. Newdeploy and container functions get them as environment variables of their pods, while poolmgr functions get them in the specialize request of the environment, for runtimes which support it.",
+	"prefix": "Prefix is prepended to the names of the environment variables, e.g. \"DB_\".",
+	"items":  "Items maps keys to environment variables. If empty, every key is projected to an environment variable of the same name.",
+}
+
+func (EnvProjection) SwaggerDoc() map[string]string {
+	return map_EnvProjection
+}
+
 var map_Environment = map[string]string{
 	"": "Environment is environment for building and running user functions.",
 }
@@ -129,6 +200,7 @@ var map_EnvironmentSpec = map[string]string{
 	"allowAccessToExternalNetwork": "Istio default blocks all egress traffic for safety. To enable accessibility of external network for builder/function pod, set to 'true'. (Optional) defaults to 'false'",
 	"resources":                    "The request and limit CPU/MEM resource setting for poolmanager to set up pods in the pre-warm pool. (Optional) defaults to no limitation.",
 	"poolsize":                     "The initial pool size for environment",
+	"poolClasses":                  "PoolClasses are additional named pools of the environment, functions choose one of them with their PoolClass. Each class has its own pool of pre-warmed pods next to the default pool. (Optional) defaults to no additional pools.",
 	"terminationGracePeriod":       "The grace time for pod to perform connection draining before termination. The unit is in seconds. (Optional) defaults to 360 seconds",
 	"keeparchive":                  "KeepArchive is used by fetcher to determine if the extracted archive or unarchived file should be placed, which is then used by specialize handler. (This is mainly for the JVM environment because .jar is one kind of zip archive.)",
 	"imagepullsecret":              "ImagePullSecret is the secret for Kubernetes to pull an image from a private registry.",
@@ -147,6 +219,10 @@ var map_ExecutionStrategy = map[string]string{
 	"SpecializationTimeout": "This is the timeout setting for executor to wait for pod specialization.",
 	"hpaMetrics":            "hpaMetrics is the list of metrics used to determine the desired replica count of the Deployment created for the function. Applicable for executor type newdeploy and container.",
 	"hpaBehavior":           "hpaBehavior is the behavior of HPA when scaling in up/down direction. Applicable for executor type newdeploy and container.",
+	"scalingMode":           "ScalingMode is the autoscaling mode of the function deployment. Defaults to \"cpu\". Applicable for executor type newdeploy.\n\nAvailable value:\n - cpu: the deployment is scaled by a HPA on hpaMetrics\n - concurrency: the deployment is scaled by the executor on the in-flight requests\n   per pod reported by the routers",
+	"concurrencyScaling":    "ConcurrencyScaling configures the concurrency scaling mode.",
+	"updateStrategy":        "UpdateStrategy configures how updates of the function are rolled out to its deployment. Applicable for executor type newdeploy and container.",
+	"scalingSchedules":      "ScalingSchedules override the scale of the function during recurring time windows, e.g. to keep pods warm during business hours. The first active window applies.",
 }
 
 func (ExecutionStrategy) SwaggerDoc() map[string]string {
@@ -154,7 +230,8 @@ func (ExecutionStrategy) SwaggerDoc() map[string]string {
 }
 
 var map_Function = map[string]string{
-	"": "Function is function runs within environment runtime with given package and secrets/configmaps.",
+	"":       "Function is function runs within environment runtime with given package and secrets/configmaps.",
+	"status": "Status reports the state of the function's resources.",
 }
 
 func (Function) SwaggerDoc() map[string]string {
@@ -191,26 +268,51 @@ func (FunctionReference) SwaggerDoc() map[string]string {
 }
 
 var map_FunctionSpec = map[string]string{
-	"":                "FunctionSpec describes the contents of the function.",
-	"environment":     "Environment is the build and runtime environment that this function is associated with. An Environment with this name should exist, otherwise the function cannot be invoked.",
-	"package":         "Reference to a package containing deployment and optionally the source.",
-	"secrets":         "Reference to a list of secrets.",
-	"configmaps":      "Reference to a list of configmaps.",
-	"resources":       "cpu and memory resources as per K8S standards This is only for newdeploy to set up resource limitation when creating deployment for a function.",
-	"InvokeStrategy":  "InvokeStrategy is a set of controls which affect how function executes",
-	"functionTimeout": "FunctionTimeout provides a maximum amount of duration within which a request for a particular function execution should be complete. This is optional. If not specified default value will be taken as 60s",
-	"idletimeout":     "IdleTimeout specifies the length of time that a function is idle before the function pod(s) are eligible for deletion. If no traffic to the function is detected within the idle timeout, the executor will then recycle the function pod(s) to release resources.",
-	"concurrency":     "Maximum number of pods to be specialized which will serve requests This is optional. If not specified default value will be taken as 500",
-	"requestsPerPod":  "RequestsPerPod indicates the maximum number of concurrent requests that can be served by a specialized pod This is optional. If not specified default value will be taken as 1",
-	"onceOnly":        "OnceOnly specifies if specialized pod will serve exactly one request in its lifetime and would be garbage collected after serving that one request This is optional. If not specified default value will be taken as false",
-	"retainPods":      "RetainPods specifies the number of specialized pods that should be retained after serving requests This is optional. If not specified default value will be taken as 0",
-	"podspec":         "Podspec specifies podspec to use for executor type container based functions Different arguments mentioned for container based function are populated inside a pod.",
+	"":                   "FunctionSpec describes the contents of the function.",
+	"environment":        "Environment is the build and runtime environment that this function is associated with. An Environment with this name should exist, otherwise the function cannot be invoked.",
+	"package":            "Reference to a package containing deployment and optionally the source.",
+	"secrets":            "Reference to a list of secrets.",
+	"configmaps":         "Reference to a list of configmaps.",
+	"configUpdatePolicy": "ConfigUpdatePolicy is what happens to function pods when a referenced secret or configmap changes.\n\nAvailable value:\n - Recycle: function pods are replaced by new ones\n - Reload: the fetcher of function pods updates the secret and configmap files\n   in place and calls the optional reload endpoint of the environment,\n   so that in-flight requests and warm state are kept. Not supported by\n   executor type container.\n\nThis is optional. If not specified default value will be taken as Recycle.",
+	"resources":          "cpu and memory resources as per K8S standards This is only for newdeploy to set up resource limitation when creating deployment for a function.",
+	"InvokeStrategy":     "InvokeStrategy is a set of controls which affect how function executes",
+	"functionTimeout":    "FunctionTimeout provides a maximum amount of duration within which a request for a particular function execution should be complete. This is optional. If not specified default value will be taken as 60s",
+	"idletimeout":        "IdleTimeout specifies the length of time that a function is idle before the function pod(s) are eligible for deletion. If no traffic to the function is detected within the idle timeout, the executor will then recycle the function pod(s) to release resources.",
+	"idleReapPolicy":     "IdleReapPolicy refines when idle function pods are reaped, in addition to the idle timeout. If not specified, the policy set by the annotation reaper.fission.io/idle-policy of the function namespace applies, if enabled.",
+	"concurrency":        "Maximum number of pods to be specialized which will serve requests This is optional. If not specified default value will be taken as 500",
+	"requestsPerPod":     "RequestsPerPod indicates the maximum number of concurrent requests that can be served by a specialized pod This is optional. If not specified default value will be taken as 1",
+	"onceOnly":           "OnceOnly specifies if specialized pod will serve exactly one request in its lifetime and would be garbage collected after serving that one request This is optional. If not specified default value will be taken as false",
+	"retainPods":         "RetainPods specifies the number of specialized pods that should be retained after serving requests This is optional. If not specified default value will be taken as 0",
+	"maxPodLifetime":     "MaxPodLifetime specifies the maximum age in seconds of a specialized pod. Older pods stop receiving new requests and are deleted once their in-flight requests finish, so that fresh pods take over. Only valid for executor type poolmgr. This is optional. If not specified pods are not recycled by age.",
+	"maxPodRequests":     "MaxPodRequests specifies the number of requests a specialized pod serves before it is recycled the same way. Only valid for executor type poolmgr. This is optional. If not specified pods are not recycled by number of requests.",
+	"poolClass":          "PoolClass is the name of the pool class of the environment to specialize pods from. Only valid for executor type poolmgr. This is optional. If not specified the default pool of the environment is used.",
+	"podspec":            "Podspec specifies podspec to use for executor type container based functions Different arguments mentioned for container based function are populated inside a pod.",
 }
 
 func (FunctionSpec) SwaggerDoc() map[string]string {
 	return map_FunctionSpec
 }
 
+var map_FunctionStatus = map[string]string{
+	"":        "FunctionStatus is the observed state of a function.",
+	"rollout": "Rollout is the result of the last update rollout of the function deployment.",
+}
+
+func (FunctionStatus) SwaggerDoc() map[string]string {
+	return map_FunctionStatus
+}
+
+var map_GitSource = map[string]string{
+	"":           "GitSource references the source of a package in a git repository.",
+	"ref":        "Ref is the branch, tag or commit to check out. The default branch of the repository is checked out if empty.",
+	"subPath":    "SubPath is the directory of the repository holding the source of the package, the root of the repository if empty.",
+	"authSecret": "AuthSecret is the name of a secret, in the namespace of the package, with the credentials of the repository: a token or password in the password key, with an optional username key, for HTTPS repositories, or a private key in the ssh-privatekey key and the host keys of the server in the known_hosts key for SSH repositories.",
+}
+
+func (GitSource) SwaggerDoc() map[string]string {
+	return map_GitSource
+}
+
 var map_HTTPTrigger = map[string]string{
 	"": "HTTPTrigger is the trigger invokes user functions when receiving HTTP requests.",
 }
@@ -244,6 +346,16 @@ func (HTTPTriggerSpec) SwaggerDoc() map[string]string {
 	return map_HTTPTriggerSpec
 }
 
+var map_IdleReapPolicy = map[string]string{
+	"":                    "IdleReapPolicy refines when idle function pods are reaped.",
+	"keepAliveWindows":    "KeepAliveWindows keep idle pods for at least their keep-alive during recurring time windows, e.g. to avoid cold starts during business hours. The longest keep-alive of the active windows applies.",
+	"reapOnPackageChange": "ReapOnPackageChange reaps idle specialized pods of a previous package of the function right away instead of after the idle timeout. Applicable for executor type poolmgr; newdeploy and container functions replace their pods on package changes anyway.",
+}
+
+func (IdleReapPolicy) SwaggerDoc() map[string]string {
+	return map_IdleReapPolicy
+}
+
 var map_IngressConfig = map[string]string{
 	"":            "IngressConfig is for router to set up Ingress.",
 	"annotations": "Annotations will be added to metadata when creating Ingress.",
@@ -266,6 +378,18 @@ func (InvokeStrategy) SwaggerDoc() map[string]string {
 	return map_InvokeStrategy
 }
 
+var map_KeepAliveWindow = map[string]string{
+	"":          "KeepAliveWindow keeps idle function pods during a recurring time window.",
+	"schedule":  "Schedule is the cron spec of the window starts, e.g. \"0 9 * * 1-5\".",
+	"duration":  "Duration is how long the window lasts after each start, e.g. \"8h\".",
+	"timeZone":  "TimeZone is the IANA time zone of the schedule, e.g. \"Europe/Berlin\". Defaults to UTC.",
+	"keepAlive": "KeepAlive is the minimum length of time function pods are idle before they are reaped during the window, e.g. \"30m\".",
+}
+
+func (KeepAliveWindow) SwaggerDoc() map[string]string {
+	return map_KeepAliveWindow
+}
+
 var map_KubernetesWatchTrigger = map[string]string{
 	"": "KubernetesWatchTrigger watches kubernetes resource events and invokes functions.",
 }
@@ -359,11 +483,12 @@ func (PackageRef) SwaggerDoc() map[string]string {
 }
 
 var map_PackageSpec = map[string]string{
-	"":            "PackageSpec includes source/deploy archives and the reference of environment to build the package.",
-	"environment": "Environment is a reference to the environment for building source archive.",
-	"source":      "Source is the archive contains source code and dependencies file. If the package status is in PENDING state, builder manager will then notify builder to compile source and save the result as deployable archive.",
-	"deployment":  "Deployment is the deployable archive that environment runtime used to run user function.",
-	"buildcmd":    "BuildCommand is a custom build command that builder used to build the source archive.",
+	"":             "PackageSpec includes source/deploy archives and the reference of environment to build the package.",
+	"environment":  "Environment is a reference to the environment for building source archive.",
+	"source":       "Source is the archive contains source code and dependencies file. If the package status is in PENDING state, builder manager will then notify builder to compile source and save the result as deployable archive.",
+	"deployment":   "Deployment is the deployable archive that environment runtime used to run user function.",
+	"buildcmd":     "BuildCommand is a custom build command that builder used to build the source archive.",
+	"buildTimeout": "BuildTimeout is the maximum duration in seconds of a build of the package. It overrides the build timeout of the environment builder.",
 }
 
 func (PackageSpec) SwaggerDoc() map[string]string {
@@ -374,6 +499,10 @@ var map_PackageStatus = map[string]string{
 	"":                    "PackageStatus contains the build status of a package also the build log for examination.",
 	"buildstatus":         "BuildStatus is the package build status.",
 	"buildlog":            "BuildLog stores build log during the compilation.",
+	"buildlogArchiveID":   "BuildLogArchiveID is the ID of the storagesvc archive holding the full build log, when it's too long to be kept in the package. BuildLog then only holds the tail of the build log.",
+	"buildCacheKey":       "BuildCacheKey is the hash of the inputs of the build: the source archive, the build command and the builder image digest. A later build with the same key may reuse the deployment archive of this one.",
+	"queuePosition":       "QueuePosition is the position of the package in the build queue of its environment while it waits for a build to start, 1 being next.",
+	"provenance":          "Provenance records what went into the deployment archive of the last successful build.",
 	"lastUpdateTimestamp": "LastUpdateTimestamp will store the timestamp the package was last updated metav1.Time is a wrapper around time.Time which supports correct marshaling to YAML and JSON. https://github.com/kubernetes/apimachinery/blob/44bd77c24ef93cd3a5eb6fef64e514025d10d44e/pkg/apis/meta/v1/time.go#L26-L35",
 }
 
@@ -381,6 +510,31 @@ func (PackageStatus) SwaggerDoc() map[string]string {
 	return map_PackageStatus
 }
 
+var map_PoolClass = map[string]string{
+	"":             "PoolClass is a named pool of pre-warmed pods of an environment, with its own resources, scheduling constraints and pool size.",
+	"name":         "Name of the pool class, referenced by functions.",
+	"resources":    "The request and limit CPU/MEM resource setting of pods in the pool, replacing the resources of the environment.",
+	"poolsize":     "The pool size of the class.",
+	"nodeSelector": "NodeSelector is added to the node selector of pods in the pool.",
+	"tolerations":  "Tolerations are added to the tolerations of pods in the pool.",
+}
+
+func (PoolClass) SwaggerDoc() map[string]string {
+	return map_PoolClass
+}
+
+var map_RolloutStatus = map[string]string{
+	"":                        "RolloutStatus describes the rollout of a function update.",
+	"phase":                   "Phase is the state of the rollout.\n\nAvailable value:\n - Progressing\n - Succeeded\n - RolledBack\n - Failed",
+	"functionResourceVersion": "FunctionResourceVersion is the resource version of the function being rolled out.",
+	"message":                 "Message explains failed rollouts.",
+	"lastUpdateTimestamp":     "LastUpdateTimestamp is the time the phase changed.",
+}
+
+func (RolloutStatus) SwaggerDoc() map[string]string {
+	return map_RolloutStatus
+}
+
 var map_RouterAuthToken = map[string]string{
 	"": "RouterAuthToken defines the authorization token for accessing router",
 }
@@ -400,8 +554,24 @@ func (Runtime) SwaggerDoc() map[string]string {
 	return map_Runtime
 }
 
+var map_ScalingSchedule = map[string]string{
+	"":         "ScalingSchedule overrides the scale of a function during a recurring time window.",
+	"name":     "Name identifies the window, e.g. \"business-hours\".",
+	"schedule": "Schedule is the cron spec of the window starts, e.g. \"0 9 * * 1-5\".",
+	"duration": "Duration is how long the window lasts after each start, e.g. \"8h\".",
+	"timeZone": "TimeZone is the IANA time zone of the schedule, e.g. \"Europe/Berlin\". Defaults to UTC.",
+	"minScale": "MinScale overrides the minimum replicas of the function deployment during the window. Applicable for executor type newdeploy and container.",
+	"maxScale": "MaxScale overrides the maximum replicas of the function deployment during the window. Applicable for executor type newdeploy and container.",
+	"warmPods": "WarmPods overrides the number of specialized pods retained after serving requests during the window. Pods are specialized ahead of requests when the window starts. Applicable for executor type poolmgr.",
+}
+
+func (ScalingSchedule) SwaggerDoc() map[string]string {
+	return map_ScalingSchedule
+}
+
 var map_SecretReference = map[string]string{
-	"": "SecretReference is a reference to a kubernetes secret.",
+	"":    "SecretReference is a reference to a kubernetes secret.",
+	"env": "Env projects the data of the secret into environment variables of the function, in addition to the files under the secrets directory.",
 }
 
 func (SecretReference) SwaggerDoc() map[string]string {
@@ -436,4 +606,16 @@ func (TimeTriggerSpec) SwaggerDoc() map[string]string {
 	return map_TimeTriggerSpec
 }
 
+var map_UpdateStrategy = map[string]string{
+	"":                "UpdateStrategy configures the rollout of function updates.",
+	"type":            "Type is the rollout strategy. Defaults to \"Rolling\".\n\nAvailable value:\n - Rolling: pods are replaced gradually, bounded by maxSurge and maxUnavailable\n - BlueGreen: the service switches to the new pods once all of them are ready",
+	"maxSurge":        "MaxSurge is the number or percentage of pods created above the desired replicas during a rolling update. Defaults to 20%.",
+	"maxUnavailable":  "MaxUnavailable is the number or percentage of pods which can be unavailable during a rolling update. Defaults to 20%.",
+	"rollbackTimeout": "RollbackTimeout is the number of seconds the new pods have to become ready. The previous version of the function is restored if they don't. Defaults to 0, which disables the rollback.",
+}
+
+func (UpdateStrategy) SwaggerDoc() map[string]string {
+	return map_UpdateStrategy
+}
+
 // AUTO-GENERATED FUNCTIONS END HERE
@@ -27,7 +27,9 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dchest/uniuri"
@@ -42,6 +44,18 @@ const (
 	// supported environment variables
 	envSrcPkg    string = "SRC_PKG"
 	envDeployPkg string = "DEPLOY_PKG"
+	envSBOMFile  string = "SBOM_FILE"
+
+	// sbomFileSuffix is appended to the deployment package filename to get
+	// the path the build command may write an SBOM of the build to.
+	sbomFileSuffix = ".sbom.json"
+
+	// MaxBuildLogLineSize is the size of the longest line of build output.
+	MaxBuildLogLineSize = 1024 * 1024
+
+	// killWaitDelay is how long to wait for the output of a killed build
+	// command to be closed.
+	killWaitDelay = 10 * time.Second
 )
 
 type (
@@ -49,27 +63,113 @@ type (
 		SrcPkgFilename string `json:"srcPkgFilename"`
 		// Command for builder to run with.
 		// A build command consists of commands, parameters and environment variables.
-		// For now, two environment variables are supported:
+		// For now, three environment variables are supported:
 		// 1. SRC_PKG: path to source package directory
 		// 2. DEPLOY_PKG: path to deployment package directory
+		// 3. SBOM_FILE: path to write an optional SBOM of the build to
 		BuildCommand string `json:"command"`
 	}
 
 	PackageBuildResponse struct {
 		ArtifactFilename string `json:"artifactFilename"`
 		BuildLogs        string `json:"buildLogs"`
+		// SBOMFilename is the SBOM file written by the build command, if any.
+		SBOMFilename string `json:"sbomFilename,omitempty"`
+		// BuilderPod is the name of the builder pod which ran the build.
+		BuilderPod string `json:"builderPod,omitempty"`
 	}
 
 	Builder struct {
 		logger           *zap.Logger
 		sharedVolumePath string
+		// name of the pod of the builder, its host name
+		podName string
+
+		// logs of the builds by source package filename, kept until the
+		// source package is cleaned
+		lock sync.Mutex
+		logs map[string]*buildLog
+		// SBOMs written by the builds by source package filename, removed
+		// when the source package is cleaned unless uploaded before
+		sboms map[string]string
 	}
 )
 
 func MakeBuilder(logger *zap.Logger, sharedVolumePath string) *Builder {
+	podName, err := os.Hostname()
+	if err != nil {
+		logger.Error("error getting builder pod name", zap.Error(err))
+	}
 	return &Builder{
 		logger:           logger.Named("builder"),
 		sharedVolumePath: sharedVolumePath,
+		podName:          podName,
+		logs:             make(map[string]*buildLog),
+		sboms:            make(map[string]string),
+	}
+}
+
+// getBuildLog returns the log of the build of the source package, creating
+// it unless it was followed before the build request arrived.
+func (builder *Builder) getBuildLog(srcPkgFilename string) *buildLog {
+	builder.lock.Lock()
+	defer builder.lock.Unlock()
+	log, ok := builder.logs[srcPkgFilename]
+	if !ok {
+		log = makeBuildLog()
+		builder.logs[srcPkgFilename] = log
+	}
+	return log
+}
+
+// lookupBuildLog returns the log of the build of the source package, or false
+// if the source package is unknown. The log is created if the source package
+// was fetched but its build didn't start yet, so that it can be followed
+// before the build request arrives. Clean removes the source package before
+// the log, so a log is never created past the clean of its package.
+func (builder *Builder) lookupBuildLog(srcPkgFilename string) (*buildLog, bool) {
+	srcPkgPath, err := utils.SanitizeFilePath(filepath.Join(builder.sharedVolumePath, srcPkgFilename), builder.sharedVolumePath)
+	if err != nil {
+		return nil, false
+	}
+	builder.lock.Lock()
+	defer builder.lock.Unlock()
+	log, ok := builder.logs[srcPkgFilename]
+	if ok {
+		return log, true
+	}
+	if _, err := os.Stat(srcPkgPath); err != nil {
+		return nil, false
+	}
+	log = makeBuildLog()
+	builder.logs[srcPkgFilename] = log
+	return log, true
+}
+
+// removeBuildLog ends the streams of the log of the build of the source
+// package and removes it.
+func (builder *Builder) removeBuildLog(srcPkgFilename string) {
+	builder.lock.Lock()
+	log, ok := builder.logs[srcPkgFilename]
+	delete(builder.logs, srcPkgFilename)
+	builder.lock.Unlock()
+	if ok {
+		log.finish()
+	}
+}
+
+// removeSBOM removes the SBOM written by the build of the source package, if
+// it's still on the shared volume.
+func (builder *Builder) removeSBOM(srcPkgFilename string) {
+	builder.lock.Lock()
+	sbomPath, ok := builder.sboms[srcPkgFilename]
+	delete(builder.sboms, srcPkgFilename)
+	builder.lock.Unlock()
+	if !ok {
+		return
+	}
+	if err := os.Remove(sbomPath); err != nil && !os.IsNotExist(err) {
+		builder.logger.Error("error removing SBOM of build", zap.Error(err), zap.String("path", sbomPath))
 	}
 }
 
@@ -134,6 +234,11 @@ func (builder *Builder) Handler(w http.ResponseWriter, r *http.Request) {
 		builder.reply(r.Context(), w, "", err.Error(), http.StatusBadRequest)
 		return
 	}
+	sbomFilename := deployPkgFilename + sbomFileSuffix
+	sbomPath := filepath.Join(builder.sharedVolumePath, sbomFilename)
+
+	buildLog := builder.getBuildLog(req.SrcPkgFilename)
+	defer buildLog.finish()
 
 	var buildArgs []string
 	buildCmd := req.BuildCommand
@@ -150,18 +255,39 @@ func (builder *Builder) Handler(w http.ResponseWriter, r *http.Request) {
 			buildArgs = append(buildArgs, args[i])
 		}
 	}
-	buildLogs, err := builder.build(r.Context(), buildCmd, buildArgs, srcPkgPath, deployPkgPath)
+	buildLogs, err := builder.build(r.Context(), buildLog, buildCmd, buildArgs, srcPkgPath, deployPkgPath, sbomPath)
 	if err != nil {
 		e := "error building source package"
 		logger.Error(e, zap.Error(err))
 
+		// remove the partial output of the build
+		if err := os.RemoveAll(deployPkgPath); err != nil {
+			logger.Error("error removing deployment package of failed build", zap.Error(err), zap.String("path", deployPkgPath))
+		}
+		if err := os.Remove(sbomPath); err != nil && !os.IsNotExist(err) {
+			logger.Error("error removing SBOM of failed build", zap.Error(err), zap.String("path", sbomPath))
+		}
+
 		// append error at the end of build logs
 		buildLogs += fmt.Sprintf("%s: %s\n", e, err.Error())
+		buildLog.append(fmt.Sprintf("%s: %s", e, err.Error()))
 		builder.reply(r.Context(), w, deployPkgFilename, buildLogs, http.StatusInternalServerError)
 		return
 	}
 
-	builder.reply(r.Context(), w, deployPkgFilename, buildLogs, http.StatusOK)
+	resp := PackageBuildResponse{
+		ArtifactFilename: deployPkgFilename,
+		BuildLogs:        buildLogs,
+		BuilderPod:       builder.podName,
+	}
+	// the SBOM is optional, the build command writes it if it can
+	if fi, err := os.Stat(sbomPath); err == nil && fi.Mode().IsRegular() {
+		resp.SBOMFilename = sbomFilename
+		builder.lock.Lock()
+		builder.sboms[req.SrcPkgFilename] = sbomPath
+		builder.lock.Unlock()
+	}
+	builder.writeResponse(r.Context(), w, resp, http.StatusOK)
 }
 
 func (builder *Builder) Clean(w http.ResponseWriter, r *http.Request) {
@@ -186,6 +312,8 @@ func (builder *Builder) Clean(w http.ResponseWriter, r *http.Request) {
 	logger.Info("builder received clean request", zap.Any("source_package", srcPkgFilename))
 
 	err := utils.DeleteOldPackages(srcPkgPath, envSrcPkg)
+	builder.removeBuildLog(srcPkgFilename)
+	builder.removeSBOM(srcPkgFilename)
 	if err != nil {
 		e := "error deleting src package after build"
 		logger.Error(e, zap.Error(err))
@@ -196,13 +324,50 @@ func (builder *Builder) Clean(w http.ResponseWriter, r *http.Request) {
 	builder.reply(r.Context(), w, srcPkgFilename, "", http.StatusOK)
 }
 
+// LogsHandler streams the output of the build of a source package line by
+// line. With follow set, the response ends with the build, otherwise it
+// returns the output so far. It responds with 404 if the source package is
+// unknown.
+func (builder *Builder) LogsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), builder.logger)
+
+	if r.Method != "GET" {
+		e := "method not allowed"
+		logger.Error(e, zap.String("http_method", r.Method))
+		http.Error(w, fmt.Sprintf("%s: %s", e, r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	srcPkgFilename := r.URL.Query().Get("name")
+	if len(srcPkgFilename) == 0 {
+		http.Error(w, "source package name is required", http.StatusBadRequest)
+		return
+	}
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+
+	buildLog, ok := builder.lookupBuildLog(srcPkgFilename)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no build of source package %q", srcPkgFilename), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	err := buildLog.stream(r.Context(), w, follow)
+	if err != nil {
+		logger.Debug("error streaming build logs", zap.Error(err), zap.String("source_package", srcPkgFilename))
+	}
+}
+
 func (builder *Builder) reply(ctx context.Context, w http.ResponseWriter, pkgFilename string, buildLogs string, statusCode int) {
-	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
-	resp := PackageBuildResponse{
+	builder.writeResponse(ctx, w, PackageBuildResponse{
 		ArtifactFilename: pkgFilename,
 		BuildLogs:        buildLogs,
-	}
+	}, statusCode)
+}
 
+func (builder *Builder) writeResponse(ctx context.Context, w http.ResponseWriter, resp PackageBuildResponse, statusCode int) {
+	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
 	rBody, err := json.Marshal(resp)
 	if err != nil {
 		e := fmt.Errorf("error encoding response body: %w", err)
@@ -223,10 +388,14 @@ func (builder *Builder) reply(ctx context.Context, w http.ResponseWriter, pkgFil
 	}
 }
 
-func (builder *Builder) build(ctx context.Context, command string, args []string, srcPkgPath string, deployPkgPath string) (string, error) {
+func (builder *Builder) build(ctx context.Context, buildLog *buildLog, command string, args []string, srcPkgPath string, deployPkgPath string, sbomPath string) (string, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
 
-	cmd := exec.Command(command, args...)
+	// the build command is killed if the build request is canceled, like
+	// when buildermgr cancels the build or it times out
+	cmd := exec.CommandContext(ctx, command, args...)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = killWaitDelay
 
 	fi, err := os.Stat(srcPkgPath)
 	if err != nil {
@@ -242,28 +411,31 @@ func (builder *Builder) build(ctx context.Context, command string, args []string
 	cmd.Env = append(os.Environ(),
 		fmt.Sprintf("%s=%s", envSrcPkg, srcPkgPath),
 		fmt.Sprintf("%s=%s", envDeployPkg, deployPkgPath),
+		fmt.Sprintf("%s=%s", envSBOMFile, sbomPath),
 	)
 
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return "", fmt.Errorf("error creating stdout pipe for cmd: %w", err)
-	}
-
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return "", fmt.Errorf("error creating stderr pipe for cmd: %w", err)
-	}
+	// stdout and stderr share a pipe so that the output is streamed in order
+	out, outWriter := io.Pipe()
+	cmd.Stdout = outWriter
+	cmd.Stderr = outWriter
 
 	// Init logs
 	logger.Info("building source package", zap.String("command", command), zap.Strings("args", args), zap.Strings("env", cmd.Env))
 
-	out := io.MultiReader(stdout, stderr)
 	scanner := bufio.NewScanner(out)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), MaxBuildLogLineSize)
 
 	err = cmd.Start()
 	if err != nil {
 		return "", fmt.Errorf("error starting cmd: %w", err)
 	}
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		outWriter.Close()
+		waitErr <- err
+	}()
+
 	fmt.Printf("========= START =========\n")
 	defer fmt.Printf("========= END ===========\n")
 	var buildLogs string
@@ -272,15 +444,24 @@ func (builder *Builder) build(ctx context.Context, command string, args []string
 		output := scanner.Text()
 		fmt.Println(output)
 		buildLogs += fmt.Sprintf("%s\n", output)
+		buildLog.append(output)
 	}
 
 	if err := scanner.Err(); err != nil {
 		scanErr := fmt.Errorf("error reading cmd output: %w", err)
 		fmt.Println(scanErr)
+		// unblock the writes of the cmd output
+		out.CloseWithError(scanErr)
+		<-waitErr
 		return buildLogs, scanErr
 	}
 
-	err = cmd.Wait()
+	err = <-waitErr
+	if ctx.Err() != nil {
+		cmdErr := fmt.Errorf("build command %q killed: %w", command, context.Cause(ctx))
+		fmt.Println(cmdErr)
+		return buildLogs, cmdErr
+	}
 	if err != nil {
 		cmdErr := fmt.Errorf("error waiting for cmd %q: %w", command, err)
 		fmt.Println(cmdErr)
@@ -17,6 +17,7 @@ package builder
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fission/fission/pkg/utils/loggerfactory"
 )
@@ -169,6 +171,62 @@ func TestBuilder(t *testing.T) {
 		}
 	})
 
+	// Test LogsHandler
+	t.Run("LogsHandler", func(t *testing.T) {
+		srcFile, err := os.Create(dir + "/test4")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer srcFile.Close()
+
+		// follow the logs before the build starts
+		logsW := httptest.NewRecorder()
+		logsDone := make(chan struct{})
+		go func() {
+			defer close(logsDone)
+			builder.LogsHandler(logsW, httptest.NewRequest(http.MethodGet, "/logs?name=test4&follow=true", http.NoBody))
+		}()
+
+		body, err := json.Marshal(&PackageBuildRequest{SrcPkgFilename: "test4", BuildCommand: "ls"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		builder.Handler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("expected status code %d, got %d", http.StatusOK, w.Result().StatusCode)
+		}
+
+		<-logsDone
+		if !strings.Contains(logsW.Body.String(), "test4\n") {
+			t.Errorf("expected streamed build logs to contain the build output, got %q", logsW.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		builder.LogsHandler(w, httptest.NewRequest(http.MethodGet, "/logs?name=test4", http.NoBody))
+		if w.Body.String() != logsW.Body.String() {
+			t.Errorf("expected build logs %q, got %q", logsW.Body.String(), w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		builder.LogsHandler(w, httptest.NewRequest(http.MethodGet, "/logs", http.NoBody))
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status code %d, got %d", http.StatusBadRequest, w.Result().StatusCode)
+		}
+
+		// unknown builds don't get a log
+		for _, name := range []string{"unknown", "../test4"} {
+			w = httptest.NewRecorder()
+			builder.LogsHandler(w, httptest.NewRequest(http.MethodGet, "/logs?name="+name, http.NoBody))
+			if w.Result().StatusCode != http.StatusNotFound {
+				t.Errorf("expected status code %d for %s, got %d", http.StatusNotFound, name, w.Result().StatusCode)
+			}
+			if _, ok := builder.logs[name]; ok {
+				t.Errorf("expected no build log of %s", name)
+			}
+		}
+	})
+
 	// Test CleanHandler
 	t.Run("CleanHandler", func(t *testing.T) {
 		for _, test := range []struct {
@@ -215,3 +273,89 @@ func TestBuilder(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildCanceled(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	dir := t.TempDir()
+	builder := MakeBuilder(logger, dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	// the background child keeps the output pipe open unless the whole
+	// process group of the build command is killed
+	start := time.Now()
+	buildLogs, err := builder.build(ctx, makeBuildLog(), "sh", []string{"-c", "echo started; sleep 30 & sleep 30"}, dir, dir+"/deploy", dir+"/deploy.sbom.json")
+	if err == nil || !strings.Contains(err.Error(), "killed") {
+		t.Fatalf("expected build command to be killed, got %v", err)
+	}
+	if !strings.Contains(buildLogs, "started") {
+		t.Errorf("expected build logs before cancellation, got %q", buildLogs)
+	}
+	if elapsed := time.Since(start); elapsed > killWaitDelay {
+		t.Errorf("build command took %v to be killed", elapsed)
+	}
+}
+
+func TestBuildSBOM(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	dir := t.TempDir()
+	builder := MakeBuilder(logger, dir)
+
+	build := func(srcPkgFilename, script string) PackageBuildResponse {
+		if err := os.WriteFile(dir+"/"+srcPkgFilename, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		buildCmd := "ls"
+		if len(script) > 0 {
+			buildCmd = dir + "/" + srcPkgFilename + ".sh"
+			if err := os.WriteFile(buildCmd, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		body, err := json.Marshal(&PackageBuildRequest{SrcPkgFilename: srcPkgFilename, BuildCommand: buildCmd})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		builder.Handler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+		var resp PackageBuildResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := build("nosbom", "")
+	if len(resp.SBOMFilename) > 0 {
+		t.Errorf("expected no SBOM, got %s", resp.SBOMFilename)
+	}
+
+	resp = build("sbom", `echo '{"bomFormat": "CycloneDX"}' > "$SBOM_FILE"`)
+	if resp.SBOMFilename != resp.ArtifactFilename+sbomFileSuffix {
+		t.Fatalf("expected SBOM of the deployment package, got %q", resp.SBOMFilename)
+	}
+	sbom, err := os.ReadFile(dir + "/" + resp.SBOMFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(sbom), "CycloneDX") {
+		t.Errorf("unexpected SBOM content %q", sbom)
+	}
+
+	// the SBOM is removed with the source package
+	w := httptest.NewRecorder()
+	builder.Clean(w, httptest.NewRequest(http.MethodDelete, "/clean?name=sbom", nil))
+	if _, err := os.Stat(dir + "/" + resp.SBOMFilename); !os.IsNotExist(err) {
+		t.Errorf("expected SBOM to be removed with the source package, got %v", err)
+	}
+
+	// the SBOM of a failed build is removed
+	resp = build("failed", `echo '{}' > "$SBOM_FILE"; exit 1`)
+	if len(resp.SBOMFilename) > 0 {
+		t.Errorf("expected no SBOM of failed build, got %s", resp.SBOMFilename)
+	}
+	if _, err := os.Stat(dir + "/" + resp.ArtifactFilename + sbomFileSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected SBOM of failed build to be removed, got %v", err)
+	}
+}
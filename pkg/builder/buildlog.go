@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type (
+	// buildLog keeps the output of a build for the readers streaming it
+	// while the build runs.
+	buildLog struct {
+		lock    sync.Mutex
+		lines   []string
+		done    bool
+		updated chan struct{}
+	}
+)
+
+func makeBuildLog() *buildLog {
+	return &buildLog{
+		updated: make(chan struct{}),
+	}
+}
+
+// append adds a line of output and wakes up the readers.
+func (l *buildLog) append(line string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.done {
+		return
+	}
+	l.lines = append(l.lines, line)
+	close(l.updated)
+	l.updated = make(chan struct{})
+}
+
+// finish marks the end of the output.
+func (l *buildLog) finish() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.done {
+		return
+	}
+	l.done = true
+	close(l.updated)
+}
+
+// next returns the lines of output from the given one, whether the output
+// ended, and a channel closed once more output is available.
+func (l *buildLog) next(from int) ([]string, bool, <-chan struct{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.lines[min(from, len(l.lines)):], l.done, l.updated
+}
+
+// stream writes the output to w, flushing it line by line. If follow is set,
+// it waits for more output until the build ends or the context is done.
+func (l *buildLog) stream(ctx context.Context, w io.Writer, follow bool) error {
+	flusher, _ := w.(http.Flusher)
+	from := 0
+	for {
+		lines, done, updated := l.next(from)
+		for _, line := range lines {
+			if _, err := io.WriteString(w, line+"\n"); err != nil {
+				return err
+			}
+		}
+		from += len(lines)
+		if flusher != nil && len(lines) > 0 {
+			flusher.Flush()
+		}
+		if done || !follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-updated:
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -39,6 +40,7 @@ type (
 	ClientInterface interface {
 		Build(context.Context, *builder.PackageBuildRequest) (*builder.PackageBuildResponse, error)
 		Clean(context.Context, string) error
+		Logs(ctx context.Context, srcPkgFilename string, follow bool) (io.ReadCloser, error)
 	}
 
 	client struct {
@@ -63,6 +65,15 @@ func (c *client) getCleanUrl(srcPkgFilename string) string {
 	return c.url + "/clean" + "?name=" + srcPkgFilename
 }
 
+func (c *client) getLogsUrl(srcPkgFilename string, follow bool) string {
+	query := url.Values{}
+	query.Set("name", srcPkgFilename)
+	if follow {
+		query.Set("follow", "true")
+	}
+	return c.url + "/logs?" + query.Encode()
+}
+
 func (c *client) Build(ctx context.Context, req *builder.PackageBuildRequest) (*builder.PackageBuildResponse, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, c.logger)
 
@@ -71,7 +82,9 @@ func (c *client) Build(ctx context.Context, req *builder.PackageBuildRequest) (*
 		return nil, fmt.Errorf("error marshaling json: %w", err)
 	}
 
-	resp, err := ctxhttp.Post(ctx, c.httpClient.StandardClient(), c.url, "application/json", bytes.NewReader(body))
+	// builds aren't retried by the client: a failed build would run again,
+	// and buildermgr retries the builds failing for infrastructure reasons
+	resp, err := ctxhttp.Post(ctx, c.httpClient.HTTPClient, c.url, "application/json", bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %w", err)
 	}
@@ -118,3 +131,21 @@ func (c *client) Clean(ctx context.Context, srcPkgFilename string) error {
 
 	return nil
 }
+
+// Logs returns the output of the build of the source package. With follow
+// set, the output is streamed until the build ends.
+func (c *client) Logs(ctx context.Context, srcPkgFilename string, follow bool) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getLogsUrl(srcPkgFilename, follow), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating http request: %w", err)
+	}
+
+	resp, err := c.httpClient.StandardClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending logs request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	storageSvcClient "github.com/fission/fission/pkg/storagesvc/client"
+	"github.com/fission/fission/pkg/utils/gitrepo"
+)
+
+// buildCacheKeyVersion is part of the build cache keys, so that changing how
+// they're computed invalidates the previous ones.
+const buildCacheKeyVersion = "v2"
+
+// buildCacheKeyIndex is the index of the package informers of the successful
+// builds by build cache key.
+const buildCacheKeyIndex = "buildCacheKey"
+
+type (
+	// artifactCache keeps the deployment archives of the successful builds
+	// by the hash of their inputs, to reuse them instead of building the
+	// same inputs again.
+	artifactCache struct {
+		logger *zap.Logger
+
+		// pkgIndexers look up the successful builds of the packages, which
+		// the builds map doesn't have after a restart of buildermgr
+		pkgIndexers []k8sCache.Indexer
+
+		lock   sync.Mutex
+		builds map[string]cachedBuild
+	}
+
+	// cachedBuild is the deployment archive of a successful build, with the
+	// provenance of the build.
+	cachedBuild struct {
+		deployment fv1.Archive
+		provenance *fv1.BuildProvenance
+	}
+)
+
+// makeArtifactCache returns the build artifact cache, or nil if it's disabled
+// with BUILD_CACHE_ENABLED.
+func makeArtifactCache(logger *zap.Logger) *artifactCache {
+	enabled, _ := strconv.ParseBool(os.Getenv("BUILD_CACHE_ENABLED"))
+	if !enabled {
+		return nil
+	}
+	return &artifactCache{
+		logger: logger.Named("artifact_cache"),
+		builds: make(map[string]cachedBuild),
+	}
+}
+
+// indexPackages indexes the successful builds of the packages of the
+// informers by build cache key. The informers must not be started yet.
+func (c *artifactCache) indexPackages(informers map[string]k8sCache.SharedIndexInformer) error {
+	if c == nil {
+		return nil
+	}
+	for ns, informer := range informers {
+		err := informer.AddIndexers(k8sCache.Indexers{buildCacheKeyIndex: indexBuildCacheKey})
+		if err != nil {
+			return fmt.Errorf("error indexing packages of namespace %s: %w", ns, err)
+		}
+		c.pkgIndexers = append(c.pkgIndexers, informer.GetIndexer())
+	}
+	return nil
+}
+
+// indexBuildCacheKey returns the build cache key of the package if it was
+// built successfully.
+func indexBuildCacheKey(obj interface{}) ([]string, error) {
+	pkg, ok := obj.(*fv1.Package)
+	if !ok || !isCachedBuild(pkg) {
+		return nil, nil
+	}
+	return []string{pkg.Status.BuildCacheKey}, nil
+}
+
+// isCachedBuild returns true if the package was built successfully from
+// inputs with a cache key.
+func isCachedBuild(pkg *fv1.Package) bool {
+	return pkg.Status.BuildStatus == fv1.BuildStatusSucceeded &&
+		len(pkg.Status.BuildCacheKey) > 0 && len(pkg.Spec.Deployment.URL) > 0
+}
+
+// add records the deployment archive and build provenance of the package if
+// it was built successfully from inputs with a cache key.
+func (c *artifactCache) add(pkg *fv1.Package) {
+	if c == nil || !isCachedBuild(pkg) {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.builds[pkg.Status.BuildCacheKey] = cachedBuild{
+		deployment: pkg.Spec.Deployment,
+		provenance: pkg.Status.Provenance,
+	}
+}
+
+// get returns the deployment archive of a previous build with the given key.
+// The archive is checked to still be in storagesvc, as it's pruned once no
+// package references it anymore.
+func (c *artifactCache) get(ctx context.Context, storageSvcUrl string, key string) (*cachedBuild, bool) {
+	if c == nil || len(key) == 0 {
+		return nil, false
+	}
+	c.lock.Lock()
+	build, ok := c.builds[key]
+	c.lock.Unlock()
+	if !ok {
+		build, ok = c.lookup(key)
+		if !ok {
+			return nil, false
+		}
+	}
+
+	exists, err := archiveExists(ctx, storageSvcUrl, build.deployment.URL)
+	if err != nil {
+		c.logger.Warn("error checking cached deployment archive, building the package", zap.Error(err), zap.String("url", build.deployment.URL))
+		return nil, false
+	}
+	if !exists {
+		c.lock.Lock()
+		if c.builds[key].deployment.URL == build.deployment.URL {
+			delete(c.builds, key)
+		}
+		c.lock.Unlock()
+		return nil, false
+	}
+	return &build, true
+}
+
+// lookup returns the deployment archive of a package built successfully with
+// the given key.
+func (c *artifactCache) lookup(key string) (cachedBuild, bool) {
+	for _, indexer := range c.pkgIndexers {
+		objs, err := indexer.ByIndex(buildCacheKeyIndex, key)
+		if err != nil {
+			c.logger.Warn("error looking up packages by build cache key", zap.Error(err), zap.String("build_cache_key", key))
+			continue
+		}
+		for _, obj := range objs {
+			pkg := obj.(*fv1.Package)
+			return cachedBuild{
+				deployment: pkg.Spec.Deployment,
+				provenance: pkg.Status.Provenance,
+			}, true
+		}
+	}
+	return cachedBuild{}, false
+}
+
+// archiveExists checks whether the storagesvc archive at the URL exists.
+func archiveExists(ctx context.Context, storageSvcUrl string, archiveURL string) (bool, error) {
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return false, err
+	}
+	id := u.Query().Get("id")
+	if len(id) == 0 {
+		return false, fmt.Errorf("no archive ID in URL %q", archiveURL)
+	}
+	exists, err := storageSvcClient.MakeClient(storageSvcUrl).Exists(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("error checking archive %s: %w", id, err)
+	}
+	return exists, nil
+}
+
+// getImageDigest returns the digest the builder image of the environment
+// resolves to: the one the image is pinned to, or else the one the builder
+// pod runs, if any. Tags may be pushed again, so it's empty if neither is
+// known.
+func getImageDigest(env *fv1.Environment, builderPod *apiv1.Pod) string {
+	if i := strings.LastIndex(env.Spec.Builder.Image, "@"); i >= 0 {
+		return env.Spec.Builder.Image[i+1:]
+	}
+	return getBuilderImageDigest(builderPod)
+}
+
+// computeBuildCacheKey returns the hash of the inputs of the package build: the
+// source archive, the build command and the builder image with the digest it
+// resolves to. It returns an empty key if the source archive has no checksum
+// nor digest, nor is a git source pinned to a commit, or if the image digest
+// is unknown, as the content of the inputs isn't known then.
+func computeBuildCacheKey(pkg *fv1.Package, env *fv1.Environment, imageDigest string) string {
+	if len(imageDigest) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", buildCacheKeyVersion, pkg.Namespace)
+
+	src := pkg.Spec.Source
+	switch {
+	case len(src.Literal) > 0:
+		sum := sha256.Sum256(src.Literal)
+		fmt.Fprintf(h, "literal\x00%s\x00", hex.EncodeToString(sum[:]))
+	case len(src.URL) > 0 && len(src.Checksum.Sum) > 0:
+		fmt.Fprintf(h, "%s\x00%s\x00", src.Checksum.Type, src.Checksum.Sum)
+	case src.Type == fv1.ArchiveTypeOCI:
+		// OCI sources are referenced by digest, so their URL is immutable
+		fmt.Fprintf(h, "oci\x00%s\x00", src.URL)
+	case src.Type == fv1.ArchiveTypeGit && src.Git != nil && gitrepo.IsCommit(src.Git.Ref):
+		// branches and tags move, only sources pinned to a commit are cached
+		fmt.Fprintf(h, "git\x00%s\x00%s\x00%s\x00", src.URL, src.Git.Ref, src.Git.SubPath)
+	default:
+		return ""
+	}
+
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%t", getBuildCommand(pkg, env), env.Spec.Builder.Image, imageDigest, env.Spec.KeepArchive)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getBuildCommand returns the build command of the package, or the one of
+// the environment builder if it has none.
+func getBuildCommand(pkg *fv1.Package, env *fv1.Environment) string {
+	if len(pkg.Spec.BuildCommand) > 0 {
+		return pkg.Spec.BuildCommand
+	}
+	return env.Spec.Builder.Command
+}
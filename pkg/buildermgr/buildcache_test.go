@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fClient "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestComputeBuildCacheKey(t *testing.T) {
+	env := &fv1.Environment{
+		Spec: fv1.EnvironmentSpec{
+			Builder: fv1.Builder{Image: "python-builder:1.0", Command: "build"},
+		},
+	}
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: fv1.PackageSpec{
+			Source: fv1.Archive{
+				Type:     fv1.ArchiveTypeUrl,
+				URL:      "http://storagesvc/v1/archive?id=src",
+				Checksum: fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "abc"},
+			},
+		},
+	}
+	digest := "sha256:" + strings.Repeat("a", 64)
+	key := computeBuildCacheKey(pkg, env, digest)
+	require.NotEmpty(t, key)
+
+	// tags of builder images may be pushed again, the build depends on the
+	// digest they resolve to
+	require.NotEqual(t, key, computeBuildCacheKey(pkg, env, "sha256:"+strings.Repeat("b", 64)))
+	require.Empty(t, computeBuildCacheKey(pkg, env, ""))
+
+	// the source URL doesn't matter, only its content
+	same := pkg.DeepCopy()
+	same.Spec.Source.URL = "http://storagesvc/v1/archive?id=other"
+	require.Equal(t, key, computeBuildCacheKey(same, env, digest))
+
+	for name, change := range map[string]func(*fv1.Package, *fv1.Environment){
+		"source":        func(p *fv1.Package, e *fv1.Environment) { p.Spec.Source.Checksum.Sum = "def" },
+		"build command": func(p *fv1.Package, e *fv1.Environment) { p.Spec.BuildCommand = "build --release" },
+		"builder image": func(p *fv1.Package, e *fv1.Environment) { e.Spec.Builder.Image = "python-builder:2.0" },
+		"keep archive":  func(p *fv1.Package, e *fv1.Environment) { e.Spec.KeepArchive = true },
+		"namespace":     func(p *fv1.Package, e *fv1.Environment) { p.Namespace = "other" },
+	} {
+		p, e := pkg.DeepCopy(), env.DeepCopy()
+		change(p, e)
+		require.NotEqual(t, key, computeBuildCacheKey(p, e, digest), name)
+	}
+
+	// the content of a source archive without checksum isn't known
+	noChecksum := pkg.DeepCopy()
+	noChecksum.Spec.Source.Checksum = fv1.Checksum{}
+	require.Empty(t, computeBuildCacheKey(noChecksum, env, digest))
+
+	literal := pkg.DeepCopy()
+	literal.Spec.Source = fv1.Archive{Type: fv1.ArchiveTypeLiteral, Literal: []byte("print('hello')")}
+	require.NotEmpty(t, computeBuildCacheKey(literal, env, digest))
+
+	// OCI sources are referenced by digest
+	ociSource := pkg.DeepCopy()
+	ociSource.Spec.Source = fv1.Archive{Type: fv1.ArchiveTypeOCI, URL: "oci://registry/hello@sha256:" + strings.Repeat("0", 64)}
+	ociKey := computeBuildCacheKey(ociSource, env, digest)
+	require.NotEmpty(t, ociKey)
+	ociSource.Spec.Source.URL = "oci://registry/hello@sha256:" + strings.Repeat("1", 64)
+	require.NotEqual(t, ociKey, computeBuildCacheKey(ociSource, env, digest))
+
+	// git sources are cached when pinned to a commit only
+	gitSource := pkg.DeepCopy()
+	gitSource.Spec.Source = fv1.Archive{Type: fv1.ArchiveTypeGit, URL: "https://github.com/fission/examples.git",
+		Git: &fv1.GitSource{Ref: "main"}}
+	require.Empty(t, computeBuildCacheKey(gitSource, env, digest))
+	gitSource.Spec.Source.Git.Ref = strings.Repeat("0", 40)
+	gitKey := computeBuildCacheKey(gitSource, env, digest)
+	require.NotEmpty(t, gitKey)
+	gitSource.Spec.Source.Git.SubPath = "hello"
+	require.NotEqual(t, gitKey, computeBuildCacheKey(gitSource, env, digest))
+}
+
+func TestGetImageDigest(t *testing.T) {
+	env := &fv1.Environment{Spec: fv1.EnvironmentSpec{Builder: fv1.Builder{Image: "python-builder:1.0"}}}
+	pod := &apiv1.Pod{
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{{
+				Name:    fv1.BuilderContainerName,
+				ImageID: "docker-pullable://python-builder@sha256:" + strings.Repeat("a", 64),
+			}},
+		},
+	}
+	require.Empty(t, getImageDigest(env, nil))
+	require.Equal(t, "sha256:"+strings.Repeat("a", 64), getImageDigest(env, pod))
+
+	// images pinned by digest don't depend on the builder pod
+	env.Spec.Builder.Image = "python-builder:1.0@sha256:" + strings.Repeat("b", 64)
+	require.Equal(t, "sha256:"+strings.Repeat("b", 64), getImageDigest(env, nil))
+	require.Equal(t, "sha256:"+strings.Repeat("b", 64), getImageDigest(env, pod))
+}
+
+func TestArtifactCache(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("BUILD_CACHE_ENABLED", "true")
+	c := makeArtifactCache(loggerfactory.GetLogger())
+	require.NotNil(t, c)
+
+	archives := map[string]bool{"deploy": true}
+	storagesvc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// archives are checked without downloading them
+		if r.Method != http.MethodHead {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		if !archives[r.URL.Query().Get("id")] {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer storagesvc.Close()
+
+	pkg := &fv1.Package{
+		Spec: fv1.PackageSpec{
+			Deployment: fv1.Archive{Type: fv1.ArchiveTypeUrl, URL: storagesvc.URL + "/v1/archive?id=deploy"},
+		},
+		Status: fv1.PackageStatus{BuildStatus: fv1.BuildStatusFailed, BuildCacheKey: "key"},
+	}
+	c.add(pkg)
+	_, ok := c.get(ctx, storagesvc.URL, "key")
+	require.False(t, ok, "failed builds must not be reused")
+
+	pkg.Status.BuildStatus = fv1.BuildStatusSucceeded
+	pkg.Status.Provenance = &fv1.BuildProvenance{BuilderImageDigest: "sha256:abc"}
+	c.add(pkg)
+	build, ok := c.get(ctx, storagesvc.URL, "key")
+	require.True(t, ok)
+	require.Equal(t, pkg.Spec.Deployment.URL, build.deployment.URL)
+	require.Equal(t, pkg.Status.Provenance, build.provenance, "the provenance of the reused build is kept")
+
+	// pruned archives are dropped from the cache
+	delete(archives, "deploy")
+	_, ok = c.get(ctx, storagesvc.URL, "key")
+	require.False(t, ok)
+	require.Empty(t, c.builds)
+
+	// builds of before a restart are looked up in the packages
+	archives["deploy"] = true
+	restarted := makeArtifactCache(loggerfactory.GetLogger())
+	informer := genInformer.NewSharedInformerFactory(fClient.NewSimpleClientset(), 0).Core().V1().Packages().Informer()
+	require.NoError(t, restarted.indexPackages(map[string]k8sCache.SharedIndexInformer{"default": informer}))
+	building := pkg.DeepCopy()
+	building.Name = "building"
+	building.Status.BuildStatus = fv1.BuildStatusRunning
+	require.NoError(t, informer.GetIndexer().Add(building))
+	_, ok = restarted.get(ctx, storagesvc.URL, "key")
+	require.False(t, ok, "packages being built must not be reused")
+	require.NoError(t, informer.GetIndexer().Add(pkg))
+	build, ok = restarted.get(ctx, storagesvc.URL, "key")
+	require.True(t, ok)
+	require.Equal(t, pkg.Spec.Deployment.URL, build.deployment.URL)
+	require.Equal(t, pkg.Status.Provenance, build.provenance)
+
+	// the cache is disabled by default
+	t.Setenv("BUILD_CACHE_ENABLED", "")
+	disabled := makeArtifactCache(loggerfactory.GetLogger())
+	require.Nil(t, disabled)
+	disabled.add(pkg)
+	_, ok = disabled.get(ctx, storagesvc.URL, "key")
+	require.False(t, ok)
+}
@@ -66,8 +66,11 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 	}
 	envWatcher.Run(ctx, mgr)
 
+	logRelay := makeBuildLogRelay(bmLogger)
+	logRelay.serve(ctx, mgr)
+
 	pkgWatcher := makePackageWatcher(bmLogger, fissionClient,
-		kubernetesClient, storageSvcUrl,
+		kubernetesClient, storageSvcUrl, logRelay, makeArtifactCache(bmLogger), makeBuildJobManager(bmLogger, envWatcher),
 		utils.GetK8sInformersForNamespaces(kubernetesClient, time.Minute*30, fv1.Pods),
 		utils.GetInformersForNamespaces(fissionClient, time.Minute*30, fv1.PackagesResource))
 	err = pkgWatcher.Run(ctx, mgr)
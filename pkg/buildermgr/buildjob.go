@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const (
+	LABEL_PACKAGE_UID = "packageUID"
+
+	// buildModeDeployment runs the builds in a long-lived builder deployment
+	// per environment, buildModeJob in a job per build.
+	buildModeDeployment = "deployment"
+	buildModeJob        = "job"
+
+	// buildJobStartTimeout is how long to wait for the pod of a build job
+	// to be ready, including pulling the builder image.
+	buildJobStartTimeout = 5 * time.Minute
+
+	buildJobPollInterval = time.Second
+
+	// buildJobTTL is how long a build job which ended by itself, like on
+	// its deadline, is kept before being deleted.
+	buildJobTTL int32 = 300
+)
+
+type (
+	// buildJobManager runs each package build in its own job, so that builds
+	// don't share the filesystem and resources of a builder pod.
+	buildJobManager struct {
+		logger *zap.Logger
+		envw   *environmentWatcher
+	}
+)
+
+// makeBuildJobManager returns the build job manager, or nil if the builds
+// run in builder deployments.
+func makeBuildJobManager(logger *zap.Logger, envw *environmentWatcher) *buildJobManager {
+	if !envw.useBuildJobs {
+		return nil
+	}
+	return &buildJobManager{
+		logger: logger.Named("build_jobs"),
+		envw:   envw,
+	}
+}
+
+// getBuildJobName returns a unique name for a build job of the package.
+func getBuildJobName(pkg *fv1.Package) string {
+	// job names are used as label values, limited to 63 characters
+	prefix := pkg.ObjectMeta.Name
+	if len(prefix) > 50 {
+		prefix = strings.TrimRight(prefix[:50], "-.")
+	}
+	return fmt.Sprintf("%s-build-%s", prefix, strings.ToLower(uniuri.NewLen(5)))
+}
+
+// start creates a build job of the package with the environment builder and
+// waits for its pod to be ready. It returns the job and its pod, which runs
+// the builder and fetcher. The job is deleted if it fails to start.
+func (m *buildJobManager) start(ctx context.Context, logger *zap.Logger, env *fv1.Environment, pkg *fv1.Package,
+	ns string, timeout time.Duration) (*batchv1.Job, *apiv1.Pod, error) {
+	kubernetesClient := m.envw.kubernetesClient
+
+	// the jobs of an interrupted build of the package are not needed anymore
+	err := m.deleteJobs(ctx, pkg, ns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = m.envw.ensureDependencyCache(ctx, env, ns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pod, err := m.envw.getBuilderPodTemplate(env, ns)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating builder pod spec: %w", err)
+	}
+	pod.ObjectMeta.Labels[LABEL_PACKAGE_UID] = string(pkg.ObjectMeta.UID)
+	pod.Spec.RestartPolicy = apiv1.RestartPolicyNever
+
+	var ownerReferences []metav1.OwnerReference
+	if m.envw.enableOwnerReferences {
+		ownerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(pkg, schema.GroupVersionKind{
+				Group:   "fission.io",
+				Version: "v1",
+				Kind:    "Package",
+			}),
+		}
+	}
+
+	backoffLimit := int32(0)
+	ttl := buildJobTTL
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            getBuildJobName(pkg),
+			Labels:          pod.ObjectMeta.Labels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template:                *pod,
+		},
+	}
+	// buildermgr deletes the job once the build is over, the deadline only
+	// ends the jobs of the builds it couldn't follow
+	if timeout > 0 {
+		deadline := int64((timeout + buildJobStartTimeout + cleanTimeout) / time.Second)
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+
+	logger.Info("creating build job", zap.String("job", job.ObjectMeta.Name))
+	job, err = kubernetesClient.BatchV1().Jobs(ns).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating build job: %w", err)
+	}
+
+	readyPod, err := m.waitForPod(ctx, job)
+	if err != nil {
+		m.stop(ctx, logger, job)
+		return nil, nil, err
+	}
+	return job, readyPod, nil
+}
+
+// waitForPod waits for the pod of the build job to be ready. It fails early
+// if the pod can't start, like when the builder image can't be pulled.
+func (m *buildJobManager) waitForPod(ctx context.Context, job *batchv1.Job) (*apiv1.Pod, error) {
+	selector := labels.Set{batchv1.JobNameLabel: job.ObjectMeta.Name}.AsSelector().String()
+
+	var readyPod *apiv1.Pod
+	var podErr error
+	err := wait.PollUntilContextTimeout(ctx, buildJobPollInterval, buildJobStartTimeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := m.envw.kubernetesClient.CoreV1().Pods(job.ObjectMeta.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			m.logger.Warn("error listing build job pods, will retry", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+			return false, nil
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase == apiv1.PodFailed || pod.Status.Phase == apiv1.PodSucceeded {
+				podErr = fmt.Errorf("build job pod %s ended before the build: %s", pod.ObjectMeta.Name, getPodFailure(pod))
+				return false, podErr
+			}
+			for _, cStatus := range pod.Status.ContainerStatuses {
+				if w := cStatus.State.Waiting; w != nil && isPodStartFailure(w.Reason) {
+					podErr = fmt.Errorf("build job pod %s can't start container %s: %s: %s",
+						pod.ObjectMeta.Name, cStatus.Name, w.Reason, w.Message)
+					return false, podErr
+				}
+			}
+			if isPodReady(pod) && len(pod.Status.PodIP) > 0 {
+				readyPod = pod
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	switch {
+	case podErr != nil:
+		return nil, podErr
+	case ctx.Err() != nil:
+		return nil, context.Cause(ctx)
+	case err != nil:
+		return nil, fmt.Errorf("build job pod not ready after %v: %w", buildJobStartTimeout, err)
+	}
+	return readyPod, nil
+}
+
+// getFailure describes why the containers of the pod of the build job were
+// terminated, if they were, like when the builder runs out of memory.
+func (m *buildJobManager) getFailure(ctx context.Context, job *batchv1.Job) string {
+	selector := labels.Set{batchv1.JobNameLabel: job.ObjectMeta.Name}.AsSelector().String()
+	pods, err := m.envw.kubernetesClient.CoreV1().Pods(job.ObjectMeta.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		m.logger.Warn("error getting build job pods", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+		return ""
+	}
+	for i := range pods.Items {
+		if failure := getPodFailure(&pods.Items[i]); len(failure) > 0 {
+			return failure
+		}
+	}
+	return ""
+}
+
+// stop deletes the build job and its pod.
+func (m *buildJobManager) stop(ctx context.Context, logger *zap.Logger, job *batchv1.Job) {
+	// the job is deleted even if the build was canceled
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanTimeout)
+	defer cancel()
+	logger.Info("deleting build job", zap.String("job", job.ObjectMeta.Name))
+	err := m.envw.kubernetesClient.BatchV1().Jobs(job.ObjectMeta.Namespace).Delete(ctx, job.ObjectMeta.Name, delOpt)
+	if err != nil {
+		logger.Error("error deleting build job", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+	}
+}
+
+// deleteJobs deletes the build jobs of the package.
+func (m *buildJobManager) deleteJobs(ctx context.Context, pkg *fv1.Package, ns string) error {
+	selector := labels.Set{LABEL_PACKAGE_UID: string(pkg.ObjectMeta.UID)}.AsSelector().String()
+	err := m.envw.kubernetesClient.BatchV1().Jobs(ns).DeleteCollection(ctx, delOpt, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("error deleting previous build jobs of package: %w", err)
+	}
+	return nil
+}
+
+func isPodReady(pod *apiv1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, cStatus := range pod.Status.ContainerStatuses {
+		if !cStatus.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// isPodStartFailure returns whether a container waiting for the reason won't
+// start without a change of its spec.
+func isPodStartFailure(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+		return true
+	}
+	return false
+}
+
+// getPodFailure describes the terminated containers of the pod, or why the
+// pod failed.
+func getPodFailure(pod *apiv1.Pod) string {
+	var failures []string
+	for _, cStatus := range pod.Status.ContainerStatuses {
+		t := cStatus.State.Terminated
+		if t == nil {
+			continue
+		}
+		failure := fmt.Sprintf("container %s terminated with exit code %d", cStatus.Name, t.ExitCode)
+		if len(t.Reason) > 0 {
+			failure += ": " + t.Reason
+		}
+		failures = append(failures, failure)
+	}
+	if len(failures) == 0 && len(pod.Status.Reason) > 0 {
+		failures = append(failures, fmt.Sprintf("%s: %s", pod.Status.Reason, pod.Status.Message))
+	}
+	return strings.Join(failures, ", ")
+}
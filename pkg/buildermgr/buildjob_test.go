@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// startBuildJobPod creates the pod of the first build job once it exists,
+// with the given container status.
+func startBuildJobPod(t *testing.T, kubernetesClient *fake.Clientset, ns string, status apiv1.ContainerStatus) {
+	ctx := context.Background()
+	go func() {
+		for {
+			jobs, err := kubernetesClient.BatchV1().Jobs(ns).List(ctx, metav1.ListOptions{})
+			if err == nil && len(jobs.Items) > 0 {
+				job := jobs.Items[0]
+				_, err = kubernetesClient.CoreV1().Pods(ns).Create(ctx, &apiv1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      job.Name + "-pod",
+						Namespace: ns,
+						Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+					},
+					Status: apiv1.PodStatus{
+						Phase:             apiv1.PodPending,
+						PodIP:             "10.0.0.1",
+						ContainerStatuses: []apiv1.ContainerStatus{status},
+					},
+				}, metav1.CreateOptions{})
+				if err != nil {
+					t.Errorf("error creating build job pod: %v", err)
+				}
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+}
+
+func TestBuildJobManager(t *testing.T) {
+	ctx := context.Background()
+	logger := loggerfactory.GetLogger()
+	kubernetesClient := fake.NewClientset()
+	fetcherCfg, err := fetcherConfig.MakeFetcherConfig("/packages")
+	require.NoError(t, err)
+
+	envw := &environmentWatcher{
+		logger:                logger,
+		kubernetesClient:      kubernetesClient,
+		fetcherConfig:         fetcherCfg,
+		enableOwnerReferences: true,
+	}
+	require.Nil(t, makeBuildJobManager(logger, envw), "build jobs are disabled by default")
+	envw.useBuildJobs = true
+	m := makeBuildJobManager(logger, envw)
+	require.NotNil(t, m)
+
+	ns := "fission-builder"
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default", ResourceVersion: "1"},
+		Spec: fv1.EnvironmentSpec{
+			Builder: fv1.Builder{Image: "python-builder"},
+		},
+	}
+	pkg := &fv1.Package{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "pkg-uid"}}
+
+	startBuildJobPod(t, kubernetesClient, ns, apiv1.ContainerStatus{Name: fv1.BuilderContainerName, Ready: true})
+	job, pod, err := m.start(ctx, logger, env, pkg, ns, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", pod.Status.PodIP)
+	require.Equal(t, "pkg-uid", job.Labels[LABEL_PACKAGE_UID])
+	require.Equal(t, apiv1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	require.Equal(t, "Package", job.OwnerReferences[0].Kind)
+	require.NotNil(t, job.Spec.ActiveDeadlineSeconds)
+	require.Greater(t, *job.Spec.ActiveDeadlineSeconds, int64(60))
+	var containers []string
+	for _, c := range job.Spec.Template.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+	require.ElementsMatch(t, []string{fv1.BuilderContainerName, "fetcher"}, containers)
+
+	// the termination of the builder is reported
+	require.Empty(t, m.getFailure(ctx, job))
+	pod, err = kubernetesClient.CoreV1().Pods(ns).Get(ctx, job.Name+"-pod", metav1.GetOptions{})
+	require.NoError(t, err)
+	pod.Status.ContainerStatuses[0].State.Terminated = &apiv1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}
+	_, err = kubernetesClient.CoreV1().Pods(ns).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Contains(t, m.getFailure(ctx, job), "OOMKilled")
+
+	m.stop(ctx, logger, job)
+	_, err = kubernetesClient.BatchV1().Jobs(ns).Get(ctx, job.Name, metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	require.NoError(t, kubernetesClient.CoreV1().Pods(ns).Delete(ctx, pod.Name, metav1.DeleteOptions{}))
+
+	// a build job which can't start is deleted
+	startBuildJobPod(t, kubernetesClient, ns, apiv1.ContainerStatus{
+		Name:  fv1.BuilderContainerName,
+		State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "image not found"}},
+	})
+	_, _, err = m.start(ctx, logger, env, pkg, ns, 0)
+	require.ErrorContains(t, err, "ImagePullBackOff")
+	jobs, err := kubernetesClient.BatchV1().Jobs(ns).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, jobs.Items)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/fission/fission/pkg/builder"
+	builderClient "github.com/fission/fission/pkg/builder/client"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/utils/httpserver"
+	"github.com/fission/fission/pkg/utils/manager"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// apiPort is the port of the buildermgr API.
+const apiPort = "8000"
+
+var (
+	// errBuildCanceled is the cause of the cancellation of the builds
+	// canceled through the buildermgr API.
+	errBuildCanceled = errors.New("build canceled")
+)
+
+type (
+	// buildLogRelay tracks the running builds, to relay their output,
+	// streamed by the builders, to the clients of the buildermgr API, and to
+	// cancel them.
+	buildLogRelay struct {
+		logger *zap.Logger
+
+		lock   sync.Mutex
+		builds map[k8stypes.NamespacedName]*runningBuild
+	}
+
+	runningBuild struct {
+		cancel context.CancelCauseFunc
+
+		lock           sync.Mutex
+		builder        builderClient.ClientInterface
+		srcPkgFilename string
+	}
+)
+
+func makeBuildLogRelay(logger *zap.Logger) *buildLogRelay {
+	return &buildLogRelay{
+		logger: logger.Named("build_log_relay"),
+		builds: make(map[k8stypes.NamespacedName]*runningBuild),
+	}
+}
+
+// add records the build of the package, canceled with cancel. It returns the
+// build, and a function to call once the build is over.
+func (r *buildLogRelay) add(pkg k8stypes.NamespacedName, cancel context.CancelCauseFunc) (*runningBuild, func()) {
+	if r == nil {
+		return nil, func() {}
+	}
+	build := &runningBuild{
+		cancel: cancel,
+	}
+	r.lock.Lock()
+	r.builds[pkg] = build
+	r.lock.Unlock()
+	return build, func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if r.builds[pkg] == build {
+			delete(r.builds, pkg)
+		}
+	}
+}
+
+func (r *buildLogRelay) get(pkg k8stypes.NamespacedName) (*runningBuild, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	build, ok := r.builds[pkg]
+	return build, ok
+}
+
+// setBuilder records the builder running the build of the source package,
+// from which the build logs can be followed.
+func (b *runningBuild) setBuilder(builder builderClient.ClientInterface, srcPkgFilename string) {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.builder = builder
+	b.srcPkgFilename = srcPkgFilename
+}
+
+func (b *runningBuild) getBuilder() (builderClient.ClientInterface, string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.builder, b.srcPkgFilename
+}
+
+// buildLogHandler streams the output of the running build of a package line
+// by line. It responds with 404 if the package isn't being built.
+func (r *buildLogRelay) buildLogHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := otelUtils.LoggerWithTraceID(ctx, r.logger)
+
+	vars := mux.Vars(req)
+	pkg := k8stypes.NamespacedName{Namespace: vars["namespace"], Name: vars["name"]}
+	follow, _ := strconv.ParseBool(req.URL.Query().Get("follow"))
+
+	build, ok := r.get(pkg)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no running build for package %s", pkg), http.StatusNotFound)
+		return
+	}
+	builderC, srcPkgFilename := build.getBuilder()
+	if builderC == nil {
+		http.Error(w, fmt.Sprintf("build of package %s not started yet", pkg), http.StatusNotFound)
+		return
+	}
+
+	logs, err := builderC.Logs(ctx, srcPkgFilename, follow)
+	if err != nil {
+		logger.Error("error getting build logs from builder", zap.Error(err), zap.Stringer("package", pkg))
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+	defer logs.Close()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), builder.MaxBuildLogLineSize)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintln(w, scanner.Text()); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		logger.Error("error relaying build logs", zap.Error(err), zap.Stringer("package", pkg))
+	}
+}
+
+// cancelHandler cancels the running build of a package. The build command is
+// killed and the files of the build are cleaned. It responds with 404 if the
+// package isn't being built.
+func (r *buildLogRelay) cancelHandler(w http.ResponseWriter, req *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(req.Context(), r.logger)
+
+	vars := mux.Vars(req)
+	pkg := k8stypes.NamespacedName{Namespace: vars["namespace"], Name: vars["name"]}
+
+	build, ok := r.get(pkg)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no running build for package %s", pkg), http.StatusNotFound)
+		return
+	}
+	logger.Info("canceling build", zap.Stringer("package", pkg))
+	build.cancel(errBuildCanceled)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// getHandler returns the handler of the buildermgr API.
+func (r *buildLogRelay) getHandler() http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/packages/{namespace}/{name}/buildlog", r.buildLogHandler).Methods("GET")
+	router.HandleFunc("/v1/packages/{namespace}/{name}/cancel", r.cancelHandler).Methods("POST")
+	router.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET")
+	return router
+}
+
+// serve starts the buildermgr API.
+func (r *buildLogRelay) serve(ctx context.Context, mgr manager.Interface) {
+	handler := otelUtils.GetHandlerWithOTEL(r.getHandler(), "fission-buildermgr", otelUtils.UrlsToIgnore("/healthz"))
+	mgr.Add(ctx, func(ctx context.Context) {
+		httpserver.StartServer(ctx, r.logger, mgr, "buildermgr", apiPort, handler)
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/fission/fission/pkg/builder"
+	bmClient "github.com/fission/fission/pkg/buildermgr/client"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+type fakeBuilderClient struct {
+	logs map[string]string
+}
+
+func (c *fakeBuilderClient) Build(ctx context.Context, req *builder.PackageBuildRequest) (*builder.PackageBuildResponse, error) {
+	return nil, nil
+}
+
+func (c *fakeBuilderClient) Clean(ctx context.Context, srcPkgFilename string) error {
+	return nil
+}
+
+func (c *fakeBuilderClient) Logs(ctx context.Context, srcPkgFilename string, follow bool) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(c.logs[srcPkgFilename])), nil
+}
+
+func TestBuildLogRelay(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	relay := makeBuildLogRelay(logger)
+	server := httptest.NewServer(relay.getHandler())
+	defer server.Close()
+	client := bmClient.MakeClient(logger, server.URL)
+	ctx := context.Background()
+
+	_, err := client.BuildLog(ctx, "default", "hello", true)
+	require.True(t, ferror.IsNotFound(err), "expected not found error, got %v", err)
+
+	pkg := k8stypes.NamespacedName{Namespace: "default", Name: "hello"}
+	buildCtx, cancel := context.WithCancelCause(ctx)
+	build, done := relay.add(pkg, cancel)
+
+	// the build waits for the builder
+	_, err = client.BuildLog(ctx, "default", "hello", true)
+	require.True(t, ferror.IsNotFound(err), "expected not found error, got %v", err)
+
+	builderC := &fakeBuilderClient{logs: map[string]string{"hello-abc": "installing\ndone\n"}}
+	build.setBuilder(builderC, "hello-abc")
+
+	logs, err := client.BuildLog(ctx, "default", "hello", true)
+	require.NoError(t, err)
+	out, err := io.ReadAll(logs)
+	require.NoError(t, err)
+	logs.Close()
+	require.Equal(t, "installing\ndone\n", string(out))
+
+	require.NoError(t, client.CancelBuild(ctx, "default", "hello"))
+	require.ErrorIs(t, context.Cause(buildCtx), errBuildCanceled)
+
+	done()
+	_, err = client.BuildLog(ctx, "default", "hello", false)
+	require.True(t, ferror.IsNotFound(err), "expected not found error, got %v", err)
+	err = client.CancelBuild(ctx, "default", "hello")
+	require.True(t, ferror.IsNotFound(err), "expected not found error, got %v", err)
+
+	resp, err := http.Get(server.URL + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
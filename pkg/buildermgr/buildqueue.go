@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/generated/clientset/versioned"
+)
+
+const (
+	// defaultMaxParallelBuilds is how many builds of an environment run at
+	// the same time, unless set with BUILD_MAX_PARALLEL or by the environment.
+	defaultMaxParallelBuilds = 4
+
+	// queuePositionInterval is how often the queue positions of the waiting
+	// packages are reported in their status.
+	queuePositionInterval = 5 * time.Second
+)
+
+type (
+	// buildQueue limits how many builds of an environment run at the same
+	// time. The waiting builds of an environment start in turn across the
+	// namespaces of their packages, and in order within a namespace, so that
+	// a namespace changing many packages doesn't hold back the others.
+	buildQueue struct {
+		logger        *zap.Logger
+		fissionClient versioned.Interface
+		maxParallel   int
+
+		lock   sync.Mutex
+		envs   map[k8stypes.NamespacedName]*envBuildQueue
+		queued map[k8stypes.UID]*queuedBuild
+	}
+
+	envBuildQueue struct {
+		limit   int
+		running int
+		// namespaces with waiting builds, in the order they're served
+		namespaces []string
+		waiting    map[string][]*queuedBuild
+	}
+
+	queuedBuild struct {
+		pkg      k8stypes.NamespacedName
+		uid      k8stypes.UID
+		enqueued time.Time
+		ready    chan struct{}
+
+		// lock is held while the queue position of the package is
+		// reported, so that it's not reported once the build started.
+		lock     sync.Mutex
+		started  bool
+		position int
+	}
+)
+
+// makeBuildQueue returns the build queue. BUILD_MAX_PARALLEL sets how many
+// builds of an environment run at the same time by default, 0 for no limit.
+func makeBuildQueue(logger *zap.Logger, fissionClient versioned.Interface) *buildQueue {
+	maxParallel := defaultMaxParallelBuilds
+	if v := os.Getenv("BUILD_MAX_PARALLEL"); len(v) > 0 {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			logger.Error("Failed to parse BUILD_MAX_PARALLEL, defaults to " + strconv.Itoa(defaultMaxParallelBuilds))
+		} else {
+			maxParallel = n
+		}
+	}
+	return &buildQueue{
+		logger:        logger.Named("build_queue"),
+		fissionClient: fissionClient,
+		maxParallel:   maxParallel,
+		envs:          make(map[k8stypes.NamespacedName]*envBuildQueue),
+		queued:        make(map[k8stypes.UID]*queuedBuild),
+	}
+}
+
+// getLimit returns how many builds of the environment run at the same time,
+// 0 for no limit.
+func (q *buildQueue) getLimit(env *fv1.Environment) int {
+	if env != nil && env.Spec.Builder.MaxParallelBuilds > 0 {
+		return env.Spec.Builder.MaxParallelBuilds
+	}
+	return q.maxParallel
+}
+
+// isQueued returns whether the package waits in the queue.
+func (q *buildQueue) isQueued(pkg *fv1.Package) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	_, ok := q.queued[pkg.ObjectMeta.UID]
+	return ok
+}
+
+// wait waits for the build of the package to be allowed to start by the
+// limit of its environment. It returns a function to call once the build is
+// over, or the cause of the cancellation of ctx.
+func (q *buildQueue) wait(ctx context.Context, pkg *fv1.Package, env *fv1.Environment) (func(), error) {
+	envKey := k8stypes.NamespacedName{Namespace: pkg.Spec.Environment.Namespace, Name: pkg.Spec.Environment.Name}
+	b := &queuedBuild{
+		pkg:      k8stypes.NamespacedName{Namespace: pkg.ObjectMeta.Namespace, Name: pkg.ObjectMeta.Name},
+		uid:      pkg.ObjectMeta.UID,
+		enqueued: time.Now(),
+		ready:    make(chan struct{}),
+	}
+
+	q.lock.Lock()
+	eq, ok := q.envs[envKey]
+	if !ok {
+		eq = &envBuildQueue{waiting: make(map[string][]*queuedBuild)}
+		q.envs[envKey] = eq
+	}
+	eq.limit = q.getLimit(env)
+	if len(eq.waiting[b.pkg.Namespace]) == 0 {
+		eq.namespaces = append(eq.namespaces, b.pkg.Namespace)
+	}
+	eq.waiting[b.pkg.Namespace] = append(eq.waiting[b.pkg.Namespace], b)
+	q.queued[b.uid] = b
+	q.dispatch(envKey, eq)
+	q.lock.Unlock()
+
+	release := func() {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		eq.running--
+		q.dispatch(envKey, eq)
+	}
+
+	select {
+	case <-b.ready:
+	case <-ctx.Done():
+		q.lock.Lock()
+		removed := q.remove(envKey, eq, b)
+		q.lock.Unlock()
+		if !removed {
+			// the build was started meanwhile
+			release()
+		}
+		return nil, context.Cause(ctx)
+	}
+
+	// wait for the report of the queue position in progress, if any
+	b.lock.Lock()
+	b.started = true
+	b.lock.Unlock()
+	return release, nil
+}
+
+// dispatch starts the waiting builds of the environment within its limit.
+// The queue lock must be held.
+func (q *buildQueue) dispatch(envKey k8stypes.NamespacedName, eq *envBuildQueue) {
+	for len(eq.namespaces) > 0 && (eq.limit == 0 || eq.running < eq.limit) {
+		ns := eq.namespaces[0]
+		b := eq.waiting[ns][0]
+		eq.waiting[ns] = eq.waiting[ns][1:]
+		eq.namespaces = eq.namespaces[1:]
+		if len(eq.waiting[ns]) > 0 {
+			eq.namespaces = append(eq.namespaces, ns)
+		} else {
+			delete(eq.waiting, ns)
+		}
+		delete(q.queued, b.uid)
+		eq.running++
+		buildQueueWait.WithLabelValues(envKey.Name, envKey.Namespace).Observe(time.Since(b.enqueued).Seconds())
+		close(b.ready)
+	}
+	q.updateMetrics(envKey, eq)
+}
+
+// remove removes the waiting build from the queue. It returns false if the
+// build isn't waiting anymore. The queue lock must be held.
+func (q *buildQueue) remove(envKey k8stypes.NamespacedName, eq *envBuildQueue, b *queuedBuild) bool {
+	if _, ok := q.queued[b.uid]; !ok {
+		return false
+	}
+	delete(q.queued, b.uid)
+	ns := b.pkg.Namespace
+	for i, w := range eq.waiting[ns] {
+		if w == b {
+			eq.waiting[ns] = append(eq.waiting[ns][:i], eq.waiting[ns][i+1:]...)
+			break
+		}
+	}
+	if len(eq.waiting[ns]) == 0 {
+		delete(eq.waiting, ns)
+		for i, n := range eq.namespaces {
+			if n == ns {
+				eq.namespaces = append(eq.namespaces[:i], eq.namespaces[i+1:]...)
+				break
+			}
+		}
+	}
+	q.updateMetrics(envKey, eq)
+	return true
+}
+
+// updateMetrics updates the metrics of the queue of the environment, and
+// drops the queue once it's empty. The queue lock must be held.
+func (q *buildQueue) updateMetrics(envKey k8stypes.NamespacedName, eq *envBuildQueue) {
+	waiting := 0
+	for _, builds := range eq.waiting {
+		waiting += len(builds)
+	}
+	buildQueueDepth.WithLabelValues(envKey.Name, envKey.Namespace).Set(float64(waiting))
+	buildsRunning.WithLabelValues(envKey.Name, envKey.Namespace).Set(float64(eq.running))
+	if waiting == 0 && eq.running == 0 {
+		delete(q.envs, envKey)
+	}
+}
+
+// positions returns the waiting builds of the environment with their
+// positions, following the order they'll start in. The queue lock must be
+// held.
+func (eq *envBuildQueue) positions() map[*queuedBuild]int {
+	positions := make(map[*queuedBuild]int)
+	for round := 0; ; round++ {
+		added := false
+		for _, ns := range eq.namespaces {
+			if round < len(eq.waiting[ns]) {
+				positions[eq.waiting[ns][round]] = len(positions) + 1
+				added = true
+			}
+		}
+		if !added {
+			return positions
+		}
+	}
+}
+
+// run reports the queue positions of the waiting packages in their status
+// until ctx is done.
+func (q *buildQueue) run(ctx context.Context) {
+	ticker := time.NewTicker(queuePositionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.reportPositions(ctx)
+		}
+	}
+}
+
+// reportPositions updates the queue position in the status of the waiting
+// packages whose position changed.
+func (q *buildQueue) reportPositions(ctx context.Context) {
+	positions := make(map[*queuedBuild]int)
+	q.lock.Lock()
+	for _, eq := range q.envs {
+		for b, position := range eq.positions() {
+			positions[b] = position
+		}
+	}
+	q.lock.Unlock()
+
+	for b, position := range positions {
+		if b.position == position {
+			continue
+		}
+		err := q.reportPosition(ctx, b, position)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			q.logger.Warn("error reporting package queue position", zap.Error(err),
+				zap.String("package", b.pkg.Name), zap.String("namespace", b.pkg.Namespace))
+		}
+	}
+}
+
+func (q *buildQueue) reportPosition(ctx context.Context, b *queuedBuild, position int) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.started {
+		return nil
+	}
+	pkg, err := q.fissionClient.CoreV1().Packages(b.pkg.Namespace).Get(ctx, b.pkg.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pkg.ObjectMeta.UID != b.uid || pkg.Status.BuildStatus != fv1.BuildStatusPending {
+		return nil
+	}
+	pkg.Status.QueuePosition = position
+	_, err = q.fissionClient.CoreV1().Packages(b.pkg.Namespace).Update(ctx, pkg, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	b.position = position
+	return nil
+}
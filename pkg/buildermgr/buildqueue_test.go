@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fissionfake "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func makeQueuedPackage(namespace, name string) *fv1.Package {
+	return &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: k8stypes.UID(namespace + "-" + name)},
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "python", Namespace: "default"},
+		},
+		Status: fv1.PackageStatus{BuildStatus: fv1.BuildStatusPending},
+	}
+}
+
+type queueResult struct {
+	release func()
+	err     error
+}
+
+// waitAsync waits for the turn of the package in the queue in the
+// background, once it's queued.
+func waitAsync(t *testing.T, ctx context.Context, q *buildQueue, pkg *fv1.Package, env *fv1.Environment) chan queueResult {
+	result := make(chan queueResult, 1)
+	go func() {
+		release, err := q.wait(ctx, pkg, env)
+		result <- queueResult{release, err}
+	}()
+	require.Eventually(t, func() bool {
+		select {
+		case r := <-result:
+			// started without waiting
+			result <- r
+			return true
+		default:
+			return q.isQueued(pkg)
+		}
+	}, time.Second, time.Millisecond)
+	return result
+}
+
+func requireStarted(t *testing.T, result chan queueResult) func() {
+	select {
+	case r := <-result:
+		require.NoError(t, r.err)
+		return r.release
+	case <-time.After(time.Second):
+		t.Fatal("build not started")
+		return nil
+	}
+}
+
+func requireWaiting(t *testing.T, result chan queueResult) {
+	select {
+	case <-result:
+		t.Fatal("build started while the limit is reached")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestBuildQueue(t *testing.T) {
+	ctx := context.Background()
+	fissionClient := fissionfake.NewSimpleClientset()
+	q := makeBuildQueue(loggerfactory.GetLogger(), fissionClient)
+	require.Equal(t, defaultMaxParallelBuilds, q.maxParallel)
+
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"},
+		Spec:       fv1.EnvironmentSpec{Builder: fv1.Builder{MaxParallelBuilds: 1}},
+	}
+
+	pkgs := map[string]*fv1.Package{}
+	for _, p := range []struct{ ns, name string }{{"a", "a1"}, {"a", "a2"}, {"a", "a3"}, {"b", "b1"}} {
+		pkg := makeQueuedPackage(p.ns, p.name)
+		_, err := fissionClient.CoreV1().Packages(p.ns).Create(ctx, pkg, metav1.CreateOptions{})
+		require.NoError(t, err)
+		pkgs[p.name] = pkg
+	}
+
+	a1 := waitAsync(t, ctx, q, pkgs["a1"], env)
+	releaseA1 := requireStarted(t, a1)
+
+	a2 := waitAsync(t, ctx, q, pkgs["a2"], env)
+	a3 := waitAsync(t, ctx, q, pkgs["a3"], env)
+	b1 := waitAsync(t, ctx, q, pkgs["b1"], env)
+	requireWaiting(t, a2)
+
+	// the namespaces take turns
+	q.reportPositions(ctx)
+	for name, position := range map[string]int{"a2": 1, "b1": 2, "a3": 3} {
+		pkg, err := fissionClient.CoreV1().Packages(pkgs[name].Namespace).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, position, pkg.Status.QueuePosition, name)
+	}
+
+	releaseA1()
+	releaseA2 := requireStarted(t, a2)
+	requireWaiting(t, a3)
+	releaseA2()
+	releaseB1 := requireStarted(t, b1)
+	releaseB1()
+	requireStarted(t, a3)()
+
+	require.Empty(t, q.envs)
+	require.Empty(t, q.queued)
+}
+
+func TestBuildQueueCancel(t *testing.T) {
+	ctx := context.Background()
+	q := makeBuildQueue(loggerfactory.GetLogger(), fissionfake.NewSimpleClientset())
+	q.maxParallel = 1
+
+	release := requireStarted(t, waitAsync(t, ctx, q, makeQueuedPackage("a", "a1"), nil))
+
+	cancelCtx, cancel := context.WithCancelCause(ctx)
+	canceled := waitAsync(t, cancelCtx, q, makeQueuedPackage("a", "a2"), nil)
+	errCanceled := errors.New("canceled")
+	cancel(errCanceled)
+	select {
+	case r := <-canceled:
+		require.ErrorIs(t, r.err, errCanceled)
+	case <-time.After(time.Second):
+		t.Fatal("queued build not canceled")
+	}
+
+	a3 := waitAsync(t, ctx, q, makeQueuedPackage("a", "a3"), nil)
+	release()
+	requireStarted(t, a3)()
+	require.Empty(t, q.envs)
+
+	// no limit
+	q.maxParallel = 0
+	for i := 0; i < 10; i++ {
+		defer requireStarted(t, waitAsync(t, ctx, q, makeQueuedPackage("a", fmt.Sprintf("p%d", i)), nil))()
+	}
+}
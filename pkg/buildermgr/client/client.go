@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.uber.org/zap"
+
+	ferror "github.com/fission/fission/pkg/error"
+)
+
+type (
+	// ClientInterface is the client of the buildermgr API.
+	ClientInterface interface {
+		// BuildLog returns the output of the running build of the package.
+		// With follow set, the output is streamed until the build ends.
+		// It returns a not found error if the package isn't being built.
+		BuildLog(ctx context.Context, namespace, name string, follow bool) (io.ReadCloser, error)
+		// CancelBuild cancels the running build of the package. It returns
+		// a not found error if the package isn't being built.
+		CancelBuild(ctx context.Context, namespace, name string) error
+	}
+
+	client struct {
+		logger     *zap.Logger
+		url        string
+		httpClient *http.Client
+	}
+)
+
+// MakeClient returns a client of the buildermgr API at the given URL.
+func MakeClient(logger *zap.Logger, builderMgrUrl string) ClientInterface {
+	return &client{
+		logger:     logger.Named("buildermgr_client"),
+		url:        strings.TrimSuffix(builderMgrUrl, "/"),
+		httpClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+	}
+}
+
+func (c *client) BuildLog(ctx context.Context, namespace, name string, follow bool) (io.ReadCloser, error) {
+	u := fmt.Sprintf("%s/v1/packages/%s/%s/buildlog", c.url, url.PathEscape(namespace), url.PathEscape(name))
+	if follow {
+		u += "?follow=true"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating http request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending build log request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+	return resp.Body, nil
+}
+
+func (c *client) CancelBuild(ctx context.Context, namespace, name string) error {
+	u := fmt.Sprintf("%s/v1/packages/%s/%s/cancel", c.url, url.PathEscape(namespace), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("error creating http request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending cancel build request: %w", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return ferror.MakeErrorFromHTTP(resp)
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -18,8 +18,12 @@ package buildermgr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -34,80 +38,181 @@ import (
 	"github.com/fission/fission/pkg/fetcher"
 	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
+	storageSvcClient "github.com/fission/fission/pkg/storagesvc/client"
 )
 
+// buildLogTailSize is the size of the tail of build logs kept in packages.
+// Longer build logs are stored in storagesvc.
+const buildLogTailSize = 4 * 1024
+
+// cleanTimeout is the deadline of cleaning the source package from the
+// builder, which is done even if the build was canceled.
+const cleanTimeout = 30 * time.Second
+
+// errBuildTimeout is the cause of the cancellation of the builds taking
+// longer than their timeout.
+var errBuildTimeout = errors.New("build timed out")
+
+// infraError is a build failure caused by the build infrastructure, like a
+// restart of the builder pod, rather than by the build itself. The builds
+// failing with an infrastructure error are retried.
+type infraError struct {
+	error
+}
+
+func (e infraError) Unwrap() error {
+	return e.error
+}
+
+func isInfraError(err error) bool {
+	var ie infraError
+	return errors.As(err, &ie)
+}
+
+// isUnreachable returns whether the request failed without getting a
+// response from the service, like when its pod restarts.
+func isUnreachable(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	code, _ := ferror.GetHTTPError(err)
+	return code == http.StatusServiceUnavailable
+}
+
+// buildOutput is the output of a successful package build.
+type buildOutput struct {
+	// deployment is the upload response of the deployment archive.
+	deployment *fetcher.ArchiveUploadResponse
+	// sbom is the upload response of the SBOM emitted by the build, if any.
+	sbom *fetcher.ArchiveUploadResponse
+	// sourceCommit is the commit checked out for git sources.
+	sourceCommit string
+	// builderPod is the name of the builder pod which ran the build, if
+	// the builder reports it.
+	builderPod string
+}
+
 // buildPackage helps to build source package into deployment package.
 // Following is the steps buildPackage function takes to complete the whole process.
 // 1. Send fetch request to fetcher to fetch source package.
 // 2. Send build request to builder to start a build.
-// 3. Send upload request to fetcher to upload deployment package.
-// 4. Return upload response and build logs.
+// 3. Send upload request to fetcher to upload the SBOM emitted by the build, if any.
+// 4. Send upload request to fetcher to upload deployment package.
+// 5. Return upload responses, checked out source commit and build logs.
+// The fetcher and builder are reached at builderHost, the builder service or
+// the pod of the build job.
 // *. Return build logs and error if any one of steps above failed.
-func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface, envBuilderNamespace string,
-	storageSvcUrl string, pkg *fv1.Package) (uploadResp *fetcher.ArchiveUploadResponse, buildLogs string, err error) {
+// The steps are canceled after the timeout, if any. The error is an
+// infraError if the build may succeed when retried.
+func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface, builderHost string,
+	storageSvcUrl string, build *runningBuild, timeout time.Duration, pkg *fv1.Package) (output *buildOutput, buildLogs string, err error) {
 
 	env, err := fissionClient.CoreV1().Environments(pkg.Spec.Environment.Namespace).Get(ctx, pkg.Spec.Environment.Name, metav1.GetOptions{})
 	if err != nil {
 		e := "error getting environment CRD info"
 		logger.Error(e, zap.Error(err))
 		e = fmt.Sprintf("%s: %v", e, err)
-		return nil, e, ferror.MakeError(http.StatusInternalServerError, e)
+		return nil, e, infraError{ferror.MakeError(http.StatusInternalServerError, e)}
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w after %v", errBuildTimeout, timeout))
+		defer cancel()
+	}
+
+	// stepError returns the error of a failed step: the cause of the
+	// cancellation of the build if it was canceled or timed out, or an
+	// infraError if infra is set.
+	stepError := func(e string, infra bool) error {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		err := ferror.MakeError(http.StatusInternalServerError, e)
+		if infra {
+			return infraError{err}
+		}
+		return err
 	}
 
-	svcName := fmt.Sprintf("%s-%s.%s", env.Name, env.ResourceVersion, envBuilderNamespace)
 	srcPkgFilename := fmt.Sprintf("%s-%s", pkg.Name, strings.ToLower(uniuri.NewLen(6)))
-	fetcherC := fetcherClient.MakeClient(logger, fmt.Sprintf("http://%s:8000", svcName))
-	builderC := builderClient.MakeClient(logger, fmt.Sprintf("http://%s:8001", svcName))
+	fetcherC := fetcherClient.MakeClient(logger, "http://"+net.JoinHostPort(builderHost, "8000"))
+	builderC := builderClient.MakeClient(logger, "http://"+net.JoinHostPort(builderHost, "8001"))
 
 	defer func() {
+		// the source package is cleaned even if the build was canceled
+		cleanCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanTimeout)
+		defer cancel()
 		logger.Info("cleaning src pkg from builder storage", zap.String("source_package", srcPkgFilename))
-		errC := cleanPackage(ctx, builderC, srcPkgFilename)
+		errC := cleanPackage(cleanCtx, builderC, srcPkgFilename)
 		if errC != nil {
 			m := "error cleaning src pkg from builder storage"
 			logger.Error(m, zap.Error(errC))
 		}
 	}()
 
+	// the build logs can be followed from the start of the build
+	build.setBuilder(builderC, srcPkgFilename)
+
 	fetchReq := &fetcher.FunctionFetchRequest{
 		FetchType:   fv1.FETCH_SOURCE,
 		Package:     pkg.ObjectMeta,
 		Filename:    srcPkgFilename,
 		KeepArchive: false,
+		ImagePullSecrets: fetcher.ImagePullSecrets(env.Spec.ImagePullSecret,
+			env.Spec.Builder.PodSpec),
 	}
 
 	// send fetch request to fetcher
-	err = fetcherC.Fetch(ctx, fetchReq)
+	fetchResp, err := fetcherC.Fetch(ctx, fetchReq)
 	if err != nil {
-		e := "error fetching source package"
-		logger.Error(e, zap.Error(err))
-		e = fmt.Sprintf("%s: %v", e, err)
-		return nil, e, ferror.MakeError(http.StatusInternalServerError, e)
+		logger.Error("error fetching source package", zap.Error(err))
+		err = stepError(fmt.Sprintf("error fetching source package: %v", err), isUnreachable(err))
+		return nil, fmt.Sprintf("%v\n", err), err
 	}
-
-	buildCmd := pkg.Spec.BuildCommand
-	if len(buildCmd) == 0 {
-		buildCmd = env.Spec.Builder.Command
+	output = &buildOutput{sourceCommit: fetchResp.SourceCommit}
+	var sourceLogs string
+	if len(output.sourceCommit) > 0 {
+		sourceLogs = fmt.Sprintf("Checked out commit %s of %s\n", output.sourceCommit, pkg.Spec.Source.URL)
 	}
 
 	pkgBuildReq := &builder.PackageBuildRequest{
 		SrcPkgFilename: srcPkgFilename,
-		BuildCommand:   buildCmd,
+		BuildCommand:   getBuildCommand(pkg, env),
 	}
 
 	logger.Info("started building with source package", zap.String("source_package", srcPkgFilename))
 	// send build request to builder
 	buildResp, err := builderC.Build(ctx, pkgBuildReq)
 	if err != nil {
-		e := fmt.Sprintf("Error building deployment package: %v", err)
 		var buildLogs string
 		if buildResp != nil {
 			buildLogs = buildResp.BuildLogs
 		}
-		buildLogs += fmt.Sprintf("%v\n", e)
-		return nil, buildLogs, ferror.MakeError(http.StatusInternalServerError, e)
+		// the build failed, unless the builder couldn't respond
+		err = stepError(fmt.Sprintf("Error building deployment package: %v", err), buildResp == nil || isUnreachable(err))
+		buildLogs += fmt.Sprintf("%v\n", err)
+		return nil, sourceLogs + buildLogs, err
 	}
 
 	logger.Info("build succeed", zap.String("source_package", srcPkgFilename), zap.String("deployment_package", buildResp.ArtifactFilename))
+	output.builderPod = buildResp.BuilderPod
+
+	// the SBOM is part of the provenance of the build, the build succeeds
+	// without it if it can't be stored
+	if len(buildResp.SBOMFilename) > 0 {
+		logger.Info("started uploading SBOM", zap.String("sbom", buildResp.SBOMFilename))
+		output.sbom, err = fetcherC.Upload(ctx, &fetcher.ArchiveUploadRequest{
+			Filename:       buildResp.SBOMFilename,
+			StorageSvcUrl:  storageSvcUrl,
+			ArchivePackage: false,
+		})
+		if err != nil {
+			logger.Error("error uploading SBOM", zap.Error(err))
+			buildResp.BuildLogs += fmt.Sprintf("Error uploading SBOM, the build provenance has no SBOM: %v\n", err)
+		}
+	}
 
 	archivePackage := !env.Spec.KeepArchive
 
@@ -119,14 +224,14 @@ func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient version
 
 	logger.Info("started uploading deployment package", zap.String("deployment_package", buildResp.ArtifactFilename))
 	// ask fetcher to upload the deployment package
-	uploadResp, err = fetcherC.Upload(ctx, uploadReq)
+	output.deployment, err = fetcherC.Upload(ctx, uploadReq)
 	if err != nil {
-		e := fmt.Sprintf("Error uploading deployment package: %v", err)
-		buildResp.BuildLogs += fmt.Sprintf("%v\n", e)
-		return nil, buildResp.BuildLogs, ferror.MakeError(http.StatusInternalServerError, e)
+		err = stepError(fmt.Sprintf("Error uploading deployment package: %v", err), true)
+		buildResp.BuildLogs += fmt.Sprintf("%v\n", err)
+		return nil, sourceLogs + buildResp.BuildLogs, err
 	}
 
-	return uploadResp, buildResp.BuildLogs, nil
+	return output, sourceLogs + buildResp.BuildLogs, nil
 }
 
 func cleanPackage(ctx context.Context, builderClient builderClient.ClientInterface, srcPkgFileName string) error {
@@ -138,13 +243,21 @@ func cleanPackage(ctx context.Context, builderClient builderClient.ClientInterfa
 	return nil
 }
 
-func updatePackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface,
+// updatePackage updates the build status and logs of the package. With an
+// upload response, the deployment archive of the package is replaced along
+// with the provenance of its build, which otherwise describes the previous
+// deployment archive.
+func updatePackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface, storageSvcUrl string,
 	pkg *fv1.Package, status fv1.BuildStatus, buildLogs string,
-	uploadResp *fetcher.ArchiveUploadResponse) (*fv1.Package, error) {
+	uploadResp *fetcher.ArchiveUploadResponse, provenance *fv1.BuildProvenance) (*fv1.Package, error) {
 
+	buildLog, buildLogArchiveID := storeBuildLog(ctx, logger, storageSvcUrl, pkg, buildLogs)
 	pkg.Status = fv1.PackageStatus{
 		BuildStatus:         status,
-		BuildLog:            buildLogs,
+		BuildLog:            buildLog,
+		BuildLogArchiveID:   buildLogArchiveID,
+		BuildCacheKey:       pkg.Status.BuildCacheKey,
+		Provenance:          pkg.Status.Provenance,
 		LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
 	}
 
@@ -154,6 +267,7 @@ func updatePackage(ctx context.Context, logger *zap.Logger, fissionClient versio
 			URL:      uploadResp.ArchiveDownloadUrl,
 			Checksum: uploadResp.Checksum,
 		}
+		pkg.Status.Provenance = provenance
 	}
 
 	// update package spec
@@ -167,3 +281,50 @@ func updatePackage(ctx context.Context, logger *zap.Logger, fissionClient versio
 	// return resource version for function to update function package ref
 	return pkg, nil
 }
+
+// storeBuildLog uploads the build log to storagesvc if it's too long to be
+// kept in the package. It returns the build log to keep in the package, and
+// the ID of the archive holding the full build log if it was uploaded.
+func storeBuildLog(ctx context.Context, logger *zap.Logger, storageSvcUrl string,
+	pkg *fv1.Package, buildLogs string) (string, string) {
+	if len(buildLogs) <= buildLogTailSize || len(storageSvcUrl) == 0 {
+		return buildLogs, ""
+	}
+	tail := buildLogTail(buildLogs)
+
+	id, err := uploadBuildLog(ctx, storageSvcUrl, pkg, buildLogs)
+	if err != nil {
+		logger.Error("error uploading build log, keeping its tail only", zap.Error(err))
+		return fmt.Sprintf("(build log truncated, error storing the full build log: %v)\n%s", err, tail), ""
+	}
+	return tail, id
+}
+
+func uploadBuildLog(ctx context.Context, storageSvcUrl string, pkg *fv1.Package, buildLogs string) (string, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("%s-buildlog-*.log", pkg.ObjectMeta.Name))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(buildLogs)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	return storageSvcClient.MakeClient(storageSvcUrl).Upload(ctx, f.Name(), nil)
+}
+
+// buildLogTail returns the last lines of the build log which fit in the tail
+// kept in the package.
+func buildLogTail(buildLogs string) string {
+	if len(buildLogs) <= buildLogTailSize {
+		return buildLogs
+	}
+	tail := buildLogs[len(buildLogs)-buildLogTailSize:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return tail
+}
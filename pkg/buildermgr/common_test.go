@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestStoreBuildLog(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	ctx := context.Background()
+	pkg := &fv1.Package{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"}}
+
+	var uploaded string
+	storagesvc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/archive" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		f, _, err := r.FormFile("uploadfile")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		uploaded = string(content)
+		fmt.Fprint(w, `{"id":"buildlog-id"}`)
+	}))
+	defer storagesvc.Close()
+
+	// short build logs are kept in the package
+	buildLog, id := storeBuildLog(ctx, logger, storagesvc.URL, pkg, "done\n")
+	require.Equal(t, "done\n", buildLog)
+	require.Empty(t, id)
+
+	var sb strings.Builder
+	for i := 0; sb.Len() <= buildLogTailSize; i++ {
+		fmt.Fprintf(&sb, "installing dependency %d\n", i)
+	}
+	buildLogs := sb.String()
+
+	buildLog, id = storeBuildLog(ctx, logger, storagesvc.URL, pkg, buildLogs)
+	require.Equal(t, "buildlog-id", id)
+	require.Equal(t, buildLogs, uploaded)
+	require.LessOrEqual(t, len(buildLog), buildLogTailSize)
+	require.True(t, strings.HasSuffix(buildLogs, buildLog))
+	require.True(t, strings.HasPrefix(buildLog, "installing dependency "), "tail should start at a line, got %q", buildLog[:40])
+
+	// the tail is kept if the build log can't be uploaded
+	storagesvc.Close()
+	buildLog, id = storeBuildLog(ctx, logger, storagesvc.URL, pkg, buildLogs)
+	require.Empty(t, id)
+	require.True(t, strings.HasPrefix(buildLog, "(build log truncated"))
+}
+
+func TestIsUnreachable(t *testing.T) {
+	require.True(t, isUnreachable(&url.Error{Op: "Post", URL: "http://builder", Err: errors.New("connection refused")}))
+	require.True(t, isUnreachable(fmt.Errorf("error fetching: %w", ferror.MakeError(ferror.ErrorServiceUnavailable, "unavailable"))))
+	require.False(t, isUnreachable(ferror.MakeError(ferror.ErrorInternal, "build command failed")))
+	require.False(t, isUnreachable(errors.New("build command failed")))
+
+	err := fmt.Errorf("error building: %w", infraError{errors.New("connection refused")})
+	require.True(t, isInfraError(err))
+	require.False(t, isInfraError(errors.New("build command failed")))
+}
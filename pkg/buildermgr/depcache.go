@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const dependencyCacheVolumeName = "build-cache"
+
+// getDependencyCacheClaimName returns the name of the persistent volume claim
+// of the dependency cache of the environment builder.
+func getDependencyCacheClaimName(env *fv1.Environment) string {
+	return fmt.Sprintf("%s-%s-build-cache", env.ObjectMeta.Name, env.ObjectMeta.Namespace)
+}
+
+// getDependencyCacheMountPath returns where the dependency cache is mounted
+// in the builder container.
+func getDependencyCacheMountPath(cache *fv1.BuilderDependencyCache) string {
+	if len(cache.MountPath) > 0 {
+		return cache.MountPath
+	}
+	return fv1.DefaultBuilderDependencyCacheMountPath
+}
+
+// getDependencyCacheAccessMode returns the access mode of the persistent
+// volume claim of the dependency cache. Build jobs of the environment run
+// concurrently and may land on different nodes, so they default to a volume
+// every node can mount.
+func (envw *environmentWatcher) getDependencyCacheAccessMode(cache *fv1.BuilderDependencyCache) apiv1.PersistentVolumeAccessMode {
+	if len(cache.AccessMode) > 0 {
+		return cache.AccessMode
+	}
+	if envw.useBuildJobs {
+		return apiv1.ReadWriteMany
+	}
+	return apiv1.ReadWriteOnce
+}
+
+// ensureDependencyCache creates the persistent volume claim of the dependency
+// cache of the environment builder if the environment has one, or deletes it
+// otherwise. An existing claim is kept as is, so the cache survives the
+// updates of the environment.
+func (envw *environmentWatcher) ensureDependencyCache(ctx context.Context, env *fv1.Environment, ns string) error {
+	if env.Spec.Builder.DependencyCache == nil {
+		return envw.deleteDependencyCache(ctx, env, ns)
+	}
+
+	name := getDependencyCacheClaimName(env)
+	_, err := envw.kubernetesClient.CoreV1().PersistentVolumeClaims(ns).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error getting builder dependency cache %s.%s: %w", name, ns, err)
+	}
+
+	var ownerReferences []metav1.OwnerReference
+	if envw.enableOwnerReferences {
+		ownerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(env, schema.GroupVersionKind{
+				Group:   "fission.io",
+				Version: "v1",
+				Kind:    "Environment",
+			}),
+		}
+	}
+
+	claim := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            name,
+			Labels:          envw.getDeploymentLabels(env.ObjectMeta.Name),
+			OwnerReferences: ownerReferences,
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes:      []apiv1.PersistentVolumeAccessMode{envw.getDependencyCacheAccessMode(env.Spec.Builder.DependencyCache)},
+			StorageClassName: env.Spec.Builder.DependencyCache.StorageClassName,
+			Resources: apiv1.VolumeResourceRequirements{
+				Requests: apiv1.ResourceList{
+					apiv1.ResourceStorage: env.Spec.Builder.DependencyCache.Size,
+				},
+			},
+		},
+	}
+	envw.logger.Info("creating builder dependency cache", zap.String("claim", name), zap.String("namespace", ns))
+	_, err = envw.kubernetesClient.CoreV1().PersistentVolumeClaims(ns).Create(ctx, claim, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating builder dependency cache %s.%s: %w", name, ns, err)
+	}
+	return nil
+}
+
+// deleteDependencyCache deletes the persistent volume claim of the dependency
+// cache of the environment builder, if any.
+func (envw *environmentWatcher) deleteDependencyCache(ctx context.Context, env *fv1.Environment, ns string) error {
+	name := getDependencyCacheClaimName(env)
+	err := envw.kubernetesClient.CoreV1().PersistentVolumeClaims(ns).Delete(ctx, name, delOpt)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting builder dependency cache %s.%s: %w", name, ns, err)
+	}
+	return nil
+}
+
+// addDependencyCache mounts the dependency cache of the environment into the
+// builder container and points the caches of the common package managers to
+// it, unless the builder container already sets them.
+func addDependencyCache(podSpec *apiv1.PodSpec, env *fv1.Environment) {
+	cache := env.Spec.Builder.DependencyCache
+	if cache == nil {
+		return
+	}
+	mountPath := getDependencyCacheMountPath(cache)
+
+	podSpec.Volumes = append(podSpec.Volumes, apiv1.Volume{
+		Name: dependencyCacheVolumeName,
+		VolumeSource: apiv1.VolumeSource{
+			PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: getDependencyCacheClaimName(env),
+			},
+		},
+	})
+
+	cacheEnvs := []apiv1.EnvVar{
+		{Name: "FISSION_BUILD_CACHE_DIR", Value: mountPath},
+		{Name: "XDG_CACHE_HOME", Value: mountPath},
+		{Name: "PIP_CACHE_DIR", Value: path.Join(mountPath, "pip")},
+		{Name: "npm_config_cache", Value: path.Join(mountPath, "npm")},
+		{Name: "GOMODCACHE", Value: path.Join(mountPath, "go", "mod")},
+		{Name: "GOCACHE", Value: path.Join(mountPath, "go", "build")},
+		{Name: "MAVEN_OPTS", Value: "-Dmaven.repo.local=" + path.Join(mountPath, "maven")},
+	}
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if container.Name != fv1.BuilderContainerName {
+			continue
+		}
+		container.VolumeMounts = append(container.VolumeMounts, apiv1.VolumeMount{
+			Name:      dependencyCacheVolumeName,
+			MountPath: mountPath,
+		})
+		set := make(map[string]bool, len(container.Env))
+		for _, e := range container.Env {
+			set[e.Name] = true
+		}
+		for _, e := range cacheEnvs {
+			if !set[e.Name] {
+				container.Env = append(container.Env, e)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestDependencyCache(t *testing.T) {
+	ctx := context.Background()
+	kubernetesClient := fake.NewClientset()
+	envw := &environmentWatcher{
+		logger:           loggerfactory.GetLogger(),
+		kubernetesClient: kubernetesClient,
+	}
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"},
+		Spec: fv1.EnvironmentSpec{
+			Builder: fv1.Builder{
+				Image: "python-builder",
+				DependencyCache: &fv1.BuilderDependencyCache{
+					Size: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
+	require.NoError(t, envw.ensureDependencyCache(ctx, env, "fission-builder"))
+	claim, err := kubernetesClient.CoreV1().PersistentVolumeClaims("fission-builder").Get(ctx, "python-default-build-cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "1Gi", claim.Spec.Resources.Requests.Storage().String())
+	require.Equal(t, []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce}, claim.Spec.AccessModes)
+	// the existing claim is kept
+	require.NoError(t, envw.ensureDependencyCache(ctx, env, "fission-builder"))
+
+	podSpec := &apiv1.PodSpec{
+		Containers: []apiv1.Container{
+			{Name: "fetcher"},
+			{Name: fv1.BuilderContainerName, Env: []apiv1.EnvVar{{Name: "PIP_CACHE_DIR", Value: "/custom"}}},
+		},
+	}
+	addDependencyCache(podSpec, env)
+	require.Len(t, podSpec.Volumes, 1)
+	require.Equal(t, "python-default-build-cache", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	require.Empty(t, podSpec.Containers[0].VolumeMounts)
+	builder := podSpec.Containers[1]
+	require.Equal(t, fv1.DefaultBuilderDependencyCacheMountPath, builder.VolumeMounts[0].MountPath)
+	envs := map[string]string{}
+	for _, e := range builder.Env {
+		require.NotContains(t, envs, e.Name)
+		envs[e.Name] = e.Value
+	}
+	require.Equal(t, "/custom", envs["PIP_CACHE_DIR"])
+	require.Equal(t, "/build-cache/npm", envs["npm_config_cache"])
+
+	// the claim is deleted once the environment has no dependency cache
+	env.Spec.Builder.DependencyCache = nil
+	require.NoError(t, envw.ensureDependencyCache(ctx, env, "fission-builder"))
+	_, err = kubernetesClient.CoreV1().PersistentVolumeClaims("fission-builder").Get(ctx, "python-default-build-cache", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestDependencyCacheBuildJobs(t *testing.T) {
+	ctx := t.Context()
+	kubernetesClient := fake.NewClientset()
+	envw := &environmentWatcher{
+		logger:           loggerfactory.GetLogger(),
+		cache:            make(map[types.UID]*builderInfo),
+		kubernetesClient: kubernetesClient,
+		nsResolver:       utils.DefaultNSResolver(),
+		useBuildJobs:     true,
+	}
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"},
+		Spec: fv1.EnvironmentSpec{
+			Version: 2,
+			Builder: fv1.Builder{
+				Image: "python-builder",
+				DependencyCache: &fv1.BuilderDependencyCache{
+					Size: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+	ns := envw.nsResolver.GetBuilderNS(env.ObjectMeta.Namespace)
+
+	// concurrent build jobs may run on different nodes
+	envw.AddUpdateBuilder(ctx, env)
+	claim, err := kubernetesClient.CoreV1().PersistentVolumeClaims(ns).Get(ctx, "python-default-build-cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteMany}, claim.Spec.AccessModes)
+
+	// the claim is deleted with the environment, though build jobs never
+	// add the environment to the builder cache
+	envw.RemoveEnvironment(ctx, env)
+	_, err = kubernetesClient.CoreV1().PersistentVolumeClaims(ns).Get(ctx, "python-default-build-cache", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+
+	// the access mode of the environment wins
+	env.Spec.Builder.DependencyCache.AccessMode = apiv1.ReadWriteOnce
+	envw.AddUpdateBuilder(ctx, env)
+	claim, err = kubernetesClient.CoreV1().PersistentVolumeClaims(ns).Get(ctx, "python-default-build-cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce}, claim.Spec.AccessModes)
+}
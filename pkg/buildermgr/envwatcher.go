@@ -75,6 +75,7 @@ type (
 		podSpecPatch           *apiv1.PodSpec
 		envWatchInformer       map[string]k8sCache.SharedIndexInformer
 		enableOwnerReferences  bool
+		useBuildJobs           bool
 	}
 )
 
@@ -98,6 +99,11 @@ func makeEnvironmentWatcher(
 
 	builderImagePullPolicy := utils.GetImagePullPolicy(os.Getenv("BUILDER_IMAGE_PULL_POLICY"))
 
+	buildMode := os.Getenv("BUILD_MODE")
+	if len(buildMode) > 0 && buildMode != buildModeDeployment && buildMode != buildModeJob {
+		logger.Error("Failed to parse BUILD_MODE, defaults to " + buildModeDeployment)
+	}
+
 	envWatcher := &environmentWatcher{
 		logger:                 logger.Named("environment_watcher"),
 		cache:                  make(map[types.UID]*builderInfo),
@@ -110,6 +116,7 @@ func makeEnvironmentWatcher(
 		podSpecPatch:           podSpecPatch,
 		envWatchInformer:       utils.GetInformersForNamespaces(fissionClient, time.Minute*30, fv1.EnvironmentResource),
 		enableOwnerReferences:  utils.IsOwnerReferencesEnabled(),
+		useBuildJobs:           buildMode == buildModeJob,
 	}
 
 	err := envWatcher.EnvWatchEventHandlers(ctx)
@@ -154,8 +161,15 @@ func (envw *environmentWatcher) EnvWatchEventHandlers(ctx context.Context) error
 				}
 			},
 			DeleteFunc: func(obj interface{}) {
-				envObj := obj.(*fv1.Environment)
-				envw.DeleteBuilder(ctx, envObj)
+				if tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				envObj, ok := obj.(*fv1.Environment)
+				if !ok {
+					envw.logger.Error("unexpected object in environment delete event", zap.Any("object", obj))
+					return
+				}
+				envw.RemoveEnvironment(ctx, envObj)
 			},
 		})
 		if err != nil {
@@ -168,6 +182,10 @@ func (envw *environmentWatcher) EnvWatchEventHandlers(ctx context.Context) error
 func (envw *environmentWatcher) AddUpdateBuilder(ctx context.Context, env *fv1.Environment) {
 	// builder is not supported with v1 interface and ignore env without builder image
 	if env.Spec.Version != 1 && len(env.Spec.Builder.Image) != 0 {
+		if envw.useBuildJobs {
+			envw.prepareBuildJobs(ctx, env)
+			return
+		}
 		if _, ok := envw.cache[crd.CacheKeyUIDFromMeta(&env.ObjectMeta)]; !ok {
 			builderInfo, err := envw.createBuilder(ctx, env, envw.nsResolver.GetBuilderNS(env.ObjectMeta.Namespace))
 			if err != nil {
@@ -188,6 +206,51 @@ func (envw *environmentWatcher) AddUpdateBuilder(ctx context.Context, env *fv1.E
 	}
 }
 
+// prepareBuildJobs creates the dependency cache of the environment builder
+// when the builds run in jobs, and removes the builder deployment and service
+// left by a previous build mode.
+func (envw *environmentWatcher) prepareBuildJobs(ctx context.Context, env *fv1.Environment) {
+	ns := envw.nsResolver.GetBuilderNS(env.ObjectMeta.Namespace)
+	err := envw.ensureDependencyCache(ctx, env, ns)
+	if err != nil {
+		envw.logger.Error("error creating builder dependency cache", zap.Error(err))
+	}
+
+	sel := envw.getDeploymentLabels(env.ObjectMeta.Name)
+	deployList, err := envw.getBuilderDeploymentList(ctx, sel, ns)
+	if err != nil {
+		envw.logger.Error("error getting the builder deployment list", zap.Error(err))
+	}
+	for _, deploy := range deployList {
+		err := envw.deleteBuilderDeploymentByName(ctx, deploy.ObjectMeta.Name, deploy.ObjectMeta.Namespace)
+		if err != nil {
+			envw.logger.Error("error removing builder deployment", zap.Error(err))
+		}
+	}
+	svcList, err := envw.getBuilderServiceList(ctx, sel, ns)
+	if err != nil {
+		envw.logger.Error("error getting the builder service list", zap.Error(err))
+	}
+	for _, svc := range svcList {
+		err := envw.deleteBuilderServiceByName(ctx, svc.ObjectMeta.Name, svc.ObjectMeta.Namespace)
+		if err != nil {
+			envw.logger.Error("error removing builder service", zap.Error(err))
+		}
+	}
+}
+
+// RemoveEnvironment deletes the builder and the dependency cache of a deleted
+// environment. The dependency cache is deleted whatever the build mode, since
+// build jobs create it without ever adding the environment to the builder
+// cache.
+func (envw *environmentWatcher) RemoveEnvironment(ctx context.Context, env *fv1.Environment) {
+	envw.DeleteBuilder(ctx, env)
+	err := envw.deleteDependencyCache(ctx, env, envw.nsResolver.GetBuilderNS(env.ObjectMeta.Namespace))
+	if err != nil {
+		envw.logger.Error("error removing builder dependency cache", zap.Error(err))
+	}
+}
+
 func (envw *environmentWatcher) DeleteBuilder(ctx context.Context, env *fv1.Environment) {
 	if _, ok := envw.cache[crd.CacheKeyUIDFromMeta(&env.ObjectMeta)]; ok {
 		envw.DeleteBuilderService(ctx, env)
@@ -269,6 +332,11 @@ func (envw *environmentWatcher) createBuilder(ctx context.Context, env *fv1.Envi
 		return nil, fmt.Errorf("found more than one builder service for environment in namespace %s %s", env.ObjectMeta.Name, ns)
 	}
 
+	err = envw.ensureDependencyCache(ctx, env, ns)
+	if err != nil {
+		return nil, err
+	}
+
 	deployList, err := envw.getBuilderDeploymentList(ctx, sel, ns)
 	if err != nil {
 		return nil, err
@@ -389,10 +457,10 @@ func (envw *environmentWatcher) getBuilderDeploymentList(ctx context.Context, se
 	return deployList.Items, nil
 }
 
-func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env *fv1.Environment, ns string) (*appsv1.Deployment, error) {
-	name := fmt.Sprintf("%v-%v", env.ObjectMeta.Name, env.ObjectMeta.ResourceVersion)
+// getBuilderPodTemplate returns the pod template of the environment builder,
+// with the fetcher sidecar and the dependency cache of the environment.
+func (envw *environmentWatcher) getBuilderPodTemplate(env *fv1.Environment, ns string) (*apiv1.PodTemplateSpec, error) {
 	sel := envw.getLabels(env.ObjectMeta.Name, ns, env.ObjectMeta.ResourceVersion)
-	var replicas int32 = 1
 
 	podAnnotations := env.ObjectMeta.Annotations
 	if podAnnotations == nil {
@@ -449,6 +517,34 @@ func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env
 
 	pod.Spec = *(util.ApplyImagePullSecret(env.Spec.ImagePullSecret, pod.Spec))
 
+	err = envw.fetcherConfig.AddFetcherToPodSpec(&pod.Spec, "builder")
+	if err != nil {
+		return nil, err
+	}
+
+	addDependencyCache(&pod.Spec, env)
+
+	if env.Spec.Builder.PodSpec != nil {
+		newPodSpec, err := util.MergePodSpec(&pod.Spec, env.Spec.Builder.PodSpec)
+		if err != nil {
+			return nil, err
+		}
+		pod.Spec = *newPodSpec
+	}
+
+	return &pod, nil
+}
+
+func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env *fv1.Environment, ns string) (*appsv1.Deployment, error) {
+	name := fmt.Sprintf("%v-%v", env.ObjectMeta.Name, env.ObjectMeta.ResourceVersion)
+	sel := envw.getLabels(env.ObjectMeta.Name, ns, env.ObjectMeta.ResourceVersion)
+	var replicas int32 = 1
+
+	pod, err := envw.getBuilderPodTemplate(env, ns)
+	if err != nil {
+		return nil, err
+	}
+
 	var ownerReferences []metav1.OwnerReference
 	if envw.enableOwnerReferences {
 		ownerReferences = []metav1.OwnerReference{
@@ -472,23 +568,10 @@ func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env
 			Selector: &metav1.LabelSelector{
 				MatchLabels: sel,
 			},
-			Template: pod,
+			Template: *pod,
 		},
 	}
 
-	err = envw.fetcherConfig.AddFetcherToPodSpec(&deployment.Spec.Template.Spec, "builder")
-	if err != nil {
-		return nil, err
-	}
-
-	if env.Spec.Builder.PodSpec != nil {
-		newPodSpec, err := util.MergePodSpec(&deployment.Spec.Template.Spec, env.Spec.Builder.PodSpec)
-		if err != nil {
-			return nil, err
-		}
-		deployment.Spec.Template.Spec = *newPodSpec
-	}
-
 	_, err = envw.kubernetesClient.AppsV1().Deployments(ns).Create(ctx, deployment, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
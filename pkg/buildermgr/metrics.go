@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fission/fission/pkg/utils/metrics"
+)
+
+var (
+	// environment: the environment's name
+	// environment_namespace: the environment's namespace
+	environmentLabels = []string{"environment", "environment_namespace"}
+	buildQueueDepth   = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fission_build_queue_depth",
+			Help: "Number of package builds waiting in the build queue by environment, environment_namespace.",
+		},
+		environmentLabels,
+	)
+	buildsRunning = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fission_builds_running",
+			Help: "Number of package builds running by environment, environment_namespace.",
+		},
+		environmentLabels,
+	)
+	buildQueueWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_build_queue_wait_seconds",
+			Help:    "The time in seconds package builds waited in the build queue by environment, environment_namespace.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 14),
+		},
+		environmentLabels,
+	)
+)
+
+func init() {
+	registry := metrics.Registry
+	registry.MustRegister(buildQueueDepth)
+	registry.MustRegister(buildsRunning)
+	registry.MustRegister(buildQueueWait)
+}
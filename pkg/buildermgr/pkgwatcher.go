@@ -18,25 +18,41 @@ package buildermgr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/cache"
 	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/fetcher"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	"github.com/fission/fission/pkg/utils"
 	"github.com/fission/fission/pkg/utils/manager"
 	"github.com/fission/fission/pkg/utils/metrics"
 )
 
+const (
+	// defaultBuildRetries is how many times the builds failing with an
+	// infrastructure error are retried, unless set with BUILD_RETRIES.
+	defaultBuildRetries = 2
+
+	// buildRetryInterval is the delay before the first retry of a build,
+	// doubled on each retry.
+	buildRetryInterval = 5 * time.Second
+)
+
 type (
 	packageWatcher struct {
 		logger        *zap.Logger
@@ -47,12 +63,39 @@ type (
 		pkgInformer   map[string]k8sCache.SharedIndexInformer
 		storageSvcUrl string
 		buildCache    *cache.Cache[crd.CacheKeyUR, *fv1.Package]
+		logRelay      *buildLogRelay
+		artifactCache *artifactCache
+		buildJobs     *buildJobManager
+		buildQueue    *buildQueue
+		buildTimeout  time.Duration
+		buildRetries  int
 	}
 )
 
 func makePackageWatcher(logger *zap.Logger, fissionClient versioned.Interface, k8sClientSet kubernetes.Interface,
-	storageSvcUrl string, podInformer,
+	storageSvcUrl string, logRelay *buildLogRelay, artifactCache *artifactCache, buildJobs *buildJobManager, podInformer,
 	pkgInformer map[string]k8sCache.SharedIndexInformer) *packageWatcher {
+	// builds have no timeout by default, unless set by their package or
+	// environment
+	var buildTimeout time.Duration
+	if v := os.Getenv("BUILD_TIMEOUT"); len(v) > 0 {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			logger.Error("Failed to parse BUILD_TIMEOUT, builds have no default timeout", zap.Error(err))
+		}
+		buildTimeout = timeout
+	}
+
+	buildRetries := defaultBuildRetries
+	if v := os.Getenv("BUILD_RETRIES"); len(v) > 0 {
+		retries, err := strconv.Atoi(v)
+		if err != nil || retries < 0 {
+			logger.Error("Failed to parse BUILD_RETRIES, defaults to " + strconv.Itoa(defaultBuildRetries))
+		} else {
+			buildRetries = retries
+		}
+	}
+
 	pkgw := &packageWatcher{
 		logger:        logger.Named("package_watcher"),
 		fissionClient: fissionClient,
@@ -62,6 +105,16 @@ func makePackageWatcher(logger *zap.Logger, fissionClient versioned.Interface, k
 		pkgInformer:   pkgInformer,
 		storageSvcUrl: storageSvcUrl,
 		buildCache:    cache.MakeCache[crd.CacheKeyUR, *fv1.Package](0, 0),
+		logRelay:      logRelay,
+		artifactCache: artifactCache,
+		buildJobs:     buildJobs,
+		buildQueue:    makeBuildQueue(logger, fissionClient),
+		buildTimeout:  buildTimeout,
+		buildRetries:  buildRetries,
+	}
+	err := artifactCache.indexPackages(pkgInformer)
+	if err != nil {
+		logger.Error("error indexing packages by build cache key, builds of before a restart aren't reused", zap.Error(err))
 	}
 	return pkgw
 }
@@ -71,6 +124,11 @@ func (pkgw *packageWatcher) buildCacheKey(obj metav1.ObjectMeta) crd.CacheKeyUR
 }
 
 func (pkgw *packageWatcher) buildWithCache(ctx context.Context, srcpkg *fv1.Package) {
+	// Ignore the updates of queued packages, like of their queue position,
+	// their latest version is built once their build starts
+	if pkgw.buildQueue.isQueued(srcpkg) {
+		return
+	}
 	// Ignore duplicate build requests
 	_, err := pkgw.buildCache.Set(pkgw.buildCacheKey(srcpkg.ObjectMeta), srcpkg)
 	if err != nil {
@@ -83,13 +141,15 @@ func (pkgw *packageWatcher) buildWithCache(ctx context.Context, srcpkg *fv1.Pack
 // build helps to update package status, checks environment builder pod status and
 // dispatches buildPackage to build source package into deployment package.
 // Following is the steps build function takes to complete the whole process.
-// 1. Check package status
+// 1. Wait for a turn in the build queue of the environment and check package status
 // 2. Update package status to running state
-// 3. Check environment builder pod status
-// 4. Call buildPackage to build package
-// 5. Update package resource in package ref of functions that share the same package
-// 6. Update package status to succeed state
+// 3. Reuse the deployment archive of a previous build with the same inputs, if any
+// 4. Check environment builder pod status, or start a build job if builds run in jobs
+// 5. Call buildPackage to build package, retrying on infrastructure errors
+// 6. Update package resource in package ref of functions that share the same package
+// 7. Update package status to succeed state with the provenance of the build
 // *. Update package status to failed state,if any one of steps above failed/time out
+// The build can be canceled through the buildermgr API until it's over.
 func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 	key := pkgw.buildCacheKey(srcpkg.ObjectMeta)
 	logger := pkgw.logger.With(zap.String("package", srcpkg.Name), zap.String("namespace", srcpkg.Namespace), zap.String("resource_version", srcpkg.ResourceVersion), zap.String("key", key.String()))
@@ -101,9 +161,47 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 		}
 	}()
 
+	// the package is updated with ctx, even once the build is canceled
+	buildCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	build, done := pkgw.logRelay.add(k8stypes.NamespacedName{Namespace: srcpkg.Namespace, Name: srcpkg.Name}, cancel)
+	defer done()
+
+	// wait for a turn in the build queue of the environment, the limit of
+	// which defaults to the one of buildermgr if the environment can't be
+	// read, as its error is reported once the build starts
+	queueEnv, _ := pkgw.fissionClient.CoreV1().Environments(srcpkg.Spec.Environment.Namespace).Get(ctx, srcpkg.Spec.Environment.Name, metav1.GetOptions{})
+	release, queueErr := pkgw.buildQueue.wait(buildCtx, srcpkg, queueEnv)
+	if queueErr == nil {
+		defer release()
+	}
+
+	// the package may have been updated while it was queued
+	requestedStatus := srcpkg.Status.BuildStatus
+	srcpkg, err := pkgw.fissionClient.CoreV1().Packages(srcpkg.Namespace).Get(ctx, srcpkg.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		logger.Info("package deleted before its build started")
+		return
+	} else if err != nil {
+		logger.Error("error getting package", zap.Error(err))
+		return
+	}
+	if queueErr != nil {
+		logger.Info("package build canceled while queued", zap.Error(queueErr))
+		pkgw.failBuild(ctx, logger, srcpkg, fmt.Sprintf("%v\n", queueErr))
+		return
+	}
+	// a running package is only built again if its build was interrupted,
+	// otherwise another build of the package started meanwhile
+	if srcpkg.Status.BuildStatus != fv1.BuildStatusPending &&
+		(srcpkg.Status.BuildStatus != fv1.BuildStatusRunning || requestedStatus != fv1.BuildStatusRunning) {
+		logger.Info("package not to be built anymore", zap.String("build_status", string(srcpkg.Status.BuildStatus)))
+		return
+	}
+
 	logger.Info("starting build for package")
 
-	pkg, err := updatePackage(ctx, logger, pkgw.fissionClient, srcpkg, fv1.BuildStatusRunning, "", nil)
+	pkg, err := updatePackage(ctx, logger, pkgw.fissionClient, pkgw.storageSvcUrl, srcpkg, fv1.BuildStatusRunning, "", nil, nil)
 	if err != nil {
 		logger.Error("error setting package pending state", zap.Error(err))
 		return
@@ -113,39 +211,130 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 	if k8serrors.IsNotFound(err) {
 		e := "environment does not exist"
 		logger.Error(e, zap.String("environment", pkg.Spec.Environment.Name))
-		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg,
-			fv1.BuildStatusFailed, fmt.Sprintf("%s: %q", e, pkg.Spec.Environment.Name), nil)
-		if er != nil {
-			logger.Error(
-				"error updating package",
-				zap.Error(er),
-			)
-		}
+		pkgw.failBuild(ctx, logger, pkg, fmt.Sprintf("%s: %q", e, pkg.Spec.Environment.Name))
+		return
+	} else if err != nil {
+		e := "error getting environment"
+		logger.Error(e, zap.String("environment", pkg.Spec.Environment.Name), zap.Error(err))
+		pkgw.failBuild(ctx, logger, pkg, fmt.Sprintf("%s %q: %v", e, pkg.Spec.Environment.Name, err))
 		return
 	}
 
-	// Create a new BackOff for health check on environment builder pod
-	healthCheckBackOff := utils.NewDefaultBackOff()
 	builderNs := pkgw.nsResolver.GetBuilderNS(env.ObjectMeta.Namespace)
 
+	// reuse the deployment archive of a previous build with the same inputs,
+	// the builder image of which is resolved by the ready builder pods, as
+	// build jobs have no pod yet
+	var readyBuilder *apiv1.Pod
+	if pkgw.buildJobs == nil {
+		readyBuilder = pkgw.getReadyBuilderPod(env, builderNs)
+	}
+	pkg.Status.BuildCacheKey = computeBuildCacheKey(pkg, env, getImageDigest(env, readyBuilder))
+	if cached, ok := pkgw.artifactCache.get(ctx, pkgw.storageSvcUrl, pkg.Status.BuildCacheKey); ok {
+		logger.Info("reusing deployment archive of a previous build", zap.String("build_cache_key", pkg.Status.BuildCacheKey))
+		buildLogs := fmt.Sprintf("Reused the deployment archive of a previous build with the same source, build command and builder image (build cache key %s)\n",
+			pkg.Status.BuildCacheKey)
+		// the provenance is the one of the build which made the archive
+		pkgw.finishBuild(ctx, logger, pkg, buildLogs, &fetcher.ArchiveUploadResponse{
+			ArchiveDownloadUrl: cached.deployment.URL,
+			Checksum:           cached.deployment.Checksum,
+		}, cached.provenance)
+		return
+	}
+
 	logger = logger.With(zap.String("environment", env.Name), zap.String("builder_namespace", builderNs), zap.String("environment_namespace", env.Namespace))
+	timeout := pkgw.getBuildTimeout(pkg, env)
+
+	var buildLogs string
+	for attempt := 0; ; attempt++ {
+		var job *batchv1.Job
+		var builderPod *apiv1.Pod
+		builderHost := fmt.Sprintf("%s-%s.%s", env.ObjectMeta.Name, env.ObjectMeta.ResourceVersion, builderNs)
+		if pkgw.buildJobs != nil {
+			job, builderPod, err = pkgw.buildJobs.start(buildCtx, logger, env, pkg, builderNs, timeout)
+			if err == nil {
+				builderHost = builderPod.Status.PodIP
+			}
+		} else {
+			builderPod, err = pkgw.waitForBuilder(buildCtx, logger, env, builderNs)
+		}
+		if err != nil {
+			logger.Error("error waiting for environment builder", zap.Error(err))
+			pkgw.failBuild(ctx, logger, pkg, buildLogs+fmt.Sprintf("%v\n", err))
+			return
+		}
+
+		start := time.Now()
+		output, attemptLogs, err := buildPackage(buildCtx, pkgw.logger, pkgw.fissionClient, builderHost, pkgw.storageSvcUrl, build, timeout, pkg)
+		end := time.Now()
+		buildLogs += attemptLogs
+		if job != nil {
+			if err != nil {
+				if failure := pkgw.buildJobs.getFailure(ctx, job); len(failure) > 0 {
+					buildLogs += fmt.Sprintf("Build job pod failed: %s\n", failure)
+				}
+			}
+			pkgw.buildJobs.stop(ctx, logger, job)
+		}
+		if err == nil {
+			if job == nil {
+				// in builder deployments, the build ran in any ready
+				// builder pod
+				builderPod = pkgw.getBuilderPod(builderNs, output.builderPod)
+			}
+			provenance := makeProvenance(pkg, env, builderPod, start, end, output)
+			// the archive is cached with the image the build ran
+			pkg.Status.BuildCacheKey = computeBuildCacheKey(pkg, env, getImageDigest(env, builderPod))
+			pkgw.finishBuild(ctx, logger, pkg, buildLogs, output.deployment, provenance)
+			return
+		}
+		logger.Error("error building package", zap.Error(err), zap.Int("attempt", attempt+1))
+
+		if !isInfraError(err) || attempt >= pkgw.buildRetries {
+			pkgw.failBuild(ctx, logger, pkg, buildLogs)
+			return
+		}
+
+		// the build didn't fail by itself, like when the builder pod restarts
+		delay := buildRetryInterval << attempt
+		buildLogs += fmt.Sprintf("Build attempt %d of %d failed due to an infrastructure error, retrying in %v\n",
+			attempt+1, pkgw.buildRetries+1, delay)
+		select {
+		case <-buildCtx.Done():
+			pkgw.failBuild(ctx, logger, pkg, buildLogs+fmt.Sprintf("%v\n", context.Cause(buildCtx)))
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// waitForBuilder waits for a builder pod of the environment to be ready and
+// returns it. It returns an error if there's none once the health check
+// backoff is over, or if the build is canceled.
+func (pkgw *packageWatcher) waitForBuilder(ctx context.Context, logger *zap.Logger, env *fv1.Environment, builderNs string) (*apiv1.Pod, error) {
+	// Create a new BackOff for health check on environment builder pod
+	healthCheckBackOff := utils.NewDefaultBackOff()
+
+	sleep := func(d time.Duration) error {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(d):
+			return nil
+		}
+	}
 
-	// if err != nil {
-	//	pkgw.logger.Error("Unable to create BackOff for Health Check", zap.Error(err))
-	//}
 	// Do health check for environment builder pod
 	for healthCheckBackOff.NextExists() {
 		// Informer store is not able to use label to find the pod,
 		// iterate all available environment builders.
 		items := pkgw.podInformer[builderNs].GetStore().List()
-		if err != nil {
-			logger.Error("error retrieving pod information for environment", zap.Error(err))
-			return
-		}
 
 		if len(items) == 0 {
 			logger.Info("builder pod does not exist for environment, will retry again later")
-			time.Sleep(healthCheckBackOff.GetCurrentBackoffDuration())
+			if err := sleep(healthCheckBackOff.GetCurrentBackoffDuration()); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
@@ -153,103 +342,158 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 			pod := item.(*apiv1.Pod)
 
 			// Filter non-matching pods
-			if pod.ObjectMeta.Labels[LABEL_ENV_NAME] != env.ObjectMeta.Name ||
-				pod.ObjectMeta.Labels[LABEL_ENV_NAMESPACE] != builderNs ||
-				pod.ObjectMeta.Labels[LABEL_ENV_RESOURCEVERSION] != env.ObjectMeta.ResourceVersion {
+			if !isBuilderPodOf(pod, env, builderNs) {
 				continue
 			}
 
-			// Pod may become "Running" state but still failed at health check, so use
-			// pod.Status.ContainerStatuses instead of pod.Status.Phase to check pod readiness states.
-			podIsReady := true
-
-			for _, cStatus := range pod.Status.ContainerStatuses {
-				podIsReady = podIsReady && cStatus.Ready
-			}
-
-			if !podIsReady {
+			if !isBuilderPodReady(pod) {
 				logger.Info("builder pod is not ready for environment, will retry again later")
-				time.Sleep(healthCheckBackOff.GetCurrentBackoffDuration())
+				if err := sleep(healthCheckBackOff.GetCurrentBackoffDuration()); err != nil {
+					return nil, err
+				}
 				break
 			}
+			return pod, nil
+		}
+		if err := sleep(healthCheckBackOff.GetNext()); err != nil {
+			return nil, err
+		}
+	}
+	logger.Error("max retries exceeded in building source package, timeout due to environment builder not ready")
+	return nil, errors.New("build timeout due to environment builder not ready")
+}
 
-			uploadResp, buildLogs, err := buildPackage(ctx, pkgw.logger, pkgw.fissionClient, builderNs, pkgw.storageSvcUrl, pkg)
-			if err != nil {
-				logger.Error("error building package", zap.Error(err))
-				_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
-				if er != nil {
-					logger.Error("error updating package", zap.Error(er))
-				}
-				return
-			}
+// isBuilderPodOf returns true if the pod is a builder of the current version
+// of the environment.
+func isBuilderPodOf(pod *apiv1.Pod, env *fv1.Environment, builderNs string) bool {
+	return pod.ObjectMeta.Labels[LABEL_ENV_NAME] == env.ObjectMeta.Name &&
+		pod.ObjectMeta.Labels[LABEL_ENV_NAMESPACE] == builderNs &&
+		pod.ObjectMeta.Labels[LABEL_ENV_RESOURCEVERSION] == env.ObjectMeta.ResourceVersion
+}
 
-			logger.Info("starting package info update")
+// isBuilderPodReady returns true if all the containers of the builder pod
+// are ready. Pod may become "Running" state but still failed at health
+// check, so use pod.Status.ContainerStatuses instead of pod.Status.Phase to
+// check pod readiness states.
+func isBuilderPodReady(pod *apiv1.Pod) bool {
+	for _, cStatus := range pod.Status.ContainerStatuses {
+		if !cStatus.Ready {
+			return false
+		}
+	}
+	return true
+}
 
-			fnList, err := pkgw.fissionClient.CoreV1().
-				Functions(pkg.Namespace).List(ctx, metav1.ListOptions{})
-			if err != nil {
-				e := "error getting function list"
-				pkgw.logger.Error(e, zap.Error(err))
-				buildLogs += fmt.Sprintf("%s: %v\n", e, err)
-				_, er := updatePackage(ctx, pkgw.logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
-				if er != nil {
-					pkgw.logger.Error(
-						"error updating package",
-						zap.Error(er),
-					)
-				}
-			}
+// getReadyBuilderPod returns a ready builder pod of the environment, or nil
+// if there's none.
+func (pkgw *packageWatcher) getReadyBuilderPod(env *fv1.Environment, builderNs string) *apiv1.Pod {
+	informer, ok := pkgw.podInformer[builderNs]
+	if !ok {
+		return nil
+	}
+	for _, item := range informer.GetStore().List() {
+		pod := item.(*apiv1.Pod)
+		if isBuilderPodOf(pod, env, builderNs) && isBuilderPodReady(pod) {
+			return pod
+		}
+	}
+	return nil
+}
 
-			// A package may be used by multiple functions. Update
-			// functions with old package resource version
-			for _, fn := range fnList.Items {
-				if fn.Spec.Package.PackageRef.Name == pkg.ObjectMeta.Name &&
-					fn.Spec.Package.PackageRef.Namespace == pkg.ObjectMeta.Namespace &&
-					fn.Spec.Package.PackageRef.ResourceVersion != pkg.ObjectMeta.ResourceVersion {
-					fn.Spec.Package.PackageRef.ResourceVersion = pkg.ObjectMeta.ResourceVersion
-					// update CRD
-					_, err = pkgw.fissionClient.CoreV1().Functions(fn.ObjectMeta.Namespace).Update(ctx, &fn, metav1.UpdateOptions{})
-					if err != nil {
-						e := "error updating function package resource version"
-						logger.Error(e, zap.Error(err))
-						buildLogs += fmt.Sprintf("%s: %v\n", e, err)
-						_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
-						if er != nil {
-							logger.Error("error updating package", zap.Error(er))
-						}
-						return
-					}
-				}
-			}
+// getBuilderPod returns the builder pod with the name, or nil if it's
+// unknown.
+func (pkgw *packageWatcher) getBuilderPod(builderNs, name string) *apiv1.Pod {
+	informer, ok := pkgw.podInformer[builderNs]
+	if !ok || len(name) == 0 {
+		return nil
+	}
+	item, exists, err := informer.GetStore().GetByKey(builderNs + "/" + name)
+	if err != nil || !exists {
+		return nil
+	}
+	return item.(*apiv1.Pod)
+}
+
+// getBuildTimeout returns the timeout of the build of the package: its own,
+// or the one of the environment builder, or the one of buildermgr.
+func (pkgw *packageWatcher) getBuildTimeout(pkg *fv1.Package, env *fv1.Environment) time.Duration {
+	if pkg.Spec.BuildTimeout > 0 {
+		return time.Duration(pkg.Spec.BuildTimeout) * time.Second
+	}
+	if env.Spec.Builder.BuildTimeout > 0 {
+		return time.Duration(env.Spec.Builder.BuildTimeout) * time.Second
+	}
+	return pkgw.buildTimeout
+}
 
-			_, err = updatePackage(ctx, logger, pkgw.fissionClient, pkg,
-				fv1.BuildStatusSucceeded, buildLogs, uploadResp)
+// failBuild marks the package build as failed with the build logs.
+func (pkgw *packageWatcher) failBuild(ctx context.Context, logger *zap.Logger, pkg *fv1.Package, buildLogs string) {
+	_, err := updatePackage(ctx, logger, pkgw.fissionClient, pkgw.storageSvcUrl, pkg, fv1.BuildStatusFailed, buildLogs, nil, nil)
+	if err != nil {
+		logger.Error("error updating package", zap.Error(err))
+	}
+}
+
+// finishBuild updates the functions using the package to its new version and
+// marks the package build as succeeded with the deployment archive and the
+// provenance of its build.
+func (pkgw *packageWatcher) finishBuild(ctx context.Context, logger *zap.Logger, pkg *fv1.Package,
+	buildLogs string, uploadResp *fetcher.ArchiveUploadResponse, provenance *fv1.BuildProvenance) {
+	logger.Info("starting package info update")
+
+	fnList, err := pkgw.fissionClient.CoreV1().
+		Functions(pkg.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		e := "error getting function list"
+		logger.Error(e, zap.Error(err))
+		buildLogs += fmt.Sprintf("%s: %v\n", e, err)
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkgw.storageSvcUrl, pkg, fv1.BuildStatusFailed, buildLogs, nil, nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
+	}
+
+	// A package may be used by multiple functions. Update
+	// functions with old package resource version
+	for _, fn := range fnList.Items {
+		if fn.Spec.Package.PackageRef.Name == pkg.ObjectMeta.Name &&
+			fn.Spec.Package.PackageRef.Namespace == pkg.ObjectMeta.Namespace &&
+			fn.Spec.Package.PackageRef.ResourceVersion != pkg.ObjectMeta.ResourceVersion {
+			fn.Spec.Package.PackageRef.ResourceVersion = pkg.ObjectMeta.ResourceVersion
+			// update CRD
+			_, err = pkgw.fissionClient.CoreV1().Functions(fn.ObjectMeta.Namespace).Update(ctx, &fn, metav1.UpdateOptions{})
 			if err != nil {
-				logger.Error("error updating package info", zap.Error(err))
-				_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
+				e := "error updating function package resource version"
+				logger.Error(e, zap.Error(err))
+				buildLogs += fmt.Sprintf("%s: %v\n", e, err)
+				_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkgw.storageSvcUrl, pkg, fv1.BuildStatusFailed, buildLogs, nil, nil)
 				if er != nil {
 					logger.Error("error updating package", zap.Error(er))
 				}
 				return
 			}
-
-			logger.Info("completed package build request")
-			return
 		}
-		time.Sleep(healthCheckBackOff.GetNext())
 	}
-	// build timeout
-	_, err = updatePackage(ctx, logger, pkgw.fissionClient, pkg,
-		fv1.BuildStatusFailed, "Build timeout due to environment builder not ready", nil)
+
+	updated, err := updatePackage(ctx, logger, pkgw.fissionClient, pkgw.storageSvcUrl, pkg,
+		fv1.BuildStatusSucceeded, buildLogs, uploadResp, provenance)
 	if err != nil {
-		logger.Error("error updating package", zap.Error(err))
+		logger.Error("error updating package info", zap.Error(err))
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkgw.storageSvcUrl, pkg, fv1.BuildStatusFailed, buildLogs, nil, nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
 	}
+	pkgw.artifactCache.add(updated)
 
-	logger.Error("max retries exceeded in building source package, timeout due to environment builder not ready")
+	logger.Info("completed package build request")
 }
 
 func (pkgw *packageWatcher) packageInformerHandler(ctx context.Context) k8sCache.ResourceEventHandlerFuncs {
 	processPkg := func(ctx context.Context, pkg *fv1.Package) {
+		pkgw.artifactCache.add(pkg)
 		var err error
 		if len(pkg.Status.BuildStatus) == 0 {
 			_, err = setInitialBuildStatus(ctx, pkgw.fissionClient, pkg)
@@ -269,6 +513,13 @@ func (pkgw *packageWatcher) packageInformerHandler(ctx context.Context) k8sCache
 	return k8sCache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pkg := obj.(*fv1.Package)
+			// the build of a running package was interrupted by a restart
+			// of buildermgr, so build it again
+			if pkg.Status.BuildStatus == fv1.BuildStatusRunning {
+				pkgw.logger.Info("restarting interrupted package build", zap.String("package", pkg.Name), zap.String("namespace", pkg.Namespace))
+				pkgw.buildWithCache(ctx, pkg)
+				return
+			}
 			processPkg(ctx, pkg)
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
@@ -294,6 +545,7 @@ func (pkgw *packageWatcher) Run(ctx context.Context, mgr manager.Interface) erro
 	mgr.Add(ctx, func(ctx context.Context) {
 		metrics.ServeMetrics(ctx, "buildermgr", pkgw.logger, mgr)
 	})
+	mgr.Add(ctx, pkgw.buildQueue.run)
 	mgr.AddInformers(ctx, pkgw.podInformer)
 	for _, pkgInformer := range pkgw.pkgInformer {
 		_, err := pkgInformer.AddEventHandler(pkgw.packageInformerHandler(ctx))
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestGetBuildTimeout(t *testing.T) {
+	pkgw := &packageWatcher{buildTimeout: time.Hour}
+	pkg := &fv1.Package{}
+	env := &fv1.Environment{}
+	require.Equal(t, time.Hour, pkgw.getBuildTimeout(pkg, env))
+
+	env.Spec.Builder.BuildTimeout = 600
+	require.Equal(t, 10*time.Minute, pkgw.getBuildTimeout(pkg, env))
+
+	pkg.Spec.BuildTimeout = 60
+	require.Equal(t, time.Minute, pkgw.getBuildTimeout(pkg, env))
+}
+
+func TestGetBuilderPod(t *testing.T) {
+	informer := k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &apiv1.Pod{}, 0, k8sCache.Indexers{})
+	pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "python-builder-abc", Namespace: "fission-builder"}}
+	require.NoError(t, informer.GetStore().Add(pod))
+	pkgw := &packageWatcher{podInformer: map[string]k8sCache.SharedIndexInformer{"fission-builder": informer}}
+
+	// the provenance is the one of the pod which ran the build
+	require.Equal(t, pod, pkgw.getBuilderPod("fission-builder", "python-builder-abc"))
+	require.Nil(t, pkgw.getBuilderPod("fission-builder", "python-builder-def"))
+	// builders of older releases don't report their pod
+	require.Nil(t, pkgw.getBuilderPod("fission-builder", ""))
+	require.Nil(t, pkgw.getBuilderPod("default", "python-builder-abc"))
+}
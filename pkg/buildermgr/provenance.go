@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// makeProvenance returns the provenance of the successful build of the
+// package with the environment, which ran in the builder pod between start
+// and end.
+func makeProvenance(pkg *fv1.Package, env *fv1.Environment, builderPod *apiv1.Pod, start, end time.Time,
+	output *buildOutput) *fv1.BuildProvenance {
+	provenance := &fv1.BuildProvenance{
+		SourceChecksum:             getSourceChecksum(pkg),
+		SourceCommit:               output.sourceCommit,
+		DeploymentChecksum:         output.deployment.Checksum,
+		BuilderImage:               env.Spec.Builder.Image,
+		BuilderImageDigest:         getBuilderImageDigest(builderPod),
+		BuildCommand:               getBuildCommand(pkg, env),
+		EnvironmentVersion:         env.Spec.Version,
+		EnvironmentResourceVersion: env.ObjectMeta.ResourceVersion,
+		StartTime:                  metav1.Time{Time: start.UTC()},
+		EndTime:                    metav1.Time{Time: end.UTC()},
+	}
+	if output.sbom != nil {
+		provenance.SBOM = &fv1.Archive{
+			Type:     fv1.ArchiveTypeUrl,
+			URL:      output.sbom.ArchiveDownloadUrl,
+			Checksum: output.sbom.Checksum,
+		}
+	}
+	return provenance
+}
+
+// getSourceChecksum returns the checksum of the source archive of the
+// package, or an empty checksum if the source is referenced by URL
+// without one.
+func getSourceChecksum(pkg *fv1.Package) fv1.Checksum {
+	src := pkg.Spec.Source
+	if len(src.Literal) > 0 {
+		sum := sha256.Sum256(src.Literal)
+		return fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: hex.EncodeToString(sum[:])}
+	}
+	return src.Checksum
+}
+
+// getBuilderImageDigest returns the digest of the image the builder
+// container of the pod runs, if the container runtime reports it.
+func getBuilderImageDigest(pod *apiv1.Pod) string {
+	if pod == nil {
+		return ""
+	}
+	for _, cStatus := range pod.Status.ContainerStatuses {
+		if cStatus.Name != fv1.BuilderContainerName {
+			continue
+		}
+		// image IDs look like docker-pullable://repo@sha256:..., or the
+		// ID of the image config with some runtimes, which isn't a digest
+		// of the image in its registry
+		if i := strings.LastIndex(cStatus.ImageID, "@"); i >= 0 {
+			return cStatus.ImageID[i+1:]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fetcher"
+)
+
+func TestGetBuilderImageDigest(t *testing.T) {
+	digest := "sha256:0123456789abcdef"
+	for imageID, expected := range map[string]string{
+		"docker-pullable://ghcr.io/fission/python-builder@" + digest: digest,
+		"ghcr.io/fission/python-builder@" + digest:                   digest,
+		// the ID of the image config isn't a digest in the registry
+		"sha256:fedcba9876543210": "",
+		"":                        "",
+	} {
+		pod := &apiv1.Pod{
+			Status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{
+					{Name: "fetcher", ImageID: "docker-pullable://ghcr.io/fission/fetcher@sha256:other"},
+					{Name: fv1.BuilderContainerName, ImageID: imageID},
+				},
+			},
+		}
+		require.Equal(t, expected, getBuilderImageDigest(pod), imageID)
+	}
+	require.Empty(t, getBuilderImageDigest(nil))
+}
+
+func TestMakeProvenance(t *testing.T) {
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "python", ResourceVersion: "42"},
+		Spec: fv1.EnvironmentSpec{
+			Version: 2,
+			Builder: fv1.Builder{Image: "python-builder:1.0", Command: "build"},
+		},
+	}
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: fv1.PackageSpec{
+			Source: fv1.Archive{Type: fv1.ArchiveTypeLiteral, Literal: []byte("print('hello')")},
+		},
+	}
+	pod := &apiv1.Pod{
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: fv1.BuilderContainerName, ImageID: "python-builder@sha256:abc"},
+			},
+		},
+	}
+	start := time.Now()
+	end := start.Add(time.Minute)
+	uploadResp := &fetcher.ArchiveUploadResponse{
+		ArchiveDownloadUrl: "http://storagesvc/v1/archive?id=deploy",
+		Checksum:           fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "deploy"},
+	}
+
+	provenance := makeProvenance(pkg, env, pod, start, end, &buildOutput{deployment: uploadResp})
+	require.Equal(t, fv1.ChecksumTypeSHA256, provenance.SourceChecksum.Type)
+	require.Len(t, provenance.SourceChecksum.Sum, 64)
+	require.Equal(t, uploadResp.Checksum, provenance.DeploymentChecksum)
+	require.Equal(t, "python-builder:1.0", provenance.BuilderImage)
+	require.Equal(t, "sha256:abc", provenance.BuilderImageDigest)
+	require.Equal(t, "build", provenance.BuildCommand)
+	require.Equal(t, 2, provenance.EnvironmentVersion)
+	require.Equal(t, "42", provenance.EnvironmentResourceVersion)
+	require.True(t, provenance.StartTime.Time.Equal(start))
+	require.True(t, provenance.EndTime.Time.Equal(end))
+	require.Nil(t, provenance.SBOM)
+	require.Empty(t, provenance.SourceCommit)
+
+	// the source checksum given with the package, if any, and the SBOM
+	pkg.Spec.BuildCommand = "build --release"
+	pkg.Spec.Source = fv1.Archive{
+		Type:     fv1.ArchiveTypeUrl,
+		URL:      "http://storagesvc/v1/archive?id=src",
+		Checksum: fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "src"},
+	}
+	sbomResp := &fetcher.ArchiveUploadResponse{
+		ArchiveDownloadUrl: "http://storagesvc/v1/archive?id=sbom",
+		Checksum:           fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "sbom"},
+	}
+	provenance = makeProvenance(pkg, env, pod, start, end, &buildOutput{deployment: uploadResp, sbom: sbomResp})
+	require.Equal(t, pkg.Spec.Source.Checksum, provenance.SourceChecksum)
+	require.Equal(t, "build --release", provenance.BuildCommand)
+	require.Equal(t, &fv1.Archive{Type: fv1.ArchiveTypeUrl, URL: sbomResp.ArchiveDownloadUrl, Checksum: sbomResp.Checksum}, provenance.SBOM)
+
+	// the commit checked out for git sources
+	pkg.Spec.Source = fv1.Archive{Type: fv1.ArchiveTypeGit, URL: "https://github.com/fission/examples.git"}
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	provenance = makeProvenance(pkg, env, pod, start, end, &buildOutput{deployment: uploadResp, sourceCommit: commit})
+	require.Equal(t, commit, provenance.SourceCommit)
+	require.Empty(t, provenance.SourceChecksum.Sum)
+}
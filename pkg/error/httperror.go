@@ -61,6 +61,8 @@ func MakeErrorFromHTTP(resp *http.Response) error {
 		errCode = ErrorRequestTimeout
 	case http.StatusTooManyRequests:
 		errCode = ErrorTooManyRequests
+	case http.StatusServiceUnavailable:
+		errCode = ErrorServiceUnavailable
 	case http.StatusUnauthorized:
 		errCode = ErrorNotAuthorized
 	default:
@@ -90,6 +92,8 @@ func (err Error) HTTPStatus() int {
 		code = http.StatusConflict
 	case ErrorTooManyRequests:
 		code = http.StatusTooManyRequests
+	case ErrorServiceUnavailable:
+		code = http.StatusServiceUnavailable
 	default:
 		code = http.StatusInternalServerError
 	}
@@ -140,6 +144,7 @@ const (
 	ErrorSizeLimitExceeded
 	ErrorRequestTimeout
 	ErrorTooManyRequests
+	ErrorServiceUnavailable
 )
 
 // must match order and len of the above const
@@ -154,4 +159,6 @@ var errorDescriptions = []string{
 	"Checksum verification failed",
 	"Size limit exceeded",
 	"Request time limit exceeded",
+	"Too many requests",
+	"Service unavailable",
 }
@@ -24,10 +24,11 @@ func TestIsNotFound(t *testing.T) {
 
 func TestGetHTTPError(t *testing.T) {
 	errs := map[int]error{
-		http.StatusBadRequest:      MakeError(ErrorInvalidArgument, ""),
-		http.StatusConflict:        fmt.Errorf("%w", MakeError(ErrorNameExists, "")),
-		http.StatusNotFound:        fmt.Errorf("%w", MakeError(ErrorNotFound, "")),
-		http.StatusTooManyRequests: fmt.Errorf("other information: %w", MakeError(ErrorTooManyRequests, "too many requests")),
+		http.StatusBadRequest:         MakeError(ErrorInvalidArgument, ""),
+		http.StatusConflict:           fmt.Errorf("%w", MakeError(ErrorNameExists, "")),
+		http.StatusNotFound:           fmt.Errorf("%w", MakeError(ErrorNotFound, "")),
+		http.StatusTooManyRequests:    fmt.Errorf("other information: %w", MakeError(ErrorTooManyRequests, "too many requests")),
+		http.StatusServiceUnavailable: MakeError(ErrorServiceUnavailable, ""),
 	}
 	for want, err := range errs {
 		code, _ := GetHTTPError(err)
@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounting
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sInformers "k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/utils/leaderelection"
+)
+
+const (
+	// ENV_USAGE_ACCOUNTING_ENABLED enables usage accounting.
+	ENV_USAGE_ACCOUNTING_ENABLED = "USAGE_ACCOUNTING_ENABLED"
+
+	// sampleInterval is how often the running function pods are sampled.
+	sampleInterval = 15 * time.Second
+
+	// bucketDuration is the granularity of the usage kept for reports.
+	bucketDuration = time.Hour
+
+	// retention is how long the usage is kept for reports.
+	retention = 7 * 24 * time.Hour
+)
+
+type (
+	// Accountant records the usage of functions: the running time and the
+	// resource requests of their pods, their invocations and cold starts.
+	// The usage is exported as metrics and kept in memory for reports.
+	// A nil Accountant doesn't record anything.
+	//
+	// Reports are best-effort: the usage kept in memory is lost when the
+	// executor restarts, and pods are sampled by the leader only, so pod
+	// usage is lost when another replica starts leading. Reports tell since
+	// when they are complete. Invocations and cold starts are recorded by
+	// the executor replica which observes them, so with more than one
+	// replica reports of a replica only cover part of them. The metrics,
+	// summed over the replicas, are the complete record of the usage.
+	Accountant struct {
+		logger *zap.Logger
+		leader *leaderelection.Elector
+
+		podLister       map[string]corelisters.PodLister
+		podListerSynced map[string]k8sCache.InformerSynced
+
+		// lastSample is only accessed by Run
+		lastSample time.Time
+
+		lock    sync.Mutex
+		buckets map[time.Time]map[k8stypes.NamespacedName]*client.Usage
+		// recordedSince is when this replica started recording the usage
+		// of pods, or started the accountant
+		recordedSince time.Time
+	}
+)
+
+// Enabled returns true if usage accounting is enabled.
+func Enabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(ENV_USAGE_ACCOUNTING_ENABLED))
+	return enabled
+}
+
+// MakeAccountant returns an Accountant sampling the pods of the informer
+// factories, which must select the pods of functions.
+func MakeAccountant(logger *zap.Logger, informerFactory map[string]k8sInformers.SharedInformerFactory,
+	leader *leaderelection.Elector) *Accountant {
+	a := &Accountant{
+		logger:          logger.Named("accounting"),
+		leader:          leader,
+		podLister:       make(map[string]corelisters.PodLister),
+		podListerSynced: make(map[string]k8sCache.InformerSynced),
+		buckets:         make(map[time.Time]map[k8stypes.NamespacedName]*client.Usage),
+		recordedSince:   time.Now(),
+	}
+	for ns, factory := range informerFactory {
+		a.podLister[ns] = factory.Core().V1().Pods().Lister()
+		a.podListerSynced[ns] = factory.Core().V1().Pods().Informer().HasSynced
+	}
+	return a
+}
+
+// RecordInvocations records requests to the function reported by a router.
+func (a *Accountant) RecordInvocations(fnMeta *metav1.ObjectMeta, n int) {
+	if a == nil || n <= 0 {
+		return
+	}
+	metrics.Invocations.WithLabelValues(fnMeta.Name, fnMeta.Namespace).Add(float64(n))
+	a.record(time.Now(), fnMeta.Namespace, fnMeta.Name, func(u *client.Usage) {
+		u.Invocations += int64(n)
+	})
+}
+
+// RecordColdStart records a function service created for the function.
+// The cold starts metric is recorded by the executor types.
+func (a *Accountant) RecordColdStart(fnMeta *metav1.ObjectMeta) {
+	if a == nil {
+		return
+	}
+	a.record(time.Now(), fnMeta.Namespace, fnMeta.Name, func(u *client.Usage) {
+		u.ColdStarts++
+	})
+}
+
+// Run periodically samples the running function pods.
+func (a *Accountant) Run(ctx context.Context) {
+	if a == nil {
+		return
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		a.sample(time.Now())
+	}, sampleInterval)
+}
+
+// sample charges the running function pods with the time elapsed since the
+// last sample.
+func (a *Accountant) sample(now time.Time) {
+	if !a.leader.IsLeader() {
+		a.lastSample = time.Time{}
+		return
+	}
+	last := a.lastSample
+	a.lastSample = now
+	for _, synced := range a.podListerSynced {
+		if !synced() {
+			return
+		}
+	}
+	if last.IsZero() {
+		// pods weren't sampled by this replica before
+		a.lock.Lock()
+		a.recordedSince = now
+		a.lock.Unlock()
+		return
+	}
+	// don't charge more than a couple of intervals if sampling was delayed
+	elapsed := min(now.Sub(last), 2*sampleInterval).Seconds()
+
+	for _, lister := range a.podLister {
+		pods, err := lister.List(labels.Everything())
+		if err != nil {
+			a.logger.Error("error listing function pods", zap.Error(err))
+			return
+		}
+		for _, pod := range pods {
+			fnName, ok1 := pod.Labels[fv1.FUNCTION_NAME]
+			fnNS, ok2 := pod.Labels[fv1.FUNCTION_NAMESPACE]
+			if !ok1 || !ok2 || pod.DeletionTimestamp != nil || pod.Status.Phase != apiv1.PodRunning {
+				continue
+			}
+			var cpu, memory float64
+			for _, c := range pod.Spec.Containers {
+				if q, ok := c.Resources.Requests[apiv1.ResourceCPU]; ok {
+					cpu += q.AsApproximateFloat64()
+				}
+				if q, ok := c.Resources.Requests[apiv1.ResourceMemory]; ok {
+					memory += q.AsApproximateFloat64()
+				}
+			}
+
+			metrics.PodSeconds.WithLabelValues(fnName, fnNS).Add(elapsed)
+			metrics.CPUReservedSeconds.WithLabelValues(fnName, fnNS).Add(cpu * elapsed)
+			metrics.MemoryReservedSeconds.WithLabelValues(fnName, fnNS).Add(memory * elapsed)
+			a.record(now, fnNS, fnName, func(u *client.Usage) {
+				u.PodSeconds += elapsed
+				u.CPUCoreSeconds += cpu * elapsed
+				u.MemoryByteSeconds += memory * elapsed
+			})
+		}
+	}
+	a.prune(now)
+}
+
+// record updates the usage of the function in the bucket of the given time.
+func (a *Accountant) record(t time.Time, namespace, function string, update func(u *client.Usage)) {
+	bucket := t.Truncate(bucketDuration)
+	key := k8stypes.NamespacedName{Namespace: namespace, Name: function}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	usages, ok := a.buckets[bucket]
+	if !ok {
+		usages = make(map[k8stypes.NamespacedName]*client.Usage)
+		a.buckets[bucket] = usages
+	}
+	u, ok := usages[key]
+	if !ok {
+		u = &client.Usage{}
+		usages[key] = u
+	}
+	update(u)
+}
+
+// prune removes the buckets older than the retention.
+func (a *Accountant) prune(now time.Time) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for bucket := range a.buckets {
+		if now.Sub(bucket) > retention+bucketDuration {
+			delete(a.buckets, bucket)
+		}
+	}
+}
+
+// Report summarizes the usage of the functions of the namespace, or of all
+// namespaces if empty, since the given time. The usage is kept by the hour,
+// so the report starts at the beginning of the hour of since, and at most
+// at the retention. Usage before the report's RecordedSince wasn't
+// recorded by this replica.
+func (a *Accountant) Report(namespace string, since time.Time) *client.UsageReport {
+	now := time.Now()
+	start := since
+	if start.Before(now.Add(-retention)) {
+		start = now.Add(-retention)
+	}
+	start = start.Truncate(bucketDuration)
+	report := &client.UsageReport{
+		Start:      start,
+		End:        now,
+		Namespaces: make([]client.NamespaceUsage, 0),
+		Functions:  make([]client.FunctionUsage, 0),
+	}
+
+	functions := make(map[k8stypes.NamespacedName]*client.Usage)
+	a.lock.Lock()
+	report.RecordedSince = a.recordedSince
+	for bucket, usages := range a.buckets {
+		if bucket.Before(start) {
+			continue
+		}
+		for key, u := range usages {
+			if namespace != "" && key.Namespace != namespace {
+				continue
+			}
+			total, ok := functions[key]
+			if !ok {
+				total = &client.Usage{}
+				functions[key] = total
+			}
+			total.Add(*u)
+		}
+	}
+	a.lock.Unlock()
+
+	namespaces := make(map[string]*client.Usage)
+	for key, u := range functions {
+		report.Functions = append(report.Functions, client.FunctionUsage{
+			Function:  key.Name,
+			Namespace: key.Namespace,
+			Usage:     *u,
+		})
+		total, ok := namespaces[key.Namespace]
+		if !ok {
+			total = &client.Usage{}
+			namespaces[key.Namespace] = total
+		}
+		total.Add(*u)
+	}
+	for ns, u := range namespaces {
+		report.Namespaces = append(report.Namespaces, client.NamespaceUsage{
+			Namespace: ns,
+			Usage:     *u,
+		})
+	}
+
+	slices.SortFunc(report.Functions, func(a, b client.FunctionUsage) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Function, b.Function))
+	})
+	slices.SortFunc(report.Namespaces, func(a, b client.NamespaceUsage) int {
+		return cmp.Compare(a.Namespace, b.Namespace)
+	})
+	return report
+}
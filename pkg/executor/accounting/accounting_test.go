@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sInformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func functionPod(name, fnNamespace, fnName string, phase apiv1.PodPhase) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "fission-function",
+			Labels: map[string]string{
+				fv1.FUNCTION_NAMESPACE: fnNamespace,
+				fv1.FUNCTION_NAME:      fnName,
+			},
+		},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{{
+				Name: "fn",
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("500m"),
+						apiv1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}},
+		},
+		Status: apiv1.PodStatus{Phase: phase},
+	}
+}
+
+func TestAccountant(t *testing.T) {
+	ctx := t.Context()
+
+	kubernetesClient := fake.NewSimpleClientset(
+		functionPod("hello-1", "tenant", "hello", apiv1.PodRunning),
+		functionPod("hello-2", "tenant", "hello", apiv1.PodRunning),
+		functionPod("hello-3", "tenant", "hello", apiv1.PodPending),
+		functionPod("other-1", "other", "world", apiv1.PodRunning),
+	)
+	factory := map[string]k8sInformers.SharedInformerFactory{
+		"fission-function": k8sInformers.NewSharedInformerFactoryWithOptions(kubernetesClient, time.Minute,
+			k8sInformers.WithNamespace("fission-function")),
+	}
+	a := MakeAccountant(loggerfactory.GetLogger(), factory, nil)
+	for _, f := range factory {
+		f.Start(ctx.Done())
+	}
+	for ns := range factory {
+		require.True(t, k8sCache.WaitForCacheSync(ctx.Done(), a.podListerSynced[ns]))
+	}
+
+	now := time.Now()
+	require.True(t, a.Report("", now.Add(-time.Hour)).RecordedSince.Before(now))
+	a.sample(now.Add(-10 * time.Second))
+	a.sample(now)
+	// sampling delays are not charged
+	a.sample(now.Add(time.Hour))
+
+	fnMeta := &metav1.ObjectMeta{Name: "hello", Namespace: "tenant"}
+	a.RecordInvocations(fnMeta, 5)
+	a.RecordInvocations(fnMeta, 0)
+	a.RecordColdStart(fnMeta)
+
+	report := a.Report("tenant", now.Add(-time.Hour))
+	require.Len(t, report.Functions, 1)
+	require.Len(t, report.Namespaces, 1)
+	u := report.Functions[0].Usage
+	require.Equal(t, "hello", report.Functions[0].Function)
+	require.InDelta(t, 2*(10+2*sampleInterval.Seconds()), u.PodSeconds, 0.01)
+	require.InDelta(t, u.PodSeconds/2, u.CPUCoreSeconds, 0.01)
+	require.InDelta(t, u.PodSeconds*(1<<30), u.MemoryByteSeconds, 1)
+	require.Equal(t, int64(5), u.Invocations)
+	require.Equal(t, int64(1), u.ColdStarts)
+	require.Equal(t, u, report.Namespaces[0].Usage)
+	// pods are recorded since the first sample of this replica
+	require.Equal(t, now.Add(-10*time.Second), report.RecordedSince)
+
+	report = a.Report("", now.Add(-time.Hour))
+	require.Len(t, report.Functions, 2)
+	require.Equal(t, "other", report.Namespaces[0].Namespace)
+	require.Equal(t, "tenant", report.Namespaces[1].Namespace)
+
+	// a nil accountant doesn't record anything
+	var nilAccountant *Accountant
+	nilAccountant.RecordInvocations(fnMeta, 1)
+	nilAccountant.RecordColdStart(fnMeta)
+}
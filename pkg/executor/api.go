@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"html"
 	"io"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
@@ -33,6 +35,7 @@ import (
 	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/executor/client"
 	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/utils/httpserver"
 	"github.com/fission/fission/pkg/utils/manager"
 	"github.com/fission/fission/pkg/utils/metrics"
@@ -115,6 +118,10 @@ func (executor *Executor) getServiceForFunctionAPI(w http.ResponseWriter, r *htt
 			zap.Error(err),
 			zap.String("function", fn.ObjectMeta.Name),
 			zap.String("fission_http_error", msg))
+		var quotaErr *quota.ExceededError
+		if errors.As(err, &quotaErr) {
+			w.Header().Set(client.QuotaExceededHeader, quotaErr.Resource)
+		}
 		http.Error(w, msg, code)
 		return
 	}
@@ -201,6 +208,13 @@ func (executor *Executor) tapServices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// each router instance reports the in-flight requests it observed,
+	// routers not identifying themselves are told apart by address
+	remoteAddr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteAddr = r.RemoteAddr
+	}
+
 	var errs error
 	for _, req := range tapSvcReqs {
 		svcHost := strings.TrimPrefix(req.ServiceURL, "http://")
@@ -213,6 +227,19 @@ func (executor *Executor) tapServices(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
+		if req.ActiveRequests != nil {
+			reporter := req.Reporter
+			if len(reporter) == 0 {
+				reporter = remoteAddr
+			}
+			et.ReportConcurrency(ctx, reporter, &req.FnMetadata, *req.ActiveRequests)
+		}
+		executor.accountant.RecordInvocations(&req.FnMetadata, req.Invocations)
+		// in-flight requests and invocations are reported without a service url
+		if len(req.ServiceURL) == 0 {
+			continue
+		}
+
 		err = et.TapService(ctx, svcHost)
 		if err != nil {
 			errs = errors.Join(errs,
@@ -260,19 +287,202 @@ func (executor *Executor) unTapService(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-// dumpDebugInfo => dump function service for pool cache
+// functionServiceGroups returns the function services cached by all executor types.
+func (executor *Executor) functionServiceGroups(ctx context.Context) ([]client.FunctionServiceGroup, error) {
+	groups := make([]client.FunctionServiceGroup, 0)
+	for _, et := range executor.executorTypes {
+		statuses, err := et.DumpDebugInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range statuses {
+			group := client.FunctionServiceGroup{
+				FunctionUID:     string(status.Function.UID),
+				ResourceVersion: status.Function.ResourceVersion,
+				Generation:      status.Function.Generation,
+				SvcWaiting:      status.SvcWaiting,
+				QueueLen:        status.QueueLen,
+				Services:        make([]client.FunctionService, 0, len(status.Services)),
+			}
+			for _, svc := range status.Services {
+				group.Services = append(group.Services, toFunctionService(svc))
+			}
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func toFunctionService(status fscache.FuncSvcStatus) client.FunctionService {
+	fsvc := client.FunctionService{
+		Name:           status.Name,
+		Executor:       status.Executor,
+		Address:        status.Address,
+		ActiveRequests: status.ActiveRequests,
+		Draining:       status.Draining,
+		Ctime:          status.Ctime,
+		Atime:          status.Atime,
+	}
+	if status.Function != nil {
+		fsvc.Function = status.Function.Name
+		fsvc.Namespace = status.Function.Namespace
+	}
+	if status.Environment != nil {
+		fsvc.Environment = status.Environment.ObjectMeta.Name
+		fsvc.EnvironmentNamespace = status.Environment.ObjectMeta.Namespace
+	}
+	for _, obj := range status.KubernetesObjects {
+		fsvc.Objects = append(fsvc.Objects, fmt.Sprintf("%s/%s", strings.ToLower(obj.Kind), obj.Name))
+	}
+	if !status.CurrentCPUUsage.IsZero() {
+		fsvc.CPUUsage = status.CurrentCPUUsage.String()
+	}
+	if !status.CPULimit.IsZero() {
+		fsvc.CPULimit = status.CPULimit.String()
+	}
+	return fsvc
+}
+
+func (executor *Executor) writeJSON(w http.ResponseWriter, v interface{}) {
+	resp, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(resp)
+	if err != nil {
+		executor.logger.Error("error writing HTTP response", zap.Error(err))
+	}
+}
+
+// dumpDebugInfo returns the function service cache state of all executor types
 func (executor *Executor) dumpDebugInfo(w http.ResponseWriter, r *http.Request) {
-	// currently we are considering dumping function only for pool manager
-	et := executor.executorTypes[fv1.ExecutorTypePoolmgr]
-	if err := et.DumpDebugInfo(r.Context()); err != nil {
+	groups, err := executor.functionServiceGroups(r.Context())
+	if err != nil {
 		code, msg := ferror.GetHTTPError(err)
 		http.Error(w, msg, code)
 		return
 	}
+	executor.writeJSON(w, groups)
+}
 
+// listFunctionServices lists the cached function services matching the query parameters
+func (executor *Executor) listFunctionServices(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := client.FunctionServiceFilter{
+		Namespace:    query.Get("namespace"),
+		Function:     query.Get("function"),
+		Environment:  query.Get("environment"),
+		ExecutorType: fv1.ExecutorType(query.Get("executorType")),
+	}
+
+	groups, err := executor.functionServiceGroups(r.Context())
+	if err != nil {
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+
+	fsvcs := make([]client.FunctionService, 0)
+	for _, group := range groups {
+		for _, fsvc := range group.Services {
+			if (filter.Namespace != "" && fsvc.Namespace != filter.Namespace) ||
+				(filter.Function != "" && fsvc.Function != filter.Function) ||
+				(filter.Environment != "" && fsvc.Environment != filter.Environment) ||
+				(filter.ExecutorType != "" && fsvc.Executor != filter.ExecutorType) {
+				continue
+			}
+			fsvcs = append(fsvcs, fsvc)
+		}
+	}
+	executor.writeJSON(w, fsvcs)
+}
+
+// getUsage returns the usage accounted to the functions of a namespace, or
+// of all namespaces, since the given time.
+func (executor *Executor) getUsage(w http.ResponseWriter, r *http.Request) {
+	if executor.accountant == nil {
+		http.Error(w, "usage accounting is not enabled", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	var since time.Time
+	if s := query.Get("since"); s != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(w, html.EscapeString(fmt.Sprintf("invalid since time '%s'", s)), http.StatusBadRequest)
+			return
+		}
+	}
+	executor.writeJSON(w, executor.accountant.Report(query.Get("namespace"), since))
+}
+
+// evictFunctionService removes a specialized pod from cache and deletes it
+func (executor *Executor) evictFunctionService(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := otelUtils.LoggerWithTraceID(ctx, executor.logger)
+
+	evictReq := client.EvictFunctionServiceRequest{}
+	err := json.NewDecoder(r.Body).Decode(&evictReq)
+	if err != nil {
+		http.Error(w, "Failed to parse request", http.StatusBadRequest)
+		return
+	}
+	et, ok := executor.executorTypes[evictReq.FnExecutorType]
+	if !ok {
+		msg := fmt.Sprintf("Unknown executor type '%s'", evictReq.FnExecutorType)
+		http.Error(w, html.EscapeString(msg), http.StatusBadRequest)
+		return
+	}
+
+	err = et.EvictFuncSvc(ctx, evictReq.Namespace, evictReq.PodName)
+	if err != nil {
+		logger.Error("error evicting function service", zap.Error(err),
+			zap.String("pod", evictReq.PodName), zap.String("namespace", evictReq.Namespace))
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+// drainFunction stops assigning requests to the function services of a function
+// and responds once in-flight requests finish or the timeout expires
+func (executor *Executor) drainFunction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := otelUtils.LoggerWithTraceID(ctx, executor.logger)
+
+	drainReq := client.DrainFunctionRequest{}
+	err := json.NewDecoder(r.Body).Decode(&drainReq)
+	if err != nil {
+		http.Error(w, "Failed to parse request", http.StatusBadRequest)
+		return
+	}
+
+	et, ok := executor.executorTypes[drainReq.FnExecutorType]
+	if !ok {
+		msg := fmt.Sprintf("Unknown executor type '%s'", drainReq.FnExecutorType)
+		http.Error(w, html.EscapeString(msg), http.StatusBadRequest)
+		return
+	}
+
+	drained, forced, err := et.DrainFunction(ctx, &drainReq.FnMetadata, drainReq.Timeout)
+	if err != nil {
+		logger.Error("error draining function", zap.Error(err),
+			zap.String("function", drainReq.FnMetadata.Name), zap.String("namespace", drainReq.FnMetadata.Namespace))
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+	executor.writeJSON(w, client.DrainFunctionResponse{
+		Drained: drained,
+		Forced:  forced,
+	})
+}
+
 // GetHandler returns an http.Handler.
 func (executor *Executor) GetHandler() http.Handler {
 	r := mux.NewRouter()
@@ -283,6 +493,10 @@ func (executor *Executor) GetHandler() http.Handler {
 	r.HandleFunc("/healthz", executor.healthHandler).Methods("GET")
 	r.HandleFunc("/v2/unTapService", executor.unTapService).Methods("POST")
 	r.HandleFunc("/v2/debugInfo", executor.dumpDebugInfo).Methods("GET")
+	r.HandleFunc("/v2/functionServices", executor.listFunctionServices).Methods("GET")
+	r.HandleFunc("/v2/evictFunctionService", executor.evictFunctionService).Methods("POST")
+	r.HandleFunc("/v2/drainFunction", executor.drainFunction).Methods("POST")
+	r.HandleFunc("/v2/usage", executor.getUsage).Methods("GET")
 	return r
 }
 
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/fscache"
+)
+
+// fakeExecutorType serves the function services it's given, and records
+// the pods it evicts and the functions it drains.
+type fakeExecutorType struct {
+	executortype.ExecutorType
+
+	statuses []fscache.FuncSvcGroupStatus
+	evicted  []string
+	drained  []string
+	// in-flight requests reported by reporter
+	reported map[string]int
+}
+
+func (et *fakeExecutorType) GetTypeName(context.Context) fv1.ExecutorType {
+	return fv1.ExecutorTypePoolmgr
+}
+
+func (et *fakeExecutorType) DumpDebugInfo(context.Context) ([]fscache.FuncSvcGroupStatus, error) {
+	return et.statuses, nil
+}
+
+func (et *fakeExecutorType) EvictFuncSvc(ctx context.Context, namespace string, podName string) error {
+	if podName == "missing" {
+		return ferror.MakeError(ferror.ErrorNotFound, "pod not found")
+	}
+	et.evicted = append(et.evicted, namespace+"/"+podName)
+	return nil
+}
+
+func (et *fakeExecutorType) DrainFunction(ctx context.Context, fnMeta *metav1.ObjectMeta, timeout time.Duration) ([]string, []string, error) {
+	et.drained = append(et.drained, fnMeta.Namespace+"/"+fnMeta.Name)
+	return []string{"pod-a"}, []string{"pod-b"}, nil
+}
+
+func (et *fakeExecutorType) ReportConcurrency(ctx context.Context, reporter string, fnMeta *metav1.ObjectMeta, activeRequests int) {
+	if et.reported == nil {
+		et.reported = make(map[string]int)
+	}
+	et.reported[reporter] = activeRequests
+}
+
+func makeFuncSvcStatus(fnName, namespace, envName, podName string) fscache.FuncSvcStatus {
+	return fscache.FuncSvcStatus{
+		FuncSvc: fscache.FuncSvc{
+			Name:        podName,
+			Function:    &metav1.ObjectMeta{Name: fnName, Namespace: namespace},
+			Environment: &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: envName, Namespace: namespace}},
+			Address:     podName + ":8888",
+			Executor:    fv1.ExecutorTypePoolmgr,
+		},
+		ActiveRequests: 1,
+	}
+}
+
+func makeTestExecutor(et *fakeExecutorType) *Executor {
+	return &Executor{
+		logger: zap.NewNop(),
+		executorTypes: map[fv1.ExecutorType]executortype.ExecutorType{
+			fv1.ExecutorTypePoolmgr: et,
+		},
+	}
+}
+
+func postJSON(t *testing.T, handler http.Handler, url string, v interface{}) *httptest.ResponseRecorder {
+	body, err := json.Marshal(v)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body)))
+	return w
+}
+
+func TestListFunctionServices(t *testing.T) {
+	et := &fakeExecutorType{
+		statuses: []fscache.FuncSvcGroupStatus{
+			{
+				Function: crd.CacheKeyURG{UID: "uid-hello"},
+				Services: []fscache.FuncSvcStatus{
+					makeFuncSvcStatus("hello", "default", "python", "pod-1"),
+					makeFuncSvcStatus("hello", "default", "python", "pod-2"),
+				},
+			},
+			{
+				Function: crd.CacheKeyURG{UID: "uid-world"},
+				Services: []fscache.FuncSvcStatus{
+					makeFuncSvcStatus("world", "other", "nodejs", "pod-3"),
+				},
+			},
+		},
+	}
+	handler := makeTestExecutor(et).GetHandler()
+
+	for _, test := range []struct {
+		query string
+		pods  []string
+	}{
+		{query: "", pods: []string{"pod-1", "pod-2", "pod-3"}},
+		{query: "?namespace=default", pods: []string{"pod-1", "pod-2"}},
+		{query: "?function=world", pods: []string{"pod-3"}},
+		{query: "?environment=python&namespace=other", pods: []string{}},
+		{query: "?executorType=newdeploy", pods: []string{}},
+	} {
+		t.Run(test.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/functionServices"+test.query, nil))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var fsvcs []client.FunctionService
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fsvcs))
+			pods := make([]string, 0, len(fsvcs))
+			for _, fsvc := range fsvcs {
+				pods = append(pods, fsvc.Name)
+			}
+			require.ElementsMatch(t, test.pods, pods)
+		})
+	}
+}
+
+func TestEvictFunctionService(t *testing.T) {
+	et := &fakeExecutorType{}
+	handler := makeTestExecutor(et).GetHandler()
+
+	w := postJSON(t, handler, "/v2/evictFunctionService", client.EvictFunctionServiceRequest{
+		Namespace: "default", PodName: "pod-1", FnExecutorType: fv1.ExecutorTypePoolmgr,
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"default/pod-1"}, et.evicted)
+
+	w = postJSON(t, handler, "/v2/evictFunctionService", client.EvictFunctionServiceRequest{
+		Namespace: "default", PodName: "missing", FnExecutorType: fv1.ExecutorTypePoolmgr,
+	})
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	for _, executorType := range []fv1.ExecutorType{"", fv1.ExecutorTypeNewdeploy} {
+		t.Run(fmt.Sprintf("executor type %q", executorType), func(t *testing.T) {
+			w := postJSON(t, handler, "/v2/evictFunctionService", client.EvictFunctionServiceRequest{
+				Namespace: "default", PodName: "pod-1", FnExecutorType: executorType,
+			})
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+	require.Len(t, et.evicted, 1)
+}
+
+func TestDrainFunction(t *testing.T) {
+	et := &fakeExecutorType{}
+	handler := makeTestExecutor(et).GetHandler()
+
+	w := postJSON(t, handler, "/v2/drainFunction", client.DrainFunctionRequest{
+		FnMetadata:     metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		FnExecutorType: fv1.ExecutorTypePoolmgr,
+		Timeout:        time.Second,
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"default/hello"}, et.drained)
+	var resp client.DrainFunctionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, []string{"pod-a"}, resp.Drained)
+	require.Equal(t, []string{"pod-b"}, resp.Forced)
+
+	for _, executorType := range []fv1.ExecutorType{"", fv1.ExecutorTypeNewdeploy} {
+		t.Run(fmt.Sprintf("executor type %q", executorType), func(t *testing.T) {
+			w := postJSON(t, handler, "/v2/drainFunction", client.DrainFunctionRequest{
+				FnMetadata:     metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+				FnExecutorType: executorType,
+			})
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+	require.Len(t, et.drained, 1)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v2/drainFunction", bytes.NewReader([]byte("{"))))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTapServicesReporter(t *testing.T) {
+	et := &fakeExecutorType{}
+	handler := makeTestExecutor(et).GetHandler()
+
+	report := func(reporter string, activeRequests int) client.TapServiceRequest {
+		return client.TapServiceRequest{
+			FnMetadata:     metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid-hello"},
+			FnExecutorType: fv1.ExecutorTypePoolmgr,
+			ActiveRequests: &activeRequests,
+			Reporter:       reporter,
+		}
+	}
+	// routers behind the same address are told apart by their identity,
+	// routers not sending one by their address
+	w := postJSON(t, handler, "/v2/tapServices", []client.TapServiceRequest{
+		report("router-a", 2), report("router-b", 3), report("", 4),
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, map[string]int{"router-a": 2, "router-b": 3, "192.0.2.1": 4}, et.reported)
+}
@@ -22,33 +22,68 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/utils/uuid"
 )
 
+// TapReportInterval is the interval at which tapped services and in-flight
+// requests are reported to the executor.
+const TapReportInterval = 5 * time.Second
+
+// QuotaExceededHeader is set to the exceeded resource on responses rejected
+// by the quota of the function namespace, which are not retried.
+const QuotaExceededHeader = "X-Fission-Quota-Exceeded"
+
 type (
 	// ClientInterface is the interface for executor client.
 	ClientInterface interface {
 		GetServiceForFunction(ctx context.Context, fn *fv1.Function) (string, error)
 		TapService(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType, serviceURL url.URL)
+		TrackRequest(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType) func()
+		CountInvocation(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType)
 		UnTapService(ctx context.Context, fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType, serviceURL *url.URL) error
+		ListFunctionServices(ctx context.Context, filter FunctionServiceFilter) ([]FunctionService, error)
+		EvictFunctionService(ctx context.Context, req EvictFunctionServiceRequest) error
+		DrainFunction(ctx context.Context, req DrainFunctionRequest) (*DrainFunctionResponse, error)
+		GetUsage(ctx context.Context, filter UsageFilter) (*UsageReport, error)
 	}
 	// client is wrapper on a HTTP client.
 	client struct {
 		logger      *zap.Logger
 		executorURL string
+		// reporter identifies this instance in the in-flight requests reports
+		reporter    string
 		tappedByURL map[string]TapServiceRequest
 		requestChan chan TapServiceRequest
 		httpClient  *retryablehttp.Client
+
+		inFlightLock sync.Mutex
+		inFlight     map[types.UID]*inFlightRequests
+
+		invocationLock sync.Mutex
+		invocations    map[types.UID]*TapServiceRequest
+	}
+
+	// inFlightRequests tracks the requests of a function being served.
+	inFlightRequests struct {
+		req     TapServiceRequest
+		current int
+		// highest in-flight requests since the last report
+		peak int
 	}
 
 	// TapServiceRequest represents
@@ -56,19 +91,156 @@ type (
 		FnMetadata     metav1.ObjectMeta
 		FnExecutorType fv1.ExecutorType
 		ServiceURL     string
+		// ActiveRequests is the highest number of in-flight requests of the
+		// function since the last report, set for functions scaling on concurrency.
+		ActiveRequests *int `json:",omitempty"`
+		// Reporter identifies the router instance reporting the in-flight
+		// requests, its pod name.
+		Reporter string `json:",omitempty"`
+		// Invocations is the number of requests to the function since the
+		// last report.
+		Invocations int `json:",omitempty"`
+	}
+
+	// FunctionServiceFilter selects the function services to list.
+	// Empty fields match everything.
+	FunctionServiceFilter struct {
+		Namespace    string
+		Function     string
+		Environment  string
+		ExecutorType fv1.ExecutorType
+	}
+
+	// FunctionService represents the state of a function service cached by the executor.
+	FunctionService struct {
+		Name                 string           `json:"name"`
+		Function             string           `json:"function"`
+		Namespace            string           `json:"namespace"`
+		Environment          string           `json:"environment,omitempty"`
+		EnvironmentNamespace string           `json:"environmentNamespace,omitempty"`
+		Executor             fv1.ExecutorType `json:"executor"`
+		Address              string           `json:"address"`
+		Objects              []string         `json:"objects,omitempty"`
+		ActiveRequests       int              `json:"activeRequests"`
+		CPUUsage             string           `json:"cpuUsage,omitempty"`
+		CPULimit             string           `json:"cpuLimit,omitempty"`
+		Draining             bool             `json:"draining,omitempty"`
+		Ctime                time.Time        `json:"ctime"`
+		Atime                time.Time        `json:"atime"`
+	}
+
+	// FunctionServiceGroup represents the function services cached for a
+	// version of a function, along with the requests waiting for them.
+	FunctionServiceGroup struct {
+		FunctionUID     string            `json:"functionUID"`
+		ResourceVersion string            `json:"resourceVersion"`
+		Generation      int64             `json:"generation"`
+		SvcWaiting      int               `json:"svcWaiting"`
+		QueueLen        int               `json:"queueLen"`
+		Services        []FunctionService `json:"services"`
+	}
+
+	// EvictFunctionServiceRequest asks the executor to evict a specialized pod
+	// of a function in the namespace.
+	EvictFunctionServiceRequest struct {
+		Namespace      string           `json:"namespace"`
+		PodName        string           `json:"podName"`
+		FnExecutorType fv1.ExecutorType `json:"executorType"`
+	}
+
+	// DrainFunctionRequest asks the executor to drain the function services of a function.
+	DrainFunctionRequest struct {
+		FnMetadata     metav1.ObjectMeta `json:"function"`
+		FnExecutorType fv1.ExecutorType  `json:"executorType"`
+		Timeout        time.Duration     `json:"timeout"`
+	}
+
+	// DrainFunctionResponse lists the function services removed by a drain.
+	DrainFunctionResponse struct {
+		Drained []string `json:"drained"`
+		Forced  []string `json:"forced"`
+	}
+
+	// UsageFilter selects the usage to report. An empty namespace selects
+	// all namespaces.
+	UsageFilter struct {
+		Namespace string
+		Since     time.Time
+	}
+
+	// Usage is the platform usage accounted to functions. Reserved CPU and
+	// memory are the resource requests of the running function pods.
+	Usage struct {
+		PodSeconds        float64 `json:"podSeconds"`
+		CPUCoreSeconds    float64 `json:"cpuCoreSeconds"`
+		MemoryByteSeconds float64 `json:"memoryByteSeconds"`
+		Invocations       int64   `json:"invocations"`
+		ColdStarts        int64   `json:"coldStarts"`
+	}
+
+	// FunctionUsage is the usage of a function.
+	FunctionUsage struct {
+		Function  string `json:"function"`
+		Namespace string `json:"namespace"`
+		Usage
+	}
+
+	// NamespaceUsage is the usage of the functions of a namespace.
+	NamespaceUsage struct {
+		Namespace string `json:"namespace"`
+		Usage
+	}
+
+	// UsageReport summarizes the usage between Start and End.
+	UsageReport struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+		// RecordedSince is when the executor started recording the usage,
+		// after its last restart or leader change. The report is incomplete
+		// if it starts before.
+		RecordedSince time.Time        `json:"recordedSince"`
+		Namespaces    []NamespaceUsage `json:"namespaces"`
+		Functions     []FunctionUsage  `json:"functions"`
 	}
 )
 
+// Add adds the usage o to the usage.
+func (u *Usage) Add(o Usage) {
+	u.PodSeconds += o.PodSeconds
+	u.CPUCoreSeconds += o.CPUCoreSeconds
+	u.MemoryByteSeconds += o.MemoryByteSeconds
+	u.Invocations += o.Invocations
+	u.ColdStarts += o.ColdStarts
+}
+
 // MakeClient initializes and returns a Client instance.
 func MakeClient(logger *zap.Logger, executorURL string) ClientInterface {
 	hc := retryablehttp.NewClient()
 	hc.HTTPClient.Transport = otelhttp.NewTransport(hc.HTTPClient.Transport)
+	hc.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if resp != nil && resp.Header.Get(QuotaExceededHeader) != "" {
+			return false, nil
+		}
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+	// return the last response once retries are exhausted, so that errors
+	// of the executor are returned with their status code
+	hc.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	// the hostname of a pod is its name, which is kept across restarts of
+	// the router container
+	reporter, err := os.Hostname()
+	if err != nil {
+		reporter = uuid.NewString()
+	}
 	c := &client{
 		logger:      logger.Named("executor_client"),
 		executorURL: strings.TrimSuffix(executorURL, "/"),
+		reporter:    reporter,
 		tappedByURL: make(map[string]TapServiceRequest),
 		requestChan: make(chan TapServiceRequest, 100),
 		httpClient:  hc,
+		inFlight:    make(map[types.UID]*inFlightRequests),
+		invocations: make(map[types.UID]*TapServiceRequest),
 	}
 	go c.service()
 	return c
@@ -139,14 +311,149 @@ func (c *client) UnTapService(ctx context.Context, fnMeta metav1.ObjectMeta, exe
 	return nil
 }
 
+// ListFunctionServices returns the function services cached by the executor.
+func (c *client) ListFunctionServices(ctx context.Context, filter FunctionServiceFilter) ([]FunctionService, error) {
+	query := url.Values{}
+	if filter.Namespace != "" {
+		query.Set("namespace", filter.Namespace)
+	}
+	if filter.Function != "" {
+		query.Set("function", filter.Function)
+	}
+	if filter.Environment != "" {
+		query.Set("environment", filter.Environment)
+	}
+	if filter.ExecutorType != "" {
+		query.Set("executorType", string(filter.ExecutorType))
+	}
+	executorURL := c.executorURL + "/v2/functionServices"
+	if len(query) > 0 {
+		executorURL += "?" + query.Encode()
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", executorURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request for listing function services: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error listing function services: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	fsvcs := []FunctionService{}
+	err = json.NewDecoder(resp.Body).Decode(&fsvcs)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding function services: %w", err)
+	}
+	return fsvcs, nil
+}
+
+// EvictFunctionService sends a request to /v2/evictFunctionService.
+func (c *client) EvictFunctionService(ctx context.Context, evictReq EvictFunctionServiceRequest) error {
+	body, err := json.Marshal(evictReq)
+	if err != nil {
+		return fmt.Errorf("could not marshal request body for evicting function service: %w", err)
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", c.executorURL+"/v2/evictFunctionService", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("could not create request for evicting function service: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error evicting function service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return ferror.MakeErrorFromHTTP(resp)
+	}
+	return nil
+}
+
+// DrainFunction sends a request to /v2/drainFunction and waits for the drain to finish.
+func (c *client) DrainFunction(ctx context.Context, drainReq DrainFunctionRequest) (*DrainFunctionResponse, error) {
+	body, err := json.Marshal(drainReq)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal request body for draining function: %w", err)
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", c.executorURL+"/v2/drainFunction", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request for draining function: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error draining function: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	drainResp := &DrainFunctionResponse{}
+	err = json.NewDecoder(resp.Body).Decode(drainResp)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding drain function response: %w", err)
+	}
+	return drainResp, nil
+}
+
+// GetUsage returns the usage accounted to functions by the executor.
+func (c *client) GetUsage(ctx context.Context, filter UsageFilter) (*UsageReport, error) {
+	query := url.Values{}
+	if filter.Namespace != "" {
+		query.Set("namespace", filter.Namespace)
+	}
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	executorURL := c.executorURL + "/v2/usage"
+	if len(query) > 0 {
+		executorURL += "?" + query.Encode()
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", executorURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request for getting usage: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting usage: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	report := &UsageReport{}
+	err = json.NewDecoder(resp.Body).Decode(report)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding usage report: %w", err)
+	}
+	return report, nil
+}
+
 func (c *client) service() {
-	ticker := time.NewTicker(time.Second * 5)
+	ticker := time.NewTicker(TapReportInterval)
 	for {
 		select {
 		case svcReq := <-c.requestChan:
 			c.tappedByURL[svcReq.ServiceURL] = svcReq
 		case <-ticker.C:
-			if len(c.tappedByURL) == 0 {
+			fnReqs := append(c.concurrencyReports(), c.invocationReports()...)
+			if len(c.tappedByURL) == 0 && len(fnReqs) == 0 {
 				continue
 			}
 
@@ -158,6 +465,7 @@ func (c *client) service() {
 				for _, req := range urls {
 					svcReqs = append(svcReqs, req)
 				}
+				svcReqs = append(svcReqs, fnReqs...)
 				c.logger.Debug("tapped services in batch", zap.Int("service_count", len(urls)))
 				err := c._tapService(context.Background(), svcReqs)
 				if err != nil {
@@ -184,6 +492,99 @@ func (c *client) TapService(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorT
 	}
 }
 
+// TrackRequest counts a request to the function as in-flight until the
+// returned function is called. The in-flight requests are reported to the
+// executor along with the tapped services.
+func (c *client) TrackRequest(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType) func() {
+	c.inFlightLock.Lock()
+	defer c.inFlightLock.Unlock()
+
+	f, ok := c.inFlight[fnMeta.UID]
+	if !ok {
+		f = &inFlightRequests{
+			req: TapServiceRequest{
+				FnMetadata: metav1.ObjectMeta{
+					Name:            fnMeta.Name,
+					Namespace:       fnMeta.Namespace,
+					ResourceVersion: fnMeta.ResourceVersion,
+					UID:             fnMeta.UID,
+				},
+				FnExecutorType: executorType,
+			},
+		}
+		c.inFlight[fnMeta.UID] = f
+	}
+	f.current++
+	f.peak = max(f.peak, f.current)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.inFlightLock.Lock()
+			defer c.inFlightLock.Unlock()
+			f.current--
+		})
+	}
+}
+
+// concurrencyReports returns the in-flight requests of the tracked functions
+// since the last report. Functions are reported idle once before they are
+// no longer tracked.
+func (c *client) concurrencyReports() []TapServiceRequest {
+	c.inFlightLock.Lock()
+	defer c.inFlightLock.Unlock()
+
+	reqs := make([]TapServiceRequest, 0, len(c.inFlight))
+	for uid, f := range c.inFlight {
+		activeRequests := f.peak
+		req := f.req
+		req.ActiveRequests = &activeRequests
+		req.Reporter = c.reporter
+		reqs = append(reqs, req)
+
+		if f.peak == 0 && f.current == 0 {
+			delete(c.inFlight, uid)
+		}
+		f.peak = f.current
+	}
+	return reqs
+}
+
+// CountInvocation counts a request to the function. The invocations are
+// reported to the executor along with the tapped services, for usage accounting.
+func (c *client) CountInvocation(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType) {
+	c.invocationLock.Lock()
+	defer c.invocationLock.Unlock()
+
+	req, ok := c.invocations[fnMeta.UID]
+	if !ok {
+		req = &TapServiceRequest{
+			FnMetadata: metav1.ObjectMeta{
+				Name:            fnMeta.Name,
+				Namespace:       fnMeta.Namespace,
+				ResourceVersion: fnMeta.ResourceVersion,
+				UID:             fnMeta.UID,
+			},
+			FnExecutorType: executorType,
+		}
+		c.invocations[fnMeta.UID] = req
+	}
+	req.Invocations++
+}
+
+// invocationReports returns the invocations of functions since the last report.
+func (c *client) invocationReports() []TapServiceRequest {
+	c.invocationLock.Lock()
+	defer c.invocationLock.Unlock()
+
+	reqs := make([]TapServiceRequest, 0, len(c.invocations))
+	for _, req := range c.invocations {
+		reqs = append(reqs, *req)
+	}
+	clear(c.invocations)
+	return reqs
+}
+
 func (c *client) _tapService(ctx context.Context, tapSvcReqs []TapServiceRequest) error {
 	executorURL := c.executorURL + "/v2/tapServices"
 
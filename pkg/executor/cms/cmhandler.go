@@ -25,6 +25,7 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 )
@@ -49,7 +50,7 @@ func getConfigmapRelatedFuncs(ctx context.Context, logger *zap.Logger, m *metav1
 }
 
 func ConfigMapEventHandlers(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface,
-	kubernetesClient kubernetes.Interface, types map[fv1.ExecutorType]executortype.ExecutorType) k8sCache.ResourceEventHandlerFuncs {
+	kubernetesClient kubernetes.Interface, recorder *events.Recorder, types map[fv1.ExecutorType]executortype.ExecutorType) k8sCache.ResourceEventHandlerFuncs {
 
 	return k8sCache.ResourceEventHandlerFuncs{
 		AddFunc:    func(obj interface{}) {},
@@ -70,7 +71,7 @@ func ConfigMapEventHandlers(ctx context.Context, logger *zap.Logger, fissionClie
 				logger.Debug("Configmap changed",
 					zap.String("configmap_name", newCm.ObjectMeta.Name),
 					zap.String("configmap_namespace", newCm.ObjectMeta.Namespace))
-				refreshPods(ctx, logger, funcs, types)
+				refreshPods(ctx, logger, recorder, "configmap "+newCm.ObjectMeta.Name, funcs, types)
 			}
 		},
 	}
@@ -21,10 +21,12 @@ import (
 	"fmt"
 
 	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 )
@@ -40,7 +42,7 @@ type (
 
 // MakeConfigSecretController makes a controller for configmaps and secrets which changes related functions
 func MakeConfigSecretController(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface,
-	kubernetesClient kubernetes.Interface, types map[fv1.ExecutorType]executortype.ExecutorType,
+	kubernetesClient kubernetes.Interface, recorder *events.Recorder, types map[fv1.ExecutorType]executortype.ExecutorType,
 	configmapInformer,
 	secretInformer map[string]cache.SharedIndexInformer) (*ConfigSecretController, error) {
 	logger.Debug("Creating ConfigMap & Secret Controller")
@@ -49,13 +51,13 @@ func MakeConfigSecretController(ctx context.Context, logger *zap.Logger, fission
 		fissionClient: fissionClient,
 	}
 	for _, informer := range configmapInformer {
-		_, err := informer.AddEventHandler(ConfigMapEventHandlers(ctx, logger, fissionClient, kubernetesClient, types))
+		_, err := informer.AddEventHandler(ConfigMapEventHandlers(ctx, logger, fissionClient, kubernetesClient, recorder, types))
 		if err != nil {
 			return nil, err
 		}
 	}
 	for _, informer := range secretInformer {
-		_, err := informer.AddEventHandler(SecretEventHandlers(ctx, logger, fissionClient, kubernetesClient, types))
+		_, err := informer.AddEventHandler(SecretEventHandlers(ctx, logger, fissionClient, kubernetesClient, recorder, types))
 		if err != nil {
 			return nil, err
 		}
@@ -64,10 +66,20 @@ func MakeConfigSecretController(ctx context.Context, logger *zap.Logger, fission
 	return cmsController, nil
 }
 
-func refreshPods(ctx context.Context, logger *zap.Logger, funcs []fv1.Function, types map[fv1.ExecutorType]executortype.ExecutorType) {
+// refreshPods refreshes the pods of the functions after the object, a
+// configmap or secret, changed.
+func refreshPods(ctx context.Context, logger *zap.Logger, recorder *events.Recorder, object string,
+	funcs []fv1.Function, types map[fv1.ExecutorType]executortype.ExecutorType) {
 	for _, f := range funcs {
 		var err error
 
+		action := "Recycling"
+		if f.Spec.ConfigUpdatePolicy == fv1.ConfigUpdateReload {
+			action = "Reloading"
+		}
+		recorder.FunctionEvent(&f.ObjectMeta, apiv1.EventTypeNormal, events.ReasonConfigRefresh,
+			"%s function pods after %s changed", action, object)
+
 		et, exists := types[f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType]
 		if exists {
 			err = et.RefreshFuncPods(ctx, logger, f)
@@ -79,6 +91,8 @@ func refreshPods(ctx context.Context, logger *zap.Logger, funcs []fv1.Function,
 			logger.Error("Failed to recycle pods for function after configmap/secret changed",
 				zap.Error(err),
 				zap.Any("function", f))
+			recorder.FunctionEvent(&f.ObjectMeta, apiv1.EventTypeWarning, events.ReasonConfigRefresh,
+				"Error refreshing function pods after %s changed: %v", object, err)
 		}
 	}
 }
@@ -25,6 +25,7 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 )
@@ -49,7 +50,7 @@ func getSecretRelatedFuncs(ctx context.Context, logger *zap.Logger, m *metav1.Ob
 }
 
 func SecretEventHandlers(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface,
-	kubernetesClient kubernetes.Interface, types map[fv1.ExecutorType]executortype.ExecutorType) k8sCache.ResourceEventHandlerFuncs {
+	kubernetesClient kubernetes.Interface, recorder *events.Recorder, types map[fv1.ExecutorType]executortype.ExecutorType) k8sCache.ResourceEventHandlerFuncs {
 	return k8sCache.ResourceEventHandlerFuncs{
 		AddFunc:    func(obj interface{}) {},
 		DeleteFunc: func(obj interface{}) {},
@@ -69,7 +70,7 @@ func SecretEventHandlers(ctx context.Context, logger *zap.Logger, fissionClient
 				logger.Debug("Secret changed",
 					zap.String("secret_name", newS.ObjectMeta.Name),
 					zap.String("secret_namespace", newS.ObjectMeta.Namespace))
-				refreshPods(ctx, logger, funcs, types)
+				refreshPods(ctx, logger, recorder, "secret "+newS.ObjectMeta.Name, funcs, types)
 			}
 		},
 	}
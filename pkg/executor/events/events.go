@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// reasons of the events emitted by the executor
+const (
+	ReasonSpecializationStarted = "SpecializationStarted"
+	ReasonSpecializationFailed  = "SpecializationFailed"
+	ReasonIdleReaped            = "IdleReaped"
+	ReasonPoolUpdated           = "PoolUpdated"
+	ReasonConfigRefresh         = "ConfigRefresh"
+	ReasonPodRecycled           = "PodRecycled"
+)
+
+type (
+	// Recorder emits Kubernetes events on functions and environments for
+	// the lifecycle decisions of the executor, so that they show up in
+	// kubectl describe. A nil Recorder doesn't emit anything.
+	Recorder struct {
+		recorder record.EventRecorder
+	}
+)
+
+// MakeRecorder returns a Recorder writing events with the kubernetes client
+// until the context is done.
+func MakeRecorder(ctx context.Context, logger *zap.Logger, kubernetesClient kubernetes.Interface) *Recorder {
+	eventBroadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	eventBroadcaster.StartLogging(logger.Named("events").Sugar().Debugf)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubernetesClient.CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		eventBroadcaster.Shutdown()
+	}()
+	return MakeRecorderFor(eventBroadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{Component: "executor"}))
+}
+
+// MakeRecorderFor returns a Recorder emitting events with the event recorder.
+func MakeRecorderFor(recorder record.EventRecorder) *Recorder {
+	return &Recorder{recorder: recorder}
+}
+
+// FunctionEvent emits an event on the function.
+func (r *Recorder) FunctionEvent(fnMeta *metav1.ObjectMeta, eventType, reason, messageFmt string, args ...interface{}) {
+	r.event(fv1.CRD_NAME_FUNCTION, fnMeta, eventType, reason, messageFmt, args...)
+}
+
+// EnvironmentEvent emits an event on the environment.
+func (r *Recorder) EnvironmentEvent(envMeta *metav1.ObjectMeta, eventType, reason, messageFmt string, args ...interface{}) {
+	r.event(fv1.CRD_NAME_ENVIRONMENT, envMeta, eventType, reason, messageFmt, args...)
+}
+
+func (r *Recorder) event(kind string, meta *metav1.ObjectMeta, eventType, reason, messageFmt string, args ...interface{}) {
+	if r == nil || meta == nil {
+		return
+	}
+	// the fission types are not registered in the client-go scheme, so
+	// reference the object directly
+	ref := &apiv1.ObjectReference{
+		Kind:            kind,
+		APIVersion:      fv1.CRD_VERSION,
+		Name:            meta.Name,
+		Namespace:       meta.Namespace,
+		UID:             meta.UID,
+		ResourceVersion: meta.ResourceVersion,
+	}
+	r.recorder.Eventf(ref, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestRecorder(t *testing.T) {
+	fnMeta := &metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid"}
+
+	// a nil recorder doesn't emit anything
+	var nilRecorder *Recorder
+	nilRecorder.FunctionEvent(fnMeta, apiv1.EventTypeNormal, ReasonIdleReaped, "reaped")
+
+	fakeRecorder := record.NewFakeRecorder(1)
+	MakeRecorderFor(fakeRecorder).FunctionEvent(fnMeta, apiv1.EventTypeWarning, ReasonSpecializationFailed, "error: %s", "timeout")
+	require.Equal(t, "Warning SpecializationFailed error: timeout", <-fakeRecorder.Events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubernetesClient := fake.NewSimpleClientset()
+	recorder := MakeRecorder(ctx, loggerfactory.GetLogger(), kubernetesClient)
+	recorder.EnvironmentEvent(&metav1.ObjectMeta{Name: "nodejs", Namespace: "default"},
+		apiv1.EventTypeNormal, ReasonPoolUpdated, "updated")
+
+	var events *apiv1.EventList
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		var err error
+		events, err = kubernetesClient.CoreV1().Events("default").List(ctx, metav1.ListOptions{})
+		return err == nil && len(events.Items) > 0, err
+	})
+	require.NoError(t, err)
+	event := events.Items[0]
+	require.Equal(t, "Environment", event.InvolvedObject.Kind)
+	require.Equal(t, "fission.io/v1", event.InvolvedObject.APIVersion)
+	require.Equal(t, "nodejs", event.InvolvedObject.Name)
+	require.Equal(t, ReasonPoolUpdated, event.Reason)
+	require.Equal(t, "executor", event.Source.Component)
+}
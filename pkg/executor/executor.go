@@ -27,26 +27,35 @@ import (
 
 	"github.com/dchest/uniuri"
 	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8sInformers "k8s.io/client-go/informers"
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/accounting"
 	"github.com/fission/fission/pkg/executor/cms"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/executortype/container"
 	"github.com/fission/fission/pkg/executor/executortype/newdeploy"
 	"github.com/fission/fission/pkg/executor/executortype/poolmgr"
 	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/util"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/leaderelection"
 	"github.com/fission/fission/pkg/utils/manager"
 	"github.com/fission/fission/pkg/utils/metrics"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
 
+// executorLeaseName is the name of the Lease executor replicas compete for.
+const executorLeaseName = "fission-executor"
+
 type (
 	// Executor defines a fission function executor.
 	Executor struct {
@@ -54,6 +63,8 @@ type (
 
 		executorTypes map[fv1.ExecutorType]executortype.ExecutorType
 		cms           *cms.ConfigSecretController
+		accountant    *accounting.Accountant
+		recorder      *events.Recorder
 
 		fissionClient versioned.Interface
 
@@ -74,11 +85,13 @@ type (
 
 // MakeExecutor returns an Executor for given ExecutorType(s).
 func MakeExecutor(ctx context.Context, logger *zap.Logger, mgr manager.Interface, cms *cms.ConfigSecretController,
-	fissionClient versioned.Interface, types map[fv1.ExecutorType]executortype.ExecutorType,
+	accountant *accounting.Accountant, recorder *events.Recorder, fissionClient versioned.Interface, types map[fv1.ExecutorType]executortype.ExecutorType,
 	informers ...k8sCache.SharedIndexInformer) (*Executor, error) {
 	executor := &Executor{
 		logger:        logger.Named("executor"),
 		cms:           cms,
+		accountant:    accountant,
+		recorder:      recorder,
 		fissionClient: fissionClient,
 		executorTypes: types,
 
@@ -235,6 +248,8 @@ func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.
 		return nil, fmt.Errorf("unknown executor type '%s'", t)
 	}
 
+	executor.recorder.FunctionEvent(&fn.ObjectMeta, apiv1.EventTypeNormal, events.ReasonSpecializationStarted,
+		"Creating function service with executor %s", t)
 	fsvc, fsvcErr := e.GetFuncSvc(ctx, fn)
 	if fsvcErr != nil {
 		e := "error creating service for function"
@@ -242,7 +257,11 @@ func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.
 			zap.Error(fsvcErr),
 			zap.String("function_name", fn.ObjectMeta.Name),
 			zap.String("function_namespace", fn.ObjectMeta.Namespace))
+		executor.recorder.FunctionEvent(&fn.ObjectMeta, apiv1.EventTypeWarning, events.ReasonSpecializationFailed,
+			"Error creating function service: %v", fsvcErr)
 		fsvcErr = fmt.Errorf("[%s] %s: %w", fn.ObjectMeta.Name, e, fsvcErr)
+	} else {
+		executor.accountant.RecordColdStart(&fn.ObjectMeta)
 	}
 
 	return fsvc, fsvcErr
@@ -287,11 +306,56 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 
 	executorInstanceID := strings.ToLower(uniuri.NewLen(8))
 
+	// With leader election enabled, several executor replicas serve requests
+	// and share the Kubernetes objects they create. The executor instance ID
+	// must then be the same for all replicas, so that a restarting replica
+	// doesn't clean up objects of the others as orphans.
+	var leader *leaderelection.Elector
+	if leaderelection.Enabled() {
+		if id := os.Getenv("EXECUTOR_INSTANCE_ID"); len(id) > 0 {
+			executorInstanceID = id
+		} else {
+			executorInstanceID = executorLeaseName
+		}
+		identity, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("error getting leader election identity: %w", err)
+		}
+		leader, err = leaderelection.New(logger, kubernetesClient, executorLeaseName, identity)
+		if err != nil {
+			return fmt.Errorf("error creating leader elector: %w", err)
+		}
+		mgr.Add(ctx, leader.Run)
+	}
+
 	podSpecPatch, err := util.GetSpecFromConfigMap(fv1.RuntimePodSpecPath)
 	if err != nil && !os.IsNotExist(err) {
 		logger.Warn("error reading data for pod spec patch", zap.String("path", fv1.RuntimePodSpecPath), zap.Error(err))
 	}
 
+	// Namespace quotas are enforced on the usage of the pods and deployments
+	// of functions, and usage is accounted from the pods of functions, both
+	// watched by informers of their own.
+	var fnObjInformerFactory map[string]k8sInformers.SharedInformerFactory
+	if quota.Enabled() || accounting.Enabled() {
+		fnObjLabel, err := quota.InformerLabel()
+		if err != nil {
+			return err
+		}
+		fnObjInformerFactory = utils.GetInformerFactoryByExecutor(kubernetesClient, fnObjLabel, time.Minute*30)
+	}
+	var enforcer *quota.Enforcer
+	if quota.Enabled() {
+		enforcer = quota.MakeEnforcer(logger, kubernetesClient, fnObjInformerFactory)
+		mgr.Add(ctx, enforcer.Run)
+	}
+	var accountant *accounting.Accountant
+	if accounting.Enabled() {
+		accountant = accounting.MakeAccountant(logger, fnObjInformerFactory, leader)
+		mgr.Add(ctx, accountant.Run)
+	}
+	recorder := events.MakeRecorder(ctx, logger, kubernetesClient)
+
 	logger.Info("Starting executor", zap.String("instanceID", executorInstanceID))
 
 	finformerFactory := make(map[string]genInformer.SharedInformerFactory, 0)
@@ -309,7 +373,7 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		fissionClient, kubernetesClient, metricsClient,
 		fetcherConfig, executorInstanceID,
 		finformerFactory,
-		gpmInformerFactory, podSpecPatch)
+		gpmInformerFactory, podSpecPatch, leader, enforcer, recorder)
 	if err != nil {
 		return fmt.Errorf("pool manager creation failed: %w", err)
 	}
@@ -324,7 +388,7 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		fissionClient, kubernetesClient,
 		fetcherConfig, executorInstanceID,
 		finformerFactory,
-		ndmInformerFactory, podSpecPatch)
+		ndmInformerFactory, podSpecPatch, leader, enforcer, recorder)
 	if err != nil {
 		return fmt.Errorf("new deploy manager creation failed: %w", err)
 	}
@@ -338,7 +402,7 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		ctx, logger,
 		fissionClient, kubernetesClient,
 		executorInstanceID, finformerFactory,
-		cnmInformerFactory)
+		cnmInformerFactory, leader, enforcer, recorder)
 	if err != nil {
 		return fmt.Errorf("container manager creation failed: %w", err)
 	}
@@ -367,7 +431,7 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 
 	configMapInformer := utils.GetK8sInformersForNamespaces(kubernetesClient, time.Minute*30, fv1.ConfigMaps)
 	secretInformer := utils.GetK8sInformersForNamespaces(kubernetesClient, time.Minute*30, fv1.Secrets)
-	cms, err := cms.MakeConfigSecretController(ctx, logger, fissionClient, kubernetesClient, executorTypes, configMapInformer, secretInformer)
+	cms, err := cms.MakeConfigSecretController(ctx, logger, fissionClient, kubernetesClient, recorder, executorTypes, configMapInformer, secretInformer)
 	if err != nil {
 		return fmt.Errorf("error creating configmap and secret controller: %w", err)
 	}
@@ -391,8 +455,11 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 	for _, informerFactory := range cnmInformerFactory {
 		informerFactory.Start(ctx.Done())
 	}
+	for _, informerFactory := range fnObjInformerFactory {
+		informerFactory.Start(ctx.Done())
+	}
 
-	api, err := MakeExecutor(ctx, logger, mgr, cms, fissionClient, executorTypes,
+	api, err := MakeExecutor(ctx, logger, mgr, cms, accountant, recorder, fissionClient, executorTypes,
 		fissionInformers...,
 	)
 	if err != nil {
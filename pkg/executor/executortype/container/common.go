@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 	apiv1 "k8s.io/api/core/v1"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/quota"
 )
 
 // getResources gets the resources(CPU, memory) set for the function
@@ -64,6 +66,19 @@ func (cn *Container) getResources(fn *fv1.Function) apiv1.ResourceRequirements {
 	return resources
 }
 
+// quotaDemand returns the replicas the function deployment is scaled up by
+// to serve the function, which count against the namespace quota.
+func (cn *Container) quotaDemand(fn *fv1.Function, ns string, deployName string) quota.Usage {
+	replicas := int64(max(fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()).MinScale, 1))
+	if lister, ok := cn.deplLister[ns]; ok {
+		depl, err := lister.Deployments(ns).Get(deployName)
+		if err == nil && depl.Spec.Replicas != nil {
+			replicas = max(replicas-int64(*depl.Spec.Replicas), 0)
+		}
+	}
+	return quota.Replicas(replicas, cn.getResources(fn).Requests)
+}
+
 // cleanupContainer cleans all kubernetes objects related to function
 func (cn *Container) cleanupContainer(ctx context.Context, ns string, name string) error {
 	var result error
@@ -86,6 +101,15 @@ func (cn *Container) cleanupContainer(ctx context.Context, ns string, name strin
 		result = errors.Join(result, err)
 	}
 
+	err = cn.rolloutops.Cleanup(ctx, ns, name)
+	if err != nil {
+		cn.logger.Error("error deleting blue/green deployment for Container function",
+			zap.Error(err),
+			zap.String("function_name", name),
+			zap.String("function_namespace", ns))
+		result = errors.Join(result, err)
+	}
+
 	err = cn.deleteDeployment(ctx, ns, name)
 	if err != nil && !k8s_err.IsNotFound(err) {
 		cn.logger.Error("error deleting deployment for Container function",
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,16 +42,22 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/reaper"
 	executorUtils "github.com/fission/fission/pkg/executor/util"
 	hpautils "github.com/fission/fission/pkg/executor/util/hpa"
+	rolloututils "github.com/fission/fission/pkg/executor/util/rollout"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
+	fissionlisters "github.com/fission/fission/pkg/generated/listers/core/v1"
 	"github.com/fission/fission/pkg/throttler"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/leaderelection"
 	"github.com/fission/fission/pkg/utils/manager"
 	"github.com/fission/fission/pkg/utils/maps"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
@@ -79,6 +86,7 @@ type (
 		throttler *throttler.Throttler
 
 		defaultIdlePodReapTime time.Duration
+		idlePolicies           *reaper.IdlePolicies
 
 		deplLister map[string]appslisters.DeploymentLister
 		svcLister  map[string]corelisters.ServiceLister
@@ -86,10 +94,22 @@ type (
 		deplListerSynced map[string]k8sCache.InformerSynced
 		svcListerSynced  map[string]k8sCache.InformerSynced
 
+		fnLister       map[string]fissionlisters.FunctionLister
+		fnListerSynced map[string]k8sCache.InformerSynced
+
 		hpaops                     *hpautils.HpaOperations
+		rolloutops                 *rolloututils.RolloutOperations
 		objectReaperIntervalSecond time.Duration
 
 		enableOwnerReferences bool
+
+		// leader is nil when running with a single executor replica
+		leader *leaderelection.Elector
+
+		// quota is nil when namespace quotas are disabled
+		quota *quota.Enforcer
+
+		recorder *events.Recorder
 	}
 )
 
@@ -102,6 +122,9 @@ func MakeContainer(
 	instanceID string,
 	finformerFactory map[string]genInformer.SharedInformerFactory,
 	cnmInformerFactory map[string]k8sInformers.SharedInformerFactory,
+	leader *leaderelection.Elector,
+	enforcer *quota.Enforcer,
+	recorder *events.Recorder,
 ) (executortype.ExecutorType, error) {
 	enableIstio := false
 	if len(os.Getenv("ENABLE_ISTIO")) > 0 {
@@ -127,14 +150,21 @@ func MakeContainer(
 		useIstio:               enableIstio,
 		// Time is set slightly higher than NewDeploy as cold starts are longer for CaaF
 		defaultIdlePodReapTime:     1 * time.Minute,
+		idlePolicies:               reaper.MakeIdlePolicies(logger, kubernetesClient, recorder),
 		objectReaperIntervalSecond: time.Duration(executorUtils.GetObjectReaperInterval(logger, fv1.ExecutorTypeContainer, 5)) * time.Second,
 		hpaops:                     hpautils.NewHpaOperations(logger, kubernetesClient, instanceID),
+		rolloutops:                 rolloututils.NewRolloutOperations(logger, kubernetesClient, fissionClient),
 		deplLister:                 make(map[string]appslisters.DeploymentLister),
 		deplListerSynced:           make(map[string]k8sCache.InformerSynced),
 		svcLister:                  make(map[string]corelisters.ServiceLister),
 		svcListerSynced:            make(map[string]k8sCache.InformerSynced),
+		fnLister:                   make(map[string]fissionlisters.FunctionLister),
+		fnListerSynced:             make(map[string]k8sCache.InformerSynced),
 
 		enableOwnerReferences: utils.IsOwnerReferencesEnabled(),
+		leader:                leader,
+		quota:                 enforcer,
+		recorder:              recorder,
 	}
 
 	for ns, informerFactory := range cnmInformerFactory {
@@ -143,12 +173,15 @@ func MakeContainer(
 		caaf.svcLister[ns] = informerFactory.Core().V1().Services().Lister()
 		caaf.svcListerSynced[ns] = informerFactory.Core().V1().Services().Informer().HasSynced
 	}
-	for _, factory := range finformerFactory {
+	for ns, factory := range finformerFactory {
+		caaf.fnLister[ns] = factory.Core().V1().Functions().Lister()
+		caaf.fnListerSynced[ns] = factory.Core().V1().Functions().Informer().HasSynced
 		_, err := factory.Core().V1().Functions().Informer().AddEventHandler(caaf.FuncInformerHandler(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to add event handler for function informer: %w", err)
 		}
 	}
+	leader.OnStartedLeading(caaf.resync)
 	return caaf, nil
 }
 
@@ -161,6 +194,9 @@ func (caaf *Container) Run(ctx context.Context, mgr manager.Interface) {
 	for _, svcListerSynced := range caaf.svcListerSynced {
 		waitSynced = append(waitSynced, svcListerSynced)
 	}
+	for _, fnListerSynced := range caaf.fnListerSynced {
+		waitSynced = append(waitSynced, fnListerSynced)
+	}
 
 	if ok := k8sCache.WaitForCacheSync(ctx.Done(), waitSynced...); !ok {
 		caaf.logger.Fatal("failed to wait for caches to sync")
@@ -168,6 +204,14 @@ func (caaf *Container) Run(ctx context.Context, mgr manager.Interface) {
 	mgr.Add(ctx, func(ctx context.Context) {
 		caaf.idleObjectReaper(ctx)
 	})
+	mgr.Add(ctx, func(ctx context.Context) {
+		wait.UntilWithContext(ctx, caaf.applyScalingSchedules, executorUtils.ScalingScheduleInterval)
+	})
+	if caaf.leader != nil {
+		mgr.Add(ctx, func(ctx context.Context) {
+			wait.UntilWithContext(ctx, caaf.reportAccessTimes, executorUtils.AccessTimeReportInterval)
+		})
+	}
 }
 
 // GetTypeName returns the executor type name.
@@ -186,6 +230,11 @@ func (caaf *Container) UnTapService(ctx context.Context, fnMeta *metav1.ObjectMe
 	// Not Implemented for CaaF.
 }
 
+// ReportConcurrency has not been implemented for CaaF.
+func (caaf *Container) ReportConcurrency(ctx context.Context, reporter string, fnMeta *metav1.ObjectMeta, activeRequests int) {
+	// Not Implemented for CaaF.
+}
+
 // MarkSpecializationFailure has not been implemented for CaaF.
 func (caaf *Container) MarkSpecializationFailure(ctx context.Context, fnMeta *metav1.ObjectMeta) {
 	// Not Implemented for CaaF.
@@ -405,6 +454,12 @@ func (caaf *Container) fnCreate(ctx context.Context, fn *fv1.Function) (*fscache
 	// deployment of the function in fission-function ns
 	ns := caaf.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
 
+	release, err := caaf.quota.Reserve(ctx, fn.ObjectMeta.Namespace, caaf.quotaDemand(fn, ns, objName))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Envoy(istio-proxy) returns 404 directly before istio pilot
 	// propagates latest Envoy-specific configuration.
 	// Since Container waits for pods of deployment to be ready,
@@ -425,7 +480,11 @@ func (caaf *Container) fnCreate(ctx context.Context, fn *fv1.Function) (*fscache
 		return nil, fmt.Errorf("error creating deployment %s: %w", objName, err)
 	}
 
-	hpa, err := caaf.hpaops.CreateOrGetHpa(ctx, fn, objName, &fn.Spec.InvokeStrategy.ExecutionStrategy, depl, deployLabels, deployAnnotations)
+	es, err := caaf.quota.LimitStrategy(ctx, fn.ObjectMeta.Namespace, depl, fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()))
+	if err != nil {
+		caaf.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+	}
+	hpa, err := caaf.hpaops.CreateOrGetHpa(ctx, fn, objName, &es, depl, deployLabels, deployAnnotations)
 	if err != nil {
 		caaf.logger.Error("error creating HPA", zap.Error(err), zap.String("hpa", objName))
 		go cleanupFunc(ns, objName)
@@ -497,13 +556,19 @@ func (caaf *Container) updateFunction(ctx context.Context, oldFn *fv1.Function,
 		oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
 		caaf.logger.Info("function does not use new deployment executor anymore, deleting resources",
 			zap.Any("function", newFn))
-		// IMP - pass the oldFn, as the new/modified function is not in cache
+		// IMP - pass the oldFn, as the new/modified function is not in cache.
+		// Other replicas than the leader only drop the function from their cache.
 		return caaf.deleteFunction(ctx, oldFn)
 	}
 
 	// Executor type changed to Container from something else
 	if oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer &&
 		newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+		// resources are created by the leader, other replicas adopt them
+		// on the first request of the function
+		if !caaf.leader.IsLeader() {
+			return nil
+		}
 		caaf.logger.Info("function type changed to Container, creating resources",
 			zap.Any("old_function", oldFn.ObjectMeta),
 			zap.Any("new_function", newFn.ObjectMeta))
@@ -514,17 +579,19 @@ func (caaf *Container) updateFunction(ctx context.Context, oldFn *fv1.Function,
 		return err
 	}
 
+	// hpa and deployment are updated by the leader
+	if !caaf.leader.IsLeader() {
+		return nil
+	}
+
 	if !reflect.DeepEqual(oldFn.Spec.InvokeStrategy, newFn.Spec.InvokeStrategy) {
 		// to support backward compatibility, if the function was created in default ns, we fall back to creating the
 		// deployment of the function in fission-function ns, so cleaning up resources there
 		ns := caaf.nsResolver.GetFunctionNS(newFn.ObjectMeta.Namespace)
 
-		fsvc, err := caaf.fsCache.GetByFunctionUID(newFn.ObjectMeta.UID)
-		if err != nil {
-			return fmt.Errorf("error updating function due to unable to find function service cache %s: %w", k8sCache.MetaObjectToName(oldFn), err)
-		}
-
-		hpa, err := caaf.hpaops.GetHpa(ctx, ns, fsvc.Name)
+		// the leader may not have the function service cached, use the
+		// object name directly
+		hpa, err := caaf.hpaops.GetHpa(ctx, ns, caaf.getObjName(newFn))
 		if err != nil {
 			caaf.updateStatus(oldFn, err, "error getting HPA while updating function")
 			return err
@@ -532,14 +599,20 @@ func (caaf *Container) updateFunction(ctx context.Context, oldFn *fv1.Function,
 
 		hpaChanged := false
 
-		if newFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale != oldFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale {
-			replicas := int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
+		// compare the scale in effect now, scaling schedule windows are
+		// applied by the leader as they start and end
+		now := time.Now()
+		oldES := oldFn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now)
+		newES := newFn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now)
+
+		if newES.MinScale != oldES.MinScale {
+			replicas := int32(newES.MinScale)
 			hpa.Spec.MinReplicas = &replicas
 			hpaChanged = true
 		}
 
-		if newFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale != oldFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale {
-			hpa.Spec.MaxReplicas = int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale)
+		if newES.MaxScale != oldES.MaxScale {
+			hpa.Spec.MaxReplicas = int32(newES.MaxScale)
 			hpaChanged = true
 		}
 
@@ -599,11 +672,7 @@ func (caaf *Container) updateFunction(ctx context.Context, oldFn *fv1.Function,
 
 func (caaf *Container) updateFuncDeployment(ctx context.Context, fn *fv1.Function) error {
 
-	fsvc, err := caaf.fsCache.GetByFunctionUID(fn.ObjectMeta.UID)
-	if err != nil {
-		return fmt.Errorf("error updating function due to unable to find function service cache %s: %w", k8sCache.MetaObjectToName(fn), err)
-	}
-	fnObjName := fsvc.Name
+	fnObjName := caaf.getObjName(fn)
 
 	deployLabels := caaf.getDeployLabels(fn.ObjectMeta)
 	caaf.logger.Info("updating deployment due to function update",
@@ -620,15 +689,16 @@ func (caaf *Container) updateFuncDeployment(ctx context.Context, fn *fv1.Functio
 
 	// the resource version inside function packageRef is changed,
 	// so the content of fetchRequest in deployment cmd is different.
-	// Therefore, the deployment update will trigger a rolling update.
+	// Therefore, the deployment update will trigger a rollout.
 	newDeployment, err := caaf.getDeploymentSpec(ctx, fn, existingDepl.Spec.Replicas, // use current replicas instead of minscale in the ExecutionStrategy.
 		fnObjName, ns, deployLabels, caaf.getDeployAnnotations(fn.ObjectMeta))
 	if err != nil {
 		caaf.updateStatus(fn, err, "failed to get new deployment spec while updating function")
 		return err
 	}
+	newDeployment.Namespace = ns
 
-	err = caaf.updateDeployment(ctx, newDeployment, ns)
+	err = caaf.rolloutops.Update(ctx, fn, existingDepl, newDeployment)
 	if err != nil {
 		caaf.updateStatus(fn, err, "failed to update deployment while updating function")
 		return err
@@ -646,16 +716,19 @@ func (caaf *Container) fnDelete(ctx context.Context, fn *fv1.Function) error {
 	// Use GetByFunctionUID instead of GetByFunction here to find correct
 	// fsvc entry.
 	fsvc, err := caaf.fsCache.GetByFunctionUID(fn.ObjectMeta.UID)
-	if err != nil {
-		return fmt.Errorf("fsvc not found in cache %s: %w", k8sCache.MetaObjectToName(fn), err)
+	if err == nil {
+		_, err = caaf.fsCache.DeleteOld(fsvc, time.Second*0)
+		if err != nil {
+			multierr = errors.Join(multierr, fmt.Errorf("error deleting function from cache: %w", err))
+		}
 	}
 
-	objName := fsvc.Name
-
-	_, err = caaf.fsCache.DeleteOld(fsvc, time.Second*0)
-	if err != nil {
-		multierr = errors.Join(multierr, fmt.Errorf("error deleting function from cache: %w", err))
+	// kubernetes objects are cleaned up by the leader, which may not have
+	// the function service cached
+	if !caaf.leader.IsLeader() {
+		return multierr
 	}
+	objName := caaf.getObjName(fn)
 
 	// to support backward compatibility, if the function was created in default ns, we fall back to creating the
 	// deployment of the function in fission-function ns, so cleaning up resources there
@@ -720,12 +793,19 @@ func (caaf *Container) idleObjectReaper(ctx context.Context) {
 }
 
 func (caaf *Container) doIdleObjectReaper(ctx context.Context) {
+	if !caaf.leader.IsLeader() {
+		return
+	}
+
 	funcSvcs, err := caaf.fsCache.ListOld(time.Second * 5)
 	if err != nil {
 		caaf.logger.Error("error reaping idle pods", zap.Error(err))
 		return
 	}
 
+	run := caaf.idlePolicies.StartRun(fv1.ExecutorTypeContainer)
+	defer run.Finish()
+
 	for i := range funcSvcs {
 		fsvc := funcSvcs[i]
 
@@ -744,12 +824,18 @@ func (caaf *Container) doIdleObjectReaper(ctx context.Context) {
 			continue
 		}
 
-		idlePodReapTime := caaf.defaultIdlePodReapTime
-		if fn.Spec.IdleTimeout != nil {
-			idlePodReapTime = time.Duration(*fn.Spec.IdleTimeout) * time.Second
+		now := time.Now()
+		idlePodReapTime := caaf.idlePolicies.IdleTimeout(ctx, fn, caaf.defaultIdlePodReapTime, now)
+		if now.Sub(caaf.getLastAccessTime(fsvc)) < idlePodReapTime {
+			continue
 		}
 
-		if time.Since(fsvc.Atime) < idlePodReapTime {
+		// the deployment is not reapable if it already runs its min scale
+		minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now).MinScale)
+		if depl := caaf.getCachedDeployment(fsvc); depl != nil && depl.Spec.Replicas != nil && *depl.Spec.Replicas <= minScale {
+			continue
+		}
+		if !run.Reap(fsvc.Function, fsvc.Name, reaper.ReasonIdleTimeout) {
 			continue
 		}
 
@@ -767,8 +853,6 @@ func (caaf *Container) doIdleObjectReaper(ctx context.Context) {
 				return
 			}
 
-			minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
-
 			// do nothing if the current replicas is already lower than minScale
 			if *currentDeploy.Spec.Replicas <= minScale {
 				return
@@ -782,6 +866,57 @@ func (caaf *Container) doIdleObjectReaper(ctx context.Context) {
 	}
 }
 
+// getCachedDeployment returns the deployment of the function service from
+// the informer cache, or nil if it isn't found.
+func (caaf *Container) getCachedDeployment(fsvc *fscache.FuncSvc) *appsv1.Deployment {
+	deployObj := getDeploymentObj(fsvc.KubernetesObjects)
+	if deployObj == nil {
+		return nil
+	}
+	lister, ok := caaf.deplLister[deployObj.Namespace]
+	if !ok {
+		return nil
+	}
+	depl, err := lister.Deployments(deployObj.Namespace).Get(deployObj.Name)
+	if err != nil {
+		return nil
+	}
+	return depl
+}
+
+// getLastAccessTime returns the latest access time of the function service
+// known by any executor replica.
+func (caaf *Container) getLastAccessTime(fsvc *fscache.FuncSvc) time.Time {
+	atime := fsvc.Atime
+	depl := caaf.getCachedDeployment(fsvc)
+	if depl == nil {
+		return atime
+	}
+	if t, ok := executorUtils.GetLastAccessTime(depl); ok && t.After(atime) {
+		atime = t
+	}
+	return atime
+}
+
+// reportAccessTimes records the access time of recently used function
+// services on their deployments, so that the leader doesn't scale down
+// deployments which are being served by other replicas.
+func (caaf *Container) reportAccessTimes(ctx context.Context) {
+	for _, fsvc := range caaf.fsCache.ListRecent(executorUtils.AccessTimeReportInterval) {
+		if fsvc.Executor != fv1.ExecutorTypeContainer {
+			continue
+		}
+		deployObj := getDeploymentObj(fsvc.KubernetesObjects)
+		if deployObj == nil {
+			continue
+		}
+		err := executorUtils.PatchLastAccessTime(ctx, caaf.kubernetesClient, deployObj, fsvc.Atime)
+		if err != nil && !k8sErrs.IsNotFound(err) {
+			caaf.logger.Warn("failed to record last access time", zap.Error(err), zap.String("function", fsvc.Function.Name))
+		}
+	}
+}
+
 func getDeploymentObj(kubeobjs []apiv1.ObjectReference) *apiv1.ObjectReference {
 	for _, kubeobj := range kubeobjs {
 		switch strings.ToLower(kubeobj.Kind) {
@@ -792,6 +927,18 @@ func getDeploymentObj(kubeobjs []apiv1.ObjectReference) *apiv1.ObjectReference {
 	return nil
 }
 
-func (caaf *Container) DumpDebugInfo(ctx context.Context) error {
-	return nil
+func (caaf *Container) DumpDebugInfo(ctx context.Context) ([]fscache.FuncSvcGroupStatus, error) {
+	return caaf.fsCache.DumpDebugInfo(ctx), nil
+}
+
+// EvictFuncSvc is not supported, the pods are managed by the function deployment.
+func (caaf *Container) EvictFuncSvc(ctx context.Context, namespace string, podName string) error {
+	return ferror.MakeError(ferror.ErrorInvalidArgument,
+		fmt.Sprintf("evicting function pods is not supported by executor type %s", caaf.GetTypeName(ctx)))
+}
+
+// DrainFunction is not supported, the function deployment scales down idle pods gracefully.
+func (caaf *Container) DrainFunction(ctx context.Context, fnMeta *metav1.ObjectMeta, timeout time.Duration) ([]string, []string, error) {
+	return nil, nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+		fmt.Sprintf("draining functions is not supported by executor type %s", caaf.GetTypeName(ctx)))
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -28,10 +29,11 @@ import (
 	k8s_err "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/intstr"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/metrics"
 	"github.com/fission/fission/pkg/executor/util"
+	rolloututils "github.com/fission/fission/pkg/executor/util/rollout"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
 
@@ -41,7 +43,7 @@ func (cn *Container) createOrGetDeployment(ctx context.Context, fn *fv1.Function
 	// The specializationTimeout here refers to the creation of the pod and not the loading of function
 	// as in other executors.
 	specializationTimeout := fn.Spec.InvokeStrategy.ExecutionStrategy.SpecializationTimeout
-	minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
+	minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()).MinScale)
 
 	// Always scale to at least one pod when createOrGetDeployment
 	// is called. The idleObjectReaper will scale-in the deployment
@@ -78,7 +80,7 @@ func (cn *Container) createOrGetDeployment(ctx context.Context, fn *fv1.Function
 		}
 		otelUtils.SpanTrackEvent(ctx, "deploymentCreated", otelUtils.GetAttributesForDeployment(depl)...)
 		if minScale > 0 {
-			depl, err = cn.waitForDeploy(ctx, depl, minScale, specializationTimeout)
+			depl, err = cn.waitForDeploy(ctx, fn, depl, minScale, specializationTimeout)
 		}
 		return depl, err
 	}
@@ -112,17 +114,12 @@ func (cn *Container) createOrGetDeployment(ctx context.Context, fn *fv1.Function
 		}
 	}
 	if existingDepl.Status.AvailableReplicas < minScale {
-		existingDepl, err = cn.waitForDeploy(ctx, existingDepl, minScale, specializationTimeout)
+		existingDepl, err = cn.waitForDeploy(ctx, fn, existingDepl, minScale, specializationTimeout)
 	}
 
 	return existingDepl, err
 }
 
-func (cn *Container) updateDeployment(ctx context.Context, deployment *appsv1.Deployment, ns string) error {
-	_, err := cn.kubernetesClient.AppsV1().Deployments(ns).Update(ctx, deployment, metav1.UpdateOptions{})
-	return err
-}
-
 func (cn *Container) deleteDeployment(ctx context.Context, ns string, name string) error {
 	// DeletePropagationBackground deletes the object immediately and dependent are deleted later
 	// DeletePropagationForeground not advisable; it marks for deletion and API can still serve those objects
@@ -132,7 +129,10 @@ func (cn *Container) deleteDeployment(ctx context.Context, ns string, name strin
 	})
 }
 
-func (cn *Container) waitForDeploy(ctx context.Context, depl *appsv1.Deployment, replicas int32, specializationTimeout int) (latestDepl *appsv1.Deployment, err error) {
+func (cn *Container) waitForDeploy(ctx context.Context, fn *fv1.Function, depl *appsv1.Deployment, replicas int32, specializationTimeout int) (latestDepl *appsv1.Deployment, err error) {
+	ctx, span := otel.Tracer("executor").Start(ctx, "Container/waitForDeploy")
+	defer span.End()
+	start := time.Now()
 	oldStatus := depl.Status
 	otelUtils.SpanTrackEvent(ctx, "waitForDeployment", otelUtils.GetAttributesForDeployment(depl)...)
 	// if no specializationTimeout is set, use default value
@@ -150,6 +150,7 @@ func (cn *Container) waitForDeploy(ctx context.Context, depl *appsv1.Deployment,
 		// since the pods may not be able to serve network traffic yet.
 		if latestDepl.Status.AvailableReplicas >= replicas {
 			otelUtils.SpanTrackEvent(ctx, "deploymentAvailable", otelUtils.GetAttributesForDeployment(latestDepl)...)
+			metrics.ObserveColdStartPhase(&fn.ObjectMeta, metrics.PhaseServiceReady, time.Since(start))
 			return latestDepl, err
 		}
 		time.Sleep(time.Second)
@@ -190,17 +191,6 @@ func (cn *Container) getDeploymentSpec(ctx context.Context, fn *fv1.Function, ta
 		podLabels[k] = v
 	}
 
-	// Set maxUnavailable and maxSurge to 20% is because we want
-	// fission to rollout newer function version gradually without
-	// affecting any online service. For example, if you set maxSurge
-	// to 100%, the new ReplicaSet scales up immediately and may
-	// consume all remaining compute resources which might be an
-	// issue if a cluster's resource is on a budget.
-	// TODO: add to ExecutionStrategy so that the user
-	// can do more fine control over different functions.
-	maxUnavailable := intstr.FromString("20%")
-	maxSurge := intstr.FromString("20%")
-
 	// Container updates the environment variable "LastUpdateTimestamp" of deployment
 	// whenever a configmap/secret gets an update, but it also leaves multiple ReplicaSets for
 	// rollback purpose. Since fission always update a deployment instead of performing a
@@ -210,7 +200,7 @@ func (cn *Container) getDeploymentSpec(ctx context.Context, fn *fv1.Function, ta
 	resources := cn.getResources(fn)
 
 	// Other executor types rely on Environments to add configmaps and secrets
-	envFromSources, err := util.ConvertConfigSecrets(ctx, fn, cn.kubernetesClient)
+	envFromSources, envVars, err := util.ConvertConfigSecrets(ctx, fn, cn.kubernetesClient)
 	if err != nil {
 		return nil, err
 	}
@@ -238,12 +228,12 @@ func (cn *Container) getDeploymentSpec(ctx context.Context, fn *fv1.Function, ta
 				},
 			},
 		},
-		Env: []apiv1.EnvVar{
+		Env: append([]apiv1.EnvVar{
 			{
 				Name:  fv1.ResourceVersionCount,
 				Value: fmt.Sprintf("%d", rvCount),
 			},
-		},
+		}, envVars...),
 		EnvFrom: envFromSources,
 		// https://istio.io/docs/setup/kubernetes/additional-setup/requirements/
 		Resources: resources,
@@ -287,14 +277,8 @@ func (cn *Container) getDeploymentSpec(ctx context.Context, fn *fv1.Function, ta
 			Selector: &metav1.LabelSelector{
 				MatchLabels: deployLabels,
 			},
-			Template: pod,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &maxUnavailable,
-					MaxSurge:       &maxSurge,
-				},
-			},
+			Template:             pod,
+			Strategy:             rolloututils.DeploymentStrategy(fn),
 			RevisionHistoryLimit: &revisionHistoryLimit,
 		},
 	}
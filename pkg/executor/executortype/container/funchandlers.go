@@ -33,6 +33,11 @@ func (caaf *Container) FuncInformerHandler(ctx context.Context) k8sCache.Resourc
 			if fnExecutorType != "" && fnExecutorType != fv1.ExecutorTypeContainer {
 				return
 			}
+			// resources are created by the leader, other replicas adopt them
+			// on the first request of the function
+			if !caaf.leader.IsLeader() {
+				return
+			}
 			// TODO: A workaround to process items in parallel. We should use workqueue ("k8s.io/client-go/util/workqueue")
 			// and worker pattern to process items instead of moving process to another goroutine.
 			// example: https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/job/job_controller.go
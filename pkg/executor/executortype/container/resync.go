@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// resync reconciles the kubernetes objects of all container functions with
+// the functions once this replica starts leading. Function events are only
+// handled by the leader, the ones received while another replica was leading
+// are caught up here.
+func (caaf *Container) resync(ctx context.Context) {
+	waitSynced := make([]k8sCache.InformerSynced, 0)
+	for _, deplListerSynced := range caaf.deplListerSynced {
+		waitSynced = append(waitSynced, deplListerSynced)
+	}
+	for _, fnListerSynced := range caaf.fnListerSynced {
+		waitSynced = append(waitSynced, fnListerSynced)
+	}
+	if ok := k8sCache.WaitForCacheSync(ctx.Done(), waitSynced...); !ok {
+		return
+	}
+	caaf.logger.Info("resyncing container functions after acquiring leadership")
+
+	functions := make(map[k8sTypes.UID]struct{})
+	for namespace, lister := range caaf.fnLister {
+		fns, err := lister.List(labels.Everything())
+		if err != nil {
+			caaf.logger.Error("error listing functions", zap.Error(err), zap.String("namespace", namespace))
+			continue
+		}
+		for _, fn := range fns {
+			if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+				continue
+			}
+			functions[fn.ObjectMeta.UID] = struct{}{}
+			err = caaf.resyncFunction(ctx, fn)
+			if err != nil {
+				caaf.logger.Error("error resyncing function", zap.Error(err),
+					zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace))
+			}
+		}
+	}
+
+	// clean up the objects of functions deleted or not using container anymore
+	selector := labels.SelectorFromSet(map[string]string{fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeContainer)})
+	for ns, lister := range caaf.deplLister {
+		depls, err := lister.Deployments(ns).List(selector)
+		if err != nil {
+			caaf.logger.Error("error listing deployments", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+		for _, depl := range depls {
+			if _, ok := functions[k8sTypes.UID(depl.Labels[fv1.FUNCTION_UID])]; ok {
+				continue
+			}
+			// functions of namespaces not watched by this executor are unknown
+			if _, ok := caaf.fnLister[depl.Labels[fv1.FUNCTION_NAMESPACE]]; !ok {
+				continue
+			}
+			caaf.logger.Info("cleaning up objects of removed function",
+				zap.String("deployment", depl.ObjectMeta.Name), zap.String("namespace", ns))
+			err = caaf.cleanupContainer(ctx, ns, depl.ObjectMeta.Name)
+			if err != nil {
+				caaf.logger.Error("error cleaning up objects of removed function", zap.Error(err),
+					zap.String("deployment", depl.ObjectMeta.Name), zap.String("namespace", ns))
+			}
+		}
+	}
+}
+
+// resyncFunction creates the objects of the function if they are missing, or
+// updates them if the function changed since.
+func (caaf *Container) resyncFunction(ctx context.Context, fn *fv1.Function) error {
+	ns := caaf.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+	objName := caaf.getObjName(fn)
+	deplLister, ok := caaf.deplLister[ns]
+	if !ok {
+		return nil
+	}
+	depl, err := deplLister.Deployments(ns).Get(objName)
+	if k8sErrs.IsNotFound(err) {
+		_, err = caaf.createFunction(ctx, fn)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if depl.Annotations[fv1.FUNCTION_RESOURCE_VERSION] == fn.ObjectMeta.ResourceVersion {
+		return nil
+	}
+
+	caaf.logger.Info("function changed while not leading, updating its objects",
+		zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace))
+	hpa, err := caaf.hpaops.GetHpa(ctx, ns, objName)
+	if err != nil {
+		return err
+	}
+	hpa.Spec.Metrics = fn.Spec.InvokeStrategy.ExecutionStrategy.Metrics
+	hpa.Spec.Behavior = fn.Spec.InvokeStrategy.ExecutionStrategy.Behavior
+	err = caaf.hpaops.UpdateHpa(ctx, hpa)
+	if err != nil {
+		return err
+	}
+	es, err := caaf.quota.LimitStrategy(ctx, fn.ObjectMeta.Namespace, depl, fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()))
+	if err != nil {
+		caaf.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+	}
+	err = caaf.hpaops.UpdateHpaScale(ctx, ns, objName, &es)
+	if err != nil {
+		return err
+	}
+	return caaf.updateFuncDeployment(ctx, fn)
+}
@@ -18,6 +18,7 @@ package executortype
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -41,8 +42,19 @@ type ExecutorType interface {
 	// GetFuncSvcFromCache retrieves function service from cache.
 	GetFuncSvcFromCache(context.Context, *fv1.Function) (*fscache.FuncSvc, error)
 
-	// DumpDebugInfo dump function service cache to temporary directory of executor pod.
-	DumpDebugInfo(context.Context) error
+	// DumpDebugInfo returns the state of the function service cache.
+	DumpDebugInfo(context.Context) ([]fscache.FuncSvcGroupStatus, error)
+
+	// EvictFuncSvc removes the function service served by the given
+	// specialized pod of a function in the namespace from cache and
+	// deletes the pod.
+	EvictFuncSvc(ctx context.Context, namespace string, podName string) error
+
+	// DrainFunction stops assigning requests to the function services of a
+	// function and cleans them up once their in-flight requests finish.
+	// Function services still busy after the timeout are cleaned up forcibly.
+	// It returns the names of the drained and forcibly removed function services.
+	DrainFunction(ctx context.Context, fnMeta *metav1.ObjectMeta, timeout time.Duration) (drained []string, forced []string, err error)
 
 	// DeleteFuncSvcFromCache deletes function service entry in cache.
 	DeleteFuncSvcFromCache(context.Context, *fscache.FuncSvc)
@@ -51,6 +63,10 @@ type ExecutorType interface {
 	// avoid idle pod reaper recycles pods.
 	TapService(ctx context.Context, serviceUrl string) error
 
+	// ReportConcurrency records the in-flight requests of a function
+	// observed by a router, for functions scaling on concurrency.
+	ReportConcurrency(ctx context.Context, reporter string, fnMeta *metav1.ObjectMeta, activeRequests int)
+
 	// UnTapService updates the isActive to false
 	UnTapService(ctx context.Context, fnMeta *metav1.ObjectMeta, svcHost string)
 
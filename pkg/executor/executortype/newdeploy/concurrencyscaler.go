@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	executorUtils "github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/executor/util/autoscaler"
+)
+
+// concurrencyScalingInterval is how often the leader scales the deployments
+// of functions scaling on concurrency.
+const concurrencyScalingInterval = 2 * time.Second
+
+// isConcurrencyScaling returns true if the function deployment is scaled on
+// the in-flight requests instead of a HPA.
+func isConcurrencyScaling(fn *fv1.Function) bool {
+	return fn.Spec.InvokeStrategy.ExecutionStrategy.ScalingMode == fv1.ScalingModeConcurrency
+}
+
+func (deploy *NewDeploy) getAutoscaler(uid k8sTypes.UID) *autoscaler.Autoscaler {
+	deploy.autoscalersLock.Lock()
+	defer deploy.autoscalersLock.Unlock()
+	a, ok := deploy.autoscalers[uid]
+	if !ok {
+		a = autoscaler.NewAutoscaler()
+		deploy.autoscalers[uid] = a
+	}
+	return a
+}
+
+func (deploy *NewDeploy) deleteAutoscaler(uid k8sTypes.UID) {
+	deploy.autoscalersLock.Lock()
+	defer deploy.autoscalersLock.Unlock()
+	delete(deploy.autoscalers, uid)
+}
+
+// ReportConcurrency records the in-flight requests of a function observed by a router.
+func (deploy *NewDeploy) ReportConcurrency(ctx context.Context, reporter string, fnMeta *metav1.ObjectMeta, activeRequests int) {
+	deploy.getAutoscaler(fnMeta.UID).Report(time.Now(), reporter, activeRequests)
+}
+
+// updateScalingMode replaces the HPA of the function deployment with the
+// concurrency autoscaler or the other way around.
+func (deploy *NewDeploy) updateScalingMode(ctx context.Context, fn *fv1.Function, ns string, objName string) error {
+	if isConcurrencyScaling(fn) {
+		deploy.logger.Info("function scales on concurrency, deleting HPA",
+			zap.String("function", fn.ObjectMeta.Name), zap.String("hpa", objName))
+		err := deploy.hpaops.DeleteHpa(ctx, ns, objName)
+		if err != nil && !k8sErrs.IsNotFound(err) {
+			return fmt.Errorf("error deleting HPA %s: %w", objName, err)
+		}
+		return nil
+	}
+
+	deploy.deleteAutoscaler(fn.ObjectMeta.UID)
+
+	env, err := deploy.fissionClient.CoreV1().Environments(fn.Spec.Environment.Namespace).
+		Get(ctx, fn.Spec.Environment.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	depl, err := deploy.kubernetesClient.AppsV1().Deployments(ns).Get(ctx, objName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	deploy.logger.Info("function scales on HPA, creating HPA",
+		zap.String("function", fn.ObjectMeta.Name), zap.String("hpa", objName))
+	es, err := deploy.quota.LimitStrategy(ctx, fn.ObjectMeta.Namespace, depl, fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()))
+	if err != nil {
+		deploy.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+	}
+	_, err = deploy.hpaops.CreateOrGetHpa(ctx, fn, objName, &es, depl,
+		deploy.getDeployLabels(fn.ObjectMeta, env.ObjectMeta), deploy.getDeployAnnotations(fn.ObjectMeta, env.ObjectMeta))
+	if err != nil {
+		return fmt.Errorf("error creating HPA %s: %w", objName, err)
+	}
+	return nil
+}
+
+// doConcurrencyScaling scales the deployments of functions scaling on
+// concurrency to the replicas recommended by their autoscaler. Deployments
+// scaled to zero are scaled up once requests are reported, scaling down to
+// zero is left to the idle object reaper.
+func (deploy *NewDeploy) doConcurrencyScaling(ctx context.Context) {
+	if !deploy.leader.IsLeader() {
+		return
+	}
+
+	now := time.Now()
+	leaderKey := executorUtils.ActiveRequestsReplicaKey(deploy.leader.Identity())
+	for namespace, lister := range deploy.fnLister {
+		fns, err := lister.List(labels.Everything())
+		if err != nil {
+			deploy.logger.Error("error listing functions", zap.Error(err), zap.String("namespace", namespace))
+			continue
+		}
+		for _, fn := range fns {
+			if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy || !isConcurrencyScaling(fn) {
+				continue
+			}
+
+			ns := deploy.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+			deplLister, ok := deploy.deplLister[ns]
+			if !ok {
+				continue
+			}
+			objName := deploy.getObjName(fn)
+			depl, err := deplLister.Deployments(ns).Get(objName)
+			if err != nil {
+				// the deployment is created on the first request of the function
+				if !k8sErrs.IsNotFound(err) {
+					deploy.logger.Error("error getting function deployment", zap.Error(err), zap.String("deployment", objName))
+				}
+				continue
+			}
+
+			a := deploy.getAutoscaler(fn.ObjectMeta.UID)
+			// in-flight requests observed by the other executor replicas
+			for replica, r := range executorUtils.GetActiveRequests(depl) {
+				if replica != leaderKey {
+					a.Report(r.Time, "executor/"+replica, r.Count)
+				}
+			}
+
+			var current int32
+			if depl.Spec.Replicas != nil {
+				current = *depl.Spec.Replicas
+			}
+			es := fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now)
+			desired, panicking := a.Scale(now, autoscaler.ConfigFromStrategy(&es),
+				current, depl.Status.ReadyReplicas)
+			desired, err = deploy.quota.ScaleLimit(ctx, fn.ObjectMeta.Namespace, depl, desired)
+			if err != nil {
+				deploy.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+			}
+			if desired == current {
+				continue
+			}
+
+			deploy.logger.Debug("scaling function on concurrency",
+				zap.String("function", fn.ObjectMeta.Name),
+				zap.String("namespace", fn.ObjectMeta.Namespace),
+				zap.Int32("current", current),
+				zap.Int32("desired", desired),
+				zap.Bool("panic", panicking))
+			err = deploy.scaleDeployment(ctx, ns, objName, desired)
+			if err != nil {
+				deploy.logger.Error("error scaling function deployment", zap.Error(err), zap.String("deployment", objName))
+			}
+		}
+	}
+}
+
+// reportActiveRequests records the in-flight requests of functions scaling
+// on concurrency reported to this replica on their deployments, so that the
+// leader scales them on the requests served through all replicas.
+func (deploy *NewDeploy) reportActiveRequests(ctx context.Context) {
+	if deploy.leader.IsLeader() {
+		return
+	}
+
+	deploy.autoscalersLock.Lock()
+	autoscalers := make(map[k8sTypes.UID]*autoscaler.Autoscaler, len(deploy.autoscalers))
+	for uid, a := range deploy.autoscalers {
+		autoscalers[uid] = a
+	}
+	deploy.autoscalersLock.Unlock()
+
+	now := time.Now()
+	for uid, a := range autoscalers {
+		activeRequests := a.ActiveRequests(now)
+		if activeRequests == 0 {
+			continue
+		}
+		fsvc, err := deploy.fsCache.GetByFunctionUID(uid)
+		if err != nil {
+			continue
+		}
+		deployObj := getDeploymentObj(fsvc.KubernetesObjects)
+		if deployObj == nil {
+			continue
+		}
+		err = executorUtils.PatchActiveRequests(ctx, deploy.kubernetesClient, deployObj, deploy.leader.Identity(),
+			executorUtils.ActiveRequests{Count: activeRequests, Time: now})
+		if err != nil && !k8sErrs.IsNotFound(err) {
+			deploy.logger.Warn("failed to record in-flight requests", zap.Error(err), zap.String("function", fsvc.Function.Name))
+		}
+	}
+}
@@ -33,7 +33,7 @@ func (deploy *NewDeploy) EnvEventHandlers(ctx context.Context) k8sCache.Resource
 			newEnv := newObj.(*fv1.Environment)
 			oldEnv := oldObj.(*fv1.Environment)
 			// Currently only an image update in environment calls for function's deployment recreation. In future there might be more attributes which would want to do it
-			if oldEnv.Spec.Runtime.Image != newEnv.Spec.Runtime.Image {
+			if oldEnv.Spec.Runtime.Image != newEnv.Spec.Runtime.Image && deploy.leader.IsLeader() {
 				deploy.logger.Debug("Updating all function of the environment that changed, old env:", zap.Any("environment", oldEnv))
 				funcs := deploy.getEnvFunctions(ctx, &newEnv.ObjectMeta)
 				for _, f := range funcs {
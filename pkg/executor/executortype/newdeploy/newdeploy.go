@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
@@ -34,7 +36,11 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/util"
+	rolloututils "github.com/fission/fission/pkg/executor/util/rollout"
+	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
 
@@ -42,7 +48,7 @@ func (deploy *NewDeploy) createOrGetDeployment(ctx context.Context, fn *fv1.Func
 	deployName string, deployLabels map[string]string, deployAnnotations map[string]string, deployNamespace string) (*appsv1.Deployment, error) {
 
 	specializationTimeout := fn.Spec.InvokeStrategy.ExecutionStrategy.SpecializationTimeout
-	minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
+	minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()).MinScale)
 
 	// Always scale to at least one pod when createOrGetDeployment
 	// is called. The idleObjectReaper will scale-in the deployment
@@ -87,7 +93,7 @@ func (deploy *NewDeploy) createOrGetDeployment(ctx context.Context, fn *fv1.Func
 			}
 		}
 		if existingDepl.Status.AvailableReplicas < minScale {
-			existingDepl, err = deploy.waitForDeploy(ctx, existingDepl, minScale, specializationTimeout)
+			existingDepl, err = deploy.waitForDeploy(ctx, fn, existingDepl, minScale, specializationTimeout)
 		}
 
 		return existingDepl, err
@@ -108,18 +114,13 @@ func (deploy *NewDeploy) createOrGetDeployment(ctx context.Context, fn *fv1.Func
 			}
 		}
 		if minScale > 0 {
-			depl, err = deploy.waitForDeploy(ctx, depl, minScale, specializationTimeout)
+			depl, err = deploy.waitForDeploy(ctx, fn, depl, minScale, specializationTimeout)
 		}
 		return depl, err
 	}
 	return nil, err
 }
 
-func (deploy *NewDeploy) updateDeployment(ctx context.Context, deployment *appsv1.Deployment, ns string) error {
-	_, err := deploy.kubernetesClient.AppsV1().Deployments(ns).Update(ctx, deployment, metav1.UpdateOptions{})
-	return err
-}
-
 func (deploy *NewDeploy) deleteDeployment(ctx context.Context, ns string, name string) error {
 	// DeletePropagationBackground deletes the object immediately and dependent are deleted later
 	// DeletePropagationForeground not advisable; it marks for deletion and API can still serve those objects
@@ -165,17 +166,6 @@ func (deploy *NewDeploy) getDeploymentSpec(ctx context.Context, fn *fv1.Function
 
 	resources := deploy.getResources(env, fn)
 
-	// Set maxUnavailable and maxSurge to 20% is because we want
-	// fission to rollout newer function version gradually without
-	// affecting any online service. For example, if you set maxSurge
-	// to 100%, the new ReplicaSet scales up immediately and may
-	// consume all remaining compute resources which might be an
-	// issue if a cluster's resource is on a budget.
-	// TODO: add to ExecutionStrategy so that the user
-	// can do more fine control over different functions.
-	maxUnavailable := intstr.FromString("20%")
-	maxSurge := intstr.FromString("20%")
-
 	// Newdeploy updates the environment variable "LastUpdateTimestamp" of deployment
 	// whenever a configmap/secret gets an update, but it also leaves multiple ReplicaSets for
 	// rollback purpose. Since fission always update a deployment instead of performing a
@@ -187,6 +177,9 @@ func (deploy *NewDeploy) getDeploymentSpec(ctx context.Context, fn *fv1.Function
 		return nil, err
 	}
 
+	// secrets and configmaps with env projection
+	envFromSources, envVars := util.ProjectConfigSecretsEnv(fn)
+
 	container, err := util.MergeContainer(&apiv1.Container{
 		Name:                   env.ObjectMeta.Name,
 		Image:                  env.Spec.Runtime.Image,
@@ -202,12 +195,13 @@ func (deploy *NewDeploy) getDeploymentSpec(ctx context.Context, fn *fv1.Function
 				},
 			},
 		},
-		Env: []apiv1.EnvVar{
+		Env: append([]apiv1.EnvVar{
 			{
 				Name:  fv1.ResourceVersionCount,
 				Value: fmt.Sprintf("%d", rvCount),
 			},
-		},
+		}, envVars...),
+		EnvFrom: envFromSources,
 		// https://istio.io/docs/setup/kubernetes/additional-setup/requirements/
 		Ports: []apiv1.ContainerPort{
 			{
@@ -269,14 +263,8 @@ func (deploy *NewDeploy) getDeploymentSpec(ctx context.Context, fn *fv1.Function
 			Selector: &metav1.LabelSelector{
 				MatchLabels: deployLabels,
 			},
-			Template: pod,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &maxUnavailable,
-					MaxSurge:       &maxSurge,
-				},
-			},
+			Template:             pod,
+			Strategy:             rolloututils.DeploymentStrategy(fn),
 			RevisionHistoryLimit: &revisionHistoryLimit,
 		},
 	}
@@ -347,6 +335,19 @@ func (deploy *NewDeploy) getResources(env *fv1.Environment, fn *fv1.Function) ap
 	return resources
 }
 
+// quotaDemand returns the replicas the function deployment is scaled up by
+// to serve the function, which count against the namespace quota.
+func (deploy *NewDeploy) quotaDemand(fn *fv1.Function, env *fv1.Environment, ns string, deployName string) quota.Usage {
+	replicas := int64(max(fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()).MinScale, 1))
+	if lister, ok := deploy.deplLister[ns]; ok {
+		depl, err := lister.Deployments(ns).Get(deployName)
+		if err == nil && depl.Spec.Replicas != nil {
+			replicas = max(replicas-int64(*depl.Spec.Replicas), 0)
+		}
+	}
+	return quota.Replicas(replicas, deploy.getResources(env, fn).Requests)
+}
+
 func (deploy *NewDeploy) createOrGetSvc(ctx context.Context, fn *fv1.Function, deployLabels map[string]string, deployAnnotations map[string]string, svcName string, svcNamespace string) (*apiv1.Service, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, deploy.logger)
 	var ownerReferences []metav1.OwnerReference
@@ -420,7 +421,10 @@ func (deploy *NewDeploy) deleteSvc(ctx context.Context, ns string, name string)
 	return deploy.kubernetesClient.CoreV1().Services(ns).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
-func (deploy *NewDeploy) waitForDeploy(ctx context.Context, depl *appsv1.Deployment, replicas int32, specializationTimeout int) (latestDepl *appsv1.Deployment, err error) {
+func (deploy *NewDeploy) waitForDeploy(ctx context.Context, fn *fv1.Function, depl *appsv1.Deployment, replicas int32, specializationTimeout int) (latestDepl *appsv1.Deployment, err error) {
+	ctx, span := otel.Tracer("executor").Start(ctx, "NewDeploy/waitForDeploy")
+	defer span.End()
+	start := time.Now()
 	oldStatus := depl.Status
 	otelUtils.SpanTrackEvent(ctx, "waitingForDeployment", otelUtils.GetAttributesForDeployment(depl)...)
 	// if no specializationTimeout is set, use default value
@@ -440,6 +444,9 @@ func (deploy *NewDeploy) waitForDeploy(ctx context.Context, depl *appsv1.Deploym
 		// since the pods may not be able to serve network traffic yet.
 		if latestDepl.Status.AvailableReplicas >= replicas {
 			otelUtils.SpanTrackEvent(ctx, "deploymentAvailable", otelUtils.GetAttributesForDeployment(latestDepl)...)
+			metrics.ObserveColdStartPhase(&fn.ObjectMeta, metrics.PhaseServiceReady, time.Since(start))
+			// the cold start doesn't wait for the phases to be recorded
+			go deploy.observeSpecializePhases(context.WithoutCancel(ctx), fn, latestDepl)
 			return latestDepl, err
 		}
 		time.Sleep(time.Second)
@@ -454,6 +461,41 @@ func (deploy *NewDeploy) waitForDeploy(ctx context.Context, depl *appsv1.Deploym
 	return nil, timeoutError
 }
 
+// observeSpecializePhases records the cold start phases of the pods of the
+// deployment, which specialize on startup and keep the phases in their
+// fetcher until asked once for them.
+func (deploy *NewDeploy) observeSpecializePhases(ctx context.Context, fn *fv1.Function, depl *appsv1.Deployment) {
+	logger := otelUtils.LoggerWithTraceID(ctx, deploy.logger)
+	selector, err := metav1.LabelSelectorAsSelector(depl.Spec.Selector)
+	if err != nil {
+		logger.Error("error parsing deployment selector", zap.Error(err), zap.String("deployment", depl.ObjectMeta.Name))
+		return
+	}
+	podList, err := deploy.kubernetesClient.CoreV1().Pods(depl.ObjectMeta.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		logger.Error("error listing deployment pods", zap.Error(err), zap.String("deployment", depl.ObjectMeta.Name))
+		return
+	}
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != apiv1.PodRunning || len(pod.Status.PodIP) == 0 {
+			continue
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		fetcherURL := "http://" + net.JoinHostPort(pod.Status.PodIP, "8000")
+		resp, err := fetcherClient.MakeClient(deploy.logger, fetcherURL).SpecializePhases(reqCtx)
+		cancel()
+		if err != nil {
+			logger.Debug("error getting specialization phases of pod", zap.Error(err), zap.String("pod", pod.ObjectMeta.Name))
+			continue
+		}
+		for _, phase := range resp.Phases {
+			metrics.ObserveColdStartPhase(&fn.ObjectMeta, phase.Name, phase.Duration)
+		}
+	}
+}
+
 // cleanupNewdeploy cleans all kubernetes objects related to function
 func (deploy *NewDeploy) cleanupNewdeploy(ctx context.Context, ns string, name string) error {
 	var result error
@@ -476,6 +518,15 @@ func (deploy *NewDeploy) cleanupNewdeploy(ctx context.Context, ns string, name s
 		result = errors.Join(result, err)
 	}
 
+	err = deploy.rolloutops.Cleanup(ctx, ns, name)
+	if err != nil {
+		deploy.logger.Error("error deleting blue/green deployment for newdeploy function",
+			zap.Error(err),
+			zap.String("function_name", name),
+			zap.String("function_namespace", ns))
+		result = errors.Join(result, err)
+	}
+
 	err = deploy.deleteDeployment(ctx, ns, name)
 	if err != nil && !k8s_err.IsNotFound(err) {
 		deploy.logger.Error("error deleting deployment for newdeploy function",
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	apiv1 "k8s.io/api/core/v1"
 	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
@@ -42,17 +43,24 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/reaper"
 	executorUtils "github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/executor/util/autoscaler"
 	hpautils "github.com/fission/fission/pkg/executor/util/hpa"
+	rolloututils "github.com/fission/fission/pkg/executor/util/rollout"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
+	fissionlisters "github.com/fission/fission/pkg/generated/listers/core/v1"
 	"github.com/fission/fission/pkg/throttler"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/leaderelection"
 	"github.com/fission/fission/pkg/utils/manager"
 	"github.com/fission/fission/pkg/utils/maps"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
@@ -81,6 +89,7 @@ type (
 		throttler *throttler.Throttler
 
 		defaultIdlePodReapTime time.Duration
+		idlePolicies           *reaper.IdlePolicies
 
 		deplLister map[string]appslisters.DeploymentLister
 		svcLister  map[string]corelisters.ServiceLister
@@ -88,12 +97,28 @@ type (
 		deplListerSynced map[string]k8sCache.InformerSynced
 		svcListerSynced  map[string]k8sCache.InformerSynced
 
-		hpaops *hpautils.HpaOperations
+		fnLister       map[string]fissionlisters.FunctionLister
+		fnListerSynced map[string]k8sCache.InformerSynced
+
+		hpaops     *hpautils.HpaOperations
+		rolloutops *rolloututils.RolloutOperations
 
 		podSpecPatch               *apiv1.PodSpec
 		objectReaperIntervalSecond time.Duration
 
 		enableOwnerReferences bool
+
+		// leader is nil when running with a single executor replica
+		leader *leaderelection.Elector
+
+		// quota is nil when namespace quotas are disabled
+		quota *quota.Enforcer
+
+		recorder *events.Recorder
+
+		// autoscalers of the functions scaling on concurrency, by function UID
+		autoscalers     map[k8sTypes.UID]*autoscaler.Autoscaler
+		autoscalersLock sync.Mutex
 	}
 )
 
@@ -108,6 +133,9 @@ func MakeNewDeploy(
 	finformerFactory map[string]genInformer.SharedInformerFactory,
 	ndmInformerFactory map[string]k8sInformers.SharedInformerFactory,
 	podSpecPatch *apiv1.PodSpec,
+	leader *leaderelection.Elector,
+	enforcer *quota.Enforcer,
+	recorder *events.Recorder,
 ) (executortype.ExecutorType, error) {
 	enableIstio := false
 	if len(os.Getenv("ENABLE_ISTIO")) > 0 {
@@ -133,16 +161,24 @@ func MakeNewDeploy(
 		useIstio:               enableIstio,
 
 		defaultIdlePodReapTime:     2 * time.Minute,
+		idlePolicies:               reaper.MakeIdlePolicies(logger, kubernetesClient, recorder),
 		objectReaperIntervalSecond: time.Duration(executorUtils.GetObjectReaperInterval(logger, fv1.ExecutorTypeNewdeploy, 5)) * time.Second,
 		hpaops:                     hpautils.NewHpaOperations(logger, kubernetesClient, instanceID),
+		rolloutops:                 rolloututils.NewRolloutOperations(logger, kubernetesClient, fissionClient),
 
 		podSpecPatch:     podSpecPatch,
 		deplLister:       make(map[string]appslisters.DeploymentLister),
 		deplListerSynced: make(map[string]k8sCache.InformerSynced),
 		svcLister:        make(map[string]corelisters.ServiceLister),
 		svcListerSynced:  make(map[string]k8sCache.InformerSynced),
+		fnLister:         make(map[string]fissionlisters.FunctionLister),
+		fnListerSynced:   make(map[string]k8sCache.InformerSynced),
 
 		enableOwnerReferences: utils.IsOwnerReferencesEnabled(),
+		leader:                leader,
+		quota:                 enforcer,
+		recorder:              recorder,
+		autoscalers:           make(map[k8sTypes.UID]*autoscaler.Autoscaler),
 	}
 
 	for ns, informerFactory := range ndmInformerFactory {
@@ -151,7 +187,9 @@ func MakeNewDeploy(
 		nd.svcLister[ns] = informerFactory.Core().V1().Services().Lister()
 		nd.svcListerSynced[ns] = informerFactory.Core().V1().Services().Informer().HasSynced
 	}
-	for _, factory := range finformerFactory {
+	for ns, factory := range finformerFactory {
+		nd.fnLister[ns] = factory.Core().V1().Functions().Lister()
+		nd.fnListerSynced[ns] = factory.Core().V1().Functions().Informer().HasSynced
 		_, err := factory.Core().V1().Functions().Informer().AddEventHandler(nd.FunctionEventHandlers(ctx))
 		if err != nil {
 			return nil, err
@@ -163,6 +201,7 @@ func MakeNewDeploy(
 			return nil, err
 		}
 	}
+	leader.OnStartedLeading(nd.resync)
 	return nd, nil
 }
 
@@ -175,6 +214,9 @@ func (deploy *NewDeploy) Run(ctx context.Context, mgr manager.Interface) {
 	for _, svcListerSynced := range deploy.svcListerSynced {
 		waitSynced = append(waitSynced, svcListerSynced)
 	}
+	for _, fnListerSynced := range deploy.fnListerSynced {
+		waitSynced = append(waitSynced, fnListerSynced)
+	}
 
 	if ok := k8sCache.WaitForCacheSync(ctx.Done(), waitSynced...); !ok {
 		deploy.logger.Fatal("failed to wait for caches to sync")
@@ -182,6 +224,20 @@ func (deploy *NewDeploy) Run(ctx context.Context, mgr manager.Interface) {
 	mgr.Add(ctx, func(ctx context.Context) {
 		deploy.idleObjectReaper(ctx)
 	})
+	mgr.Add(ctx, func(ctx context.Context) {
+		wait.UntilWithContext(ctx, deploy.doConcurrencyScaling, concurrencyScalingInterval)
+	})
+	mgr.Add(ctx, func(ctx context.Context) {
+		wait.UntilWithContext(ctx, deploy.applyScalingSchedules, executorUtils.ScalingScheduleInterval)
+	})
+	if deploy.leader != nil {
+		mgr.Add(ctx, func(ctx context.Context) {
+			wait.UntilWithContext(ctx, deploy.reportAccessTimes, executorUtils.AccessTimeReportInterval)
+		})
+		mgr.Add(ctx, func(ctx context.Context) {
+			wait.UntilWithContext(ctx, deploy.reportActiveRequests, executorUtils.ActiveRequestsReportInterval)
+		})
+	}
 }
 
 // GetTypeName returns the executor type name.
@@ -280,6 +336,14 @@ func (deploy *NewDeploy) RefreshFuncPods(ctx context.Context, logger *zap.Logger
 		UID:       env.ObjectMeta.UID,
 	})
 
+	if f.Spec.ConfigUpdatePolicy == fv1.ConfigUpdateReload {
+		err = executorUtils.ReloadFuncPods(ctx, logger, deploy.kubernetesClient, deploy.nsResolver.GetFunctionNS(f.ObjectMeta.Namespace), funcLabels, &f)
+		if err == nil {
+			return nil
+		}
+		logger.Error("error reloading function pods, recycling them instead", zap.Error(err), zap.String("function", f.ObjectMeta.Name))
+	}
+
 	dep, err := deploy.kubernetesClient.AppsV1().Deployments(deploy.nsResolver.GetFunctionNS(f.ObjectMeta.Namespace)).List(ctx, metav1.ListOptions{
 		LabelSelector: labels.Set(funcLabels).AsSelector().String(),
 	})
@@ -450,6 +514,12 @@ func (deploy *NewDeploy) fnCreate(ctx context.Context, fn *fv1.Function) (*fscac
 	// deployment of the function in fission-function ns
 	ns := deploy.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
 
+	release, err := deploy.quota.Reserve(ctx, fn.ObjectMeta.Namespace, deploy.quotaDemand(fn, env, ns, objName))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Envoy(istio-proxy) returns 404 directly before istio pilot
 	// propagates latest Envoy-specific configuration.
 	// Since newdeploy waits for pods of deployment to be ready,
@@ -470,13 +540,6 @@ func (deploy *NewDeploy) fnCreate(ctx context.Context, fn *fv1.Function) (*fscac
 		return nil, fmt.Errorf("error creating deployment %s: %w", objName, err)
 	}
 
-	hpa, err := deploy.hpaops.CreateOrGetHpa(ctx, fn, objName, &fn.Spec.InvokeStrategy.ExecutionStrategy, depl, deployLabels, deployAnnotations)
-	if err != nil {
-		deploy.logger.Error("error creating HPA", zap.Error(err), zap.String("hpa", objName))
-		go cleanupFunc(context.Background(), ns, objName)
-		return nil, fmt.Errorf("error creating HPA %s: %w", objName, err)
-	}
-
 	kubeObjRefs := []apiv1.ObjectReference{
 		{
 			// obj.TypeMeta.Kind does not work hence this, needs investigation and a fix
@@ -495,14 +558,28 @@ func (deploy *NewDeploy) fnCreate(ctx context.Context, fn *fv1.Function) (*fscac
 			ResourceVersion: svc.ObjectMeta.ResourceVersion,
 			UID:             svc.ObjectMeta.UID,
 		},
-		{
+	}
+
+	// functions scaling on concurrency are scaled by the executor instead of a HPA
+	if !isConcurrencyScaling(fn) {
+		es, err := deploy.quota.LimitStrategy(ctx, fn.ObjectMeta.Namespace, depl, fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()))
+		if err != nil {
+			deploy.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+		}
+		hpa, err := deploy.hpaops.CreateOrGetHpa(ctx, fn, objName, &es, depl, deployLabels, deployAnnotations)
+		if err != nil {
+			deploy.logger.Error("error creating HPA", zap.Error(err), zap.String("hpa", objName))
+			go cleanupFunc(context.Background(), ns, objName)
+			return nil, fmt.Errorf("error creating HPA %s: %w", objName, err)
+		}
+		kubeObjRefs = append(kubeObjRefs, apiv1.ObjectReference{
 			Kind:            "horizontalpodautoscaler",
 			Name:            hpa.ObjectMeta.Name,
 			APIVersion:      hpa.APIVersion,
 			Namespace:       hpa.ObjectMeta.Namespace,
 			ResourceVersion: hpa.ObjectMeta.ResourceVersion,
 			UID:             hpa.ObjectMeta.UID,
-		},
+		})
 	}
 
 	fsvc := &fscache.FuncSvc{
@@ -543,13 +620,19 @@ func (deploy *NewDeploy) updateFunction(ctx context.Context, oldFn *fv1.Function
 		oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
 		deploy.logger.Info("function does not use new deployment executor anymore, deleting resources",
 			zap.Any("function", newFn))
-		// IMP - pass the oldFn, as the new/modified function is not in cache
+		// IMP - pass the oldFn, as the new/modified function is not in cache.
+		// Other replicas than the leader only drop the function from their cache.
 		return deploy.deleteFunction(ctx, oldFn)
 	}
 
 	// Executor type changed to New Deployment from something else
 	if oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy &&
 		newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
+		// resources are created by the leader, other replicas adopt them
+		// on the first request of the function
+		if !deploy.leader.IsLeader() {
+			return nil
+		}
 		deploy.logger.Info("function type changed to new deployment, creating resources",
 			zap.Any("old_function", oldFn.ObjectMeta),
 			zap.Any("new_function", newFn.ObjectMeta))
@@ -560,6 +643,11 @@ func (deploy *NewDeploy) updateFunction(ctx context.Context, oldFn *fv1.Function
 		return err
 	}
 
+	// hpa and deployment are updated by the leader
+	if !deploy.leader.IsLeader() {
+		return nil
+	}
+
 	deployChanged := false
 
 	if !reflect.DeepEqual(oldFn.Spec.InvokeStrategy, newFn.Spec.InvokeStrategy) {
@@ -567,46 +655,58 @@ func (deploy *NewDeploy) updateFunction(ctx context.Context, oldFn *fv1.Function
 		// to support backward compatibility, if the function was created in default ns, we fall back to creating the
 		// deployment of the function in fission-function ns, so cleaning up resources there
 		ns := deploy.nsResolver.GetFunctionNS(newFn.ObjectMeta.Namespace)
+		// the leader may not have the function service cached, use the
+		// object name directly
+		objName := deploy.getObjName(newFn)
 
-		fsvc, err := deploy.fsCache.GetByFunctionUID(newFn.ObjectMeta.UID)
-		if err != nil {
-			return fmt.Errorf("error updating function due to unable to find function service cache %s: %w", k8sCache.MetaObjectToName(oldFn), err)
-		}
+		if isConcurrencyScaling(oldFn) != isConcurrencyScaling(newFn) {
+			err := deploy.updateScalingMode(ctx, newFn, ns, objName)
+			if err != nil {
+				deploy.updateStatus(oldFn, err, "error changing scaling mode while updating function")
+				return err
+			}
+		} else if !isConcurrencyScaling(newFn) {
+			hpa, err := deploy.hpaops.GetHpa(ctx, ns, objName)
+			if err != nil {
+				deploy.updateStatus(oldFn, err, "error getting HPA while updating function")
+				return err
+			}
 
-		hpa, err := deploy.hpaops.GetHpa(ctx, ns, fsvc.Name)
-		if err != nil {
-			deploy.updateStatus(oldFn, err, "error getting HPA while updating function")
-			return err
-		}
+			hpaChanged := false
 
-		hpaChanged := false
+			// compare the scale in effect now, scaling schedule windows are
+			// applied by the leader as they start and end
+			now := time.Now()
+			oldES := oldFn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now)
+			newES := newFn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now)
 
-		if newFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale != oldFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale {
-			replicas := int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
-			hpa.Spec.MinReplicas = &replicas
-			hpaChanged = true
-		}
+			if newES.MinScale != oldES.MinScale {
+				replicas := int32(newES.MinScale)
+				hpa.Spec.MinReplicas = &replicas
+				hpaChanged = true
+			}
 
-		if newFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale != oldFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale {
-			hpa.Spec.MaxReplicas = int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale)
-			hpaChanged = true
-		}
+			if newES.MaxScale != oldES.MaxScale {
+				hpa.Spec.MaxReplicas = int32(newES.MaxScale)
+				hpaChanged = true
+			}
 
-		if !reflect.DeepEqual(newFn.Spec.InvokeStrategy.ExecutionStrategy.Metrics, oldFn.Spec.InvokeStrategy.ExecutionStrategy.Metrics) {
-			hpa.Spec.Metrics = newFn.Spec.InvokeStrategy.ExecutionStrategy.Metrics
-			hpaChanged = true
-		}
+			if !reflect.DeepEqual(newFn.Spec.InvokeStrategy.ExecutionStrategy.Metrics, oldFn.Spec.InvokeStrategy.ExecutionStrategy.Metrics) {
+				hpa.Spec.Metrics = newFn.Spec.InvokeStrategy.ExecutionStrategy.Metrics
+				hpaChanged = true
+			}
 
-		if !reflect.DeepEqual(newFn.Spec.InvokeStrategy.ExecutionStrategy.Behavior, oldFn.Spec.InvokeStrategy.ExecutionStrategy.Behavior) {
-			hpa.Spec.Behavior = newFn.Spec.InvokeStrategy.ExecutionStrategy.Behavior
-			hpaChanged = true
-		}
+			if !reflect.DeepEqual(newFn.Spec.InvokeStrategy.ExecutionStrategy.Behavior, oldFn.Spec.InvokeStrategy.ExecutionStrategy.Behavior) {
+				hpa.Spec.Behavior = newFn.Spec.InvokeStrategy.ExecutionStrategy.Behavior
+				hpaChanged = true
+			}
 
-		if hpaChanged {
-			err := deploy.hpaops.UpdateHpa(ctx, hpa)
-			if err != nil {
-				deploy.updateStatus(oldFn, err, "error updating HPA while updating function")
-				return err
+			if hpaChanged {
+				err := deploy.hpaops.UpdateHpa(ctx, hpa)
+				if err != nil {
+					deploy.updateStatus(oldFn, err, "error updating HPA while updating function")
+					return err
+				}
 			}
 		}
 	}
@@ -654,11 +754,7 @@ func (deploy *NewDeploy) updateFunction(ctx context.Context, oldFn *fv1.Function
 }
 
 func (deploy *NewDeploy) updateFuncDeployment(ctx context.Context, fn *fv1.Function, env *fv1.Environment) error {
-	fsvc, err := deploy.fsCache.GetByFunctionUID(fn.ObjectMeta.UID)
-	if err != nil {
-		return fmt.Errorf("error updating function due to unable to find function service cache: %s: %w", k8sCache.MetaObjectToName(fn), err)
-	}
-	fnObjName := fsvc.Name
+	fnObjName := deploy.getObjName(fn)
 
 	deployLabels := deploy.getDeployLabels(fn.ObjectMeta, env.ObjectMeta)
 	deploy.logger.Info("updating deployment due to function/environment update",
@@ -675,7 +771,7 @@ func (deploy *NewDeploy) updateFuncDeployment(ctx context.Context, fn *fv1.Funct
 
 	// the resource version inside function packageRef is changed,
 	// so the content of fetchRequest in deployment cmd is different.
-	// Therefore, the deployment update will trigger a rolling update.
+	// Therefore, the deployment update will trigger a rollout.
 	newDeployment, err := deploy.getDeploymentSpec(ctx, fn, env,
 		existingDepl.Spec.Replicas, // use current replicas instead of minscale in the ExecutionStrategy.
 		fnObjName, ns, deployLabels, deploy.getDeployAnnotations(fn.ObjectMeta, env.ObjectMeta))
@@ -683,8 +779,9 @@ func (deploy *NewDeploy) updateFuncDeployment(ctx context.Context, fn *fv1.Funct
 		deploy.updateStatus(fn, err, "failed to get new deployment spec while updating function")
 		return err
 	}
+	newDeployment.Namespace = ns
 
-	err = deploy.updateDeployment(ctx, newDeployment, ns)
+	err = deploy.rolloutops.Update(ctx, fn, existingDepl, newDeployment)
 	if err != nil {
 		deploy.updateStatus(fn, err, "failed to update deployment while updating function")
 		return err
@@ -696,22 +793,27 @@ func (deploy *NewDeploy) updateFuncDeployment(ctx context.Context, fn *fv1.Funct
 func (deploy *NewDeploy) fnDelete(ctx context.Context, fn *fv1.Function) error {
 	var errs error
 
+	deploy.deleteAutoscaler(fn.ObjectMeta.UID)
+
 	// GetByFunction uses resource version as part of cache key, however,
 	// the resource version in function metadata will be changed when a function
 	// is deleted and cause newdeploy backend fails to delete the entry.
 	// Use GetByFunctionUID instead of GetByFunction here to find correct
 	// fsvc entry.
 	fsvc, err := deploy.fsCache.GetByFunctionUID(fn.ObjectMeta.UID)
-	if err != nil {
-		return fmt.Errorf("fsvc not found in cache: %s: %w", k8sCache.MetaObjectToName(fn), err)
+	if err == nil {
+		_, err = deploy.fsCache.DeleteOld(fsvc, time.Second*0)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error deleting the function from cache"))
+		}
 	}
 
-	objName := fsvc.Name
-
-	_, err = deploy.fsCache.DeleteOld(fsvc, time.Second*0)
-	if err != nil {
-		errs = errors.Join(errs, fmt.Errorf("error deleting the function from cache"))
+	// kubernetes objects are cleaned up by the leader, which may not have
+	// the function service cached
+	if !deploy.leader.IsLeader() {
+		return errs
 	}
+	objName := deploy.getObjName(fn)
 
 	// to support backward compatibility, if the function was created in default ns, we fall back to creating the
 	// deployment of the function in fission-function ns, so cleaning up resources there
@@ -784,6 +886,10 @@ func (deploy *NewDeploy) idleObjectReaper(ctx context.Context) {
 }
 
 func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
+	if !deploy.leader.IsLeader() {
+		return
+	}
+
 	envList := make(map[k8sTypes.UID]struct{})
 	for _, namespace := range utils.DefaultNSResolver().FissionResourceNS {
 		envs, err := deploy.fissionClient.CoreV1().Environments(namespace).List(ctx, metav1.ListOptions{})
@@ -802,6 +908,9 @@ func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
 		return
 	}
 
+	run := deploy.idlePolicies.StartRun(fv1.ExecutorTypeNewdeploy)
+	defer run.Finish()
+
 	for i := range funcSvcs {
 		fsvc := funcSvcs[i]
 		if fsvc.Executor != fv1.ExecutorTypeNewdeploy {
@@ -827,12 +936,18 @@ func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
 			continue
 		}
 
-		idlePodReapTime := deploy.defaultIdlePodReapTime
-		if fn.Spec.IdleTimeout != nil {
-			idlePodReapTime = time.Duration(*fn.Spec.IdleTimeout) * time.Second
+		now := time.Now()
+		idlePodReapTime := deploy.idlePolicies.IdleTimeout(ctx, fn, deploy.defaultIdlePodReapTime, now)
+		if now.Sub(deploy.getLastAccessTime(fsvc)) < idlePodReapTime {
+			continue
 		}
 
-		if time.Since(fsvc.Atime) < idlePodReapTime {
+		// the deployment is not reapable if it already runs its min scale
+		minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now).MinScale)
+		if depl := deploy.getCachedDeployment(fsvc); depl != nil && depl.Spec.Replicas != nil && *depl.Spec.Replicas <= minScale {
+			continue
+		}
+		if !run.Reap(fsvc.Function, fsvc.Name, reaper.ReasonIdleTimeout) {
 			continue
 		}
 
@@ -850,8 +965,6 @@ func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
 				return
 			}
 
-			minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
-
 			// do nothing if the current replicas is already lower than minScale
 			if *currentDeploy.Spec.Replicas <= minScale {
 				return
@@ -865,6 +978,57 @@ func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
 	}
 }
 
+// getCachedDeployment returns the deployment of the function service from
+// the informer cache, or nil if it isn't found.
+func (deploy *NewDeploy) getCachedDeployment(fsvc *fscache.FuncSvc) *appsv1.Deployment {
+	deployObj := getDeploymentObj(fsvc.KubernetesObjects)
+	if deployObj == nil {
+		return nil
+	}
+	lister, ok := deploy.deplLister[deployObj.Namespace]
+	if !ok {
+		return nil
+	}
+	depl, err := lister.Deployments(deployObj.Namespace).Get(deployObj.Name)
+	if err != nil {
+		return nil
+	}
+	return depl
+}
+
+// getLastAccessTime returns the latest access time of the function service
+// known by any executor replica.
+func (deploy *NewDeploy) getLastAccessTime(fsvc *fscache.FuncSvc) time.Time {
+	atime := fsvc.Atime
+	depl := deploy.getCachedDeployment(fsvc)
+	if depl == nil {
+		return atime
+	}
+	if t, ok := executorUtils.GetLastAccessTime(depl); ok && t.After(atime) {
+		atime = t
+	}
+	return atime
+}
+
+// reportAccessTimes records the access time of recently used function
+// services on their deployments, so that the leader doesn't scale down
+// deployments which are being served by other replicas.
+func (deploy *NewDeploy) reportAccessTimes(ctx context.Context) {
+	for _, fsvc := range deploy.fsCache.ListRecent(executorUtils.AccessTimeReportInterval) {
+		if fsvc.Executor != fv1.ExecutorTypeNewdeploy {
+			continue
+		}
+		deployObj := getDeploymentObj(fsvc.KubernetesObjects)
+		if deployObj == nil {
+			continue
+		}
+		err := executorUtils.PatchLastAccessTime(ctx, deploy.kubernetesClient, deployObj, fsvc.Atime)
+		if err != nil && !k8sErrs.IsNotFound(err) {
+			deploy.logger.Warn("failed to record last access time", zap.Error(err), zap.String("function", fsvc.Function.Name))
+		}
+	}
+}
+
 func getDeploymentObj(kubeobjs []apiv1.ObjectReference) *apiv1.ObjectReference {
 	for _, kubeobj := range kubeobjs {
 		switch strings.ToLower(kubeobj.Kind) {
@@ -898,6 +1062,18 @@ func (deploy *NewDeploy) scaleDeployment(ctx context.Context, deplNS string, dep
 	return err
 }
 
-func (deploy *NewDeploy) DumpDebugInfo(ctx context.Context) error {
-	return nil
+func (deploy *NewDeploy) DumpDebugInfo(ctx context.Context) ([]fscache.FuncSvcGroupStatus, error) {
+	return deploy.fsCache.DumpDebugInfo(ctx), nil
+}
+
+// EvictFuncSvc is not supported, the pods are managed by the function deployment.
+func (deploy *NewDeploy) EvictFuncSvc(ctx context.Context, namespace string, podName string) error {
+	return ferror.MakeError(ferror.ErrorInvalidArgument,
+		fmt.Sprintf("evicting function pods is not supported by executor type %s", deploy.GetTypeName(ctx)))
+}
+
+// DrainFunction is not supported, the function deployment scales down idle pods gracefully.
+func (deploy *NewDeploy) DrainFunction(ctx context.Context, fnMeta *metav1.ObjectMeta, timeout time.Duration) ([]string, []string, error) {
+	return nil, nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+		fmt.Sprintf("draining functions is not supported by executor type %s", deploy.GetTypeName(ctx)))
 }
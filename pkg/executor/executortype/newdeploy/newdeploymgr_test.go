@@ -58,7 +58,7 @@ func TestRefreshFuncPods(t *testing.T) {
 	}
 
 	executor, err := MakeNewDeploy(ctx, logger, fissionClient, kubernetesClient, fetcherConfig, "test",
-		factory, ndmInformerFactory, nil)
+		factory, ndmInformerFactory, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("new deploy manager creation failed: %s", err)
 	}
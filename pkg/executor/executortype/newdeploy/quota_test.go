@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	asv2 "k8s.io/api/autoscaling/v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	k8sInformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	k8stesting "k8s.io/client-go/testing"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/quota"
+	"github.com/fission/fission/pkg/executor/util/autoscaler"
+	hpautils "github.com/fission/fission/pkg/executor/util/hpa"
+	fissionlisters "github.com/fission/fission/pkg/generated/listers/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// makeQuotaDeploy returns a newdeploy manager enforcing a quota of 3 replicas
+// in the tenant namespace, where the function deployment runs 1 replica and
+// another function 1 more. Scales of deployments are sent to the returned channel.
+func makeQuotaDeploy(t *testing.T, fn *fv1.Function, objects ...runtime.Object) (*NewDeploy, chan int32) {
+	ctx := t.Context()
+	logger := loggerfactory.GetLogger()
+
+	deploy := &NewDeploy{
+		logger:      logger,
+		nsResolver:  &utils.NamespaceResolver{},
+		autoscalers: make(map[k8sTypes.UID]*autoscaler.Autoscaler),
+	}
+	replicas := int32(1)
+	functionDepl := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "tenant",
+				Labels:    map[string]string{fv1.FUNCTION_NAMESPACE: "tenant"},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+	objects = append(objects,
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "tenant",
+			Annotations: map[string]string{fv1.ANNOTATION_QUOTA_REPLICAS: "3"},
+		}},
+		functionDepl(deploy.getObjName(fn)),
+		functionDepl("other"),
+	)
+	kubernetesClient := fake.NewSimpleClientset(objects...)
+	scaled := make(chan int32, 10)
+	kubernetesClient.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		scaled <- scale.Spec.Replicas
+		return true, scale, nil
+	})
+
+	factory := map[string]k8sInformers.SharedInformerFactory{
+		"tenant": k8sInformers.NewSharedInformerFactoryWithOptions(kubernetesClient, time.Minute,
+			k8sInformers.WithNamespace("tenant")),
+	}
+	deploy.kubernetesClient = kubernetesClient
+	deploy.quota = quota.MakeEnforcer(logger, kubernetesClient, factory)
+	deploy.hpaops = hpautils.NewHpaOperations(logger, kubernetesClient, "test")
+	deploy.deplLister = map[string]appslisters.DeploymentLister{"tenant": factory["tenant"].Apps().V1().Deployments().Lister()}
+	informer := factory["tenant"].Apps().V1().Deployments().Informer()
+	factory["tenant"].Core().V1().Pods().Informer()
+	factory["tenant"].Start(ctx.Done())
+	require.True(t, k8sCache.WaitForCacheSync(ctx.Done(), informer.HasSynced,
+		factory["tenant"].Core().V1().Pods().Informer().HasSynced))
+
+	indexer := k8sCache.NewIndexer(k8sCache.MetaNamespaceKeyFunc, k8sCache.Indexers{k8sCache.NamespaceIndex: k8sCache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(fn))
+	deploy.fnLister = map[string]fissionlisters.FunctionLister{"tenant": fissionlisters.NewFunctionLister(indexer)}
+	return deploy, scaled
+}
+
+func TestQuotaLimitsScaleUp(t *testing.T) {
+	ctx := t.Context()
+	minScale := 5
+
+	t.Run("concurrency scaling", func(t *testing.T) {
+		fn := &fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "tenant", UID: "8c0d9f2e-6f8a-4c63-a1a4-3c4c1d1a9f10"},
+			Spec: fv1.FunctionSpec{
+				InvokeStrategy: fv1.InvokeStrategy{
+					ExecutionStrategy: fv1.ExecutionStrategy{
+						ExecutorType:       fv1.ExecutorTypeNewdeploy,
+						MaxScale:           10,
+						ScalingMode:        fv1.ScalingModeConcurrency,
+						ConcurrencyScaling: &fv1.ConcurrencyScaling{TargetConcurrency: 1},
+					},
+				},
+			},
+		}
+		deploy, scaled := makeQuotaDeploy(t, fn)
+		deploy.getAutoscaler(fn.UID).Report(time.Now(), "router", 10)
+
+		deploy.doConcurrencyScaling(ctx)
+		select {
+		case replicas := <-scaled:
+			require.Equal(t, int32(2), replicas)
+		default:
+			t.Fatal("deployment not scaled")
+		}
+	})
+
+	t.Run("hpa and scaling schedules", func(t *testing.T) {
+		fn := &fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "tenant", UID: "8c0d9f2e-6f8a-4c63-a1a4-3c4c1d1a9f11"},
+			Spec: fv1.FunctionSpec{
+				InvokeStrategy: fv1.InvokeStrategy{
+					ExecutionStrategy: fv1.ExecutionStrategy{
+						ExecutorType: fv1.ExecutorTypeNewdeploy,
+						MinScale:     1,
+						MaxScale:     10,
+						ScalingSchedules: []fv1.ScalingSchedule{{
+							Name:     "always",
+							Schedule: "* * * * *",
+							Duration: metav1.Duration{Duration: time.Hour},
+							MinScale: &minScale,
+						}},
+					},
+				},
+			},
+		}
+		hpaMin := int32(1)
+		deploy, scaled := makeQuotaDeploy(t, fn, &asv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: (&NewDeploy{}).getObjName(fn), Namespace: "tenant"},
+			Spec:       asv2.HorizontalPodAutoscalerSpec{MinReplicas: &hpaMin, MaxReplicas: 10},
+		})
+
+		deploy.applyScalingSchedules(ctx)
+		hpa, err := deploy.hpaops.GetHpa(ctx, "tenant", deploy.getObjName(fn))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), hpa.Spec.MaxReplicas)
+		require.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		select {
+		case replicas := <-scaled:
+			require.Equal(t, int32(2), replicas)
+		default:
+			t.Fatal("deployment not scaled")
+		}
+	})
+}
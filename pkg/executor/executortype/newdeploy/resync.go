@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// resync reconciles the kubernetes objects of all newdeploy functions with
+// the functions and environments once this replica starts leading. Function
+// and environment events are only handled by the leader, the ones received
+// while another replica was leading are caught up here.
+func (deploy *NewDeploy) resync(ctx context.Context) {
+	waitSynced := make([]k8sCache.InformerSynced, 0)
+	for _, deplListerSynced := range deploy.deplListerSynced {
+		waitSynced = append(waitSynced, deplListerSynced)
+	}
+	for _, fnListerSynced := range deploy.fnListerSynced {
+		waitSynced = append(waitSynced, fnListerSynced)
+	}
+	if ok := k8sCache.WaitForCacheSync(ctx.Done(), waitSynced...); !ok {
+		return
+	}
+	deploy.logger.Info("resyncing newdeploy functions after acquiring leadership")
+
+	functions := make(map[k8sTypes.UID]struct{})
+	for namespace, lister := range deploy.fnLister {
+		fns, err := lister.List(labels.Everything())
+		if err != nil {
+			deploy.logger.Error("error listing functions", zap.Error(err), zap.String("namespace", namespace))
+			continue
+		}
+		for _, fn := range fns {
+			if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy {
+				continue
+			}
+			functions[fn.ObjectMeta.UID] = struct{}{}
+			err = deploy.resyncFunction(ctx, fn)
+			if err != nil {
+				deploy.logger.Error("error resyncing function", zap.Error(err),
+					zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace))
+			}
+		}
+	}
+
+	// clean up the objects of functions deleted or not using newdeploy anymore
+	selector := labels.SelectorFromSet(map[string]string{fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeNewdeploy)})
+	for ns, lister := range deploy.deplLister {
+		depls, err := lister.Deployments(ns).List(selector)
+		if err != nil {
+			deploy.logger.Error("error listing deployments", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+		for _, depl := range depls {
+			if _, ok := functions[k8sTypes.UID(depl.Labels[fv1.FUNCTION_UID])]; ok {
+				continue
+			}
+			// functions of namespaces not watched by this executor are unknown
+			if _, ok := deploy.fnLister[depl.Labels[fv1.FUNCTION_NAMESPACE]]; !ok {
+				continue
+			}
+			deploy.logger.Info("cleaning up objects of removed function",
+				zap.String("deployment", depl.ObjectMeta.Name), zap.String("namespace", ns))
+			err = deploy.cleanupNewdeploy(ctx, ns, depl.ObjectMeta.Name)
+			if err != nil {
+				deploy.logger.Error("error cleaning up objects of removed function", zap.Error(err),
+					zap.String("deployment", depl.ObjectMeta.Name), zap.String("namespace", ns))
+			}
+		}
+	}
+}
+
+// resyncFunction creates the objects of the function if they are missing, or
+// updates them if the function or the image of its environment changed since.
+func (deploy *NewDeploy) resyncFunction(ctx context.Context, fn *fv1.Function) error {
+	ns := deploy.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+	objName := deploy.getObjName(fn)
+	deplLister, ok := deploy.deplLister[ns]
+	if !ok {
+		return nil
+	}
+	depl, err := deplLister.Deployments(ns).Get(objName)
+	if k8sErrs.IsNotFound(err) {
+		_, err = deploy.createFunction(ctx, fn)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	env, err := deploy.fissionClient.CoreV1().Environments(fn.Spec.Environment.Namespace).
+		Get(ctx, fn.Spec.Environment.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if depl.Annotations[fv1.FUNCTION_RESOURCE_VERSION] == fn.ObjectMeta.ResourceVersion &&
+		hasContainerImage(depl, env.ObjectMeta.Name, env.Spec.Runtime.Image) {
+		return nil
+	}
+
+	deploy.logger.Info("function changed while not leading, updating its objects",
+		zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace))
+	err = deploy.updateScalingMode(ctx, fn, ns, objName)
+	if err != nil {
+		return err
+	}
+	if !isConcurrencyScaling(fn) {
+		hpa, err := deploy.hpaops.GetHpa(ctx, ns, objName)
+		if err != nil {
+			return err
+		}
+		hpa.Spec.Metrics = fn.Spec.InvokeStrategy.ExecutionStrategy.Metrics
+		hpa.Spec.Behavior = fn.Spec.InvokeStrategy.ExecutionStrategy.Behavior
+		err = deploy.hpaops.UpdateHpa(ctx, hpa)
+		if err != nil {
+			return err
+		}
+		es, err := deploy.quota.LimitStrategy(ctx, fn.ObjectMeta.Namespace, depl, fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(time.Now()))
+		if err != nil {
+			deploy.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+		}
+		err = deploy.hpaops.UpdateHpaScale(ctx, ns, objName, &es)
+		if err != nil {
+			return err
+		}
+	}
+	return deploy.updateFuncDeployment(ctx, fn, env)
+}
+
+// hasContainerImage returns true if the container of the environment in the
+// deployment runs the image.
+func hasContainerImage(depl *appsv1.Deployment, name string, image string) bool {
+	for _, container := range depl.Spec.Template.Spec.Containers {
+		if container.Name == name {
+			return container.Image == image
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	k8sInformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/util/autoscaler"
+	hpautils "github.com/fission/fission/pkg/executor/util/hpa"
+	rolloututils "github.com/fission/fission/pkg/executor/util/rollout"
+	fClient "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	fissionlisters "github.com/fission/fission/pkg/generated/listers/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/leaderelection"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestResync(t *testing.T) {
+	ctx := t.Context()
+	logger := loggerfactory.GetLogger()
+
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "tenant"},
+		Spec:       fv1.EnvironmentSpec{Runtime: fv1.Runtime{Image: "python:3"}},
+	}
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "tenant", UID: "8c0d9f2e-6f8a-4c63-a1a4-3c4c1d1a9f12", ResourceVersion: "2"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Name: "python", Namespace: "tenant"},
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeNewdeploy},
+			},
+		},
+	}
+	deleted := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "tenant", UID: "8c0d9f2e-6f8a-4c63-a1a4-3c4c1d1a9f13"},
+	}
+
+	deploy := &NewDeploy{
+		logger:      logger,
+		nsResolver:  &utils.NamespaceResolver{},
+		instanceID:  "test",
+		fsCache:     fscache.MakeFunctionServiceCache(logger),
+		autoscalers: make(map[k8sTypes.UID]*autoscaler.Autoscaler),
+	}
+	functionDepl := func(fn *fv1.Function) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        deploy.getObjName(fn),
+				Namespace:   "tenant",
+				Labels:      deploy.getDeployLabels(fn.ObjectMeta, env.ObjectMeta),
+				Annotations: deploy.getDeployAnnotations(fn.ObjectMeta, env.ObjectMeta),
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "python", Image: "python:3"}}},
+				},
+			},
+		}
+	}
+	kubernetesClient := fake.NewSimpleClientset(functionDepl(fn), functionDepl(deleted))
+	fissionClient := fClient.NewSimpleClientset(env)
+	factory := k8sInformers.NewSharedInformerFactoryWithOptions(kubernetesClient, time.Minute,
+		k8sInformers.WithNamespace("tenant"))
+	deploy.kubernetesClient = kubernetesClient
+	deploy.fissionClient = fissionClient
+	deploy.hpaops = hpautils.NewHpaOperations(logger, kubernetesClient, "test")
+	deploy.rolloutops = rolloututils.NewRolloutOperations(logger, kubernetesClient, fissionClient)
+	deploy.deplLister = map[string]appslisters.DeploymentLister{"tenant": factory.Apps().V1().Deployments().Lister()}
+	deploy.deplListerSynced = map[string]k8sCache.InformerSynced{"tenant": factory.Apps().V1().Deployments().Informer().HasSynced}
+	factory.Start(ctx.Done())
+
+	indexer := k8sCache.NewIndexer(k8sCache.MetaNamespaceKeyFunc, k8sCache.Indexers{k8sCache.NamespaceIndex: k8sCache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(fn))
+	deploy.fnLister = map[string]fissionlisters.FunctionLister{"tenant": fissionlisters.NewFunctionLister(indexer)}
+
+	t.Run("non-leaders don't create objects of functions changed to newdeploy", func(t *testing.T) {
+		t.Setenv(leaderelection.ENV_LEADER_ELECTION_NAMESPACE, "fission")
+		leader, err := leaderelection.New(zap.NewNop(), kubernetesClient, "executor", "replica-a")
+		require.NoError(t, err)
+		deploy.leader = leader
+		t.Cleanup(func() { deploy.leader = nil })
+
+		changed := fn.DeepCopy()
+		changed.ObjectMeta.UID = "8c0d9f2e-6f8a-4c63-a1a4-3c4c1d1a9f14"
+		changed.ObjectMeta.ResourceVersion = "3"
+		old := changed.DeepCopy()
+		old.ObjectMeta.ResourceVersion = "1"
+		old.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType = fv1.ExecutorTypePoolmgr
+		require.NoError(t, deploy.updateFunction(ctx, old, changed))
+		_, err = kubernetesClient.AppsV1().Deployments("tenant").Get(ctx, deploy.getObjName(changed), metav1.GetOptions{})
+		require.True(t, k8sErrs.IsNotFound(err))
+	})
+
+	t.Run("leader cleans up objects of removed functions", func(t *testing.T) {
+		deploy.resync(ctx)
+		_, err := kubernetesClient.AppsV1().Deployments("tenant").Get(ctx, deploy.getObjName(deleted), metav1.GetOptions{})
+		require.True(t, k8sErrs.IsNotFound(err))
+		depl, err := kubernetesClient.AppsV1().Deployments("tenant").Get(ctx, deploy.getObjName(fn), metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "2", depl.Annotations[fv1.FUNCTION_RESOURCE_VERSION])
+	})
+}
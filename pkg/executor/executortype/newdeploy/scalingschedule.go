@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// applyScalingSchedules keeps the HPAs and deployments of functions with scaling schedules
+// at the scale of the window active now. Deployments are scaled up to the
+// minimum scale of a window when it starts, scaling them down after it ends
+// is left to the HPA and the idle object reaper.
+//
+// When namespace quotas are enforced, the scale of all functions is limited
+// to the replicas left by the quota of their namespace, so that their HPAs
+// don't scale them beyond it.
+func (deploy *NewDeploy) applyScalingSchedules(ctx context.Context) {
+	if !deploy.leader.IsLeader() {
+		return
+	}
+
+	now := time.Now()
+	for namespace, lister := range deploy.fnLister {
+		fns, err := lister.List(labels.Everything())
+		if err != nil {
+			deploy.logger.Error("error listing functions", zap.Error(err), zap.String("namespace", namespace))
+			continue
+		}
+		for _, fn := range fns {
+			if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy {
+				continue
+			}
+			if len(fn.Spec.InvokeStrategy.ExecutionStrategy.ScalingSchedules) == 0 && deploy.quota == nil {
+				continue
+			}
+
+			ns := deploy.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+			objName := deploy.getObjName(fn)
+			deplLister, ok := deploy.deplLister[ns]
+			if !ok {
+				continue
+			}
+			depl, err := deplLister.Deployments(ns).Get(objName)
+			if err != nil {
+				// the deployment is created on the first request of the function
+				if !k8sErrs.IsNotFound(err) {
+					deploy.logger.Error("error getting function deployment", zap.Error(err), zap.String("deployment", objName))
+				}
+				continue
+			}
+
+			es, err := deploy.quota.LimitStrategy(ctx, fn.ObjectMeta.Namespace, depl, fn.Spec.InvokeStrategy.ExecutionStrategy.ScaledAt(now))
+			if err != nil {
+				deploy.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+			}
+			// functions scaling on concurrency get the scale of the window
+			// from doConcurrencyScaling
+			if !isConcurrencyScaling(fn) {
+				err = deploy.hpaops.UpdateHpaScale(ctx, ns, objName, &es)
+				if err != nil {
+					if !k8sErrs.IsNotFound(err) {
+						deploy.logger.Error("error updating HPA scale", zap.Error(err), zap.String("hpa", objName))
+					}
+					continue
+				}
+			}
+
+			if depl.Spec.Replicas != nil && *depl.Spec.Replicas >= int32(es.MinScale) {
+				continue
+			}
+			replicas, err := deploy.quota.ScaleLimit(ctx, fn.ObjectMeta.Namespace, depl, int32(es.MinScale))
+			if err != nil {
+				deploy.logger.Warn("error limiting function scale to namespace quota", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+				continue
+			}
+			if depl.Spec.Replicas != nil && *depl.Spec.Replicas >= replicas {
+				continue
+			}
+
+			deploy.logger.Debug("scaling function up to the minimum scale of its scaling schedule",
+				zap.String("function", fn.ObjectMeta.Name),
+				zap.String("namespace", fn.ObjectMeta.Namespace),
+				zap.Int32("replicas", replicas))
+			err = deploy.scaleDeployment(ctx, ns, objName, replicas)
+			if err != nil {
+				deploy.logger.Error("error scaling function deployment", zap.Error(err), zap.String("deployment", objName))
+			}
+		}
+	}
+}
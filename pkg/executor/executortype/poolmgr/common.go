@@ -16,7 +16,11 @@ limitations under the License.
 
 package poolmgr
 
-import fv1 "github.com/fission/fission/pkg/apis/core/v1"
+import (
+	apiv1 "k8s.io/api/core/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
 
 func getEnvPoolSize(env *fv1.Environment) int32 {
 	var poolsize int32
@@ -28,6 +32,38 @@ func getEnvPoolSize(env *fv1.Environment) int32 {
 	return poolsize
 }
 
+// getPoolClasses returns the pool classes of the environment, starting with
+// the empty class of the default pool.
+func getPoolClasses(env *fv1.Environment) []string {
+	classes := []string{""}
+	for _, class := range env.Spec.PoolClasses {
+		classes = append(classes, class.Name)
+	}
+	return classes
+}
+
+// getPoolSize returns the pool size of the class of the environment. The
+// empty class is the default pool of the environment.
+func getPoolSize(env *fv1.Environment, class string) int32 {
+	if len(class) == 0 {
+		return getEnvPoolSize(env)
+	}
+	poolClass := env.GetPoolClass(class)
+	if poolClass == nil {
+		return 0
+	}
+	return int32(poolClass.Poolsize)
+}
+
+// getPoolResources returns the resources of pods in the pool of the class of
+// the environment.
+func getPoolResources(env *fv1.Environment, class string) apiv1.ResourceRequirements {
+	if poolClass := env.GetPoolClass(class); poolClass != nil {
+		return poolClass.Resources
+	}
+	return env.Spec.Resources
+}
+
 func getSpecializedPodLabels(env *fv1.Environment) map[string]string {
 	specialPodLabels := make(map[string]string)
 	specialPodLabels[fv1.EXECUTOR_TYPE] = string(fv1.ExecutorTypePoolmgr)
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/dchest/uniuri"
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
@@ -46,11 +47,14 @@ import (
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/metrics"
 	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/leaderelection"
 	"github.com/fission/fission/pkg/utils/maps"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
@@ -61,6 +65,7 @@ type (
 		logger                   *zap.Logger
 		lock                     sync.Mutex
 		env                      *fv1.Environment
+		class                    string                        // pool class, empty for the default pool of the environment
 		deployment               *appsv1.Deployment            // kubernetes deployment
 		fnNamespace              string                        // namespace to keep our resources
 		podReadyTimeout          time.Duration                 // timeout for generic pods to become ready
@@ -80,6 +85,9 @@ type (
 		instanceID               string // poolmgr instance id
 		podSpecPatch             *apiv1.PodSpec
 		enableOwnerReferences    bool
+		leader                   *leaderelection.Elector // nil when running with a single executor replica
+		pkgNodes                 *packageNodes           // nil when the node-local package cache is disabled
+		recorder                 *events.Recorder
 		// TODO: move this field into fsCache
 		podFSVCMap sync.Map
 	}
@@ -92,14 +100,20 @@ func MakeGenericPool(
 	kubernetesClient kubernetes.Interface,
 	metricsClient metricsclient.Interface,
 	env *fv1.Environment,
+	class string,
 	fnNamespace string,
 	fsCache *fscache.FunctionServiceCache,
 	fetcherConfig *fetcherConfig.Config,
 	instanceID string,
 	enableIstio bool,
-	podSpecPatch *apiv1.PodSpec) *GenericPool {
+	podSpecPatch *apiv1.PodSpec,
+	leader *leaderelection.Elector,
+	recorder *events.Recorder) *GenericPool {
 
 	gpLogger := logger.Named("generic_pool")
+	if len(class) > 0 {
+		gpLogger = gpLogger.With(zap.String("poolClass", class))
+	}
 
 	podReadyTimeoutStr := os.Getenv("POD_READY_TIMEOUT")
 	podReadyTimeout, err := time.ParseDuration(podReadyTimeoutStr)
@@ -118,6 +132,7 @@ func MakeGenericPool(
 	gp := &GenericPool{
 		logger:                   gpLogger,
 		env:                      env,
+		class:                    class,
 		fissionClient:            fissionClient,
 		kubernetesClient:         kubernetesClient,
 		metricsClient:            metricsClient,
@@ -133,8 +148,13 @@ func MakeGenericPool(
 		podFSVCMap:               sync.Map{},
 		podSpecPatch:             podSpecPatch,
 		enableOwnerReferences:    utils.IsOwnerReferencesEnabled(),
+		leader:                   leader,
+		recorder:                 recorder,
 		lock:                     sync.Mutex{},
 	}
+	if fetcherConfig.PackageCacheEnabled() {
+		gp.pkgNodes = makePackageNodes()
+	}
 
 	gp.runtimeImagePullPolicy = utils.GetImagePullPolicy(os.Getenv("RUNTIME_IMAGE_PULL_POLICY"))
 
@@ -162,6 +182,9 @@ func (gp *GenericPool) getEnvironmentPoolLabels(env *fv1.Environment) map[string
 	envLabels[fv1.ENVIRONMENT_NAMESPACE] = env.ObjectMeta.Namespace
 	envLabels[fv1.ENVIRONMENT_UID] = string(env.ObjectMeta.UID)
 	envLabels["managed"] = "true" // this allows us to easily find pods managed by the deployment
+	if len(gp.class) > 0 {
+		envLabels[fv1.POOL_CLASS] = gp.class
+	}
 	return envLabels
 }
 
@@ -236,8 +259,10 @@ func (gp *GenericPool) updateCPUUtilizationSvc(ctx context.Context) {
 }
 
 // choosePod picks a ready pod from the pool and relabels it, waiting if necessary.
-// returns the key and pod API object.
-func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]string) (string, *apiv1.Pod, error) {
+// Ready pods on the preferred nodes are picked first, if there are any.
+// returns the key and pod API object, the key is empty if the pod wasn't
+// taken from the ready pod queue.
+func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]string, preferredNodes map[string]struct{}) (string, *apiv1.Pod, error) {
 	startTime := time.Now()
 	podTimeout := startTime.Add(gp.podReadyTimeout)
 	deadline, ok := ctx.Deadline()
@@ -253,6 +278,11 @@ func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]strin
 		logger.Error("timed out waiting for ready pod lister synced")
 		return "", nil, errors.New("ready pod lister not synced")
 	}
+	if pod := gp.choosePodOnNodes(ctx, newLabels, preferredNodes); pod != nil {
+		logger.Info("chose pod on preferred node", zap.Any("labels", newLabels),
+			zap.String("pod", pod.Name), zap.String("node", pod.Spec.NodeName), zap.Duration("elapsed_time", time.Since(startTime)))
+		return "", pod, nil
+	}
 	for {
 		// Retries took too long, error out.
 		if time.Now().After(podTimeout) {
@@ -285,6 +315,12 @@ func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]strin
 			gp.readyPodQueue.Done(key)
 			continue
 		}
+		if pod.Labels[fv1.POOL_CLASS] != gp.class {
+			// the selector of the default pool matches the pods of the
+			// pool classes too, they are picked by their own pools.
+			gp.readyPodQueue.Done(key)
+			continue
+		}
 		if utils.IsPodTerminated(pod) {
 			logger.Error("pod is terminated", zap.String("key", key))
 			gp.readyPodQueue.Done(key)
@@ -301,49 +337,20 @@ func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]strin
 		otelUtils.SpanTrackEvent(ctx, "foundPod", otelUtils.GetAttributesForPod(chosenPod)...)
 
 		if gp.env.Spec.AllowedFunctionsPerContainer != fv1.AllowedFunctionsPerContainerInfinite {
-			// Relabel.  If the pod already got picked and
-			// modified, this should fail; in that case just
-			// retry.
-			// Append executor instance id to pod annotations to
-			// indicate this pod is managed by this executor.
-			annotations := gp.getDeployAnnotations(gp.env)
-			patch := map[string]interface{}{
-				"metadata": map[string]interface{}{
-					"annotations": annotations,
-					"labels":      newLabels,
-				},
-			}
-			patchBytes, _ := json.Marshal(patch)
-			logger.Info("relabel pod", zap.String("pod", string((patchBytes))))
-			newPod, err := gp.kubernetesClient.CoreV1().Pods(chosenPod.Namespace).Patch(ctx, chosenPod.Name, k8sTypes.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
-			if err != nil && errors.Is(err, context.Canceled) {
+			patched, err := gp.relabelPod(ctx, chosenPod, newLabels)
+			if !patched && errors.Is(err, context.Canceled) {
 				// ending retry loop when the request canceled
 				gp.readyPodQueue.Done(key)
 				gp.readyPodQueue.AddAfter(key, expoDelay)
 				return "", nil, fmt.Errorf("failed to relabel pod: %s", err)
-			} else if err != nil {
+			} else if !patched {
 				logger.Error("failed to relabel pod", zap.Error(err), zap.String("pod", chosenPod.Name), zap.Duration("delay", expoDelay))
 				gp.readyPodQueue.Done(key)
 				gp.readyPodQueue.AddAfter(key, expoDelay)
 				expoDelay *= 2
 				continue
-			}
-			otelUtils.SpanTrackEvent(ctx, "podRelabel", otelUtils.GetAttributesForPod(chosenPod)...)
-
-			// With StrategicMergePatchType, the client-go sometimes return
-			// nil error and the labels & annotations remain the same.
-			// So we have to check both of them to ensure the patch success.
-			for k, v := range newLabels {
-				if newPod.Labels[k] != v {
-					return "", nil, fmt.Errorf("value of necessary labels '%s' mismatch: want '%s', get '%v'",
-						k, v, newPod.Labels[k])
-				}
-			}
-			for k, v := range annotations {
-				if newPod.Annotations[k] != v {
-					return "", nil, fmt.Errorf("value of necessary annotations '%s' mismatch: want '%s', get '%v'",
-						k, v, newPod.Annotations[k])
-				}
+			} else if err != nil {
+				return "", nil, err
 			}
 		}
 
@@ -354,6 +361,54 @@ func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]strin
 	}
 }
 
+// relabelPod marks the pool pod as taken by the function. If the pod already
+// got picked and modified by another request the patch fails, patched is false
+// and the caller may retry. A non-nil error with patched set means the patch
+// didn't apply the labels and annotations.
+func (gp *GenericPool) relabelPod(ctx context.Context, chosenPod *apiv1.Pod, newLabels map[string]string) (patched bool, err error) {
+	logger := otelUtils.LoggerWithTraceID(ctx, gp.logger)
+
+	// Append executor instance id to pod annotations to
+	// indicate this pod is managed by this executor.
+	annotations := gp.getDeployAnnotations(gp.env)
+	metadata := map[string]interface{}{
+		"annotations": annotations,
+		"labels":      newLabels,
+	}
+	if gp.leader != nil {
+		// Other executor replicas may pick the same pod, the resource
+		// version makes the patch fail for all of them but one.
+		metadata["resourceVersion"] = chosenPod.ObjectMeta.ResourceVersion
+	}
+	patch := map[string]interface{}{
+		"metadata": metadata,
+	}
+	patchBytes, _ := json.Marshal(patch)
+	logger.Info("relabel pod", zap.String("pod", string((patchBytes))))
+	newPod, err := gp.kubernetesClient.CoreV1().Pods(chosenPod.Namespace).Patch(ctx, chosenPod.Name, k8sTypes.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	if err != nil {
+		return false, err
+	}
+	otelUtils.SpanTrackEvent(ctx, "podRelabel", otelUtils.GetAttributesForPod(chosenPod)...)
+
+	// With StrategicMergePatchType, the client-go sometimes return
+	// nil error and the labels & annotations remain the same.
+	// So we have to check both of them to ensure the patch success.
+	for k, v := range newLabels {
+		if newPod.Labels[k] != v {
+			return true, fmt.Errorf("value of necessary labels '%s' mismatch: want '%s', get '%v'",
+				k, v, newPod.Labels[k])
+		}
+	}
+	for k, v := range annotations {
+		if newPod.Annotations[k] != v {
+			return true, fmt.Errorf("value of necessary annotations '%s' mismatch: want '%s', get '%v'",
+				k, v, newPod.Annotations[k])
+		}
+	}
+	return true, nil
+}
+
 func (gp *GenericPool) labelsForFunction(metadata *metav1.ObjectMeta) map[string]string {
 	label := gp.getEnvironmentPoolLabels(gp.env)
 	label[fv1.FUNCTION_NAME] = metadata.Name
@@ -410,6 +465,8 @@ func (gp *GenericPool) getFetcherURL(podIP string) string {
 // (via fetcher), and calls the function-run container to load it, resulting in a
 // specialized pod.
 func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv1.Function) error {
+	ctx, span := otel.Tracer("executor").Start(ctx, "GenericPool/specializePod")
+	defer span.End()
 	logger := otelUtils.LoggerWithTraceID(ctx, gp.logger)
 
 	// for fetcher we don't need to create a service, just talk to the pod directly
@@ -464,10 +521,13 @@ func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv
 
 	// Fetcher will download user function to share volume of pod, and
 	// invoke environment specialize api for pod specialization.
-	err := fetcherClient.MakeClient(gp.logger, fetcherURL).Specialize(ctx, &specializeReq)
+	resp, err := fetcherClient.MakeClient(gp.logger, fetcherURL).Specialize(ctx, &specializeReq)
 	if err != nil {
 		return err
 	}
+	for _, phase := range resp.Phases {
+		metrics.ObserveColdStartPhase(&fn.ObjectMeta, phase.Name, phase.Duration)
+	}
 	otelUtils.SpanTrackEvent(ctx, "specializedPod", otelUtils.GetAttributesForPod(pod)...)
 	return nil
 }
@@ -545,16 +605,25 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 		}
 	}
 
-	key, pod, err := gp.choosePod(ctx, funcLabels)
+	pkg := packageKey(fn)
+	start := time.Now()
+	chooseCtx, span := otel.Tracer("executor").Start(ctx, "GenericPool/choosePod")
+	key, pod, err := gp.choosePod(chooseCtx, funcLabels, gp.pkgNodes.get(pkg))
+	span.End()
 	if err != nil {
 		return nil, err
 	}
-	gp.readyPodQueue.Done(key)
+	metrics.ObserveColdStartPhase(&fn.ObjectMeta, metrics.PhaseChoosePod, time.Since(start))
+	if len(key) > 0 {
+		gp.readyPodQueue.Done(key)
+	}
 	err = gp.specializePod(ctx, pod, fn)
 	if err != nil {
 		go gp.scheduleDeletePod(context.Background(), pod.ObjectMeta.Name)
 		return nil, err
 	}
+	specializedAt := time.Now()
+	gp.pkgNodes.add(pkg, pod.Spec.NodeName)
 	logger.Info("specialized pod", zap.String("pod", pod.ObjectMeta.Name), zap.String("podNamespace", pod.ObjectMeta.Namespace), zap.String("podIP", pod.Status.PodIP))
 
 	var svcHost string
@@ -585,9 +654,11 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 	}
 
 	otelUtils.SpanTrackEvent(ctx, "addFunctionLabel", otelUtils.GetAttributesForPod(pod)...)
-	// patch svc-host and resource version to the pod annotations for new executor to adopt the pod
-	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s","%s":"%s"}}}`,
-		fv1.ANNOTATION_SVC_HOST, svcHost, fv1.FUNCTION_RESOURCE_VERSION, fn.ObjectMeta.ResourceVersion)
+	// patch svc-host, resource version and specialization time to the pod
+	// annotations for new executor to adopt the pod
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s","%s":"%s","%s":"%s"}}}`,
+		fv1.ANNOTATION_SVC_HOST, svcHost, fv1.FUNCTION_RESOURCE_VERSION, fn.ObjectMeta.ResourceVersion,
+		fv1.ANNOTATION_SPECIALIZATION_TIME, specializedAt.UTC().Format(time.RFC3339Nano))
 	p, err := gp.kubernetesClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
 	if err != nil {
 		// just log the error since it won't affect the function serving
@@ -607,17 +678,9 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 			UID:             pod.ObjectMeta.UID,
 		},
 	}
-	cpuUsage := resource.MustParse("0m")
-	for _, container := range pod.Spec.Containers {
-		val := *container.Resources.Limits.Cpu()
-		cpuUsage.Add(val)
-	}
-
-	// set cpuLimit to 85th percentage of the cpuUsage
-	cpuLimit, err := gp.getPercent(cpuUsage, 0.85)
+	cpuLimit, err := getPodCPULimit(pod)
 	if err != nil {
 		logger.Error("failed to get 85 of CPU usage", zap.Error(err))
-		cpuLimit = cpuUsage
 	}
 	logger.Debug("cpuLimit set to", zap.Any("cpulimit", cpuLimit))
 
@@ -630,13 +693,15 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 		KubernetesObjects: kubeObjRefs,
 		Executor:          fv1.ExecutorTypePoolmgr,
 		CPULimit:          cpuLimit,
-		Ctime:             time.Now(),
-		Atime:             time.Now(),
+		PackageRef:        fn.Spec.Package.PackageRef,
+		Ctime:             specializedAt,
+		Atime:             specializedAt,
 	}
 
 	gp.fsCache.PodToFsvc.Store(pod.GetObjectMeta().GetName(), fsvc)
 	gp.podFSVCMap.Store(pod.ObjectMeta.Name, []interface{}{crd.CacheKeyURGFromMeta(fsvc.Function), fsvc.Address})
-	gp.fsCache.AddFunc(ctx, *fsvc, fn.GetRequestPerPod(), fn.GetRetainPods())
+	gp.fsCache.SetRecyclePolicy(crd.CacheKeyURGFromMeta(fsvc.Function), recyclePolicy(fn))
+	gp.fsCache.AddFunc(ctx, *fsvc, fn.GetRequestPerPod(), getRetainPods(fn, time.Now()))
 
 	logger.Info("added function service",
 		zap.String("pod", pod.ObjectMeta.Name),
@@ -649,17 +714,45 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 }
 
 // getPercent returns  x percent of the quantity i.e multiple it x/100
-func (gp *GenericPool) getPercent(cpuUsage resource.Quantity, percentage float64) (resource.Quantity, error) {
+func getPercent(cpuUsage resource.Quantity, percentage float64) (resource.Quantity, error) {
 	val := int64(math.Ceil(float64(cpuUsage.MilliValue()) * percentage))
 	return resource.ParseQuantity(fmt.Sprintf("%dm", val))
 }
 
+// getPodCPULimit returns the CPU usage above which a specialized pod is
+// considered busy, 85th percentage of the pod's CPU limit. On error the
+// full CPU limit is returned.
+func getPodCPULimit(pod *apiv1.Pod) (resource.Quantity, error) {
+	cpuUsage := resource.MustParse("0m")
+	for _, container := range pod.Spec.Containers {
+		val := *container.Resources.Limits.Cpu()
+		cpuUsage.Add(val)
+	}
+	cpuLimit, err := getPercent(cpuUsage, 0.85)
+	if err != nil {
+		return cpuUsage, err
+	}
+	return cpuLimit, nil
+}
+
+// refreshEnv updates the pool environment without updating the deployment.
+func (gp *GenericPool) refreshEnv(env *fv1.Environment) {
+	gp.lock.Lock()
+	defer gp.lock.Unlock()
+	gp.env = env
+}
+
 // destroys the pool -- the deployment, replicaset and pods
 func (gp *GenericPool) destroy(ctx context.Context) error {
 	gp.lock.Lock()
 	defer gp.lock.Unlock()
 	close(gp.stopReadyPodControllerCh)
 
+	// the deployment is deleted by the leader
+	if !gp.leader.IsLeader() {
+		return nil
+	}
+
 	deletePropagation := metav1.DeletePropagationBackground
 	delOpt := metav1.DeleteOptions{
 		PropagationPolicy: &deletePropagation,
@@ -19,6 +19,7 @@ package poolmgr
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"go.uber.org/zap"
@@ -29,11 +30,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/utils/maps"
 )
 
-// getPoolName returns a unique name of an environment
-func getPoolName(env *fv1.Environment) string {
+// getPoolName returns a unique name of the pool of the class of an environment
+func getPoolName(env *fv1.Environment, class string) string {
 	// TODO: get rid of resource version here
 	var envPodName string
 
@@ -53,7 +56,11 @@ func getPoolName(env *fv1.Environment) string {
 		envPodName = env.ObjectMeta.Name[:nameLength] + "-" + env.ObjectMeta.Namespace[:namespaceLength]
 	}
 
-	return "poolmgr-" + strings.ToLower(fmt.Sprintf("%s-%s", envPodName, env.ResourceVersion))
+	poolName := "poolmgr-" + strings.ToLower(fmt.Sprintf("%s-%s", envPodName, env.ResourceVersion))
+	if len(class) > 0 {
+		poolName += "-" + class
+	}
+	return poolName
 }
 
 func (gp *GenericPool) genDeploymentMeta(env *fv1.Environment) metav1.ObjectMeta {
@@ -71,7 +78,7 @@ func (gp *GenericPool) genDeploymentMeta(env *fv1.Environment) metav1.ObjectMeta
 		}
 	}
 	return metav1.ObjectMeta{
-		Name:            getPoolName(env),
+		Name:            getPoolName(env, gp.class),
 		Labels:          deployLabels,
 		Annotations:     deployAnnotations,
 		OwnerReferences: ownerReferences,
@@ -101,10 +108,9 @@ func (gp *GenericPool) genDeploymentSpec(env *fv1.Environment) (*appsv1.Deployme
 		podAnnotations["sidecar.istio.io/inject"] = "false"
 	}
 
-	podLabels := env.ObjectMeta.Labels
-	if podLabels == nil {
-		podLabels = make(map[string]string)
-	}
+	// copy the labels, the pool labels of a class must not leak into the
+	// environment shared with the other pools
+	podLabels := maps.CopyStringMap(env.ObjectMeta.Labels)
 
 	for k, v := range deployLabels {
 		podLabels[k] = v
@@ -115,7 +121,7 @@ func (gp *GenericPool) genDeploymentSpec(env *fv1.Environment) (*appsv1.Deployme
 		Image:                  env.Spec.Runtime.Image,
 		ImagePullPolicy:        gp.runtimeImagePullPolicy,
 		TerminationMessagePath: "/dev/termination-log",
-		Resources:              env.Spec.Resources,
+		Resources:              getPoolResources(env, gp.class),
 		// Pod is removed from endpoints list for service when it's
 		// state became "Termination". We used preStop hook as the
 		// workaround for connection draining since pod maybe shutdown
@@ -174,7 +180,7 @@ func (gp *GenericPool) genDeploymentSpec(env *fv1.Environment) (*appsv1.Deployme
 
 	pod.Spec = *(util.ApplyImagePullSecret(env.Spec.ImagePullSecret, pod.Spec))
 
-	poolsize := getEnvPoolSize(env)
+	poolsize := getPoolSize(env, gp.class)
 	switch env.Spec.AllowedFunctionsPerContainer {
 	case fv1.AllowedFunctionsPerContainerInfinite:
 		poolsize = 1
@@ -212,6 +218,20 @@ func (gp *GenericPool) genDeploymentSpec(env *fv1.Environment) (*appsv1.Deployme
 		}
 		deploymentSpec.Template.Spec = *newPodSpec
 	}
+
+	// scheduling constraints of the class are added last
+	if class := env.GetPoolClass(gp.class); class != nil {
+		podSpec := &deploymentSpec.Template.Spec
+		if len(class.NodeSelector) > 0 {
+			// the node selector may be shared with the environment
+			nodeSelector := maps.CopyStringMap(podSpec.NodeSelector)
+			for k, v := range class.NodeSelector {
+				nodeSelector[k] = v
+			}
+			podSpec.NodeSelector = nodeSelector
+		}
+		podSpec.Tolerations = append(slices.Clone(podSpec.Tolerations), class.Tolerations...)
+	}
 	return &deploymentSpec, nil
 }
 
@@ -278,7 +298,7 @@ func (gp *GenericPool) updatePoolDeployment(ctx context.Context, env *fv1.Enviro
 	deployMeta.Name = gp.deployment.Name
 	newDeployment.ObjectMeta = deployMeta
 
-	poolsize := getEnvPoolSize(env)
+	poolsize := getPoolSize(env, gp.class)
 	switch env.Spec.AllowedFunctionsPerContainer {
 	case fv1.AllowedFunctionsPerContainerInfinite:
 		poolsize = 1
@@ -288,6 +308,8 @@ func (gp *GenericPool) updatePoolDeployment(ctx context.Context, env *fv1.Enviro
 	depl, err := gp.kubernetesClient.AppsV1().Deployments(gp.fnNamespace).Update(ctx, newDeployment, metav1.UpdateOptions{})
 	if err != nil {
 		logger.Error("error updating deployment in kubernetes", zap.Error(err), zap.String("deployment", depl.Name))
+		gp.recorder.EnvironmentEvent(&env.ObjectMeta, apiv1.EventTypeWarning, events.ReasonPoolUpdated,
+			"Error updating pool deployment %s: %v", gp.deployment.Name, err)
 		return err
 	}
 	// possible concurrency issue here as
@@ -296,5 +318,7 @@ func (gp *GenericPool) updatePoolDeployment(ctx context.Context, env *fv1.Enviro
 	gp.env = env
 	gp.deployment = depl
 	logger.Info("Updated deployment for pool", zap.String("deployment", depl.Name))
+	gp.recorder.EnvironmentEvent(&env.ObjectMeta, apiv1.EventTypeNormal, events.ReasonPoolUpdated,
+		"Updated pool deployment %s/%s with %d replicas", depl.Namespace, depl.Name, poolsize)
 	return nil
 }
@@ -20,16 +20,23 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
 )
 
 func TestGetPoolName(t *testing.T) {
 	tests := []struct {
-		name string
-		env  *fv1.Environment
-		want string
+		name  string
+		env   *fv1.Environment
+		class string
+		want  string
 	}{
 		{
 			"Under character limit",
@@ -44,6 +51,7 @@ func TestGetPoolName(t *testing.T) {
 					ResourceVersion: "2517",
 				},
 			},
+			"",
 			"poolmgr-test-testns-2517",
 		},
 		{
@@ -59,12 +67,29 @@ func TestGetPoolName(t *testing.T) {
 					ResourceVersion: "2518",
 				},
 			},
+			"",
 			"poolmgr-justtryingtoincrea-checkingifthegetpo-2518",
 		},
+		{
+			"Pool class",
+			&fv1.Environment{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       fv1.CRD_NAME_ENVIRONMENT,
+					APIVersion: fv1.CRD_VERSION,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					Namespace:       "testns",
+					ResourceVersion: "2519",
+				},
+			},
+			"highmem",
+			"poolmgr-test-testns-2519-highmem",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := getPoolName(tt.env); got != tt.want {
+			if got := getPoolName(tt.env, tt.class); got != tt.want {
 				t.Errorf("getPoolName() = %s, want = %s len(getPoolName()) = %x len(want) = %x", got, tt.want, len(got), len(tt.want))
 			} else {
 				fmt.Printf("getPoolName() = %s,length of string = %x", got, len(got))
@@ -72,3 +97,86 @@ func TestGetPoolName(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolClassDeploymentSpec(t *testing.T) {
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "python",
+			Namespace:       "default",
+			ResourceVersion: "100",
+			Labels:          map[string]string{"team": "data"},
+		},
+		Spec: fv1.EnvironmentSpec{
+			Version:  3,
+			Poolsize: 3,
+			Runtime: fv1.Runtime{
+				Image: "fission/python-env",
+				PodSpec: &apiv1.PodSpec{
+					NodeSelector: map[string]string{"zone": "a"},
+				},
+			},
+			Resources: apiv1.ResourceRequirements{
+				Requests: apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("128Mi")},
+			},
+			PoolClasses: []fv1.PoolClass{
+				{
+					Name:     "highmem",
+					Poolsize: 1,
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("2Gi")},
+					},
+					NodeSelector: map[string]string{"pool": "highmem"},
+					Tolerations: []apiv1.Toleration{
+						{Key: "highmem", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule},
+					},
+				},
+			},
+		},
+	}
+	cfg, err := fetcherConfig.MakeFetcherConfig("/userfunc")
+	require.NoError(t, err)
+
+	newPool := func(class string) *GenericPool {
+		return &GenericPool{
+			logger:        loggerfactory.GetLogger(),
+			env:           env,
+			class:         class,
+			fetcherConfig: cfg,
+		}
+	}
+
+	// the order of the merged containers isn't stable
+	runtimeMemory := func(spec *appsv1.DeploymentSpec) string {
+		for _, c := range spec.Template.Spec.Containers {
+			if c.Name == env.ObjectMeta.Name {
+				return c.Resources.Requests.Memory().String()
+			}
+		}
+		t.Fatal("runtime container not found")
+		return ""
+	}
+
+	spec, err := newPool("").genDeploymentSpec(env)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), *spec.Replicas)
+	require.NotContains(t, spec.Selector.MatchLabels, fv1.POOL_CLASS)
+	require.Equal(t, map[string]string{"zone": "a"}, spec.Template.Spec.NodeSelector)
+	require.Empty(t, spec.Template.Spec.Tolerations)
+	require.Equal(t, "128Mi", runtimeMemory(spec))
+
+	spec, err = newPool("highmem").genDeploymentSpec(env)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), *spec.Replicas)
+	require.Equal(t, "highmem", spec.Selector.MatchLabels[fv1.POOL_CLASS])
+	require.Equal(t, "highmem", spec.Template.Labels[fv1.POOL_CLASS])
+	require.Equal(t, map[string]string{"zone": "a", "pool": "highmem"}, spec.Template.Spec.NodeSelector)
+	require.Len(t, spec.Template.Spec.Tolerations, 1)
+	require.Equal(t, "2Gi", runtimeMemory(spec))
+
+	// the environment is not modified
+	require.Equal(t, map[string]string{"zone": "a"}, env.Spec.Runtime.PodSpec.NodeSelector)
+	require.Equal(t, map[string]string{"team": "data"}, env.ObjectMeta.Labels)
+
+	require.Equal(t, []string{"", "highmem"}, getPoolClasses(env))
+	require.Equal(t, int32(0), getPoolSize(env, "unknown"))
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -44,15 +46,20 @@ import (
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/cache"
 	"github.com/fission/fission/pkg/crd"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/events"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/reaper"
 	executorUtils "github.com/fission/fission/pkg/executor/util"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
+	flisterv1 "github.com/fission/fission/pkg/generated/listers/core/v1"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/leaderelection"
 	"github.com/fission/fission/pkg/utils/manager"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
@@ -66,13 +73,19 @@ type requestType int
 const (
 	GET_POOL requestType = iota
 	CLEANUP_POOL
+	CLEANUP_STALE_POOLS
+	REFRESH_POOL
 )
 
+// forcedReapMinIdleTime is how long a pod must have been idle before it is
+// reaped regardless of its idle timeout, so that pods in use are never reaped.
+const forcedReapMinIdleTime = 5 * time.Second
+
 type (
 	GenericPoolManager struct {
 		logger *zap.Logger
 
-		pools            map[k8sTypes.UID]*GenericPool
+		pools            map[poolKey]*GenericPool
 		kubernetesClient kubernetes.Interface
 		metricsClient    metricsclient.Interface
 		nsResolver       *utils.NamespaceResolver
@@ -92,17 +105,40 @@ type (
 		// podListerSynced returns true if the pod store has been synced at least once.
 		podListerSynced map[string]k8sCache.InformerSynced
 
+		fnLister       map[string]flisterv1.FunctionLister
+		fnListerSynced map[string]k8sCache.InformerSynced
+
+		// podInformer is only set when running with multiple executor
+		// replicas, to pick up pods specialized by other replicas.
+		podInformer map[string]k8sCache.SharedIndexInformer
+
+		// leader is nil when running with a single executor replica
+		leader *leaderelection.Elector
+
+		// quota is nil when namespace quotas are disabled
+		quota *quota.Enforcer
+
+		recorder *events.Recorder
+
 		defaultIdlePodReapTime time.Duration
+		idlePolicies           *reaper.IdlePolicies
 
 		poolPodC *PoolPodController
 
 		podSpecPatch               *apiv1.PodSpec
 		objectReaperIntervalSecond time.Duration
 	}
+	// poolKey identifies the pool of a class of an environment, the empty
+	// class is the default pool of the environment.
+	poolKey struct {
+		envUID k8sTypes.UID
+		class  string
+	}
 	request struct {
 		requestType
 		ctx             context.Context
 		env             *fv1.Environment
+		class           string
 		responseChannel chan *response
 	}
 	response struct {
@@ -122,6 +158,9 @@ func MakeGenericPoolManager(ctx context.Context,
 	finformerFactory map[string]genInformer.SharedInformerFactory,
 	gpmInformerFactory map[string]k8sInformers.SharedInformerFactory,
 	podSpecPatch *apiv1.PodSpec,
+	leader *leaderelection.Elector,
+	enforcer *quota.Enforcer,
+	recorder *events.Recorder,
 ) (executortype.ExecutorType, error) {
 
 	gpmLogger := logger.Named("generic_pool_manager")
@@ -142,7 +181,7 @@ func MakeGenericPoolManager(ctx context.Context,
 	}
 	gpm := &GenericPoolManager{
 		logger:                     gpmLogger,
-		pools:                      make(map[k8sTypes.UID]*GenericPool),
+		pools:                      make(map[poolKey]*GenericPool),
 		kubernetesClient:           kubernetesClient,
 		nsResolver:                 utils.DefaultNSResolver(),
 		metricsClient:              metricsClient,
@@ -152,6 +191,7 @@ func MakeGenericPoolManager(ctx context.Context,
 		instanceID:                 instanceID,
 		requestChannel:             make(chan *request),
 		defaultIdlePodReapTime:     2 * time.Minute,
+		idlePolicies:               reaper.MakeIdlePolicies(gpmLogger, kubernetesClient, recorder),
 		fetcherConfig:              fetcherConfig,
 		enableIstio:                enableIstio,
 		poolPodC:                   poolPodC,
@@ -159,12 +199,30 @@ func MakeGenericPoolManager(ctx context.Context,
 		objectReaperIntervalSecond: time.Duration(executorUtils.GetObjectReaperInterval(logger, fv1.ExecutorTypePoolmgr, 5)) * time.Second,
 		podLister:                  make(map[string]corelisters.PodLister),
 		podListerSynced:            make(map[string]k8sCache.InformerSynced),
+		leader:                     leader,
+		quota:                      enforcer,
+		recorder:                   recorder,
 	}
 	for ns, informerFactory := range gpmInformerFactory {
 		gpm.podLister[ns] = informerFactory.Core().V1().Pods().Lister()
 		gpm.podListerSynced[ns] = informerFactory.Core().V1().Pods().Informer().HasSynced
 	}
 
+	gpm.fnLister = make(map[string]flisterv1.FunctionLister)
+	gpm.fnListerSynced = make(map[string]k8sCache.InformerSynced)
+	for ns, factory := range finformerFactory {
+		gpm.fnLister[ns] = factory.Core().V1().Functions().Lister()
+		gpm.fnListerSynced[ns] = factory.Core().V1().Functions().Informer().HasSynced
+	}
+	if leader != nil {
+		gpm.podInformer = make(map[string]k8sCache.SharedIndexInformer)
+		for ns, informerFactory := range gpmInformerFactory {
+			gpm.podInformer[ns] = informerFactory.Core().V1().Pods().Informer()
+		}
+	}
+
+	leader.OnStartedLeading(poolPodC.resync)
+
 	gpm.logger.Debug("inside MakeGenericPoolManager")
 
 	return gpm, nil
@@ -196,6 +254,15 @@ func (gpm *GenericPoolManager) Run(ctx context.Context, mgr manager.Interface) {
 	mgr.Add(ctx, func(ctx context.Context) {
 		gpm.idleObjectReaper(ctx)
 	})
+	mgr.Add(ctx, func(ctx context.Context) {
+		gpm.podRecycler(ctx)
+	})
+	mgr.Add(ctx, func(ctx context.Context) {
+		wait.UntilWithContext(ctx, gpm.prewarmPods, executorUtils.ScalingScheduleInterval)
+	})
+	if gpm.leader != nil {
+		gpm.runSpecializedPodSync(ctx, mgr)
+	}
 	mgr.Add(ctx, func(ctx context.Context) {
 		gpm.poolPodC.Run(ctx, ctx.Done(), mgr)
 	})
@@ -226,16 +293,31 @@ func (gpm *GenericPoolManager) GetFuncSvc(ctx context.Context, fn *fv1.Function)
 		return nil, fErr
 	}
 
-	pool, created, err := gpm.getPool(ctx, env)
+	class := fn.Spec.PoolClass
+	if len(class) > 0 && env.GetPoolClass(class) == nil {
+		fErr = ferror.MakeError(ferror.ErrorNotFound,
+			fmt.Sprintf("pool class %q not found in environment %s.%s", class, env.ObjectMeta.Name, env.ObjectMeta.Namespace))
+		return nil, fErr
+	}
+
+	pool, created, err := gpm.getPool(ctx, env, class)
 	if err != nil {
 		fErr = err
 		return nil, fErr
 	}
 
 	if created {
-		logger.Info("created pool for the environment", zap.String("env", env.ObjectMeta.Name), zap.String("namespace", gpm.nsResolver.ResolveNamespace(gpm.nsResolver.FunctionNamespace)))
+		logger.Info("created pool for the environment", zap.String("env", env.ObjectMeta.Name), zap.String("poolClass", class), zap.String("namespace", gpm.nsResolver.ResolveNamespace(gpm.nsResolver.FunctionNamespace)))
 	}
 
+	// the specialized pod counts against the quota of the function namespace
+	release, err := gpm.quota.Reserve(ctx, fn.ObjectMeta.Namespace, quota.SpecializedPods(1, getPoolResources(env, class).Requests))
+	if err != nil {
+		fErr = err
+		return nil, fErr
+	}
+	defer release()
+
 	// from GenericPool -> get one function container
 	// (this also adds to the cache)
 	logger.Debug("getting function service from pool", zap.String("function", fn.ObjectMeta.Name))
@@ -261,6 +343,11 @@ func (gpm *GenericPoolManager) UnTapService(ctx context.Context, fnMeta *metav1.
 	gpm.fsCache.MarkAvailable(key, svcHost)
 }
 
+// ReportConcurrency is a no-op, specialized pods are assigned to requests
+// based on the requests per pod of the function.
+func (gpm *GenericPoolManager) ReportConcurrency(ctx context.Context, reporter string, fnMeta *metav1.ObjectMeta, activeRequests int) {
+}
+
 func (gpm *GenericPoolManager) TapService(ctx context.Context, svcHost string) error {
 	otelUtils.SpanTrackEvent(ctx, "TapService",
 		attribute.KeyValue{Key: "svcHost", Value: attribute.StringValue(svcHost)})
@@ -314,7 +401,7 @@ func (gpm *GenericPoolManager) RefreshFuncPods(ctx context.Context, logger *zap.
 		return err
 	}
 
-	gp, created, err := gpm.getPool(ctx, env)
+	gp, created, err := gpm.getPool(ctx, env, f.Spec.PoolClass)
 	if err != nil {
 		return err
 	}
@@ -323,6 +410,16 @@ func (gpm *GenericPoolManager) RefreshFuncPods(ctx context.Context, logger *zap.
 		gpm.logger.Info("created pool for the environment", zap.String("env", env.ObjectMeta.Name), zap.String("namespace", gpm.nsResolver.ResolveNamespace(gpm.nsResolver.FunctionNamespace)))
 	}
 
+	funcLabels := gp.labelsForFunction(&f.ObjectMeta)
+
+	if f.Spec.ConfigUpdatePolicy == fv1.ConfigUpdateReload {
+		err = executorUtils.ReloadFuncPods(ctx, logger, gpm.kubernetesClient, gp.fnNamespace, funcLabels, &f)
+		if err == nil {
+			return nil
+		}
+		logger.Error("error reloading function pods, recycling them instead", zap.Error(err), zap.String("function", f.ObjectMeta.Name))
+	}
+
 	funcSvc, err := gp.fsCache.GetByFunction(&f.ObjectMeta)
 
 	// delete function service address from cache only when function service address found in cache
@@ -330,8 +427,6 @@ func (gpm *GenericPoolManager) RefreshFuncPods(ctx context.Context, logger *zap.
 		gp.fsCache.DeleteEntry(funcSvc)
 	}
 
-	funcLabels := gp.labelsForFunction(&f.ObjectMeta)
-
 	podList, err := gpm.kubernetesClient.CoreV1().Pods(f.Spec.Environment.Namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labels.Set(funcLabels).AsSelector().String(),
 	})
@@ -367,16 +462,19 @@ func (gpm *GenericPoolManager) AdoptExistingResources(ctx context.Context) {
 		for i := range envs.Items {
 			env := envs.Items[i]
 
-			if getEnvPoolSize(&env) > 0 {
+			for _, class := range getPoolClasses(&env) {
+				if getPoolSize(&env, class) == 0 {
+					continue
+				}
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
-					_, created, err := gpm.getPool(ctx, &env)
+					_, created, err := gpm.getPool(ctx, &env, class)
 					if err != nil {
 						gpm.logger.Error("adopt pool failed", zap.Error(err))
 					}
 					if created {
-						gpm.logger.Info("created pool for the environment", zap.String("env", env.ObjectMeta.Name), zap.String("namespace", gpm.nsResolver.ResolveNamespace(gpm.nsResolver.FunctionNamespace)))
+						gpm.logger.Info("created pool for the environment", zap.String("env", env.ObjectMeta.Name), zap.String("poolClass", class), zap.String("namespace", gpm.nsResolver.ResolveNamespace(gpm.nsResolver.FunctionNamespace)))
 					}
 				}()
 			}
@@ -521,43 +619,45 @@ func (gpm *GenericPoolManager) service() {
 			// just because they are missing in the cache, we end up creating another duplicate pool.
 			var err error
 			created := false
-			pool, ok := gpm.pools[crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta)]
+			key := poolKey{envUID: crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta), class: req.class}
+			pool, ok := gpm.pools[key]
+			if !ok && len(req.class) > 0 && req.env.GetPoolClass(req.class) == nil {
+				req.responseChannel <- &response{error: fmt.Errorf("pool class %q not found in environment %s.%s",
+					req.class, req.env.ObjectMeta.Name, req.env.ObjectMeta.Namespace)}
+				continue
+			}
 			if !ok {
 				// To support backward compatibility, if envs are created in default ns, we go ahead
 				// and create pools in fission-function ns as earlier.
 				ns := gpm.nsResolver.GetFunctionNS(req.env.ObjectMeta.Namespace)
 				pool = MakeGenericPool(gpm.logger, gpm.fissionClient, gpm.kubernetesClient,
-					gpm.metricsClient, req.env, ns, gpm.fsCache,
-					gpm.fetcherConfig, gpm.instanceID, gpm.enableIstio, gpm.podSpecPatch)
+					gpm.metricsClient, req.env, req.class, ns, gpm.fsCache,
+					gpm.fetcherConfig, gpm.instanceID, gpm.enableIstio, gpm.podSpecPatch, gpm.leader, gpm.recorder)
 				err = pool.setup(req.ctx)
 				if err != nil {
 					req.responseChannel <- &response{error: err}
 					continue
 				}
-				gpm.pools[crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta)] = pool
+				gpm.pools[key] = pool
 				created = true
 			}
 			req.responseChannel <- &response{pool: pool, created: created}
 		case CLEANUP_POOL:
-			env := *req.env
-			gpm.logger.Info("destroying pool",
-				zap.String("environment", env.ObjectMeta.Name),
-				zap.String("namespace", env.ObjectMeta.Namespace))
-
-			key := crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta)
-			pool, ok := gpm.pools[key]
-			if !ok {
-				gpm.logger.Error("Could not find pool", zap.String("environment", env.ObjectMeta.Name), zap.String("namespace", env.ObjectMeta.Namespace))
-				continue
+			gpm.destroyPool(req.ctx, poolKey{envUID: crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta), class: req.class}, req.env)
+			// no response, caller doesn't wait
+		case CLEANUP_STALE_POOLS:
+			// destroy the pools of classes removed from the environment
+			for key := range gpm.pools {
+				if key.envUID == crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta) &&
+					len(key.class) > 0 && req.env.GetPoolClass(key.class) == nil {
+					gpm.destroyPool(req.ctx, key, req.env)
+				}
 			}
-			delete(gpm.pools, key)
-			if pool != nil {
-				err := pool.destroy(req.ctx)
-				if err != nil {
-					gpm.logger.Error("failed to destroy pool",
-						zap.String("environment", env.ObjectMeta.Name),
-						zap.String("namespace", env.ObjectMeta.Namespace),
-						zap.Error(err))
+			// no response, caller doesn't wait
+		case REFRESH_POOL:
+			for key, pool := range gpm.pools {
+				if key.envUID == crd.CacheKeyUIDFromMeta(&req.env.ObjectMeta) {
+					pool.refreshEnv(req.env)
 				}
 			}
 			// no response, caller doesn't wait
@@ -565,25 +665,79 @@ func (gpm *GenericPoolManager) service() {
 	}
 }
 
-func (gpm *GenericPoolManager) getPool(ctx context.Context, env *fv1.Environment) (*GenericPool, bool, error) {
+// destroyPool removes the pool from the pool manager and deletes its
+// deployment. It must only be called by the service goroutine.
+func (gpm *GenericPoolManager) destroyPool(ctx context.Context, key poolKey, env *fv1.Environment) {
+	gpm.logger.Info("destroying pool",
+		zap.String("environment", env.ObjectMeta.Name),
+		zap.String("namespace", env.ObjectMeta.Namespace),
+		zap.String("poolClass", key.class))
+
+	pool, ok := gpm.pools[key]
+	if !ok {
+		gpm.logger.Error("Could not find pool", zap.String("environment", env.ObjectMeta.Name), zap.String("namespace", env.ObjectMeta.Namespace),
+			zap.String("poolClass", key.class))
+		return
+	}
+	delete(gpm.pools, key)
+	if pool != nil {
+		err := pool.destroy(ctx)
+		if err != nil {
+			gpm.logger.Error("failed to destroy pool",
+				zap.String("environment", env.ObjectMeta.Name),
+				zap.String("namespace", env.ObjectMeta.Namespace),
+				zap.String("poolClass", key.class),
+				zap.Error(err))
+		}
+	}
+}
+
+// getPool returns the pool of the class of the environment, creating it if
+// it doesn't exist yet. The empty class is the default pool.
+func (gpm *GenericPoolManager) getPool(ctx context.Context, env *fv1.Environment, class string) (*GenericPool, bool, error) {
 	otelUtils.SpanTrackEvent(ctx, "getPool", otelUtils.GetAttributesForEnv(env)...)
 	c := make(chan *response)
 	gpm.requestChannel <- &request{
 		ctx:             ctx,
 		requestType:     GET_POOL,
 		env:             env,
+		class:           class,
 		responseChannel: c,
 	}
 	resp := <-c
 	return resp.pool, resp.created, resp.error
 }
 
-func (gpm *GenericPoolManager) cleanupPool(ctx context.Context, env *fv1.Environment) {
+func (gpm *GenericPoolManager) cleanupPool(ctx context.Context, env *fv1.Environment, class string) {
 	otelUtils.SpanTrackEvent(ctx, "cleanupPool", otelUtils.GetAttributesForEnv(env)...)
 	gpm.requestChannel <- &request{
 		ctx:         ctx,
 		requestType: CLEANUP_POOL,
 		env:         env,
+		class:       class,
+	}
+}
+
+// cleanupStalePools destroys the pools of classes which are no longer
+// declared by the environment.
+func (gpm *GenericPoolManager) cleanupStalePools(ctx context.Context, env *fv1.Environment) {
+	otelUtils.SpanTrackEvent(ctx, "cleanupStalePools", otelUtils.GetAttributesForEnv(env)...)
+	gpm.requestChannel <- &request{
+		ctx:         ctx,
+		requestType: CLEANUP_STALE_POOLS,
+		env:         env,
+	}
+}
+
+// refreshPool updates the environment of the existing pools of the
+// environment without touching their deployments, which are reconciled by
+// the leader.
+func (gpm *GenericPoolManager) refreshPool(ctx context.Context, env *fv1.Environment) {
+	otelUtils.SpanTrackEvent(ctx, "refreshPool", otelUtils.GetAttributesForEnv(env)...)
+	gpm.requestChannel <- &request{
+		ctx:         ctx,
+		requestType: REFRESH_POOL,
+		env:         env,
 	}
 }
 
@@ -628,6 +782,10 @@ func (gpm *GenericPoolManager) idleObjectReaper(ctx context.Context) {
 }
 
 func (gpm *GenericPoolManager) doIdleObjectReaper(ctx context.Context) {
+	if !gpm.leader.IsLeader() {
+		return
+	}
+
 	envList := make(map[k8sTypes.UID]struct{})
 	for _, namespace := range utils.DefaultNSResolver().FissionResourceNS {
 		envs, err := gpm.fissionClient.CoreV1().Environments(namespace).List(ctx, metav1.ListOptions{})
@@ -653,12 +811,25 @@ func (gpm *GenericPoolManager) doIdleObjectReaper(ctx context.Context) {
 		}
 	}
 
+	// the warm pods of scaling schedules change as their windows start and end
+	for _, fn := range fnList {
+		if len(fn.Spec.InvokeStrategy.ExecutionStrategy.ScalingSchedules) > 0 {
+			gpm.fsCache.SetRetainPods(crd.CacheKeyURGFromMeta(&fn.ObjectMeta), getRetainPods(&fn, time.Now()))
+		}
+	}
+
 	funcSvcs, err := gpm.fsCache.ListOldForPool(time.Second * 5)
 	if err != nil {
 		gpm.logger.Error("error reaping idle pods", zap.Error(err))
 		return
 	}
 
+	run := gpm.idlePolicies.StartRun(fv1.ExecutorTypePoolmgr)
+	defer run.Finish()
+	now := time.Now()
+	// idle pods kept by their idle timeout, by environment
+	keptIdle := make(map[k8sTypes.UID][]*fscache.FuncSvc)
+
 	for i := range funcSvcs {
 		fsvc := funcSvcs[i]
 
@@ -683,38 +854,69 @@ func (gpm *GenericPoolManager) doIdleObjectReaper(ctx context.Context) {
 
 		idlePodReapTime := gpm.defaultIdlePodReapTime
 		if fn, ok := fnList[fsvc.Function.UID]; ok {
-			if fn.Spec.IdleTimeout != nil {
-				idlePodReapTime = time.Duration(*fn.Spec.IdleTimeout) * time.Second
+			if fsvc.PackageRef != (fv1.PackageRef{}) && fsvc.PackageRef != fn.Spec.Package.PackageRef &&
+				gpm.idlePolicies.ReapOnPackageChange(ctx, &fn) {
+				gpm.reapIdlePod(ctx, run, fsvc, forcedReapMinIdleTime, reaper.ReasonPackageChange)
+				continue
 			}
+			idlePodReapTime = gpm.idlePolicies.IdleTimeout(ctx, &fn, gpm.defaultIdlePodReapTime, now)
 		}
 
-		if time.Since(fsvc.Atime) < idlePodReapTime {
+		if now.Sub(fsvc.Atime) < idlePodReapTime {
+			keptIdle[fsvc.Environment.ObjectMeta.UID] = append(keptIdle[fsvc.Environment.ObjectMeta.UID], fsvc)
 			continue
 		}
+		gpm.reapIdlePod(ctx, run, fsvc, idlePodReapTime, reaper.ReasonIdleTimeout)
+	}
 
-		go func() {
-			deleted, err := gpm.fsCache.DeleteOldPoolCache(ctx, fsvc, idlePodReapTime)
-			if err != nil {
-				gpm.logger.Error("error deleting Kubernetes objects for function service",
-					zap.Error(err),
-					zap.Any("service", fsvc))
-			}
-			if deleted {
-				for i := range fsvc.KubernetesObjects {
-					gpm.logger.Info("release idle function resources",
-						zap.String("function", fsvc.Function.Name),
-						zap.String("address", fsvc.Address),
-						zap.String("executor", string(fsvc.Executor)),
-						zap.String("pod", fsvc.Name),
-					)
-					reaper.CleanupKubeObject(ctx, gpm.logger, gpm.kubernetesClient, &fsvc.KubernetesObjects[i])
-					time.Sleep(50 * time.Millisecond)
-				}
-			}
-		}()
+	// reap the longest idle pods of environments above the cap of idle pods
+	maxIdlePods := gpm.idlePolicies.MaxIdlePodsPerEnvironment()
+	if maxIdlePods <= 0 {
+		return
+	}
+	for _, fsvcs := range keptIdle {
+		if len(fsvcs) <= maxIdlePods {
+			continue
+		}
+		slices.SortFunc(fsvcs, func(a, b *fscache.FuncSvc) int {
+			return a.Atime.Compare(b.Atime)
+		})
+		for _, fsvc := range fsvcs[:len(fsvcs)-maxIdlePods] {
+			gpm.reapIdlePod(ctx, run, fsvc, forcedReapMinIdleTime, reaper.ReasonEnvironmentCap)
+		}
 	}
 }
 
+// reapIdlePod deletes the pod of an idle function service, unless the idle
+// reaper runs in dry-run mode or the pod was used in the last minIdleTime.
+func (gpm *GenericPoolManager) reapIdlePod(ctx context.Context, run *reaper.IdleReapRun, fsvc *fscache.FuncSvc,
+	minIdleTime time.Duration, reason string) {
+	if !run.Reap(fsvc.Function, fsvc.Name, reason) {
+		return
+	}
+	go func() {
+		deleted, err := gpm.fsCache.DeleteOldPoolCache(ctx, fsvc, minIdleTime)
+		if err != nil {
+			gpm.logger.Error("error deleting Kubernetes objects for function service",
+				zap.Error(err),
+				zap.Any("service", fsvc))
+		}
+		if deleted {
+			for i := range fsvc.KubernetesObjects {
+				gpm.logger.Info("release idle function resources",
+					zap.String("function", fsvc.Function.Name),
+					zap.String("address", fsvc.Address),
+					zap.String("executor", string(fsvc.Executor)),
+					zap.String("pod", fsvc.Name),
+					zap.String("reason", reason),
+				)
+				reaper.CleanupKubeObject(ctx, gpm.logger, gpm.kubernetesClient, &fsvc.KubernetesObjects[i])
+				time.Sleep(50 * time.Millisecond)
+			}
+		}
+	}()
+}
+
 // WebsocketStartEventChecker checks if the pod has emitted a websocket connection start event
 func (gpm *GenericPoolManager) WebsocketStartEventChecker(ctx context.Context, kubeClient kubernetes.Interface) error {
 	var wg wait.Group
@@ -763,6 +965,9 @@ func (gpm *GenericPoolManager) NoActiveConnectionEventChecker(ctx context.Contex
 						return
 					}
 					gpm.fsCache.DeleteFunctionSvc(ctx, fsvc)
+					if !gpm.leader.IsLeader() {
+						return
+					}
 					for i := range fsvc.KubernetesObjects {
 						gpm.logger.Info("release idle function resources due to  inactivity",
 							zap.String("function", fsvc.Function.Name),
@@ -786,6 +991,150 @@ func (gpm *GenericPoolManager) NoActiveConnectionEventChecker(ctx context.Contex
 	return nil
 }
 
-func (gpm *GenericPoolManager) DumpDebugInfo(ctx context.Context) error {
-	return gpm.fsCache.DumpDebugInfo(ctx)
+func (gpm *GenericPoolManager) DumpDebugInfo(ctx context.Context) ([]fscache.FuncSvcGroupStatus, error) {
+	return gpm.fsCache.DumpDebugInfo(ctx), nil
+}
+
+// EvictFuncSvc removes the function service served by a specialized pod
+// of a function in the namespace from cache and deletes the pod.
+func (gpm *GenericPoolManager) EvictFuncSvc(ctx context.Context, namespace string, podName string) error {
+	// pods of functions in the default namespace run in the function namespace
+	podNS := gpm.nsResolver.GetFunctionNS(namespace)
+	pod, err := gpm.kubernetesClient.CoreV1().Pods(podNS).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("pod %s/%s not found", podNS, podName))
+		}
+		return err
+	}
+	if pod.Labels[fv1.EXECUTOR_TYPE] != string(fv1.ExecutorTypePoolmgr) || pod.Labels["managed"] != "false" {
+		return ferror.MakeError(ferror.ErrorInvalidArgument,
+			fmt.Sprintf("pod %s/%s is not a specialized function pod", podNS, podName))
+	}
+
+	if value, ok := gpm.fsCache.PodToFsvc.LoadAndDelete(pod.Name); ok {
+		if fsvc, ok := value.(*fscache.FuncSvc); ok {
+			gpm.fsCache.DeleteFunctionSvc(ctx, fsvc)
+		}
+	}
+
+	gpm.logger.Info("evict specialized function pod",
+		zap.String("pod", pod.Name), zap.String("namespace", pod.Namespace),
+		zap.String("function", pod.Labels[fv1.FUNCTION_NAME]))
+	reaper.CleanupKubeObject(ctx, gpm.logger, gpm.kubernetesClient, &apiv1.ObjectReference{
+		Kind:      "pod",
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+	})
+	return nil
+}
+
+// DrainFunction stops assigning requests to the function services of the
+// function and deletes their pods once in-flight requests finish. With more
+// than one executor replica, the drain is shared with the other replicas
+// through the pods.
+func (gpm *GenericPoolManager) DrainFunction(ctx context.Context, fnMeta *metav1.ObjectMeta, timeout time.Duration) ([]string, []string, error) {
+	fsvcs := gpm.fsCache.DrainFunc(fnMeta.UID)
+	if len(fsvcs) == 0 {
+		return nil, nil, nil
+	}
+	gpm.logger.Info("draining function services",
+		zap.String("function", fnMeta.Name), zap.String("namespace", fnMeta.Namespace),
+		zap.Int("count", len(fsvcs)))
+	if gpm.leader != nil {
+		return gpm.drainPods(ctx, fnMeta, fsvcs, timeout)
+	}
+
+	// the request may go away while draining, but released pods
+	// must be cleaned up anyway
+	cleanupCtx := context.WithoutCancel(ctx)
+	release := func(force bool) ([]string, int) {
+		released, remaining := gpm.fsCache.ReleaseDrained(fnMeta.UID, force)
+		names := make([]string, 0, len(released))
+		for _, fsvc := range released {
+			gpm.fsCache.PodToFsvc.Delete(fsvc.Name)
+			for i := range fsvc.KubernetesObjects {
+				reaper.CleanupKubeObject(cleanupCtx, gpm.logger, gpm.kubernetesClient, &fsvc.KubernetesObjects[i])
+			}
+			names = append(names, fsvc.Name)
+		}
+		return names, remaining
+	}
+
+	drained := make([]string, 0, len(fsvcs))
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		names, remaining := release(false)
+		drained = append(drained, names...)
+		if remaining == 0 {
+			return drained, nil, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			forced, _ := release(true)
+			gpm.logger.Warn("timed out draining function services, removed busy function services",
+				zap.String("function", fnMeta.Name), zap.String("namespace", fnMeta.Namespace),
+				zap.Strings("function_services", forced))
+			return drained, forced, nil
+		case <-ctx.Done():
+			forced, _ := release(true)
+			return drained, forced, ctx.Err()
+		}
+	}
+}
+
+// drainPods records the drain on the pods of the function services and waits
+// for the leader to delete them. Every executor replica stops assigning
+// requests to drained pods and records its in-flight requests on them, the
+// leader deletes them once no replica serves requests through them, or at
+// the deadline of the drain. Pods left at the deadline are forced.
+func (gpm *GenericPoolManager) drainPods(ctx context.Context, fnMeta *metav1.ObjectMeta, fsvcs []*fscache.FuncSvc, timeout time.Duration) ([]string, []string, error) {
+	deadline := time.Now().Add(timeout)
+	pending := make(map[string]apiv1.ObjectReference)
+	for _, fsvc := range fsvcs {
+		for _, obj := range fsvc.KubernetesObjects {
+			if !strings.EqualFold(obj.Kind, "pod") {
+				continue
+			}
+			err := executorUtils.PatchDrainDeadline(ctx, gpm.kubernetesClient, &obj, deadline)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("error draining pod %s/%s: %w", obj.Namespace, obj.Name, err)
+			}
+			pending[obj.Name] = obj
+		}
+	}
+
+	drained := make([]string, 0, len(pending))
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		for name, obj := range pending {
+			_, err := gpm.kubernetesClient.CoreV1().Pods(obj.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				drained = append(drained, name)
+				delete(pending, name)
+			} else if err != nil {
+				gpm.logger.Warn("failed to get drained pod", zap.Error(err), zap.String("pod", name))
+			}
+		}
+		if len(pending) == 0 {
+			return drained, nil, nil
+		}
+		if !time.Now().Before(deadline) {
+			forced := slices.Sorted(maps.Keys(pending))
+			gpm.logger.Warn("timed out draining function services, busy function services are removed by the leader",
+				zap.String("function", fnMeta.Name), zap.String("namespace", fnMeta.Namespace),
+				zap.Strings("function_services", forced))
+			return drained, forced, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return drained, nil, ctx.Err()
+		}
+	}
 }
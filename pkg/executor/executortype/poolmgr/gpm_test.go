@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/utils"
+)
+
+func TestEvictFuncSvc(t *testing.T) {
+	ctx := t.Context()
+	logger := zap.NewNop()
+	kubernetesClient := fake.NewSimpleClientset(
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "specialized",
+			Namespace: "fission-function",
+			Labels: map[string]string{
+				fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypePoolmgr),
+				"managed":         "false",
+			},
+		}},
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "generic",
+			Namespace: "fission-function",
+			Labels: map[string]string{
+				fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypePoolmgr),
+				"managed":         "true",
+			},
+		}},
+	)
+	gpm := &GenericPoolManager{
+		logger:           logger,
+		kubernetesClient: kubernetesClient,
+		nsResolver:       &utils.NamespaceResolver{FunctionNamespace: "fission-function", DefaultNamespace: metav1.NamespaceDefault, Logger: logger},
+		fsCache:          fscache.MakeFunctionServiceCache(logger),
+	}
+
+	// generic pods aren't function services
+	code, _ := ferror.GetHTTPError(gpm.EvictFuncSvc(ctx, metav1.NamespaceDefault, "generic"))
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = ferror.GetHTTPError(gpm.EvictFuncSvc(ctx, "other", "specialized"))
+	require.Equal(t, http.StatusNotFound, code)
+
+	// pods of functions in the default namespace run in the function namespace
+	require.NoError(t, gpm.EvictFuncSvc(ctx, metav1.NamespaceDefault, "specialized"))
+	_, err := kubernetesClient.CoreV1().Pods("fission-function").Get(ctx, "specialized", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// packageAffinityTTL is how long a node is assumed to hold a package in its
+// package cache after a pod on the node fetched it.
+const packageAffinityTTL = time.Hour
+
+// packageNodes remembers the nodes where pool pods fetched a package. The
+// fetchers on those nodes likely still hold the package in the node-local
+// package cache, so specializing a pod there skips the download.
+type packageNodes struct {
+	lock  sync.Mutex
+	nodes map[string]map[string]time.Time // package -> node -> last fetch
+}
+
+func makePackageNodes() *packageNodes {
+	return &packageNodes{
+		nodes: make(map[string]map[string]time.Time),
+	}
+}
+
+// packageKey identifies the package content of the function.
+func packageKey(fn *fv1.Function) string {
+	ref := fn.Spec.Package.PackageRef
+	return fmt.Sprintf("%s/%s@%s", ref.Namespace, ref.Name, ref.ResourceVersion)
+}
+
+// add records that the node fetched the package. It's a no-op on a nil
+// packageNodes, which is used when the package cache is disabled.
+func (pn *packageNodes) add(pkg string, node string) {
+	if pn == nil || len(node) == 0 {
+		return
+	}
+	pn.lock.Lock()
+	defer pn.lock.Unlock()
+	if pn.nodes[pkg] == nil {
+		pn.nodes[pkg] = make(map[string]time.Time)
+	}
+	pn.nodes[pkg][node] = time.Now()
+}
+
+// get returns the nodes which fetched the package recently.
+func (pn *packageNodes) get(pkg string) map[string]struct{} {
+	if pn == nil {
+		return nil
+	}
+	pn.lock.Lock()
+	defer pn.lock.Unlock()
+	nodes := make(map[string]struct{})
+	for node, fetched := range pn.nodes[pkg] {
+		if time.Since(fetched) > packageAffinityTTL {
+			delete(pn.nodes[pkg], node)
+			continue
+		}
+		nodes[node] = struct{}{}
+	}
+	if len(nodes) == 0 {
+		delete(pn.nodes, pkg)
+	}
+	return nodes
+}
+
+// choosePodOnNodes picks a ready pod running on one of the nodes and
+// relabels it. It doesn't wait for pods, nil is returned if there is no
+// suitable pod.
+func (gp *GenericPool) choosePodOnNodes(ctx context.Context, newLabels map[string]string, nodes map[string]struct{}) *apiv1.Pod {
+	if len(nodes) == 0 || gp.env.Spec.AllowedFunctionsPerContainer == fv1.AllowedFunctionsPerContainerInfinite {
+		return nil
+	}
+	logger := otelUtils.LoggerWithTraceID(ctx, gp.logger)
+
+	pods, err := gp.readyPodLister.List(labels.Everything())
+	if err != nil {
+		logger.Error("error listing ready pods", zap.Error(err))
+		return nil
+	}
+	candidates := make([]*apiv1.Pod, 0)
+	for _, pod := range pods {
+		if _, ok := nodes[pod.Spec.NodeName]; !ok || pod.Labels[fv1.POOL_CLASS] != gp.class {
+			continue
+		}
+		if utils.IsPodTerminated(pod) || !utils.IsReadyPod(pod) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		candidates = append(candidates, pod)
+	}
+	// spread concurrent requests over the candidates
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	for _, pod := range candidates {
+		chosenPod := pod.DeepCopy()
+		patched, err := gp.relabelPod(ctx, chosenPod, newLabels)
+		if !patched {
+			// most likely picked by another request
+			logger.Debug("failed to relabel pod on preferred node", zap.Error(err), zap.String("pod", chosenPod.Name))
+			continue
+		}
+		if err != nil {
+			logger.Error("failed to relabel pod on preferred node", zap.Error(err), zap.String("pod", chosenPod.Name))
+			return nil
+		}
+		return chosenPod
+	}
+	return nil
+}
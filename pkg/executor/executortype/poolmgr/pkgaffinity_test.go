@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageNodes(t *testing.T) {
+	pn := makePackageNodes()
+	require.Empty(t, pn.get("pkg"))
+
+	pn.add("pkg", "node-a")
+	pn.add("pkg", "node-b")
+	pn.add("pkg", "")
+	pn.add("other", "node-c")
+	require.Equal(t, map[string]struct{}{"node-a": {}, "node-b": {}}, pn.get("pkg"))
+
+	// nodes which fetched the package long ago are forgotten
+	pn.nodes["pkg"]["node-a"] = time.Now().Add(-2 * packageAffinityTTL)
+	require.Equal(t, map[string]struct{}{"node-b": {}}, pn.get("pkg"))
+
+	var nilNodes *packageNodes
+	nilNodes.add("pkg", "node-a")
+	require.Nil(t, nilNodes.get("pkg"))
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/fscache"
+	executorUtils "github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/manager"
+)
+
+// runSpecializedPodSync keeps the function service cache in sync with pods
+// specialized by other executor replicas, and periodically records the last
+// access time of the function services served by this replica on their pods.
+func (gpm *GenericPoolManager) runSpecializedPodSync(ctx context.Context, mgr manager.Interface) {
+	waitSynced := make([]k8sCache.InformerSynced, 0)
+	for _, synced := range gpm.fnListerSynced {
+		waitSynced = append(waitSynced, synced)
+	}
+	if ok := k8sCache.WaitForCacheSync(ctx.Done(), waitSynced...); !ok {
+		gpm.logger.Fatal("failed to wait for function caches to sync")
+	}
+
+	for ns, informer := range gpm.podInformer {
+		_, err := informer.AddEventHandler(gpm.specializedPodEventHandlers(ctx))
+		if err != nil {
+			gpm.logger.Fatal("failed to add specialized pod event handler", zap.Error(err), zap.String("namespace", ns))
+		}
+	}
+
+	mgr.Add(ctx, func(ctx context.Context) {
+		wait.UntilWithContext(ctx, gpm.reportAccessTimes, executorUtils.AccessTimeReportInterval)
+	})
+}
+
+func (gpm *GenericPoolManager) specializedPodEventHandlers(ctx context.Context) k8sCache.ResourceEventHandlerFuncs {
+	return k8sCache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pod, ok := obj.(*apiv1.Pod)
+			if ok {
+				gpm.syncSpecializedPod(ctx, pod)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			pod, ok := newObj.(*apiv1.Pod)
+			if ok {
+				gpm.syncSpecializedPod(ctx, pod)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			pod, ok := obj.(*apiv1.Pod)
+			if !ok {
+				tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown)
+				if !ok {
+					return
+				}
+				pod, ok = tombstone.Obj.(*apiv1.Pod)
+				if !ok {
+					return
+				}
+			}
+			gpm.forgetSpecializedPod(ctx, pod)
+		},
+	}
+}
+
+// syncSpecializedPod adds a pod specialized by any executor replica to the
+// function service cache, or moves its access time forward if it's cached already.
+func (gpm *GenericPoolManager) syncSpecializedPod(ctx context.Context, pod *apiv1.Pod) {
+	if pod.Labels["managed"] != "false" {
+		return
+	}
+	if !IsPodActive(pod) || !utils.IsReadyPod(pod) {
+		gpm.forgetSpecializedPod(ctx, pod)
+		return
+	}
+	// svc host is patched after specialization, until then the pod is not usable
+	if _, ok := pod.Annotations[fv1.ANNOTATION_SVC_HOST]; !ok {
+		return
+	}
+
+	fn, err := gpm.getSpecializedPodFunction(pod)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			gpm.logger.Debug("skip syncing specialized pod", zap.Error(err), zap.String("pod", pod.Name))
+		}
+		return
+	}
+
+	fsvc, err := gpm.fsvcFromSpecializedPod(ctx, pod, fn)
+	if err != nil {
+		gpm.logger.Warn("failed to sync specialized pod", zap.Error(err), zap.String("pod", pod.Name))
+		return
+	}
+
+	if atime, ok := executorUtils.GetLastAccessTime(pod); ok && atime.After(fsvc.Atime) {
+		fsvc.Atime = atime
+	}
+	// the pool cache keeps the cached value and only moves its access time forward
+	if _, loaded := gpm.fsCache.PodToFsvc.LoadOrStore(pod.Name, fsvc); !loaded {
+		gpm.logger.Info("adopt specialized function pod",
+			zap.String("pod", pod.Name), zap.String("function", fn.ObjectMeta.Name))
+	}
+	key := crd.CacheKeyURGFromMeta(fsvc.Function)
+	gpm.fsCache.SetRecyclePolicy(key, recyclePolicy(fn))
+	gpm.fsCache.AdoptFunc(*fsvc, getRetainPods(fn, time.Now()))
+	// replicas only record in-flight requests on pods which are recycling,
+	// drained pods are recycled by all replicas
+	_, drained := executorUtils.GetDrainDeadline(pod)
+	if drained || len(executorUtils.GetActiveRequests(pod)) > 0 {
+		gpm.fsCache.MarkRecycling(key, fsvc.Address)
+	}
+}
+
+// forgetSpecializedPod removes a pod which is no longer usable from the
+// function service cache.
+func (gpm *GenericPoolManager) forgetSpecializedPod(ctx context.Context, pod *apiv1.Pod) {
+	value, ok := gpm.fsCache.PodToFsvc.LoadAndDelete(pod.Name)
+	if !ok {
+		return
+	}
+	fsvc, ok := value.(*fscache.FuncSvc)
+	if !ok {
+		return
+	}
+	gpm.fsCache.DeleteFunctionSvc(ctx, fsvc)
+}
+
+// getSpecializedPodFunction returns the function the pod is specialized for.
+// It fails if the function has changed since the pod was specialized.
+func (gpm *GenericPoolManager) getSpecializedPodFunction(pod *apiv1.Pod) (*fv1.Function, error) {
+	fnName, ok1 := pod.Labels[fv1.FUNCTION_NAME]
+	fnNS, ok2 := pod.Labels[fv1.FUNCTION_NAMESPACE]
+	fnUID, ok3 := pod.Labels[fv1.FUNCTION_UID]
+	fnRV, ok4 := pod.Annotations[fv1.FUNCTION_RESOURCE_VERSION]
+	if !(ok1 && ok2 && ok3 && ok4) {
+		return nil, errors.New("pod lacks function labels or annotations")
+	}
+
+	lister, ok := gpm.fnLister[fnNS]
+	if !ok {
+		return nil, fmt.Errorf("no function lister found for namespace %s", fnNS)
+	}
+	fn, err := lister.Functions(fnNS).Get(fnName)
+	if err != nil {
+		return nil, err
+	}
+	if string(fn.ObjectMeta.UID) != fnUID || fn.ObjectMeta.ResourceVersion != fnRV {
+		return nil, fmt.Errorf("pod specialized for a different version of function %s", fnName)
+	}
+	return fn, nil
+}
+
+func (gpm *GenericPoolManager) fsvcFromSpecializedPod(ctx context.Context, pod *apiv1.Pod, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	env, err := gpm.getFunctionEnv(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	cpuLimit, err := getPodCPULimit(pod)
+	if err != nil {
+		gpm.logger.Error("failed to get CPU limit of specialized pod", zap.Error(err), zap.String("pod", pod.Name))
+	}
+
+	// pods specialized by executors of older releases lack the specialization time
+	ctime := pod.CreationTimestamp.Time
+	if t, ok := executorUtils.GetSpecializationTime(pod); ok {
+		ctime = t
+	}
+
+	m := fn.ObjectMeta // only cache necessary part
+	return &fscache.FuncSvc{
+		Name:        pod.Name,
+		Function:    &m,
+		Environment: env,
+		Address:     pod.Annotations[fv1.ANNOTATION_SVC_HOST],
+		KubernetesObjects: []apiv1.ObjectReference{
+			{
+				Kind:            "pod",
+				Name:            pod.Name,
+				APIVersion:      pod.APIVersion,
+				Namespace:       pod.Namespace,
+				ResourceVersion: pod.ResourceVersion,
+				UID:             pod.UID,
+			},
+		},
+		Executor:   fv1.ExecutorTypePoolmgr,
+		CPULimit:   cpuLimit,
+		PackageRef: fn.Spec.Package.PackageRef,
+		Ctime:      ctime,
+		Atime:      ctime,
+	}, nil
+}
+
+// reportAccessTimes records the access time of recently used function
+// services on their pods, so that the leader doesn't reap pods which
+// are being served by other replicas.
+func (gpm *GenericPoolManager) reportAccessTimes(ctx context.Context) {
+	now := time.Now()
+	for _, fsvc := range gpm.fsCache.ListActiveForPool(executorUtils.AccessTimeReportInterval) {
+		atime := fsvc.Atime
+		if atime.Before(now.Add(-executorUtils.AccessTimeReportInterval)) {
+			// busy serving requests
+			atime = now
+		}
+		for i := range fsvc.KubernetesObjects {
+			err := executorUtils.PatchLastAccessTime(ctx, gpm.kubernetesClient, &fsvc.KubernetesObjects[i], atime)
+			if err != nil && !apierrors.IsNotFound(err) {
+				gpm.logger.Warn("failed to record last access time", zap.Error(err),
+					zap.String("function", fsvc.Function.Name), zap.String("pod", fsvc.Name))
+			}
+		}
+	}
+}
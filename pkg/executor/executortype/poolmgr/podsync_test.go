@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/cache"
+	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/fscache"
+	executorUtils "github.com/fission/fission/pkg/executor/util"
+	flisterv1 "github.com/fission/fission/pkg/generated/listers/core/v1"
+)
+
+// makePodSyncManager returns a pool manager syncing the pods specialized
+// for the function, which uses the environment.
+func makePodSyncManager(t *testing.T, fn *fv1.Function, env *fv1.Environment, pods ...*apiv1.Pod) *GenericPoolManager {
+	logger := zap.NewNop()
+	indexer := k8sCache.NewIndexer(k8sCache.MetaNamespaceKeyFunc, k8sCache.Indexers{k8sCache.NamespaceIndex: k8sCache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(fn))
+
+	kubernetesClient := fake.NewSimpleClientset()
+	for _, pod := range pods {
+		_, err := kubernetesClient.CoreV1().Pods(pod.Namespace).Create(t.Context(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	gpm := &GenericPoolManager{
+		logger:           logger,
+		kubernetesClient: kubernetesClient,
+		functionEnv:      cache.MakeCache[crd.CacheKeyUR, *fv1.Environment](time.Minute, 0),
+		fsCache:          fscache.MakeFunctionServiceCache(logger),
+		fnLister:         map[string]flisterv1.FunctionLister{fn.Namespace: flisterv1.NewFunctionLister(indexer)},
+	}
+	_, err := gpm.functionEnv.Set(crd.CacheKeyURFromMeta(&fn.ObjectMeta), env)
+	require.NoError(t, err)
+	return gpm
+}
+
+// makeSpecializedPod returns a ready pod specialized for the function by
+// an executor replica.
+func makeSpecializedPod(name string, fn *fv1.Function) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "fission-function",
+			Labels: map[string]string{
+				"managed":              "false",
+				fv1.EXECUTOR_TYPE:      string(fv1.ExecutorTypePoolmgr),
+				fv1.FUNCTION_NAME:      fn.Name,
+				fv1.FUNCTION_NAMESPACE: fn.Namespace,
+				fv1.FUNCTION_UID:       string(fn.UID),
+			},
+			Annotations: map[string]string{
+				fv1.ANNOTATION_SVC_HOST:       name + ":8888",
+				fv1.FUNCTION_RESOURCE_VERSION: fn.ResourceVersion,
+			},
+		},
+		Status: apiv1.PodStatus{
+			Phase:             apiv1.PodRunning,
+			PodIP:             "10.0.0.1",
+			ContainerStatuses: []apiv1.ContainerStatus{{Name: "env", Ready: true}},
+		},
+	}
+}
+
+// cachedPods returns the names of the pods in the pool cache.
+func cachedPods(t *testing.T, gpm *GenericPoolManager) []string {
+	pods := make([]string, 0)
+	for _, group := range gpm.fsCache.DumpDebugInfo(t.Context()) {
+		for _, svc := range group.Services {
+			pods = append(pods, svc.Name)
+		}
+	}
+	return pods
+}
+
+func TestSyncSpecializedPod(t *testing.T) {
+	ctx := t.Context()
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid-hello", ResourceVersion: "2"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Name: "python", Namespace: "default"},
+		},
+	}
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"}}
+
+	t.Run("adopts and forgets pods", func(t *testing.T) {
+		pod := makeSpecializedPod("pod-1", fn)
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		ctime := time.Now().Add(-2 * time.Minute).UTC()
+		pod.Annotations[fv1.ANNOTATION_SPECIALIZATION_TIME] = ctime.Format(time.RFC3339Nano)
+		atime := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+		pod.Annotations[fv1.ANNOTATION_LAST_ACCESS_TIME] = atime.Format(time.RFC3339)
+		gpm := makePodSyncManager(t, fn, env, pod)
+
+		gpm.syncSpecializedPod(ctx, pod)
+		require.Equal(t, []string{"pod-1"}, cachedPods(t, gpm))
+		value, ok := gpm.fsCache.PodToFsvc.Load("pod-1")
+		require.True(t, ok)
+		fsvc := value.(*fscache.FuncSvc)
+		require.Equal(t, "pod-1:8888", fsvc.Address)
+		require.Equal(t, env, fsvc.Environment)
+		require.Equal(t, atime, fsvc.Atime)
+		// pods are as old as their specialization, not as the pool pod
+		require.True(t, ctime.Equal(fsvc.Ctime))
+
+		// syncing again keeps a single function service
+		gpm.syncSpecializedPod(ctx, pod)
+		require.Equal(t, []string{"pod-1"}, cachedPods(t, gpm))
+
+		// pods no longer ready are forgotten
+		notReady := pod.DeepCopy()
+		notReady.Status.ContainerStatuses[0].Ready = false
+		gpm.syncSpecializedPod(ctx, notReady)
+		require.Empty(t, cachedPods(t, gpm))
+		_, ok = gpm.fsCache.PodToFsvc.Load("pod-1")
+		require.False(t, ok)
+	})
+
+	t.Run("forgets deleted pods", func(t *testing.T) {
+		pod := makeSpecializedPod("pod-1", fn)
+		gpm := makePodSyncManager(t, fn, env, pod)
+		handlers := gpm.specializedPodEventHandlers(ctx)
+
+		handlers.OnAdd(pod, false)
+		require.Equal(t, []string{"pod-1"}, cachedPods(t, gpm))
+		handlers.OnDelete(k8sCache.DeletedFinalStateUnknown{Key: "fission-function/pod-1", Obj: pod})
+		require.Empty(t, cachedPods(t, gpm))
+	})
+
+	for name, mutate := range map[string]func(pod *apiv1.Pod){
+		"generic pod": func(pod *apiv1.Pod) {
+			pod.Labels["managed"] = "true"
+		},
+		"pod being specialized": func(pod *apiv1.Pod) {
+			delete(pod.Annotations, fv1.ANNOTATION_SVC_HOST)
+		},
+		"pod of an older function version": func(pod *apiv1.Pod) {
+			pod.Annotations[fv1.FUNCTION_RESOURCE_VERSION] = "1"
+		},
+		"pod of a deleted function": func(pod *apiv1.Pod) {
+			pod.Labels[fv1.FUNCTION_NAME] = "deleted"
+		},
+		"terminating pod": func(pod *apiv1.Pod) {
+			pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		},
+	} {
+		t.Run("ignores "+name, func(t *testing.T) {
+			pod := makeSpecializedPod("pod-1", fn)
+			mutate(pod)
+			gpm := makePodSyncManager(t, fn, env, pod)
+
+			gpm.syncSpecializedPod(ctx, pod)
+			require.Empty(t, cachedPods(t, gpm))
+		})
+	}
+}
+
+func TestReportAccessTimes(t *testing.T) {
+	ctx := t.Context()
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid-hello", ResourceVersion: "2"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Name: "python", Namespace: "default"},
+		},
+	}
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"}}
+	pod := makeSpecializedPod("pod-1", fn)
+	gpm := makePodSyncManager(t, fn, env, pod)
+	gpm.syncSpecializedPod(ctx, pod)
+
+	// the adopted pod was accessed when it was specialized, long ago
+	gpm.reportAccessTimes(ctx)
+	p, err := gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	_, ok := executorUtils.GetLastAccessTime(p)
+	require.False(t, ok)
+
+	value, _ := gpm.fsCache.PodToFsvc.Load("pod-1")
+	fsvc := value.(*fscache.FuncSvc)
+	_, err = gpm.fsCache.GetFuncSvc(ctx, fsvc.Function, 1, 1)
+	require.NoError(t, err)
+
+	gpm.reportAccessTimes(ctx)
+	p, err = gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	atime, ok := executorUtils.GetLastAccessTime(p)
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now(), atime, time.Minute)
+}
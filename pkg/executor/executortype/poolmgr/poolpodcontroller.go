@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	k8sInformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	k8sCache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -60,6 +61,9 @@ type (
 		// podListerSynced returns true if the pod store has been synced at least once.
 		podListerSynced map[string]k8sCache.InformerSynced
 
+		rsLister       map[string]appslisters.ReplicaSetLister
+		rsListerSynced map[string]k8sCache.InformerSynced
+
 		envCreateUpdateQueue workqueue.TypedRateLimitingInterface[string]
 		envDeleteQueue       workqueue.TypedRateLimitingInterface[*fv1.Environment]
 
@@ -84,6 +88,8 @@ func NewPoolPodController(ctx context.Context, logger *zap.Logger,
 		envListerSynced:      make(map[string]k8sCache.InformerSynced, 0),
 		podLister:            make(map[string]corelisters.PodLister),
 		podListerSynced:      make(map[string]k8sCache.InformerSynced),
+		rsLister:             make(map[string]appslisters.ReplicaSetLister),
+		rsListerSynced:       make(map[string]k8sCache.InformerSynced),
 		envCreateUpdateQueue: workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[string](), workqueue.TypedRateLimitingQueueConfig[string]{Name: "EnvAddUpdateQueue"}),
 		envDeleteQueue:       workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[*fv1.Environment](), workqueue.TypedRateLimitingQueueConfig[*fv1.Environment]{Name: "EnvDeleteQueue"}),
 		spCleanupPodQueue:    workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[string](), workqueue.TypedRateLimitingQueueConfig[string]{Name: "SpecializedPodCleanupQueue"}),
@@ -127,6 +133,8 @@ func NewPoolPodController(ctx context.Context, logger *zap.Logger,
 		}
 		p.podListerSynced[ns] = informerFactory.Core().V1().Pods().Informer().HasSynced
 		p.podLister[ns] = informerFactory.Core().V1().Pods().Lister()
+		p.rsLister[ns] = informerFactory.Apps().V1().ReplicaSets().Lister()
+		p.rsListerSynced[ns] = informerFactory.Apps().V1().ReplicaSets().Informer().HasSynced
 	}
 
 	p.logger.Info("pool pod controller handlers registered")
@@ -247,6 +255,92 @@ func (p *PoolPodController) enqueueEnvDelete(obj interface{}) {
 	p.envDeleteQueue.Add(env)
 }
 
+// resync requeues all environments, and the specialized pods of deleted
+// environments and of scaled down pool replica sets, once this replica
+// starts leading. Pools and specialized pods are only updated and deleted by
+// the leader, events received while another replica was leading are caught
+// up here.
+func (p *PoolPodController) resync(ctx context.Context) {
+	waitSynced := make([]k8sCache.InformerSynced, 0)
+	for _, synced := range p.podListerSynced {
+		waitSynced = append(waitSynced, synced)
+	}
+	for _, synced := range p.rsListerSynced {
+		waitSynced = append(waitSynced, synced)
+	}
+	for _, synced := range p.envListerSynced {
+		waitSynced = append(waitSynced, synced)
+	}
+	if ok := k8sCache.WaitForCacheSync(ctx.Done(), waitSynced...); !ok {
+		return
+	}
+	p.logger.Info("resyncing environments after acquiring leadership")
+
+	for ns, lister := range p.envLister {
+		envs, err := lister.List(labels.Everything())
+		if err != nil {
+			p.logger.Error("error listing environments", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+		for _, env := range envs {
+			p.enqueueEnvAdd(env)
+		}
+	}
+
+	for ns, lister := range p.rsLister {
+		rsList, err := lister.ReplicaSets(ns).List(labels.SelectorFromSet(map[string]string{
+			fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypePoolmgr),
+		}))
+		if err != nil {
+			p.logger.Error("error listing replica sets", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+		for _, rs := range rsList {
+			p.processRS(rs)
+		}
+	}
+
+	for ns, lister := range p.podLister {
+		specializedPods, err := lister.Pods(ns).List(labels.SelectorFromSet(map[string]string{
+			fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypePoolmgr),
+			"managed":         "false",
+		}))
+		if err != nil {
+			p.logger.Error("failed to list specialized pods", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+		for _, pod := range specializedPods {
+			if !IsPodActive(pod) || p.envExists(pod) {
+				continue
+			}
+			key, err := k8sCache.MetaNamespaceKeyFunc(pod)
+			if err != nil {
+				p.logger.Error("Failed to get key for pod", zap.Error(err))
+				continue
+			}
+			p.spCleanupPodQueue.Add(key)
+		}
+	}
+}
+
+// envExists returns false if the environment of the specialized pod was
+// deleted. It returns true if it can't be known.
+func (p *PoolPodController) envExists(pod *v1.Pod) bool {
+	namespace := pod.Labels[fv1.ENVIRONMENT_NAMESPACE]
+	lister, ok := p.envLister[namespace]
+	if !ok {
+		return true
+	}
+	env, err := lister.Environments(namespace).Get(pod.Labels[fv1.ENVIRONMENT_NAME])
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return string(env.ObjectMeta.UID) == pod.Labels[fv1.ENVIRONMENT_UID]
+}
+
 func (p *PoolPodController) Run(ctx context.Context, stopCh <-chan struct{}, mgr manager.Interface) {
 	defer utilruntime.HandleCrash()
 	defer p.envCreateUpdateQueue.ShutDown()
@@ -312,28 +406,15 @@ func (p *PoolPodController) envCreateUpdateQueueProcessFunc(ctx context.Context)
 	handleEnv := func(ctx context.Context, env *fv1.Environment) error {
 		log := p.logger.With(zap.String("env", env.ObjectMeta.Name), zap.String("namespace", env.ObjectMeta.Namespace))
 		log.Debug("env reconsile request processing")
-		pool, created, err := p.gpm.getPool(ctx, env)
-		if err != nil {
-			log.Error("error getting pool", zap.Error(err))
-			return err
-		}
-		if created {
-			log.Info("created pool for the environment")
-			return nil
-		}
-		poolsize := getEnvPoolSize(env)
-		if poolsize == 0 {
-			log.Info("pool size is zero")
-			p.gpm.cleanupPool(ctx, env)
-			return nil
-		}
-		err = pool.updatePoolDeployment(ctx, env)
-		if err != nil {
-			log.Error("error updating pool", zap.Error(err))
-			return err
+		p.gpm.cleanupStalePools(ctx, env)
+		// each pool class is its own pool with its own deployment
+		for _, class := range getPoolClasses(env) {
+			err := p.handlePool(ctx, env, class)
+			if err != nil {
+				log.Error("error handling pool", zap.String("poolClass", class), zap.Error(err))
+				return err
+			}
 		}
-		// If any specialized pods are running, those would be
-		// deleted by replicaSet controller.
 		return nil
 	}
 
@@ -388,6 +469,40 @@ func (p *PoolPodController) envCreateUpdateQueueProcessFunc(ctx context.Context)
 	return false
 }
 
+// handlePool reconciles the pool of the class of the environment.
+func (p *PoolPodController) handlePool(ctx context.Context, env *fv1.Environment, class string) error {
+	log := p.logger.With(zap.String("env", env.ObjectMeta.Name), zap.String("namespace", env.ObjectMeta.Namespace),
+		zap.String("poolClass", class))
+	pool, created, err := p.gpm.getPool(ctx, env, class)
+	if err != nil {
+		log.Error("error getting pool", zap.Error(err))
+		return err
+	}
+	if created {
+		log.Info("created pool for the environment")
+		return nil
+	}
+	poolsize := getPoolSize(env, class)
+	if poolsize == 0 {
+		log.Info("pool size is zero")
+		p.gpm.cleanupPool(ctx, env, class)
+		return nil
+	}
+	if !p.gpm.leader.IsLeader() {
+		// the pool deployment is updated by the leader
+		p.gpm.refreshPool(ctx, env)
+		return nil
+	}
+	err = pool.updatePoolDeployment(ctx, env)
+	if err != nil {
+		log.Error("error updating pool", zap.Error(err))
+		return err
+	}
+	// If any specialized pods are running, those would be
+	// deleted by replicaSet controller.
+	return nil
+}
+
 func (p *PoolPodController) envDeleteQueueProcessFunc(ctx context.Context) bool {
 	env, quit := p.envDeleteQueue.Get()
 	if quit {
@@ -395,7 +510,9 @@ func (p *PoolPodController) envDeleteQueueProcessFunc(ctx context.Context) bool
 	}
 	defer p.envDeleteQueue.Done(env)
 	p.logger.Debug("env delete request processing")
-	p.gpm.cleanupPool(ctx, env)
+	for _, class := range getPoolClasses(env) {
+		p.gpm.cleanupPool(ctx, env, class)
+	}
 	specializePodLables := getSpecializedPodLabels(env)
 	ns := p.nsResolver.ResolveNamespace(p.nsResolver.FunctionNamespace)
 	podLister, ok := p.podLister[ns]
@@ -474,6 +591,11 @@ func (p *PoolPodController) spCleanupPodQueueProcessFunc(ctx context.Context) bo
 			p.logger.Error("could not convert item from PodToFsvc", zap.String("key", key))
 		}
 	}
+	if !p.gpm.leader.IsLeader() {
+		// the pod is deleted by the leader
+		p.spCleanupPodQueue.Forget(key)
+		return false
+	}
 	err = p.kubernetesClient.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		p.logger.Error("failed to delete pod", zap.Error(err), zap.String("pod", name), zap.String("pod_namespace", namespace))
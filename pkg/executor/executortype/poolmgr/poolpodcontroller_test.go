@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/dchest/uniuri"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -68,7 +69,7 @@ func TestPoolPodControllerPodCleanup(t *testing.T) {
 		logger,
 		fissionClient, kubernetesClient, metricsClient,
 		fetcherConfig, executorInstanceID,
-		factory, gpmInformerFactory, nil)
+		factory, gpmInformerFactory, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Error creating generic pool manager: %v", err)
 	}
@@ -132,3 +133,53 @@ func TestPoolPodControllerPodCleanup(t *testing.T) {
 		t.Fatalf("Pod %v still exists", getPod.ObjectMeta)
 	}
 }
+
+func TestPoolPodControllerResync(t *testing.T) {
+	ctx := t.Context()
+	logger := loggerfactory.GetLogger()
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: metav1.NamespaceDefault, UID: "uid-python"}}
+	specializedPod := func(name string, env *fv1.Environment) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    getSpecializedPodLabels(env),
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	deletedEnv := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "go", Namespace: metav1.NamespaceDefault, UID: "uid-go"}}
+	kubernetesClient := fake.NewSimpleClientset(specializedPod("python-pod", env), specializedPod("go-pod", deletedEnv))
+	fissionClient := fClient.NewSimpleClientset(env)
+	factory := map[string]genInformer.SharedInformerFactory{
+		metav1.NamespaceDefault: genInformer.NewFilteredSharedInformerFactory(fissionClient, time.Minute*30, metav1.NamespaceDefault, nil),
+	}
+	executorLabel, err := utils.GetInformerLabelByExecutor(fv1.ExecutorTypePoolmgr)
+	require.NoError(t, err)
+	gpmInformerFactory := utils.GetInformerFactoryByExecutor(kubernetesClient, executorLabel, time.Minute*30)
+
+	ppc, err := NewPoolPodController(ctx, logger, kubernetesClient, false, factory, gpmInformerFactory)
+	require.NoError(t, err)
+	t.Cleanup(ppc.envCreateUpdateQueue.ShutDown)
+	t.Cleanup(ppc.spCleanupPodQueue.ShutDown)
+	for _, f := range factory {
+		f.Start(ctx.Done())
+	}
+	for _, informerFactory := range gpmInformerFactory {
+		informerFactory.Start(ctx.Done())
+	}
+	// drop the events of the initial listing, as a non-leader would
+	require.True(t, k8sCache.WaitForCacheSync(ctx.Done(), ppc.envListerSynced[metav1.NamespaceDefault]))
+	for ppc.envCreateUpdateQueue.Len() > 0 {
+		key, _ := ppc.envCreateUpdateQueue.Get()
+		ppc.envCreateUpdateQueue.Done(key)
+	}
+
+	ppc.resync(ctx)
+	require.Equal(t, 1, ppc.envCreateUpdateQueue.Len())
+	key, _ := ppc.envCreateUpdateQueue.Get()
+	require.Equal(t, "default/python", key)
+	require.Equal(t, 1, ppc.spCleanupPodQueue.Len())
+	key, _ = ppc.spCleanupPodQueue.Get()
+	require.Equal(t, "default/go-pod", key)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/events"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/reaper"
+	executorUtils "github.com/fission/fission/pkg/executor/util"
+)
+
+const (
+	// podRecycleInterval is how often specialized pods which reached the limits
+	// of their function's recycle policy are looked up and deleted.
+	podRecycleInterval = 5 * time.Second
+
+	// podRecycleGracePeriod is how long the leader waits after a pod started
+	// recycling before deleting it, so that the other executor replicas stop
+	// assigning requests to it and report their in-flight requests.
+	podRecycleGracePeriod = 3 * podRecycleInterval
+
+	// activeRequestsReportExpiry is the age after which the in-flight requests
+	// reported on a pod are ignored, the replica reporting them is gone.
+	activeRequestsReportExpiry = 3 * podRecycleInterval
+)
+
+// recyclePolicy returns the recycle policy of the specialized pods of the function.
+func recyclePolicy(fn *fv1.Function) fscache.RecyclePolicy {
+	return fscache.RecyclePolicy{
+		MaxLifetime: time.Duration(fn.GetMaxPodLifetime()) * time.Second,
+		MaxRequests: fn.GetMaxPodRequests(),
+	}
+}
+
+// recyclingPodState is the state of a recycling pod recorded by the executor
+// replicas in its annotations.
+type recyclingPodState struct {
+	// remoteActiveRequests is set if other replicas serve requests through the pod
+	remoteActiveRequests bool
+	// drainDeadline is when a drained pod is deleted regardless of its
+	// in-flight requests, zero if the pod isn't drained
+	drainDeadline time.Time
+}
+
+// podRecycler deletes specialized pods which served the max requests or
+// outlived the max lifetime of their function, or which are drained, once
+// their in-flight requests finish. Requests are counted by each executor
+// replica for the function services it assigns. Every replica stops
+// assigning requests to recycling pods and records its in-flight requests on
+// them, the leader deletes the pods once no replica serves requests through
+// them, or once the deadline of their drain passed.
+func (gpm *GenericPoolManager) podRecycler(ctx context.Context) {
+	wait.UntilWithContext(ctx, gpm.recyclePods, podRecycleInterval)
+}
+
+func (gpm *GenericPoolManager) recyclePods(ctx context.Context) {
+	now := time.Now()
+	for _, svc := range gpm.fsCache.RecyclingFuncSvcs() {
+		if gpm.leader != nil {
+			gpm.reportRecyclingActiveRequests(ctx, svc, now)
+		}
+		if !gpm.leader.IsLeader() {
+			continue
+		}
+		state, err := gpm.getRecyclingPodState(ctx, svc.FuncSvc, now)
+		if err != nil {
+			gpm.logger.Warn("failed to get in-flight requests of recycling pod", zap.Error(err),
+				zap.String("function", svc.Function.Name), zap.String("pod", svc.Name))
+			continue
+		}
+		drained := !state.drainDeadline.IsZero()
+		// busy drained pods are deleted anyway once the deadline passed
+		if !drained || now.Before(state.drainDeadline) {
+			if svc.ActiveRequests > 0 || state.remoteActiveRequests {
+				continue
+			}
+			if gpm.leader != nil && now.Sub(svc.Since) < podRecycleGracePeriod {
+				continue
+			}
+		}
+		gpm.recyclePod(ctx, svc.FuncSvc, drained)
+	}
+}
+
+func (gpm *GenericPoolManager) recyclePod(ctx context.Context, fsvc *fscache.FuncSvc, drained bool) {
+	msg := "recycle function pod"
+	if drained {
+		msg = "delete drained function pod"
+	}
+	gpm.logger.Info(msg,
+		zap.String("function", fsvc.Function.Name),
+		zap.String("namespace", fsvc.Function.Namespace),
+		zap.String("pod", fsvc.Name),
+		zap.Duration("age", time.Since(fsvc.Ctime)))
+	gpm.fsCache.PodToFsvc.Delete(fsvc.Name)
+	gpm.fsCache.DeleteFunctionSvc(ctx, fsvc)
+	for i := range fsvc.KubernetesObjects {
+		reaper.CleanupKubeObject(ctx, gpm.logger, gpm.kubernetesClient, &fsvc.KubernetesObjects[i])
+	}
+	if drained {
+		return
+	}
+	metrics.PodsRecycled.WithLabelValues(fsvc.Function.Name, fsvc.Function.Namespace).Inc()
+	gpm.recorder.FunctionEvent(fsvc.Function, apiv1.EventTypeNormal, events.ReasonPodRecycled,
+		"Recycled function pod %s after %s", fsvc.Name, time.Since(fsvc.Ctime).Round(time.Second))
+}
+
+// reportRecyclingActiveRequests records the in-flight requests of this replica
+// on a recycling pod. The record also tells the other replicas to stop
+// assigning requests to the pod.
+func (gpm *GenericPoolManager) reportRecyclingActiveRequests(ctx context.Context, svc fscache.RecyclingSvc, now time.Time) {
+	for i := range svc.KubernetesObjects {
+		err := executorUtils.PatchActiveRequests(ctx, gpm.kubernetesClient, &svc.KubernetesObjects[i], gpm.leader.Identity(),
+			executorUtils.ActiveRequests{Count: svc.ActiveRequests, Time: now})
+		if err != nil && !apierrors.IsNotFound(err) {
+			gpm.logger.Warn("failed to record in-flight requests", zap.Error(err),
+				zap.String("function", svc.Function.Name), zap.String("pod", svc.Name))
+		}
+	}
+}
+
+// getRecyclingPodState returns whether other executor replicas recently
+// recorded in-flight requests on the pod of the function service, and the
+// deadline of its drain if it's drained.
+func (gpm *GenericPoolManager) getRecyclingPodState(ctx context.Context, fsvc *fscache.FuncSvc, now time.Time) (recyclingPodState, error) {
+	var state recyclingPodState
+	if gpm.leader == nil {
+		return state, nil
+	}
+	ownKey := executorUtils.ActiveRequestsReplicaKey(gpm.leader.Identity())
+	for _, obj := range fsvc.KubernetesObjects {
+		if !strings.EqualFold(obj.Kind, "pod") {
+			continue
+		}
+		pod, err := gpm.kubernetesClient.CoreV1().Pods(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return state, err
+		}
+		if deadline, ok := executorUtils.GetDrainDeadline(pod); ok {
+			state.drainDeadline = deadline
+		}
+		for replica, r := range executorUtils.GetActiveRequests(pod) {
+			if replica != ownKey && r.Count > 0 && now.Sub(r.Time) < activeRequestsReportExpiry {
+				state.remoteActiveRequests = true
+			}
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/events"
+	"github.com/fission/fission/pkg/executor/fscache"
+	executorUtils "github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/utils/leaderelection"
+)
+
+func TestRecyclePods(t *testing.T) {
+	ctx := t.Context()
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid-hello", ResourceVersion: "2"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Name: "python", Namespace: "default"},
+		},
+	}
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"}}
+
+	t.Run("marks pods recycled by other replicas", func(t *testing.T) {
+		pod := makeSpecializedPod("pod-1", fn)
+		gpm := makePodSyncManager(t, fn, env, pod)
+
+		gpm.syncSpecializedPod(ctx, pod)
+		require.Empty(t, gpm.fsCache.RecyclingFuncSvcs())
+
+		pod.Annotations[fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX+executorUtils.ActiveRequestsReplicaKey("replica-b")] =
+			"1," + time.Now().UTC().Format(time.RFC3339Nano)
+		gpm.syncSpecializedPod(ctx, pod)
+		recycling := gpm.fsCache.RecyclingFuncSvcs()
+		require.Len(t, recycling, 1)
+		require.Equal(t, "pod-1", recycling[0].Name)
+	})
+
+	t.Run("single replica deletes idle recycling pods", func(t *testing.T) {
+		pod := makeSpecializedPod("pod-1", fn)
+		gpm := makePodSyncManager(t, fn, env, pod)
+		gpm.recorder = events.MakeRecorderFor(record.NewFakeRecorder(10))
+
+		gpm.syncSpecializedPod(ctx, pod)
+		value, ok := gpm.fsCache.PodToFsvc.Load("pod-1")
+		require.True(t, ok)
+		gpm.fsCache.MarkRecycling(crd.CacheKeyURGFromMeta(&fn.ObjectMeta), value.(*fscache.FuncSvc).Address)
+
+		gpm.recyclePods(ctx)
+		require.Empty(t, cachedPods(t, gpm))
+		_, err := gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("non-leader reports in-flight requests and keeps pods", func(t *testing.T) {
+		t.Setenv(leaderelection.ENV_LEADER_ELECTION_NAMESPACE, "fission")
+		pod := makeSpecializedPod("pod-1", fn)
+		gpm := makePodSyncManager(t, fn, env, pod)
+		leader, err := leaderelection.New(zap.NewNop(), gpm.kubernetesClient, "executor", "replica-a")
+		require.NoError(t, err)
+		gpm.leader = leader
+
+		gpm.syncSpecializedPod(ctx, pod)
+		value, ok := gpm.fsCache.PodToFsvc.Load("pod-1")
+		require.True(t, ok)
+		gpm.fsCache.MarkRecycling(crd.CacheKeyURGFromMeta(&fn.ObjectMeta), value.(*fscache.FuncSvc).Address)
+
+		gpm.recyclePods(ctx)
+		require.Equal(t, []string{"pod-1"}, cachedPods(t, gpm))
+		reported, err := gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		reports := executorUtils.GetActiveRequests(reported)
+		require.Contains(t, reports, executorUtils.ActiveRequestsReplicaKey("replica-a"))
+		require.Equal(t, 0, reports[executorUtils.ActiveRequestsReplicaKey("replica-a")].Count)
+	})
+
+	t.Run("in-flight requests of other replicas keep pods", func(t *testing.T) {
+		t.Setenv(leaderelection.ENV_LEADER_ELECTION_NAMESPACE, "fission")
+		now := time.Now()
+		pod := makeSpecializedPod("pod-1", fn)
+		pod.Annotations[fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX+executorUtils.ActiveRequestsReplicaKey("replica-a")] =
+			"3," + now.UTC().Format(time.RFC3339Nano)
+		pod.Annotations[fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX+executorUtils.ActiveRequestsReplicaKey("replica-c")] =
+			"2," + now.Add(-time.Minute).UTC().Format(time.RFC3339Nano)
+		gpm := makePodSyncManager(t, fn, env, pod)
+		leader, err := leaderelection.New(zap.NewNop(), gpm.kubernetesClient, "executor", "replica-a")
+		require.NoError(t, err)
+		gpm.leader = leader
+		fsvc := &fscache.FuncSvc{
+			Function:          &fn.ObjectMeta,
+			KubernetesObjects: []apiv1.ObjectReference{{Kind: "pod", Name: pod.Name, Namespace: pod.Namespace}},
+		}
+
+		// own and expired reports are ignored
+		state, err := gpm.getRecyclingPodState(ctx, fsvc, now)
+		require.NoError(t, err)
+		require.False(t, state.remoteActiveRequests)
+
+		pod.Annotations[fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX+executorUtils.ActiveRequestsReplicaKey("replica-b")] =
+			"1," + now.UTC().Format(time.RFC3339Nano)
+		_, err = gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		state, err = gpm.getRecyclingPodState(ctx, fsvc, now)
+		require.NoError(t, err)
+		require.True(t, state.remoteActiveRequests)
+	})
+
+	t.Run("leader deletes busy drained pods at the deadline", func(t *testing.T) {
+		t.Setenv(leaderelection.ENV_LEADER_ELECTION_NAMESPACE, "fission")
+		now := time.Now()
+		pod := makeSpecializedPod("pod-1", fn)
+		pod.Annotations[fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX+executorUtils.ActiveRequestsReplicaKey("replica-b")] =
+			"1," + now.UTC().Format(time.RFC3339Nano)
+		gpm := makePodSyncManager(t, fn, env, pod)
+		leader, err := leaderelection.New(zap.NewNop(), gpm.kubernetesClient, "executor", "replica-a")
+		require.NoError(t, err)
+		gpm.leader = leader
+		gpm.syncSpecializedPod(ctx, pod)
+		value, ok := gpm.fsCache.PodToFsvc.Load("pod-1")
+		require.True(t, ok)
+		podRef := &value.(*fscache.FuncSvc).KubernetesObjects[0]
+
+		// drained pods are recycled by every replica
+		require.NoError(t, executorUtils.PatchDrainDeadline(ctx, gpm.kubernetesClient, podRef, now.Add(time.Minute)))
+		drained, err := gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		gpm.syncSpecializedPod(ctx, drained)
+		require.Len(t, gpm.fsCache.RecyclingFuncSvcs(), 1)
+
+		leading := make(chan struct{})
+		leader.OnStartedLeading(func(context.Context) { close(leading) })
+		leaderCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+		go leader.Run(leaderCtx)
+		select {
+		case <-leading:
+		case <-time.After(10 * time.Second):
+			t.Fatal("replica didn't acquire the lease")
+		}
+
+		// busy pods are kept until the deadline
+		gpm.recyclePods(ctx)
+		require.Equal(t, []string{"pod-1"}, cachedPods(t, gpm))
+
+		require.NoError(t, executorUtils.PatchDrainDeadline(ctx, gpm.kubernetesClient, podRef, now.Add(-time.Second)))
+		gpm.recyclePods(ctx)
+		require.Empty(t, cachedPods(t, gpm))
+		_, err = gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
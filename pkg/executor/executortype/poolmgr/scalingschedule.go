@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// maxWindowSchedules bounds the number of cached window schedules.
+const maxWindowSchedules = 1024
+
+type windowScheduleKey struct {
+	schedule string
+	timeZone string
+}
+
+var (
+	// windowSchedules caches the parsed window schedules, the retained pods
+	// of functions are checked on the request path.
+	windowSchedules    = make(map[windowScheduleKey]fv1.WindowSchedule)
+	windowSchedulesMtx sync.RWMutex
+)
+
+// parseWindowSchedule returns the parsed cron schedule and time zone of a
+// window, parsing them only the first time they are seen.
+func parseWindowSchedule(schedule, timeZone string) fv1.WindowSchedule {
+	key := windowScheduleKey{schedule: schedule, timeZone: timeZone}
+	windowSchedulesMtx.RLock()
+	ws, ok := windowSchedules[key]
+	windowSchedulesMtx.RUnlock()
+	if ok {
+		return ws
+	}
+
+	ws = fv1.ParseWindowSchedule(schedule, timeZone)
+	windowSchedulesMtx.Lock()
+	defer windowSchedulesMtx.Unlock()
+	if len(windowSchedules) >= maxWindowSchedules {
+		// schedules of deleted functions are dropped this way
+		clear(windowSchedules)
+	}
+	windowSchedules[key] = ws
+	return ws
+}
+
+// activeScalingSchedule returns the first scaling schedule of the function
+// whose window contains t, or nil if there is none.
+func activeScalingSchedule(fn *fv1.Function, t time.Time) *fv1.ScalingSchedule {
+	schedules := fn.Spec.InvokeStrategy.ExecutionStrategy.ScalingSchedules
+	for i := range schedules {
+		s := &schedules[i]
+		if parseWindowSchedule(s.Schedule, s.TimeZone).IsActive(s.Duration.Duration, t) {
+			return s
+		}
+	}
+	return nil
+}
+
+// getRetainPods returns the number of specialized pods of the function to
+// retain at t, overridden by the warm pods of the scaling schedule active
+// at t.
+func getRetainPods(fn *fv1.Function, t time.Time) int {
+	s := activeScalingSchedule(fn, t)
+	if s != nil && s.WarmPods != nil {
+		return *s.WarmPods
+	}
+	return fn.GetRetainPods()
+}
+
+// prewarmPods specializes pods for the functions whose active scaling
+// schedule keeps warm pods, until the function has as many specialized
+// pods as the schedule keeps. Pods are then warm as soon as a window
+// starts instead of after its first requests. Once warm, the pods are
+// retained by the idle object reaper until the window ends.
+func (gpm *GenericPoolManager) prewarmPods(ctx context.Context) {
+	if !gpm.leader.IsLeader() {
+		return
+	}
+
+	now := time.Now()
+	for namespace, lister := range gpm.fnLister {
+		fns, err := lister.List(labels.Everything())
+		if err != nil {
+			gpm.logger.Error("error listing functions", zap.Error(err), zap.String("namespace", namespace))
+			continue
+		}
+		for _, fn := range fns {
+			if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypePoolmgr {
+				continue
+			}
+			s := activeScalingSchedule(fn, now)
+			if s == nil || s.WarmPods == nil {
+				continue
+			}
+			specialized, err := gpm.countSpecializedPods(fn)
+			if err != nil {
+				gpm.logger.Error("error counting specialized pods", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+				continue
+			}
+			for range *s.WarmPods - specialized {
+				fsvc, err := gpm.GetFuncSvc(ctx, fn)
+				if err != nil {
+					gpm.logger.Error("error prewarming function pod", zap.Error(err),
+						zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace))
+					break
+				}
+				// the function service is returned in use, release it for requests
+				gpm.UnTapService(ctx, fsvc.Function, fsvc.Address)
+				gpm.logger.Info("prewarmed function pod for scaling schedule",
+					zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace),
+					zap.String("schedule", s.Name), zap.String("pod", fsvc.Name))
+			}
+		}
+	}
+}
+
+// countSpecializedPods returns the number of active pods specialized, or
+// being specialized, for the current version of the function by any executor
+// replica.
+func (gpm *GenericPoolManager) countSpecializedPods(fn *fv1.Function) (int, error) {
+	ns := gpm.nsResolver.GetFunctionNS(fn.Spec.Environment.Namespace)
+	lister, ok := gpm.podLister[ns]
+	if !ok {
+		return 0, fmt.Errorf("no pod lister found for namespace %s", ns)
+	}
+	pods, err := lister.Pods(ns).List(labels.SelectorFromSet(map[string]string{
+		fv1.FUNCTION_UID: string(fn.ObjectMeta.UID),
+		"managed":        "false",
+	}))
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, pod := range pods {
+		if !IsPodActive(pod) {
+			continue
+		}
+		// the resource version is patched once the specialization finished
+		if rv, ok := pod.Annotations[fv1.FUNCTION_RESOURCE_VERSION]; !ok || rv == fn.ObjectMeta.ResourceVersion {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+)
+
+func TestPrewarmPods(t *testing.T) {
+	warmPods := 2
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid-hello", ResourceVersion: "2"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Name: "python", Namespace: "default"},
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType: fv1.ExecutorTypePoolmgr,
+					ScalingSchedules: []fv1.ScalingSchedule{{
+						Name:     "always",
+						Schedule: "* * * * *",
+						Duration: metav1.Duration{Duration: time.Hour},
+						WarmPods: &warmPods,
+					}},
+				},
+			},
+		},
+	}
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "python", Namespace: "default"}}
+
+	specializing := makeSpecializedPod("specializing", fn)
+	delete(specializing.Annotations, fv1.FUNCTION_RESOURCE_VERSION)
+	outdated := makeSpecializedPod("outdated", fn)
+	outdated.Annotations[fv1.FUNCTION_RESOURCE_VERSION] = "1"
+	failed := makeSpecializedPod("failed", fn)
+	failed.Status.Phase = apiv1.PodFailed
+	pods := []*apiv1.Pod{makeSpecializedPod("specialized", fn), specializing, outdated, failed}
+
+	gpm := makePodSyncManager(t, fn, env, pods...)
+	gpm.nsResolver = &utils.NamespaceResolver{FunctionNamespace: "fission-function"}
+	indexer := k8sCache.NewIndexer(k8sCache.MetaNamespaceKeyFunc, k8sCache.Indexers{k8sCache.NamespaceIndex: k8sCache.MetaNamespaceIndexFunc})
+	for _, pod := range pods {
+		require.NoError(t, indexer.Add(pod))
+	}
+	gpm.podLister = map[string]corelisters.PodLister{"fission-function": corelisters.NewPodLister(indexer)}
+
+	specialized, err := gpm.countSpecializedPods(fn)
+	require.NoError(t, err)
+	require.Equal(t, 2, specialized)
+
+	// the function has as many pods as its scaling schedule keeps warm
+	gpm.prewarmPods(t.Context())
+	require.Empty(t, cachedPods(t, gpm))
+	list, err := gpm.kubernetesClient.CoreV1().Pods("fission-function").List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, len(pods))
+}
+
+func TestGetRetainPods(t *testing.T) {
+	warmPods := 3
+	fn := &fv1.Function{
+		Spec: fv1.FunctionSpec{
+			RetainPods: 1,
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType: fv1.ExecutorTypePoolmgr,
+					ScalingSchedules: []fv1.ScalingSchedule{{
+						Name:     "business-hours",
+						Schedule: "0 9 * * 1-5",
+						Duration: metav1.Duration{Duration: 8 * time.Hour},
+						TimeZone: "Europe/Berlin",
+						WarmPods: &warmPods,
+					}},
+				},
+			},
+		},
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	require.Equal(t, 3, getRetainPods(fn, time.Date(2026, 10, 19, 10, 0, 0, 0, berlin)))
+	require.Equal(t, 1, getRetainPods(fn, time.Date(2026, 10, 19, 18, 0, 0, 0, berlin)))
+	// the function spec alone doesn't depend on the clock
+	require.Equal(t, 1, fn.GetRetainPods())
+
+	// the schedule is parsed once
+	require.Contains(t, windowSchedules, windowScheduleKey{schedule: "0 9 * * 1-5", timeZone: "Europe/Berlin"})
+	require.Equal(t, parseWindowSchedule("0 9 * * 1-5", "Europe/Berlin"), windowSchedules[windowScheduleKey{schedule: "0 9 * * 1-5", timeZone: "Europe/Berlin"}])
+}
@@ -34,7 +34,6 @@ import (
 	"github.com/fission/fission/pkg/crd"
 	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/executor/metrics"
-	"github.com/fission/fission/pkg/executor/util"
 )
 
 type fscRequestType int
@@ -59,11 +58,29 @@ type (
 		KubernetesObjects []apiv1.ObjectReference // Kubernetes Objects (within the function namespace)
 		Executor          fv1.ExecutorType
 		CPULimit          resource.Quantity
+		PackageRef        fv1.PackageRef // package the function service was specialized with, if known
 
 		Ctime time.Time
 		Atime time.Time
 	}
 
+	// FuncSvcStatus represents the state of a cached function service
+	FuncSvcStatus struct {
+		FuncSvc
+		ActiveRequests  int
+		CurrentCPUUsage resource.Quantity
+		Draining        bool
+	}
+
+	// FuncSvcGroupStatus represents the state of the function services
+	// cached for a function
+	FuncSvcGroupStatus struct {
+		Function   crd.CacheKeyURG
+		SvcWaiting int
+		QueueLen   int
+		Services   []FuncSvcStatus
+	}
+
 	// FunctionServiceCache represents the function service cache
 	FunctionServiceCache struct {
 		logger            *zap.Logger
@@ -167,25 +184,55 @@ func (fsc *FunctionServiceCache) service() {
 	}
 }
 
-// DumpDebugInfo => dump function service cache data to temporary directory of executor pod.
-func (fsc *FunctionServiceCache) DumpDebugInfo(ctx context.Context) error {
-	fsc.logger.Info("dumping function service")
+// DumpDebugInfo returns a snapshot of the function services in the cache,
+// grouped by function.
+func (fsc *FunctionServiceCache) DumpDebugInfo(ctx context.Context) []FuncSvcGroupStatus {
+	groups := fsc.connFunctionCache.ListFnSvcGroupStatus()
+	for _, fsvc := range fsc.byFunction.Copy() {
+		groups = append(groups, FuncSvcGroupStatus{
+			Function: crd.CacheKeyURGFromMeta(fsvc.Function),
+			Services: []FuncSvcStatus{{FuncSvc: *fsvc}},
+		})
+	}
+	return groups
+}
 
-	file, err := util.CreateDumpFile(fsc.logger)
-	if err != nil {
-		fsc.logger.Error("error while creating file/dir", zap.String("error", err.Error()))
-		return err
-	}
-	defer file.Close()
+// DrainFunc stops assigning requests to the function services of the
+// function with the given UID, and returns the drained function services.
+func (fsc *FunctionServiceCache) DrainFunc(uid types.UID) []*FuncSvc {
+	return fsc.connFunctionCache.DrainValues(uid)
+}
 
-	err = fsc.connFunctionCache.LogFnSvcGroup(ctx, file)
-	if err != nil {
-		fsc.logger.Error("error while logging function service group", zap.String("error", err.Error()))
-		return err
-	}
+// ReleaseDrained removes the drained function services of the function with
+// the given UID which finished serving requests, or all of them if force
+// is set. It returns the removed function services, so that they can be
+// cleaned up, and the number of function services still serving requests.
+func (fsc *FunctionServiceCache) ReleaseDrained(uid types.UID, force bool) ([]*FuncSvc, int) {
+	return fsc.connFunctionCache.ReleaseDrainedValues(uid, force)
+}
 
-	fsc.logger.Info("dumped function service")
-	return nil
+// SetRecyclePolicy sets the recycle policy of the function services of a function.
+func (fsc *FunctionServiceCache) SetRecyclePolicy(key crd.CacheKeyURG, policy RecyclePolicy) {
+	fsc.connFunctionCache.SetRecyclePolicy(key, policy)
+}
+
+// SetRetainPods sets the number of specialized pods of a function which are
+// retained after serving requests.
+func (fsc *FunctionServiceCache) SetRetainPods(key crd.CacheKeyURG, retainPods int) {
+	fsc.connFunctionCache.SetSvcRetain(key, retainPods)
+}
+
+// RecyclingFuncSvcs returns the function services which reached the limits
+// of their recycle policy. They are deleted with DeleteFunctionSvc once no
+// executor replica serves requests through them anymore.
+func (fsc *FunctionServiceCache) RecyclingFuncSvcs() []RecyclingSvc {
+	return fsc.connFunctionCache.RecycleValues()
+}
+
+// MarkRecycling stops assigning requests to a function service which reached
+// the limits of its recycle policy on another executor replica.
+func (fsc *FunctionServiceCache) MarkRecycling(key crd.CacheKeyURG, svcHost string) {
+	fsc.connFunctionCache.MarkRecycling(key, svcHost)
 }
 
 // GetByFunction gets a function service from cache using function key.
@@ -240,12 +287,20 @@ func (fsc *FunctionServiceCache) GetByFunctionUID(uid types.UID) (*FuncSvc, erro
 	return &fsvcCopy, nil
 }
 
-// AddFunc adds a function service to pool cache.
+// AddFunc adds a function service to pool cache. The creation time of the
+// function service is kept if it's set.
 func (fsc *FunctionServiceCache) AddFunc(ctx context.Context, fsvc FuncSvc, requestsPerPod, svcsRetain int) {
+	if fsvc.Ctime.IsZero() {
+		now := time.Now()
+		fsvc.Ctime = now
+		fsvc.Atime = now
+	}
 	fsc.connFunctionCache.SetSvcValue(ctx, crd.CacheKeyURGFromMeta(fsvc.Function), fsvc.Address, &fsvc, fsvc.CPULimit, requestsPerPod, svcsRetain)
-	now := time.Now()
-	fsvc.Ctime = now
-	fsvc.Atime = now
+}
+
+// AdoptFunc adds a function service specialized by another executor replica to pool cache.
+func (fsc *FunctionServiceCache) AdoptFunc(fsvc FuncSvc, svcsRetain int) {
+	fsc.connFunctionCache.AdoptSvcValue(crd.CacheKeyURGFromMeta(fsvc.Function), fsvc.Address, &fsvc, fsvc.CPULimit, svcsRetain)
 }
 
 func (fsc *FunctionServiceCache) MarkFuncDeleted(key crd.CacheKeyURG) {
@@ -428,6 +483,23 @@ func (fsc *FunctionServiceCache) ListOldForPool(age time.Duration) ([]*FuncSvc,
 	return resp.objects, resp.error
 }
 
+// ListActiveForPool returns a list of function services in pool cache which
+// are busy or have been accessed within the given age.
+func (fsc *FunctionServiceCache) ListActiveForPool(age time.Duration) []*FuncSvc {
+	return fsc.connFunctionCache.ListActiveValue(age)
+}
+
+// ListRecent returns a list of function services in cache accessed within the given age.
+func (fsc *FunctionServiceCache) ListRecent(age time.Duration) []*FuncSvc {
+	funcObjects := make([]*FuncSvc, 0)
+	for _, fsvc := range fsc.byFunction.Copy() {
+		if time.Since(fsvc.Atime) < age {
+			funcObjects = append(funcObjects, fsvc)
+		}
+	}
+	return funcObjects
+}
+
 // Log makes a LOG type cache request.
 func (fsc *FunctionServiceCache) Log() {
 	fsc.logger.Info("--- FunctionService Cache Contents")
@@ -188,4 +188,10 @@ func TestFunctionServiceNewCache(t *testing.T) {
 	vals, err = fsc.ListOldForPool(0)
 	require.NoError(t, err)
 	require.Equal(t, 0, len(vals))
+
+	// the scaling schedule of the function no longer keeps pods warm
+	fsc.SetRetainPods(key, 0)
+	vals, err = fsc.ListOldForPool(0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(vals))
 }
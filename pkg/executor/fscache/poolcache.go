@@ -17,11 +17,9 @@ limitations under the License.
 package fscache
 
 import (
-	"bufio"
 	"context"
-	"errors"
 	"fmt"
-	"io"
+	"time"
 
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -42,8 +40,16 @@ const (
 	deleteValue
 	setCPUUtilization
 	markSpecializationFailure
-	logFuncSvc
 	markDeleted
+	adoptValue
+	listActiveValue
+	listStatus
+	drainValues
+	releaseDrained
+	setRecyclePolicy
+	recycleValues
+	markRecycling
+	setSvcRetain
 )
 
 type (
@@ -52,6 +58,10 @@ type (
 		activeRequests  int               // number of requests served by function pod
 		currentCPUUsage resource.Quantity // current cpu usage of the specialized function pod
 		cpuLimit        resource.Quantity // if currentCPUUsage is more than cpuLimit cache miss occurs in getValue request
+		draining        bool              // draining function services are not assigned new requests
+		servedRequests  int               // number of requests assigned to the function pod in its lifetime
+		recycling       bool              // function pod reached the limits of the recycle policy
+		recyclingSince  time.Time         // time the function pod started recycling
 	}
 
 	funcSvcGroup struct {
@@ -60,6 +70,22 @@ type (
 		svcs       map[string]*funcSvcInfo
 		queue      *Queue
 		deleted    bool
+		recycle    RecyclePolicy
+	}
+
+	// RecyclingSvc is a function service which reached the limits of the
+	// recycle policy of its function.
+	RecyclingSvc struct {
+		*FuncSvc
+		ActiveRequests int
+		Since          time.Time
+	}
+
+	// RecyclePolicy limits the lifetime and the number of requests served by
+	// the specialized pods of a function. Zero values disable the limits.
+	RecyclePolicy struct {
+		MaxLifetime time.Duration
+		MaxRequests int
 	}
 
 	// PoolCache implements a simple cache implementation having values mapped by two keys [function][address].
@@ -75,17 +101,22 @@ type (
 		ctx             context.Context
 		function        crd.CacheKeyURG
 		address         string
-		dumpWriter      io.Writer
 		value           *FuncSvc
 		requestsPerPod  int
 		cpuUsage        resource.Quantity
 		responseChannel chan *response
 		concurrency     int
 		svcsRetain      int
+		age             time.Duration
+		force           bool
+		recycle         RecyclePolicy
 	}
 	response struct {
 		error
 		allValues    []*FuncSvc
+		recycling    []RecyclingSvc
+		groups       []FuncSvcGroupStatus
+		remaining    int
 		value        *FuncSvc
 		svcWaitValue *svcWait
 	}
@@ -131,13 +162,20 @@ func (c *PoolCache) service() {
 			}
 			found := false
 			totalActiveRequests := 0
+			drainingSvcs := 0
 			// check if any specialized pod is available
 			for addr := range funcSvcGroup.svcs {
+				if funcSvcGroup.svcs[addr].draining {
+					// draining pods neither serve new requests nor count against the concurrency
+					drainingSvcs++
+					continue
+				}
 				totalActiveRequests += funcSvcGroup.svcs[addr].activeRequests
 				if funcSvcGroup.svcs[addr].activeRequests < req.requestsPerPod &&
 					funcSvcGroup.svcs[addr].currentCPUUsage.Cmp(funcSvcGroup.svcs[addr].cpuLimit) < 1 {
 					// mark active
 					funcSvcGroup.svcs[addr].activeRequests++
+					funcSvcGroup.markServed(funcSvcGroup.svcs[addr])
 					if c.logger.Core().Enabled(zap.DebugLevel) {
 						otelUtils.LoggerWithTraceID(req.ctx, c.logger).Debug("Increase active requests with getValue", zap.String("function", req.function.String()), zap.String("address", addr), zap.Int("activeRequests", funcSvcGroup.svcs[addr].activeRequests))
 					}
@@ -151,7 +189,7 @@ func (c *PoolCache) service() {
 				req.responseChannel <- resp
 				continue
 			}
-			concurrencyUsed := len(funcSvcGroup.svcs) + (funcSvcGroup.svcWaiting - funcSvcGroup.queue.Len())
+			concurrencyUsed := len(funcSvcGroup.svcs) - drainingSvcs + (funcSvcGroup.svcWaiting - funcSvcGroup.queue.Len())
 			// if concurrency is available then be aggressive and use it as we are not sure if specialization will complete for other requests
 			if req.concurrency > 0 && concurrencyUsed < req.concurrency {
 				funcSvcGroup.svcWaiting++
@@ -193,6 +231,7 @@ func (c *PoolCache) service() {
 			c.cache[req.function].svcRetain = req.svcsRetain
 			c.cache[req.function].svcs[req.address].val = req.value
 			c.cache[req.function].svcs[req.address].activeRequests++
+			c.cache[req.function].markServed(c.cache[req.function].svcs[req.address])
 			if c.cache[req.function].svcWaiting > 0 {
 				c.cache[req.function].svcWaiting--
 				svcCapacity := req.requestsPerPod - c.cache[req.function].svcs[req.address].activeRequests
@@ -208,6 +247,7 @@ func (c *PoolCache) service() {
 					if popped.ctx.Err() == nil {
 						popped.svcChannel <- req.value
 						c.cache[req.function].svcs[req.address].activeRequests++
+						c.cache[req.function].markServed(c.cache[req.function].svcs[req.address])
 						i++
 					}
 					close(popped.svcChannel)
@@ -218,6 +258,34 @@ func (c *PoolCache) service() {
 				otelUtils.LoggerWithTraceID(req.ctx, c.logger).Debug("Increase active requests with setValue", zap.String("function", req.function.String()), zap.String("address", req.address), zap.Int("activeRequests", c.cache[req.function].svcs[req.address].activeRequests))
 			}
 			c.cache[req.function].svcs[req.address].cpuLimit = req.cpuUsage
+		case adoptValue:
+			// function service specialized by another executor replica, cache it
+			// without marking it active and only move its access time forward
+			if _, ok := c.cache[req.function]; !ok {
+				c.cache[req.function] = NewFuncSvcGroup()
+			}
+			if svc, ok := c.cache[req.function].svcs[req.address]; ok {
+				if req.value.Atime.After(svc.val.Atime) {
+					svc.val.Atime = req.value.Atime
+				}
+				continue
+			}
+			c.cache[req.function].svcRetain = req.svcsRetain
+			c.cache[req.function].svcs[req.address] = &funcSvcInfo{
+				val:      req.value,
+				cpuLimit: req.cpuUsage,
+			}
+		case listActiveValue:
+			vals := make([]*FuncSvc, 0)
+			for _, values := range c.cache {
+				for _, value := range values.svcs {
+					if value.activeRequests > 0 || time.Since(value.val.Atime) < req.age {
+						vals = append(vals, value.val)
+					}
+				}
+			}
+			resp.allValues = vals
+			req.responseChannel <- resp
 		case markDeleted:
 			for key := range c.cache {
 				if key.UID == req.function.UID {
@@ -302,44 +370,101 @@ func (c *PoolCache) service() {
 				}
 			}
 			req.responseChannel <- resp
-		case logFuncSvc:
-			datawriter := bufio.NewWriter(req.dumpWriter)
-
-			writefnSvcGrp := func(svcGrp *funcSvcGroup) error {
-				_, err := fmt.Fprintf(datawriter, "svc_waiting:%d\tqueue_len:%d", svcGrp.svcWaiting, svcGrp.queue.Len())
-				if err != nil {
-					return err
+		case listStatus:
+			groups := make([]FuncSvcGroupStatus, 0, len(c.cache))
+			for key, values := range c.cache {
+				group := FuncSvcGroupStatus{
+					Function:   key,
+					SvcWaiting: values.svcWaiting,
+					QueueLen:   values.queue.Len(),
+					Services:   make([]FuncSvcStatus, 0, len(values.svcs)),
 				}
-
-				if len(svcGrp.svcs) == 0 {
-					_, err := datawriter.WriteString("\n")
-					if err != nil {
-						return err
-					}
+				for _, value := range values.svcs {
+					group.Services = append(group.Services, FuncSvcStatus{
+						FuncSvc:         *value.val,
+						ActiveRequests:  value.activeRequests,
+						CurrentCPUUsage: value.currentCPUUsage,
+						Draining:        value.draining,
+					})
 				}
-
-				for addr, fnSvc := range svcGrp.svcs {
-					_, err := fmt.Fprintf(datawriter, "\tfunction_name:%s\tfn_svc_address:%s\tactive_req:%d\tcurrent_cpu_usage:%v\tcpu_limit:%v\n",
-						fnSvc.val.Function.Name, addr, fnSvc.activeRequests, fnSvc.currentCPUUsage, fnSvc.cpuLimit)
-					if err != nil {
-						return err
-					}
+				groups = append(groups, group)
+			}
+			resp.groups = groups
+			req.responseChannel <- resp
+		case drainValues:
+			vals := make([]*FuncSvc, 0)
+			for key, values := range c.cache {
+				if key.UID != req.function.UID {
+					continue
+				}
+				for _, value := range values.svcs {
+					value.draining = true
+					vals = append(vals, value.val)
 				}
-				return nil
 			}
-
-			for _, fnSvcGrp := range c.cache {
-				err := writefnSvcGrp(fnSvcGrp)
-				if err != nil {
-					resp.error = err
-					break
+			resp.allValues = vals
+			req.responseChannel <- resp
+		case releaseDrained:
+			// remove drained function services which finished serving requests,
+			// or all of them if forced, so that they can be cleaned up
+			vals := make([]*FuncSvc, 0)
+			for key, values := range c.cache {
+				if key.UID != req.function.UID {
+					continue
 				}
+				for addr, value := range values.svcs {
+					if !value.draining {
+						continue
+					}
+					if value.activeRequests == 0 || req.force {
+						vals = append(vals, value.val)
+						delete(values.svcs, addr)
+					} else {
+						resp.remaining++
+					}
+				}
+				if values.deleted && len(values.svcs) == 0 {
+					delete(c.cache, key)
+				}
+			}
+			resp.allValues = vals
+			req.responseChannel <- resp
+		case setRecyclePolicy:
+			if _, ok := c.cache[req.function]; !ok {
+				c.cache[req.function] = NewFuncSvcGroup()
 			}
-			err := datawriter.Flush()
-			if err != nil {
-				resp.error = errors.Join(resp.error, err)
+			c.cache[req.function].recycle = req.recycle
+		case setSvcRetain:
+			// only update functions which have function services
+			if group, ok := c.cache[req.function]; ok {
+				group.svcRetain = req.svcsRetain
+			}
+		case recycleValues:
+			// stop assigning requests to function services which are past their
+			// lifetime, and list the ones which are recycling
+			vals := make([]RecyclingSvc, 0)
+			for _, values := range c.cache {
+				for _, value := range values.svcs {
+					if values.recycle.MaxLifetime > 0 && time.Since(value.val.Ctime) >= values.recycle.MaxLifetime {
+						value.markRecycling()
+					}
+					if value.recycling {
+						vals = append(vals, RecyclingSvc{
+							FuncSvc:        value.val,
+							ActiveRequests: value.activeRequests,
+							Since:          value.recyclingSince,
+						})
+					}
+				}
 			}
+			resp.recycling = vals
 			req.responseChannel <- resp
+		case markRecycling:
+			if group, ok := c.cache[req.function]; ok {
+				if svc, ok := group.svcs[req.address]; ok {
+					svc.markRecycling()
+				}
+			}
 		default:
 			resp.error = ferror.MakeError(ferror.ErrorInvalidArgument,
 				fmt.Sprintf("invalid request type: %v", req.requestType))
@@ -348,6 +473,25 @@ func (c *PoolCache) service() {
 	}
 }
 
+// markServed counts a request assigned to the function service and stops
+// assigning new requests once it reached the max requests of the group.
+func (group *funcSvcGroup) markServed(svc *funcSvcInfo) {
+	svc.servedRequests++
+	if group.recycle.MaxRequests > 0 && svc.servedRequests >= group.recycle.MaxRequests {
+		svc.markRecycling()
+	}
+}
+
+// markRecycling stops assigning new requests to the function service.
+func (svc *funcSvcInfo) markRecycling() {
+	if svc.recycling {
+		return
+	}
+	svc.recycling = true
+	svc.recyclingSince = time.Now()
+	svc.draining = true
+}
+
 func (c *PoolCache) MarkFuncDeleted(function crd.CacheKeyURG) {
 	c.requestChannel <- &request{
 		requestType: markDeleted,
@@ -406,6 +550,33 @@ func (c *PoolCache) SetSvcValue(ctx context.Context, function crd.CacheKeyURG, a
 	}
 }
 
+// AdoptSvcValue caches the value at key [function][address] without marking it as active.
+// If the value exists already, only its access time is updated.
+func (c *PoolCache) AdoptSvcValue(function crd.CacheKeyURG, address string, value *FuncSvc, cpuLimit resource.Quantity, svcsRetain int) {
+	c.requestChannel <- &request{
+		requestType:     adoptValue,
+		function:        function,
+		address:         address,
+		value:           value,
+		cpuUsage:        cpuLimit,
+		svcsRetain:      svcsRetain,
+		responseChannel: make(chan *response),
+	}
+}
+
+// ListActiveValue returns the function services which are serving requests
+// or have been accessed within the given age.
+func (c *PoolCache) ListActiveValue(age time.Duration) []*FuncSvc {
+	respChannel := make(chan *response)
+	c.requestChannel <- &request{
+		requestType:     listActiveValue,
+		age:             age,
+		responseChannel: respChannel,
+	}
+	resp := <-respChannel
+	return resp.allValues
+}
+
 // SetCPUUtilization updates/sets the CPU utilization limit for the pod
 func (c *PoolCache) SetCPUUtilization(function crd.CacheKeyURG, address string, cpuUsage resource.Quantity) {
 	c.requestChannel <- &request{
@@ -451,13 +622,89 @@ func (c *PoolCache) MarkSpecializationFailure(function crd.CacheKeyURG) {
 	}
 }
 
-func (c *PoolCache) LogFnSvcGroup(ctx context.Context, file io.Writer) error {
+// ListFnSvcGroupStatus returns a snapshot of the function service groups
+// stored in the Cache, including the state of each function service.
+func (c *PoolCache) ListFnSvcGroupStatus() []FuncSvcGroupStatus {
 	respChannel := make(chan *response)
 	c.requestChannel <- &request{
-		requestType:     logFuncSvc,
-		dumpWriter:      file,
+		requestType:     listStatus,
 		responseChannel: respChannel,
 	}
 	resp := <-respChannel
-	return resp.error
+	return resp.groups
+}
+
+// DrainValues stops assigning requests to the function services of the
+// function with the given UID and returns them.
+func (c *PoolCache) DrainValues(uid types.UID) []*FuncSvc {
+	respChannel := make(chan *response)
+	c.requestChannel <- &request{
+		requestType:     drainValues,
+		function:        crd.CacheKeyURG{UID: uid},
+		responseChannel: respChannel,
+	}
+	resp := <-respChannel
+	return resp.allValues
+}
+
+// ReleaseDrainedValues removes the drained function services of the function
+// with the given UID which are not serving requests anymore, or all of them
+// when force is set. It returns the removed values and the number of drained
+// function services which are still serving requests.
+func (c *PoolCache) ReleaseDrainedValues(uid types.UID, force bool) ([]*FuncSvc, int) {
+	respChannel := make(chan *response)
+	c.requestChannel <- &request{
+		requestType:     releaseDrained,
+		function:        crd.CacheKeyURG{UID: uid},
+		force:           force,
+		responseChannel: respChannel,
+	}
+	resp := <-respChannel
+	return resp.allValues, resp.remaining
+}
+
+// SetRecyclePolicy sets the recycle policy of the function services of the function.
+func (c *PoolCache) SetRecyclePolicy(function crd.CacheKeyURG, policy RecyclePolicy) {
+	c.requestChannel <- &request{
+		requestType:     setRecyclePolicy,
+		function:        function,
+		recycle:         policy,
+		responseChannel: make(chan *response),
+	}
+}
+
+// SetSvcRetain sets the number of function services of the function which
+// are retained after serving requests.
+func (c *PoolCache) SetSvcRetain(function crd.CacheKeyURG, svcsRetain int) {
+	c.requestChannel <- &request{
+		requestType:     setSvcRetain,
+		function:        function,
+		svcsRetain:      svcsRetain,
+		responseChannel: make(chan *response),
+	}
+}
+
+// RecycleValues marks the function services past the max lifetime of their
+// function as draining, and returns the function services which reached the
+// limits of the recycle policy. Recycling function services stay cached until
+// they are deleted with DeleteValue.
+func (c *PoolCache) RecycleValues() []RecyclingSvc {
+	respChannel := make(chan *response)
+	c.requestChannel <- &request{
+		requestType:     recycleValues,
+		responseChannel: respChannel,
+	}
+	resp := <-respChannel
+	return resp.recycling
+}
+
+// MarkRecycling stops assigning requests to the value at key [function][address],
+// because it reached the limits of the recycle policy on another executor replica.
+func (c *PoolCache) MarkRecycling(function crd.CacheKeyURG, address string) {
+	c.requestChannel <- &request{
+		requestType:     markRecycling,
+		function:        function,
+		address:         address,
+		responseChannel: make(chan *response),
+	}
 }
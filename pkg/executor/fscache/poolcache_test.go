@@ -8,6 +8,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -132,6 +133,145 @@ func TestPoolCache(t *testing.T) {
 			log.Panicf("found value when expected it to be nil")
 		}
 	})
+
+	t.Run("Test adopted svc is available and only moves access time forward", func(t *testing.T) {
+		c6 := NewPoolCache(logger)
+		atime := time.Now().Add(-time.Minute)
+		c6.AdoptSvcValue(keyFunc, "ip", &FuncSvc{
+			Name:  "value",
+			Atime: atime,
+		}, resource.MustParse("45m"), 0)
+
+		require.Len(t, c6.ListAvailableValue(), 1)
+		require.Len(t, c6.ListActiveValue(30*time.Second), 0)
+
+		c6.AdoptSvcValue(keyFunc, "ip", &FuncSvc{
+			Name:  "value",
+			Atime: atime.Add(-time.Minute),
+		}, resource.MustParse("45m"), 0)
+		require.Len(t, c6.ListActiveValue(2*time.Minute), 1)
+		require.Len(t, c6.ListActiveValue(30*time.Second), 0)
+
+		c6.AdoptSvcValue(keyFunc, "ip", &FuncSvc{
+			Name:  "value",
+			Atime: time.Now(),
+		}, resource.MustParse("45m"), 0)
+		require.Len(t, c6.ListActiveValue(30*time.Second), 1)
+
+		// adopted svc serves requests
+		_, err := c6.GetSvcValue(ctx, keyFunc, requestsPerPod, concurrency)
+		checkErr(err)
+	})
+
+	t.Run("Test drained svc is not assigned and released once idle", func(t *testing.T) {
+		c7 := NewPoolCache(logger)
+		c7.SetSvcValue(ctx, keyFunc, "ip", &FuncSvc{
+			Name: "value",
+		}, resource.MustParse("45m"), 10, 0)
+		c7.SetSvcValue(ctx, keyFunc2, "ip2", &FuncSvc{
+			Name: "value2",
+		}, resource.MustParse("45m"), 10, 0)
+
+		require.Len(t, c7.DrainValues(keyFunc.UID), 1)
+
+		// drained svc is not assigned new requests
+		_, err := c7.GetSvcValue(ctx, keyFunc, requestsPerPod, concurrency)
+		require.Error(t, err)
+
+		// svc still serving a request is not released
+		released, remaining := c7.ReleaseDrainedValues(keyFunc.UID, false)
+		require.Len(t, released, 0)
+		require.Equal(t, 1, remaining)
+
+		for _, group := range c7.ListFnSvcGroupStatus() {
+			for _, svc := range group.Services {
+				require.Equal(t, group.Function == keyFunc, svc.Draining)
+				require.Equal(t, 1, svc.ActiveRequests)
+			}
+		}
+
+		c7.MarkAvailable(keyFunc, "ip")
+		released, remaining = c7.ReleaseDrainedValues(keyFunc.UID, false)
+		require.Len(t, released, 1)
+		require.Equal(t, "value", released[0].Name)
+		require.Equal(t, 0, remaining)
+
+		// other functions are not drained
+		released, _ = c7.ReleaseDrainedValues(keyFunc2.UID, true)
+		require.Len(t, released, 0)
+	})
+
+	t.Run("Test svc is recycled after max requests once idle", func(t *testing.T) {
+		c8 := NewPoolCache(logger)
+		c8.SetRecyclePolicy(keyFunc, RecyclePolicy{MaxRequests: 2})
+		c8.SetSvcValue(ctx, keyFunc, "ip", &FuncSvc{
+			Name:  "value",
+			Ctime: time.Now(),
+		}, resource.MustParse("45m"), 10, 0)
+
+		// second request reaches the max requests
+		_, err := c8.GetSvcValue(ctx, keyFunc, requestsPerPod+1, concurrency)
+		require.NoError(t, err)
+		_, err = c8.GetSvcValue(ctx, keyFunc, requestsPerPod+1, concurrency)
+		require.Error(t, err)
+		recycling := c8.RecycleValues()
+		require.Len(t, recycling, 1)
+		require.Equal(t, "value", recycling[0].Name)
+		require.Equal(t, 2, recycling[0].ActiveRequests)
+
+		c8.MarkAvailable(keyFunc, "ip")
+		c8.MarkAvailable(keyFunc, "ip")
+		recycling = c8.RecycleValues()
+		require.Len(t, recycling, 1)
+		require.Equal(t, 0, recycling[0].ActiveRequests)
+		require.False(t, recycling[0].Since.IsZero())
+
+		// recycling svcs stay cached until deleted
+		require.Len(t, c8.ListFnSvcGroupStatus()[0].Services, 1)
+		require.NoError(t, c8.DeleteValue(ctx, keyFunc, "ip"))
+		require.Len(t, c8.RecycleValues(), 0)
+	})
+
+	t.Run("Test svc is recycled after max lifetime", func(t *testing.T) {
+		c9 := NewPoolCache(logger)
+		c9.SetRecyclePolicy(keyFunc, RecyclePolicy{MaxLifetime: time.Minute})
+		c9.SetSvcValue(ctx, keyFunc, "ip", &FuncSvc{
+			Name:  "old",
+			Ctime: time.Now().Add(-2 * time.Minute),
+		}, resource.MustParse("45m"), 10, 0)
+		c9.SetSvcValue(ctx, keyFunc, "ip2", &FuncSvc{
+			Name:  "new",
+			Ctime: time.Now(),
+		}, resource.MustParse("45m"), 10, 0)
+		c9.MarkAvailable(keyFunc, "ip2")
+
+		// the old svc is busy, it is marked draining
+		recycling := c9.RecycleValues()
+		require.Len(t, recycling, 1)
+		require.Equal(t, "old", recycling[0].Name)
+		require.Equal(t, 1, recycling[0].ActiveRequests)
+
+		fsvc, err := c9.GetSvcValue(ctx, keyFunc, requestsPerPod, concurrency)
+		require.NoError(t, err)
+		require.Equal(t, "new", fsvc.Name)
+	})
+
+	t.Run("Test svc adopted from another replica is marked recycling", func(t *testing.T) {
+		c10 := NewPoolCache(logger)
+		c10.AdoptSvcValue(keyFunc, "ip", &FuncSvc{
+			Name:  "adopted",
+			Ctime: time.Now(),
+		}, resource.MustParse("45m"), 0)
+		require.Len(t, c10.RecycleValues(), 0)
+
+		c10.MarkRecycling(keyFunc, "ip")
+		recycling := c10.RecycleValues()
+		require.Len(t, recycling, 1)
+		require.Equal(t, "adopted", recycling[0].Name)
+
+		_, err := c10.GetSvcValue(ctx, keyFunc, requestsPerPod, concurrency)
+		require.Error(t, err)
+	})
 }
 
 func TestPoolCacheRequests(t *testing.T) {
@@ -17,7 +17,10 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/utils/metrics"
 )
@@ -49,11 +52,124 @@ var (
 		},
 		functionLabels,
 	)
+	// phase: the phase of the cold start, one of choose_pod, fetch, unarchive,
+	// secrets_configmaps, load and service_ready
+	coldStartPhaseLabels   = []string{"function_name", "function_namespace", "phase"}
+	ColdStartPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_function_cold_start_phase_seconds",
+			Help:    "The duration in seconds of the phases of cold starts by function_name, function_namespace and phase.",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		coldStartPhaseLabels,
+	)
+	PodsRecycled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_function_pods_recycled_total",
+			Help: "Count of specialized function pods deleted after reaching their max lifetime or max requests",
+		},
+		functionLabels,
+	)
+	PodSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_function_pod_seconds_total",
+			Help: "The running time in seconds of the pods of functions, summed over pods.",
+		},
+		functionLabels,
+	)
+	CPUReservedSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_function_cpu_reserved_core_seconds_total",
+			Help: "The CPU cores requested by the running pods of functions, integrated over time.",
+		},
+		functionLabels,
+	)
+	MemoryReservedSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_function_memory_reserved_byte_seconds_total",
+			Help: "The memory bytes requested by the running pods of functions, integrated over time.",
+		},
+		functionLabels,
+	)
+	Invocations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_function_invocations_total",
+			Help: "Count of requests to functions reported by the routers.",
+		},
+		functionLabels,
+	)
+
+	// executor: the executor type of the function
+	// reason: why the function service is reaped, one of idle_timeout,
+	// package_change and environment_cap
+	idleReapLabels = []string{"function_name", "function_namespace", "executor", "reason"}
+	IdleReaped     = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_function_idle_reaped_total",
+			Help: "Count of idle function services reaped, pods deleted or deployments scaled down, by reason.",
+		},
+		idleReapLabels,
+	)
+	IdleReapCandidates = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fission_function_idle_reap_candidates",
+			Help: "The function services found reapable by the last run of the idle reaper, by reason. They are only reaped when not in dry-run mode.",
+		},
+		idleReapLabels,
+	)
+
+	// namespace: the namespace of the functions
+	// resource: the resource limited by the quota of the namespace
+	quotaLabels    = []string{"namespace", "resource"}
+	NamespaceQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fission_namespace_quota_limit",
+			Help: "The quota of a resource for the functions of a namespace.",
+		},
+		quotaLabels,
+	)
+	NamespaceQuotaUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fission_namespace_quota_used",
+			Help: "The usage of a resource limited by a quota by the functions of a namespace.",
+		},
+		quotaLabels,
+	)
+	NamespaceQuotaRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_namespace_quota_rejections_total",
+			Help: "Count of function services not created because of the quota of a namespace",
+		},
+		quotaLabels,
+	)
+)
+
+// Phases of cold starts observed by the executor, the other phases are
+// reported by the fetcher.
+const (
+	PhaseChoosePod    = "choose_pod"
+	PhaseServiceReady = "service_ready"
 )
 
+// ObserveColdStartPhase records the duration of a cold start phase of the function.
+func ObserveColdStartPhase(fnMeta *metav1.ObjectMeta, phase string, d time.Duration) {
+	ColdStartPhaseDuration.WithLabelValues(fnMeta.Name, fnMeta.Namespace, phase).Observe(d.Seconds())
+}
+
 func init() {
 	registry := metrics.Registry
 	registry.MustRegister(ColdStarts)
 	registry.MustRegister(FuncRunningSummary)
 	registry.MustRegister(ColdStartsError)
+	registry.MustRegister(ColdStartPhaseDuration)
+	registry.MustRegister(PodsRecycled)
+	registry.MustRegister(PodSeconds)
+	registry.MustRegister(CPUReservedSeconds)
+	registry.MustRegister(MemoryReservedSeconds)
+	registry.MustRegister(Invocations)
+	registry.MustRegister(IdleReaped)
+	registry.MustRegister(IdleReapCandidates)
+	registry.MustRegister(NamespaceQuota)
+	registry.MustRegister(NamespaceQuotaUsed)
+	registry.MustRegister(NamespaceQuotaRejections)
 }
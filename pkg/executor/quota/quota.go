@@ -0,0 +1,573 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sInformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/metrics"
+)
+
+const (
+	// ENV_NAMESPACE_QUOTA_ENABLED enables namespace quotas. Quotas are read
+	// from namespace annotations, which requires permission to get namespaces.
+	ENV_NAMESPACE_QUOTA_ENABLED = "NAMESPACE_QUOTA_ENABLED"
+
+	// quotaTTL is how long the quota of a namespace is cached, and how often
+	// the quota metrics are updated.
+	quotaTTL = 30 * time.Second
+)
+
+// resources limited by namespace quotas
+const (
+	ResourceSpecializedPods = "specialized-pods"
+	ResourceReplicas        = "replicas"
+	ResourceCPU             = "cpu"
+	ResourceMemory          = "memory"
+)
+
+type (
+	// Usage is the amount of resources used by the functions of a namespace,
+	// or needed to serve a function.
+	Usage struct {
+		SpecializedPods int64
+		Replicas        int64
+		CPU             resource.Quantity
+		Memory          resource.Quantity
+	}
+
+	// Quota limits the resources used by the functions of a namespace.
+	// Nil limits are not enforced.
+	Quota struct {
+		SpecializedPods *int64
+		Replicas        *int64
+		CPU             *resource.Quantity
+		Memory          *resource.Quantity
+	}
+
+	// ExceededError is returned when serving a function would exceed the
+	// quota of its namespace.
+	ExceededError struct {
+		Namespace string
+		Resource  string
+		Used      string
+		Requested string
+		Limit     string
+	}
+
+	// Enforcer rejects function services which would exceed the quota of
+	// the function namespace. Quotas are set with annotations on namespaces
+	// and the usage is computed from the pods and deployments of functions.
+	// A nil Enforcer doesn't enforce any quota.
+	Enforcer struct {
+		logger           *zap.Logger
+		kubernetesClient kubernetes.Interface
+
+		podLister        map[string]corelisters.PodLister
+		podListerSynced  map[string]k8sCache.InformerSynced
+		deplLister       map[string]appslisters.DeploymentLister
+		deplListerSynced map[string]k8sCache.InformerSynced
+
+		lock     sync.Mutex
+		quotas   map[string]cachedQuota
+		reserved map[string]Usage
+	}
+
+	cachedQuota struct {
+		quota   *Quota
+		fetched time.Time
+	}
+)
+
+// Enabled returns true if namespace quotas are enabled.
+func Enabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(ENV_NAMESPACE_QUOTA_ENABLED))
+	return enabled
+}
+
+// InformerLabel selects the pods and deployments of functions.
+func InformerLabel() (labels.Selector, error) {
+	req, err := labels.NewRequirement(fv1.FUNCTION_NAMESPACE, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*req), nil
+}
+
+// MakeEnforcer returns an Enforcer computing the usage from the pods and
+// deployments of the informer factories, which must select the objects
+// of functions.
+func MakeEnforcer(logger *zap.Logger, kubernetesClient kubernetes.Interface,
+	informerFactory map[string]k8sInformers.SharedInformerFactory) *Enforcer {
+	e := &Enforcer{
+		logger:           logger.Named("quota"),
+		kubernetesClient: kubernetesClient,
+		podLister:        make(map[string]corelisters.PodLister),
+		podListerSynced:  make(map[string]k8sCache.InformerSynced),
+		deplLister:       make(map[string]appslisters.DeploymentLister),
+		deplListerSynced: make(map[string]k8sCache.InformerSynced),
+		quotas:           make(map[string]cachedQuota),
+		reserved:         make(map[string]Usage),
+	}
+	for ns, factory := range informerFactory {
+		e.podLister[ns] = factory.Core().V1().Pods().Lister()
+		e.podListerSynced[ns] = factory.Core().V1().Pods().Informer().HasSynced
+		e.deplLister[ns] = factory.Apps().V1().Deployments().Lister()
+		e.deplListerSynced[ns] = factory.Apps().V1().Deployments().Informer().HasSynced
+	}
+	return e
+}
+
+// SpecializedPods returns the usage of n specialized pods with the given
+// resource requests.
+func SpecializedPods(n int64, requests apiv1.ResourceList) Usage {
+	u := Usage{SpecializedPods: n}
+	u.addRequests(requests, n)
+	return u
+}
+
+// Replicas returns the usage of n deployment replicas with the given
+// resource requests.
+func Replicas(n int64, requests apiv1.ResourceList) Usage {
+	u := Usage{Replicas: n}
+	u.addRequests(requests, n)
+	return u
+}
+
+func (u *Usage) addRequests(requests apiv1.ResourceList, n int64) {
+	if cpu, ok := requests[apiv1.ResourceCPU]; ok {
+		u.CPU.Add(*resource.NewMilliQuantity(cpu.MilliValue()*n, resource.DecimalSI))
+	}
+	if memory, ok := requests[apiv1.ResourceMemory]; ok {
+		u.Memory.Add(*resource.NewQuantity(memory.Value()*n, resource.BinarySI))
+	}
+}
+
+func (u *Usage) add(o Usage) {
+	u.SpecializedPods += o.SpecializedPods
+	u.Replicas += o.Replicas
+	u.CPU.Add(o.CPU)
+	u.Memory.Add(o.Memory)
+}
+
+func (u *Usage) sub(o Usage) {
+	u.SpecializedPods -= o.SpecializedPods
+	u.Replicas -= o.Replicas
+	u.CPU.Sub(o.CPU)
+	u.Memory.Sub(o.Memory)
+}
+
+func (u Usage) isZero() bool {
+	return u.SpecializedPods == 0 && u.Replicas == 0 && u.CPU.IsZero() && u.Memory.IsZero()
+}
+
+// ParseQuota returns the quota set by the annotations of a namespace, or nil
+// if none is set. Invalid limits are returned as an error and not enforced.
+func ParseQuota(annotations map[string]string) (*Quota, error) {
+	q := &Quota{}
+	found := false
+	var errs []error
+
+	parseInt := func(key string) *int64 {
+		val, ok := annotations[key]
+		if !ok {
+			return nil
+		}
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || n < 0 {
+			errs = append(errs, fmt.Errorf("invalid value %q of annotation %s", val, key))
+			return nil
+		}
+		found = true
+		return &n
+	}
+	parseQuantity := func(key string) *resource.Quantity {
+		val, ok := annotations[key]
+		if !ok {
+			return nil
+		}
+		quantity, err := resource.ParseQuantity(val)
+		if err != nil || quantity.Sign() < 0 {
+			errs = append(errs, fmt.Errorf("invalid value %q of annotation %s", val, key))
+			return nil
+		}
+		found = true
+		return &quantity
+	}
+
+	q.SpecializedPods = parseInt(fv1.ANNOTATION_QUOTA_SPECIALIZED_PODS)
+	q.Replicas = parseInt(fv1.ANNOTATION_QUOTA_REPLICAS)
+	q.CPU = parseQuantity(fv1.ANNOTATION_QUOTA_CPU)
+	q.Memory = parseQuantity(fv1.ANNOTATION_QUOTA_MEMORY)
+
+	if !found {
+		q = nil
+	}
+	return q, errors.Join(errs...)
+}
+
+// exceeded returns an error for the first resource of which the quota is
+// exceeded by adding the demand to the usage. Resources not demanded are
+// not checked, so that a namespace over its quota of specialized pods can
+// still scale up deployments.
+func (q *Quota) exceeded(namespace string, used, demand Usage) *ExceededError {
+	countExceeded := func(res string, limit *int64, used, demand int64) *ExceededError {
+		if limit == nil || demand <= 0 || used+demand <= *limit {
+			return nil
+		}
+		return &ExceededError{
+			Namespace: namespace,
+			Resource:  res,
+			Used:      strconv.FormatInt(used, 10),
+			Requested: strconv.FormatInt(demand, 10),
+			Limit:     strconv.FormatInt(*limit, 10),
+		}
+	}
+	quantityExceeded := func(res string, limit *resource.Quantity, used, demand resource.Quantity) *ExceededError {
+		if limit == nil || demand.Sign() <= 0 {
+			return nil
+		}
+		total := used.DeepCopy()
+		total.Add(demand)
+		if total.Cmp(*limit) <= 0 {
+			return nil
+		}
+		return &ExceededError{
+			Namespace: namespace,
+			Resource:  res,
+			Used:      used.String(),
+			Requested: demand.String(),
+			Limit:     limit.String(),
+		}
+	}
+
+	if err := countExceeded(ResourceSpecializedPods, q.SpecializedPods, used.SpecializedPods, demand.SpecializedPods); err != nil {
+		return err
+	}
+	if err := countExceeded(ResourceReplicas, q.Replicas, used.Replicas, demand.Replicas); err != nil {
+		return err
+	}
+	if err := quantityExceeded(ResourceCPU, q.CPU, used.CPU, demand.CPU); err != nil {
+		return err
+	}
+	return quantityExceeded(ResourceMemory, q.Memory, used.Memory, demand.Memory)
+}
+
+// replicasHeadroom returns how many replicas with the given resource requests
+// can be added to the usage without exceeding the quota.
+func (q *Quota) replicasHeadroom(used Usage, requests apiv1.ResourceList) int64 {
+	headroom := int64(math.MaxInt64)
+	if q.Replicas != nil {
+		headroom = min(headroom, *q.Replicas-used.Replicas)
+	}
+	if cpu, ok := requests[apiv1.ResourceCPU]; ok && q.CPU != nil && cpu.MilliValue() > 0 {
+		headroom = min(headroom, (q.CPU.MilliValue()-used.CPU.MilliValue())/cpu.MilliValue())
+	}
+	if memory, ok := requests[apiv1.ResourceMemory]; ok && q.Memory != nil && memory.Value() > 0 {
+		headroom = min(headroom, (q.Memory.Value()-used.Memory.Value())/memory.Value())
+	}
+	return max(headroom, 0)
+}
+
+func (err *ExceededError) Error() string {
+	return fmt.Sprintf("quota of %s of namespace %s exceeded: used %s, requested %s, limited to %s",
+		err.Resource, err.Namespace, err.Used, err.Requested, err.Limit)
+}
+
+// Unwrap returns the error as a fission error, so that it is served as
+// too many requests.
+func (err *ExceededError) Unwrap() error {
+	return ferror.MakeError(ferror.ErrorTooManyRequests, err.Error())
+}
+
+// Reserve reserves the resources needed to serve a function of the namespace
+// until the returned function is called, once the function service is created
+// or failed to be. It returns an ExceededError if the quota of the namespace
+// doesn't allow it, and a service unavailable error if the quota or the usage
+// of the namespace is not known.
+//
+// Reservations are tracked by each executor replica, and the usage is only
+// known once the informers observe the created objects. Concurrent requests
+// across replicas may briefly exceed a quota.
+func (e *Enforcer) Reserve(ctx context.Context, namespace string, demand Usage) (func(), error) {
+	if e == nil {
+		return func() {}, nil
+	}
+
+	q, err := e.getQuota(ctx, namespace)
+	if err != nil {
+		return nil, ferror.MakeError(ferror.ErrorServiceUnavailable,
+			fmt.Sprintf("error getting quota of namespace %s: %v", namespace, err))
+	}
+	if q == nil {
+		return func() {}, nil
+	}
+	used, err := e.usage(namespace)
+	if err != nil {
+		return nil, ferror.MakeError(ferror.ErrorServiceUnavailable,
+			fmt.Sprintf("error getting resource usage of namespace %s: %v", namespace, err))
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	used.add(e.reserved[namespace])
+	recordUsage(namespace, q, used)
+	if exceeded := q.exceeded(namespace, used, demand); exceeded != nil {
+		metrics.NamespaceQuotaRejections.WithLabelValues(namespace, exceeded.Resource).Inc()
+		return nil, exceeded
+	}
+
+	reserved := e.reserved[namespace]
+	reserved.add(demand)
+	e.reserved[namespace] = reserved
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.lock.Lock()
+			defer e.lock.Unlock()
+			reserved := e.reserved[namespace]
+			reserved.sub(demand)
+			if reserved.isZero() {
+				delete(e.reserved, namespace)
+				return
+			}
+			e.reserved[namespace] = reserved
+		})
+	}, nil
+}
+
+// ScaleLimit returns the replicas, at most desired, the deployment of a
+// function of the namespace can be scaled to without exceeding the quota of
+// the namespace. Scaling down is never limited. If the quota or the usage of
+// the namespace is not known, the deployment is not scaled up.
+//
+// Like reservations, scale ups of several functions of a namespace at the
+// same time may briefly exceed a quota.
+func (e *Enforcer) ScaleLimit(ctx context.Context, namespace string, depl *appsv1.Deployment, desired int32) (int32, error) {
+	var current int32
+	if depl.Spec.Replicas != nil {
+		current = *depl.Spec.Replicas
+	}
+	if e == nil || desired <= current {
+		return desired, nil
+	}
+
+	q, err := e.getQuota(ctx, namespace)
+	if err != nil {
+		return current, fmt.Errorf("error getting quota of namespace %s: %w", namespace, err)
+	}
+	if q == nil {
+		return desired, nil
+	}
+	used, err := e.usage(namespace)
+	if err != nil {
+		return current, fmt.Errorf("error getting resource usage of namespace %s: %w", namespace, err)
+	}
+
+	e.lock.Lock()
+	used.add(e.reserved[namespace])
+	e.lock.Unlock()
+	recordUsage(namespace, q, used)
+
+	requests := podRequests(&depl.Spec.Template.Spec)
+	headroom := q.replicasHeadroom(used, requests)
+	if int64(desired-current) <= headroom {
+		return desired, nil
+	}
+	if exceeded := q.exceeded(namespace, used, Replicas(headroom+1, requests)); exceeded != nil {
+		metrics.NamespaceQuotaRejections.WithLabelValues(namespace, exceeded.Resource).Inc()
+	}
+	return current + int32(headroom), nil
+}
+
+// LimitStrategy returns the execution strategy with its scale limited to the
+// replicas the deployment can be scaled to within the namespace quota, so that
+// the HPA of the deployment doesn't scale it beyond the quota. If the quota or
+// the usage of the namespace is not known, the scale is limited to the current
+// replicas and the error is returned.
+func (e *Enforcer) LimitStrategy(ctx context.Context, namespace string, depl *appsv1.Deployment, es fv1.ExecutionStrategy) (fv1.ExecutionStrategy, error) {
+	maxScale := int32(max(es.MaxScale, es.MinScale, 1))
+	limit, err := e.ScaleLimit(ctx, namespace, depl, maxScale)
+	if limit < maxScale {
+		es.MaxScale = max(int(limit), 1)
+		es.MinScale = min(es.MinScale, es.MaxScale)
+	}
+	return es, err
+}
+
+// podRequests returns the resource requests of the containers of the pod.
+func podRequests(spec *apiv1.PodSpec) apiv1.ResourceList {
+	requests := apiv1.ResourceList{}
+	for _, c := range spec.Containers {
+		for name, quantity := range c.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	return requests
+}
+
+// Run periodically updates the quota metrics of the namespaces of which
+// functions were served.
+func (e *Enforcer) Run(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	wait.UntilWithContext(ctx, e.updateMetrics, quotaTTL)
+}
+
+func (e *Enforcer) updateMetrics(ctx context.Context) {
+	e.lock.Lock()
+	namespaces := make([]string, 0, len(e.quotas))
+	for ns := range e.quotas {
+		namespaces = append(namespaces, ns)
+	}
+	e.lock.Unlock()
+
+	for _, ns := range namespaces {
+		q, err := e.getQuota(ctx, ns)
+		if err != nil {
+			e.logger.Warn("error getting namespace quota", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+		if q == nil {
+			metrics.NamespaceQuota.DeletePartialMatch(map[string]string{"namespace": ns})
+			metrics.NamespaceQuotaUsed.DeletePartialMatch(map[string]string{"namespace": ns})
+			continue
+		}
+		used, err := e.usage(ns)
+		if err != nil {
+			continue
+		}
+		recordUsage(ns, q, used)
+	}
+}
+
+// getQuota returns the cached quota of the namespace, or gets it from the
+// namespace annotations once the cached quota expires.
+func (e *Enforcer) getQuota(ctx context.Context, namespace string) (*Quota, error) {
+	e.lock.Lock()
+	cached, ok := e.quotas[namespace]
+	e.lock.Unlock()
+	if ok && time.Since(cached.fetched) < quotaTTL {
+		return cached.quota, nil
+	}
+
+	var q *Quota
+	ns, err := e.kubernetesClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		if !k8sErrs.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		q, err = ParseQuota(ns.Annotations)
+		if err != nil {
+			e.logger.Warn("ignoring invalid namespace quota", zap.Error(err), zap.String("namespace", namespace))
+		}
+	}
+
+	e.lock.Lock()
+	e.quotas[namespace] = cachedQuota{quota: q, fetched: time.Now()}
+	e.lock.Unlock()
+	return q, nil
+}
+
+// usage returns the resources used by the functions of the namespace.
+func (e *Enforcer) usage(namespace string) (Usage, error) {
+	var used Usage
+	for ns, synced := range e.podListerSynced {
+		if !synced() || !e.deplListerSynced[ns]() {
+			return used, fmt.Errorf("function objects in namespace %s are not synced yet", ns)
+		}
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{fv1.FUNCTION_NAMESPACE: namespace})
+	for _, lister := range e.podLister {
+		pods, err := lister.List(selector)
+		if err != nil {
+			return used, err
+		}
+		for _, pod := range pods {
+			if pod.DeletionTimestamp != nil || pod.Status.Phase == apiv1.PodSucceeded || pod.Status.Phase == apiv1.PodFailed {
+				continue
+			}
+			if pod.Labels[fv1.EXECUTOR_TYPE] == string(fv1.ExecutorTypePoolmgr) {
+				used.SpecializedPods++
+			}
+			for _, c := range pod.Spec.Containers {
+				used.addRequests(c.Resources.Requests, 1)
+			}
+		}
+	}
+	for _, lister := range e.deplLister {
+		depls, err := lister.List(selector)
+		if err != nil {
+			return used, err
+		}
+		for _, depl := range depls {
+			if depl.Spec.Replicas != nil {
+				used.Replicas += int64(*depl.Spec.Replicas)
+			}
+		}
+	}
+	return used, nil
+}
+
+func recordUsage(namespace string, q *Quota, used Usage) {
+	if q.SpecializedPods != nil {
+		metrics.NamespaceQuota.WithLabelValues(namespace, ResourceSpecializedPods).Set(float64(*q.SpecializedPods))
+		metrics.NamespaceQuotaUsed.WithLabelValues(namespace, ResourceSpecializedPods).Set(float64(used.SpecializedPods))
+	}
+	if q.Replicas != nil {
+		metrics.NamespaceQuota.WithLabelValues(namespace, ResourceReplicas).Set(float64(*q.Replicas))
+		metrics.NamespaceQuotaUsed.WithLabelValues(namespace, ResourceReplicas).Set(float64(used.Replicas))
+	}
+	if q.CPU != nil {
+		metrics.NamespaceQuota.WithLabelValues(namespace, ResourceCPU).Set(q.CPU.AsApproximateFloat64())
+		metrics.NamespaceQuotaUsed.WithLabelValues(namespace, ResourceCPU).Set(used.CPU.AsApproximateFloat64())
+	}
+	if q.Memory != nil {
+		metrics.NamespaceQuota.WithLabelValues(namespace, ResourceMemory).Set(q.Memory.AsApproximateFloat64())
+		metrics.NamespaceQuotaUsed.WithLabelValues(namespace, ResourceMemory).Set(used.Memory.AsApproximateFloat64())
+	}
+}
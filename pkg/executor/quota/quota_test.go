@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sInformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestParseQuota(t *testing.T) {
+	q, err := ParseQuota(map[string]string{"foo": "bar"})
+	require.NoError(t, err)
+	require.Nil(t, q)
+
+	q, err = ParseQuota(map[string]string{
+		fv1.ANNOTATION_QUOTA_SPECIALIZED_PODS: "10",
+		fv1.ANNOTATION_QUOTA_CPU:              "2",
+		fv1.ANNOTATION_QUOTA_MEMORY:           "lots",
+	})
+	require.Error(t, err)
+	require.NotNil(t, q)
+	require.Equal(t, int64(10), *q.SpecializedPods)
+	require.Nil(t, q.Replicas)
+	require.Equal(t, 0, q.CPU.Cmp(resource.MustParse("2")))
+	require.Nil(t, q.Memory)
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	q, err := ParseQuota(map[string]string{
+		fv1.ANNOTATION_QUOTA_SPECIALIZED_PODS: "2",
+		fv1.ANNOTATION_QUOTA_MEMORY:           "1Gi",
+	})
+	require.NoError(t, err)
+
+	requests := apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("256Mi")}
+	used := SpecializedPods(1, requests)
+	require.Nil(t, q.exceeded("ns", used, SpecializedPods(1, requests)))
+
+	used.add(SpecializedPods(1, requests))
+	exceeded := q.exceeded("ns", used, SpecializedPods(1, requests))
+	require.NotNil(t, exceeded)
+	require.Equal(t, ResourceSpecializedPods, exceeded.Resource)
+
+	// specialized pods are not demanded by deployments
+	exceeded = q.exceeded("ns", used, Replicas(3, requests))
+	require.NotNil(t, exceeded)
+	require.Equal(t, ResourceMemory, exceeded.Resource)
+	require.Nil(t, q.exceeded("ns", used, Replicas(2, requests)))
+
+	code, _ := ferror.GetHTTPError(exceeded)
+	require.Equal(t, http.StatusTooManyRequests, code)
+}
+
+func TestEnforcerReserve(t *testing.T) {
+	ctx := t.Context()
+	logger := loggerfactory.GetLogger()
+
+	kubernetesClient := fake.NewSimpleClientset(
+		&apiv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "tenant",
+				Annotations: map[string]string{
+					fv1.ANNOTATION_QUOTA_SPECIALIZED_PODS: "2",
+					fv1.ANNOTATION_QUOTA_REPLICAS:         "1",
+				},
+			},
+		},
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "specialized",
+				Namespace: "fission-function",
+				Labels: map[string]string{
+					fv1.FUNCTION_NAMESPACE: "tenant",
+					fv1.EXECUTOR_TYPE:      string(fv1.ExecutorTypePoolmgr),
+				},
+			},
+		},
+	)
+	factory := map[string]k8sInformers.SharedInformerFactory{
+		"fission-function": k8sInformers.NewSharedInformerFactoryWithOptions(kubernetesClient, time.Minute,
+			k8sInformers.WithNamespace("fission-function")),
+	}
+	e := MakeEnforcer(logger, kubernetesClient, factory)
+	for _, f := range factory {
+		f.Start(ctx.Done())
+	}
+	for ns := range factory {
+		require.True(t, k8sCache.WaitForCacheSync(ctx.Done(), e.podListerSynced[ns], e.deplListerSynced[ns]))
+	}
+
+	release, err := e.Reserve(ctx, "tenant", SpecializedPods(1, nil))
+	require.NoError(t, err)
+
+	// the reserved pod counts until it is released
+	_, err = e.Reserve(ctx, "tenant", SpecializedPods(1, nil))
+	var exceeded *ExceededError
+	require.True(t, errors.As(err, &exceeded))
+	require.Equal(t, ResourceSpecializedPods, exceeded.Resource)
+
+	release()
+	release()
+	release, err = e.Reserve(ctx, "tenant", SpecializedPods(1, nil))
+	require.NoError(t, err)
+	release()
+
+	// replicas are limited independently
+	release, err = e.Reserve(ctx, "tenant", Replicas(1, nil))
+	require.NoError(t, err)
+	release()
+	_, err = e.Reserve(ctx, "tenant", Replicas(2, nil))
+	require.Error(t, err)
+
+	// namespaces without quota are not limited
+	_, err = e.Reserve(ctx, "other", SpecializedPods(10, nil))
+	require.NoError(t, err)
+
+	// a nil enforcer doesn't enforce quotas
+	var nilEnforcer *Enforcer
+	release, err = nilEnforcer.Reserve(ctx, "tenant", SpecializedPods(10, nil))
+	require.NoError(t, err)
+	release()
+}
+
+func TestReplicasHeadroom(t *testing.T) {
+	q, err := ParseQuota(map[string]string{
+		fv1.ANNOTATION_QUOTA_REPLICAS: "10",
+		fv1.ANNOTATION_QUOTA_CPU:      "1",
+	})
+	require.NoError(t, err)
+
+	requests := apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("300m")}
+	used := Replicas(1, requests)
+	headroom := q.replicasHeadroom(used, requests)
+	require.Equal(t, int64(2), headroom)
+	require.Nil(t, q.exceeded("ns", used, Replicas(headroom, requests)))
+	require.NotNil(t, q.exceeded("ns", used, Replicas(headroom+1, requests)))
+
+	// replicas without requests are only limited by the replicas quota
+	require.Equal(t, int64(9), q.replicasHeadroom(used, nil))
+	used.add(Replicas(20, nil))
+	require.Equal(t, int64(0), q.replicasHeadroom(used, nil))
+}
+
+func TestEnforcerScaleLimit(t *testing.T) {
+	ctx := t.Context()
+	logger := loggerfactory.GetLogger()
+
+	replicas := int32(2)
+	depl := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "function",
+			Namespace: "fission-function",
+			Labels:    map[string]string{fv1.FUNCTION_NAMESPACE: "tenant"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{
+						Name: "function",
+						Resources: apiv1.ResourceRequirements{
+							Requests: apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("256Mi")},
+						},
+					}},
+				},
+			},
+		},
+	}
+	kubernetesClient := fake.NewSimpleClientset(
+		&apiv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "tenant",
+				Annotations: map[string]string{
+					fv1.ANNOTATION_QUOTA_REPLICAS: "10",
+					fv1.ANNOTATION_QUOTA_MEMORY:   "1Gi",
+				},
+			},
+		},
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		depl,
+	)
+	factory := map[string]k8sInformers.SharedInformerFactory{
+		"fission-function": k8sInformers.NewSharedInformerFactoryWithOptions(kubernetesClient, time.Minute,
+			k8sInformers.WithNamespace("fission-function")),
+	}
+	e := MakeEnforcer(logger, kubernetesClient, factory)
+	for _, f := range factory {
+		f.Start(ctx.Done())
+	}
+	for ns := range factory {
+		require.True(t, k8sCache.WaitForCacheSync(ctx.Done(), e.podListerSynced[ns], e.deplListerSynced[ns]))
+	}
+
+	// the replicas don't run pods yet, 4 more fit in the memory quota
+	limit, err := e.ScaleLimit(ctx, "tenant", depl, 20)
+	require.NoError(t, err)
+	require.Equal(t, int32(6), limit)
+
+	// scaling down is never limited
+	limit, err = e.ScaleLimit(ctx, "tenant", depl, 1)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), limit)
+
+	// namespaces without quota are not limited
+	limit, err = e.ScaleLimit(ctx, "other", depl, 20)
+	require.NoError(t, err)
+	require.Equal(t, int32(20), limit)
+
+	es, err := e.LimitStrategy(ctx, "tenant", depl, fv1.ExecutionStrategy{MinScale: 7, MaxScale: 8})
+	require.NoError(t, err)
+	require.Equal(t, 6, es.MinScale)
+	require.Equal(t, 6, es.MaxScale)
+
+	es, err = e.LimitStrategy(ctx, "tenant", depl, fv1.ExecutionStrategy{MinScale: 1, MaxScale: 3})
+	require.NoError(t, err)
+	require.Equal(t, 1, es.MinScale)
+	require.Equal(t, 3, es.MaxScale)
+
+	// a nil enforcer doesn't enforce quotas
+	var nilEnforcer *Enforcer
+	limit, err = nilEnforcer.ScaleLimit(ctx, "tenant", depl, 20)
+	require.NoError(t, err)
+	require.Equal(t, int32(20), limit)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reaper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/events"
+	"github.com/fission/fission/pkg/executor/metrics"
+)
+
+const (
+	// ENV_IDLE_REAPER_DRY_RUN makes the idle reapers only log and record
+	// metrics of the function services they would reap.
+	ENV_IDLE_REAPER_DRY_RUN = "IDLE_REAPER_DRY_RUN"
+
+	// ENV_IDLE_REAPER_NAMESPACE_POLICIES enables the idle reap policies set
+	// by namespace annotations, which requires permission to get namespaces.
+	ENV_IDLE_REAPER_NAMESPACE_POLICIES = "IDLE_REAPER_NAMESPACE_POLICIES_ENABLED"
+
+	// ENV_IDLE_REAPER_MAX_IDLE_PODS_PER_ENVIRONMENT caps the idle specialized
+	// pods of each poolmgr environment.
+	ENV_IDLE_REAPER_MAX_IDLE_PODS_PER_ENVIRONMENT = "IDLE_REAPER_MAX_IDLE_PODS_PER_ENVIRONMENT"
+
+	// namespacePolicyTTL is how long the idle reap policy of a namespace is cached.
+	namespacePolicyTTL = 30 * time.Second
+)
+
+// reasons for reaping idle function services
+const (
+	ReasonIdleTimeout    = "idle_timeout"
+	ReasonPackageChange  = "package_change"
+	ReasonEnvironmentCap = "environment_cap"
+)
+
+type (
+	// IdlePolicies decides when idle function services are reaped, from the
+	// idle timeout and idle reap policy of functions, the idle reap policy of
+	// their namespace and the executor settings.
+	IdlePolicies struct {
+		logger           *zap.Logger
+		kubernetesClient kubernetes.Interface
+		recorder         *events.Recorder
+
+		dryRun            bool
+		namespacePolicies bool
+		maxIdlePodsPerEnv int
+
+		lock     sync.Mutex
+		policies map[string]cachedPolicy
+	}
+
+	cachedPolicy struct {
+		policy  *fv1.IdleReapPolicy
+		fetched time.Time
+	}
+
+	// IdleReapRun records the function services reaped by a run of an idle reaper.
+	IdleReapRun struct {
+		policies   *IdlePolicies
+		executor   fv1.ExecutorType
+		candidates map[[3]string]int
+	}
+)
+
+// MakeIdlePolicies returns the idle policies configured by the environment
+// of the executor.
+func MakeIdlePolicies(logger *zap.Logger, kubernetesClient kubernetes.Interface, recorder *events.Recorder) *IdlePolicies {
+	p := &IdlePolicies{
+		logger:           logger.Named("idle_policies"),
+		kubernetesClient: kubernetesClient,
+		recorder:         recorder,
+		policies:         make(map[string]cachedPolicy),
+	}
+	p.dryRun, _ = strconv.ParseBool(os.Getenv(ENV_IDLE_REAPER_DRY_RUN))
+	p.namespacePolicies, _ = strconv.ParseBool(os.Getenv(ENV_IDLE_REAPER_NAMESPACE_POLICIES))
+	if val := os.Getenv(ENV_IDLE_REAPER_MAX_IDLE_PODS_PER_ENVIRONMENT); val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			p.logger.Warn("ignoring invalid max idle pods per environment", zap.String("value", val))
+		} else {
+			p.maxIdlePodsPerEnv = n
+		}
+	}
+	if p.dryRun {
+		p.logger.Info("idle reaper running in dry-run mode, idle function services are not reaped")
+	}
+	return p
+}
+
+// ParseIdleReapPolicy returns the idle reap policy set by the annotations of
+// a namespace, or nil if none is set.
+func ParseIdleReapPolicy(annotations map[string]string) (*fv1.IdleReapPolicy, error) {
+	val, ok := annotations[fv1.ANNOTATION_IDLE_REAP_POLICY]
+	if !ok {
+		return nil, nil
+	}
+	policy := &fv1.IdleReapPolicy{}
+	if err := json.Unmarshal([]byte(val), policy); err != nil {
+		return nil, fmt.Errorf("invalid value of annotation %s: %w", fv1.ANNOTATION_IDLE_REAP_POLICY, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid value of annotation %s: %w", fv1.ANNOTATION_IDLE_REAP_POLICY, err)
+	}
+	return policy, nil
+}
+
+// MaxIdlePodsPerEnvironment returns the cap of idle specialized pods of each
+// environment, or 0 if there is none.
+func (p *IdlePolicies) MaxIdlePodsPerEnvironment() int {
+	return p.maxIdlePodsPerEnv
+}
+
+// Policy returns the idle reap policy of the function, or else the one of
+// its namespace. It returns nil if neither is set.
+func (p *IdlePolicies) Policy(ctx context.Context, fn *fv1.Function) *fv1.IdleReapPolicy {
+	if fn.Spec.IdleReapPolicy != nil {
+		return fn.Spec.IdleReapPolicy
+	}
+	if !p.namespacePolicies {
+		return nil
+	}
+
+	namespace := fn.ObjectMeta.Namespace
+	p.lock.Lock()
+	cached, ok := p.policies[namespace]
+	p.lock.Unlock()
+	if ok && time.Since(cached.fetched) < namespacePolicyTTL {
+		return cached.policy
+	}
+
+	var policy *fv1.IdleReapPolicy
+	ns, err := p.kubernetesClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			// keep the last known policy until the namespace can be read
+			p.logger.Warn("error getting namespace idle reap policy", zap.Error(err), zap.String("namespace", namespace))
+			return cached.policy
+		}
+	} else {
+		policy, err = ParseIdleReapPolicy(ns.Annotations)
+		if err != nil {
+			p.logger.Warn("ignoring invalid namespace idle reap policy", zap.Error(err), zap.String("namespace", namespace))
+		}
+	}
+
+	p.lock.Lock()
+	p.policies[namespace] = cachedPolicy{policy: policy, fetched: time.Now()}
+	p.lock.Unlock()
+	return policy
+}
+
+// IdleTimeout returns how long the function services of the function must
+// be idle at time t before they are reaped: the idle timeout of the function,
+// or else the default one, extended to the keep-alive of the active windows.
+func (p *IdlePolicies) IdleTimeout(ctx context.Context, fn *fv1.Function, defaultTimeout time.Duration, t time.Time) time.Duration {
+	timeout := defaultTimeout
+	if fn.Spec.IdleTimeout != nil {
+		timeout = time.Duration(*fn.Spec.IdleTimeout) * time.Second
+	}
+	if policy := p.Policy(ctx, fn); policy != nil {
+		timeout = max(timeout, policy.KeepAliveAt(t))
+	}
+	return timeout
+}
+
+// ReapOnPackageChange returns true if the function services specialized with
+// a previous package of the function are reaped right away.
+func (p *IdlePolicies) ReapOnPackageChange(ctx context.Context, fn *fv1.Function) bool {
+	policy := p.Policy(ctx, fn)
+	return policy != nil && policy.ReapOnPackageChange
+}
+
+// StartRun starts recording a run of the idle reaper of the executor type.
+func (p *IdlePolicies) StartRun(executor fv1.ExecutorType) *IdleReapRun {
+	return &IdleReapRun{
+		policies:   p,
+		executor:   executor,
+		candidates: make(map[[3]string]int),
+	}
+}
+
+// Reap records that the function service of the function is reaped for the
+// reason. It returns false in dry-run mode, in which case the function service
+// must be kept.
+func (r *IdleReapRun) Reap(fnMeta *metav1.ObjectMeta, fsvcName string, reason string) bool {
+	r.candidates[[3]string{fnMeta.Name, fnMeta.Namespace, reason}]++
+	if r.policies.dryRun {
+		r.policies.logger.Info("dry-run: would reap idle function service",
+			zap.String("function", fnMeta.Name),
+			zap.String("namespace", fnMeta.Namespace),
+			zap.String("executor", string(r.executor)),
+			zap.String("service", fsvcName),
+			zap.String("reason", reason))
+		return false
+	}
+	metrics.IdleReaped.WithLabelValues(fnMeta.Name, fnMeta.Namespace, string(r.executor), reason).Inc()
+	r.policies.recorder.FunctionEvent(fnMeta, apiv1.EventTypeNormal, events.ReasonIdleReaped,
+		"Reaping function service %s of executor %s: %s", fsvcName, r.executor, reason)
+	return true
+}
+
+// Finish records the function services found reapable by the run.
+func (r *IdleReapRun) Finish() {
+	metrics.IdleReapCandidates.DeletePartialMatch(prometheus.Labels{"executor": string(r.executor)})
+	for labels, n := range r.candidates {
+		metrics.IdleReapCandidates.WithLabelValues(labels[0], labels[1], string(r.executor), labels[2]).Set(float64(n))
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reaper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+const nightlyPolicy = `{"keepAliveWindows":[{"schedule":"0 20 * * *","duration":"4h","keepAlive":"1h"}],"reapOnPackageChange":true}`
+
+func TestParseIdleReapPolicy(t *testing.T) {
+	policy, err := ParseIdleReapPolicy(map[string]string{})
+	require.NoError(t, err)
+	require.Nil(t, policy)
+
+	policy, err = ParseIdleReapPolicy(map[string]string{fv1.ANNOTATION_IDLE_REAP_POLICY: nightlyPolicy})
+	require.NoError(t, err)
+	require.True(t, policy.ReapOnPackageChange)
+	require.Len(t, policy.KeepAliveWindows, 1)
+	require.Equal(t, time.Hour, policy.KeepAliveWindows[0].KeepAlive.Duration)
+
+	_, err = ParseIdleReapPolicy(map[string]string{fv1.ANNOTATION_IDLE_REAP_POLICY: "{"})
+	require.Error(t, err)
+	_, err = ParseIdleReapPolicy(map[string]string{
+		fv1.ANNOTATION_IDLE_REAP_POLICY: `{"keepAliveWindows":[{"schedule":"bad","duration":"1h","keepAlive":"1h"}]}`,
+	})
+	require.Error(t, err)
+}
+
+func TestIdleTimeout(t *testing.T) {
+	t.Setenv(ENV_IDLE_REAPER_NAMESPACE_POLICIES, "true")
+	ctx := context.Background()
+	kubernetesClient := fake.NewSimpleClientset(&apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nightly",
+			Annotations: map[string]string{fv1.ANNOTATION_IDLE_REAP_POLICY: nightlyPolicy},
+		},
+	})
+	p := MakeIdlePolicies(loggerfactory.GetLogger(), kubernetesClient, nil)
+
+	day := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC)
+	idleTimeout := 300
+
+	fn := &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"}}
+	require.Equal(t, 2*time.Minute, p.IdleTimeout(ctx, fn, 2*time.Minute, night))
+	fn.Spec.IdleTimeout = &idleTimeout
+	require.Equal(t, 5*time.Minute, p.IdleTimeout(ctx, fn, 2*time.Minute, night))
+	require.False(t, p.ReapOnPackageChange(ctx, fn))
+
+	// the namespace policy applies to functions without their own policy
+	fn = &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "nightly"}}
+	require.Equal(t, 2*time.Minute, p.IdleTimeout(ctx, fn, 2*time.Minute, day))
+	require.Equal(t, time.Hour, p.IdleTimeout(ctx, fn, 2*time.Minute, night))
+	require.True(t, p.ReapOnPackageChange(ctx, fn))
+
+	fn.Spec.IdleReapPolicy = &fv1.IdleReapPolicy{}
+	require.Equal(t, 2*time.Minute, p.IdleTimeout(ctx, fn, 2*time.Minute, night))
+	require.False(t, p.ReapOnPackageChange(ctx, fn))
+}
+
+func TestIdleReapRunDryRun(t *testing.T) {
+	fnMeta := &metav1.ObjectMeta{Name: "fn", Namespace: "default"}
+
+	p := MakeIdlePolicies(loggerfactory.GetLogger(), fake.NewSimpleClientset(), nil)
+	run := p.StartRun(fv1.ExecutorTypePoolmgr)
+	require.True(t, run.Reap(fnMeta, "pod-1", ReasonIdleTimeout))
+	run.Finish()
+
+	t.Setenv(ENV_IDLE_REAPER_DRY_RUN, "true")
+	t.Setenv(ENV_IDLE_REAPER_MAX_IDLE_PODS_PER_ENVIRONMENT, "3")
+	p = MakeIdlePolicies(loggerfactory.GetLogger(), fake.NewSimpleClientset(), nil)
+	require.Equal(t, 3, p.MaxIdlePodsPerEnvironment())
+	run = p.StartRun(fv1.ExecutorTypePoolmgr)
+	require.False(t, run.Reap(fnMeta, "pod-1", ReasonIdleTimeout))
+	require.False(t, run.Reap(fnMeta, "pod-2", ReasonIdleTimeout))
+	require.Equal(t, 2, run.candidates[[3]string{"fn", "default", ReasonIdleTimeout}])
+	run.Finish()
+}
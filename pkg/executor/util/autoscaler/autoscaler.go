@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// Defaults of the concurrency scaling configuration.
+const (
+	DefaultTargetConcurrency        = 10
+	DefaultStableWindow             = 60 * time.Second
+	DefaultPanicWindow              = 6 * time.Second
+	DefaultPanicThreshold           = 2.0
+	DefaultScaleUpStabilization     = 0 * time.Second
+	DefaultScaleDownStabilization   = 60 * time.Second
+	defaultReporterExpiry           = 15 * time.Second
+	maxRecommendationHistoryEntries = 1000
+)
+
+type (
+	// Config is the concurrency scaling configuration of a function.
+	Config struct {
+		TargetConcurrency      float64
+		MinScale               int32
+		MaxScale               int32
+		StableWindow           time.Duration
+		PanicWindow            time.Duration
+		PanicThreshold         float64
+		ScaleUpStabilization   time.Duration
+		ScaleDownStabilization time.Duration
+	}
+
+	sample struct {
+		time  time.Time
+		value float64
+	}
+
+	report struct {
+		time           time.Time
+		activeRequests int
+	}
+
+	// Autoscaler computes the desired replicas of a function from the
+	// in-flight requests reported by the routers.
+	Autoscaler struct {
+		lock sync.Mutex
+
+		// latest in-flight requests of the function reported by each router
+		reports map[string]report
+		// total in-flight requests sampled at every scaling decision
+		samples []sample
+		// desired replicas recommended at every scaling decision
+		recommendations []sample
+
+		panicStart       time.Time
+		maxPanicReplicas int32
+	}
+)
+
+// ConfigFromStrategy returns the concurrency scaling configuration of the
+// execution strategy, with defaults for the unset fields.
+func ConfigFromStrategy(es *fv1.ExecutionStrategy) Config {
+	cfg := Config{
+		TargetConcurrency:      DefaultTargetConcurrency,
+		MinScale:               int32(es.MinScale),
+		MaxScale:               int32(es.MaxScale),
+		StableWindow:           DefaultStableWindow,
+		PanicWindow:            DefaultPanicWindow,
+		PanicThreshold:         DefaultPanicThreshold,
+		ScaleUpStabilization:   DefaultScaleUpStabilization,
+		ScaleDownStabilization: DefaultScaleDownStabilization,
+	}
+	if cfg.MaxScale < cfg.MinScale {
+		cfg.MaxScale = cfg.MinScale
+	}
+
+	cs := es.ConcurrencyScaling
+	if cs == nil {
+		return cfg
+	}
+	if cs.TargetConcurrency > 0 {
+		cfg.TargetConcurrency = float64(cs.TargetConcurrency)
+	}
+	if cs.StableWindowSeconds > 0 {
+		cfg.StableWindow = time.Duration(cs.StableWindowSeconds) * time.Second
+	}
+	if cs.PanicWindowSeconds > 0 {
+		cfg.PanicWindow = time.Duration(cs.PanicWindowSeconds) * time.Second
+	}
+	if cfg.PanicWindow > cfg.StableWindow {
+		cfg.PanicWindow = cfg.StableWindow
+	}
+	if cs.PanicThresholdPercent > 100 {
+		cfg.PanicThreshold = float64(cs.PanicThresholdPercent) / 100
+	}
+	if cs.ScaleUpStabilizationSeconds != nil {
+		cfg.ScaleUpStabilization = time.Duration(*cs.ScaleUpStabilizationSeconds) * time.Second
+	}
+	if cs.ScaleDownStabilizationSeconds != nil {
+		cfg.ScaleDownStabilization = time.Duration(*cs.ScaleDownStabilizationSeconds) * time.Second
+	}
+	return cfg
+}
+
+// NewAutoscaler returns an Autoscaler without any reports.
+func NewAutoscaler() *Autoscaler {
+	return &Autoscaler{
+		reports: make(map[string]report),
+	}
+}
+
+// Report records the in-flight requests of the function at a router.
+func (a *Autoscaler) Report(now time.Time, reporter string, activeRequests int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.reports[reporter] = report{time: now, activeRequests: activeRequests}
+}
+
+// ActiveRequests returns the in-flight requests of the function reported
+// by all routers.
+func (a *Autoscaler) ActiveRequests(now time.Time) int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	total := 0
+	for _, r := range a.reports {
+		if now.Sub(r.time) <= defaultReporterExpiry {
+			total += r.activeRequests
+		}
+	}
+	return total
+}
+
+// Scale samples the in-flight requests of the function and returns the
+// desired replicas, given the current and ready replicas of the deployment.
+// It also returns whether the function scales in panic mode.
+//
+// A deployment scaled to zero is only scaled up once requests are reported;
+// scaling down to zero is left to the idle object reaper.
+func (a *Autoscaler) Scale(now time.Time, cfg Config, current int32, ready int32) (int32, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	total := 0
+	for reporter, r := range a.reports {
+		// routers report at least every few seconds while serving requests
+		if now.Sub(r.time) > defaultReporterExpiry {
+			delete(a.reports, reporter)
+			continue
+		}
+		total += r.activeRequests
+	}
+	a.samples = append(trimSamples(a.samples, now, cfg.StableWindow), sample{time: now, value: float64(total)})
+
+	stableAvg := average(a.samples, now, cfg.StableWindow)
+	panicAvg := average(a.samples, now, cfg.PanicWindow)
+	if current == 0 && stableAvg == 0 && panicAvg == 0 {
+		return 0, false
+	}
+
+	stableDesired := int32(math.Ceil(stableAvg / cfg.TargetConcurrency))
+	panicDesired := int32(math.Ceil(panicAvg / cfg.TargetConcurrency))
+
+	readyPods := max(ready, 1)
+	if float64(panicDesired)/float64(readyPods) >= cfg.PanicThreshold {
+		if a.panicStart.IsZero() {
+			a.maxPanicReplicas = current
+		}
+		// stay in panic mode for a stable window after the last burst
+		a.panicStart = now
+	} else if !a.panicStart.IsZero() && now.Sub(a.panicStart) >= cfg.StableWindow {
+		a.panicStart = time.Time{}
+		a.maxPanicReplicas = 0
+	}
+	panicking := !a.panicStart.IsZero()
+
+	var desired int32
+	if panicking {
+		// never scale down in panic mode
+		desired = max(panicDesired, stableDesired, a.maxPanicReplicas)
+		a.maxPanicReplicas = desired
+	} else {
+		desired = a.stabilize(now, cfg, current, stableDesired)
+	}
+
+	lower := max(cfg.MinScale, 1)
+	upper := max(cfg.MaxScale, lower)
+	return min(max(desired, lower), upper), panicking
+}
+
+// stabilize smooths the recommended replicas like a HPA does, scaling up to
+// the lowest recommendation of the scale up window and down to the highest
+// recommendation of the scale down window.
+func (a *Autoscaler) stabilize(now time.Time, cfg Config, current int32, recommended int32) int32 {
+	a.recommendations = append(trimSamples(a.recommendations, now, max(cfg.ScaleUpStabilization, cfg.ScaleDownStabilization)),
+		sample{time: now, value: float64(recommended)})
+	if len(a.recommendations) > maxRecommendationHistoryEntries {
+		a.recommendations = a.recommendations[len(a.recommendations)-maxRecommendationHistoryEntries:]
+	}
+
+	upRecommendation := recommended
+	downRecommendation := recommended
+	for _, r := range a.recommendations {
+		age := now.Sub(r.time)
+		if age <= cfg.ScaleUpStabilization {
+			upRecommendation = min(upRecommendation, int32(r.value))
+		}
+		if age <= cfg.ScaleDownStabilization {
+			downRecommendation = max(downRecommendation, int32(r.value))
+		}
+	}
+
+	switch {
+	case upRecommendation > current:
+		return upRecommendation
+	case downRecommendation < current:
+		return downRecommendation
+	default:
+		return current
+	}
+}
+
+func trimSamples(samples []sample, now time.Time, window time.Duration) []sample {
+	i := 0
+	for i < len(samples) && now.Sub(samples[i].time) > window {
+		i++
+	}
+	return samples[i:]
+}
+
+func average(samples []sample, now time.Time, window time.Duration) float64 {
+	sum, count := 0.0, 0
+	for i := len(samples) - 1; i >= 0 && now.Sub(samples[i].time) <= window; i-- {
+		sum += samples[i].value
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
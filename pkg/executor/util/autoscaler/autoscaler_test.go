@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestConfigFromStrategy(t *testing.T) {
+	cfg := ConfigFromStrategy(&fv1.ExecutionStrategy{MinScale: 2})
+	require.Equal(t, float64(DefaultTargetConcurrency), cfg.TargetConcurrency)
+	require.Equal(t, int32(2), cfg.MaxScale)
+	require.Equal(t, DefaultScaleDownStabilization, cfg.ScaleDownStabilization)
+
+	zero := int32(0)
+	cfg = ConfigFromStrategy(&fv1.ExecutionStrategy{
+		MaxScale: 5,
+		ConcurrencyScaling: &fv1.ConcurrencyScaling{
+			TargetConcurrency:             4,
+			StableWindowSeconds:           30,
+			PanicWindowSeconds:            60,
+			PanicThresholdPercent:         300,
+			ScaleDownStabilizationSeconds: &zero,
+		},
+	})
+	require.Equal(t, float64(4), cfg.TargetConcurrency)
+	require.Equal(t, 30*time.Second, cfg.StableWindow)
+	require.Equal(t, 30*time.Second, cfg.PanicWindow)
+	require.Equal(t, 3.0, cfg.PanicThreshold)
+	require.Equal(t, time.Duration(0), cfg.ScaleDownStabilization)
+}
+
+func TestAutoscaler(t *testing.T) {
+	cfg := Config{
+		TargetConcurrency:      10,
+		MinScale:               0,
+		MaxScale:               10,
+		StableWindow:           60 * time.Second,
+		PanicWindow:            6 * time.Second,
+		PanicThreshold:         2,
+		ScaleUpStabilization:   0,
+		ScaleDownStabilization: 60 * time.Second,
+	}
+	start := time.Now()
+
+	t.Run("scaled to zero deployment stays at zero without requests", func(t *testing.T) {
+		a := NewAutoscaler()
+		desired, _ := a.Scale(start, cfg, 0, 0)
+		require.Equal(t, int32(0), desired)
+	})
+
+	t.Run("scales from zero once requests are reported", func(t *testing.T) {
+		a := NewAutoscaler()
+		a.Report(start, "router-1", 1)
+		desired, _ := a.Scale(start, cfg, 0, 0)
+		require.Equal(t, int32(1), desired)
+	})
+
+	t.Run("scales on in-flight requests of all routers", func(t *testing.T) {
+		a := NewAutoscaler()
+		now := start
+		for i := 0; i < 30; i++ {
+			a.Report(now, "router-1", 15)
+			a.Report(now, "router-2", 15)
+			desired, _ := a.Scale(now, cfg, 3, 3)
+			require.Equal(t, int32(3), desired)
+			now = now.Add(2 * time.Second)
+		}
+	})
+
+	t.Run("panics on bursts and does not scale down while panicking", func(t *testing.T) {
+		a := NewAutoscaler()
+		now := start
+		for i := 0; i < 30; i++ {
+			a.Report(now, "router-1", 10)
+			_, panicking := a.Scale(now, cfg, 1, 1)
+			require.False(t, panicking)
+			now = now.Add(2 * time.Second)
+		}
+
+		a.Report(now, "router-1", 80)
+		desired, panicking := a.Scale(now, cfg, 1, 1)
+		require.True(t, panicking)
+		// (10 + 10 + 10 + 80) / 4 in-flight requests over the panic window
+		require.Equal(t, int32(3), desired)
+
+		// burst is over, but the function stays scaled up until a stable window passes
+		for i := 0; i < 5; i++ {
+			now = now.Add(2 * time.Second)
+			a.Report(now, "router-1", 10)
+			desired, panicking = a.Scale(now, cfg, 3, 3)
+			require.True(t, panicking)
+			require.Equal(t, int32(3), desired)
+		}
+
+		for i := 0; i < 31; i++ {
+			now = now.Add(2 * time.Second)
+			a.Report(now, "router-1", 10)
+			desired, panicking = a.Scale(now, cfg, 3, 3)
+		}
+		require.False(t, panicking)
+		// scale down is stabilized over the scale down window
+		require.Greater(t, desired, int32(1))
+	})
+
+	t.Run("scales down after the stabilization window", func(t *testing.T) {
+		a := NewAutoscaler()
+		now := start
+		for i := 0; i < 30; i++ {
+			a.Report(now, "router-1", 40)
+			a.Scale(now, cfg, 4, 4)
+			now = now.Add(2 * time.Second)
+		}
+
+		var desired int32
+		for i := 0; i < 70; i++ {
+			a.Report(now, "router-1", 0)
+			desired, _ = a.Scale(now, cfg, 4, 4)
+			now = now.Add(2 * time.Second)
+		}
+		require.Equal(t, int32(1), desired)
+	})
+
+	t.Run("stale reports are ignored", func(t *testing.T) {
+		a := NewAutoscaler()
+		a.Report(start, "router-1", 100)
+		now := start.Add(time.Minute)
+		a.Report(now, "router-2", 10)
+		require.Equal(t, 10, a.ActiveRequests(now))
+		desired, _ := a.Scale(now, cfg, 1, 1)
+		require.Equal(t, int32(1), desired)
+	})
+}
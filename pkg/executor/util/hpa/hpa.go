@@ -84,14 +84,7 @@ func (hpaops *HpaOperations) CreateOrGetHpa(ctx context.Context, fn *fv1.Functio
 	}
 	logger := otelUtils.LoggerWithTraceID(ctx, hpaops.logger)
 
-	minRepl := int32(execStrategy.MinScale)
-	if minRepl == 0 {
-		minRepl = 1
-	}
-	maxRepl := int32(execStrategy.MaxScale)
-	if maxRepl == 0 {
-		maxRepl = minRepl
-	}
+	minRepl, maxRepl := scaleBounds(execStrategy)
 	targetCPU := int32(execStrategy.TargetCPUPercent) // nolint: staticcheck
 	var hpaMetrics []asv2.MetricSpec
 	if targetCPU > 0 && targetCPU < 100 {
@@ -161,6 +154,36 @@ func (hpaops *HpaOperations) CreateOrGetHpa(ctx context.Context, fn *fv1.Functio
 	return nil, err
 }
 
+// UpdateHpaScale sets the min and max replicas of the HPA to the scale of the
+// execution strategy, if they differ.
+func (hpaops *HpaOperations) UpdateHpaScale(ctx context.Context, ns, name string, execStrategy *fv1.ExecutionStrategy) error {
+	hpa, err := hpaops.GetHpa(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+	minRepl, maxRepl := scaleBounds(execStrategy)
+	if hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas == minRepl && hpa.Spec.MaxReplicas == maxRepl {
+		return nil
+	}
+	hpa.Spec.MinReplicas = &minRepl
+	hpa.Spec.MaxReplicas = maxRepl
+	return hpaops.UpdateHpa(ctx, hpa)
+}
+
+// scaleBounds returns the min and max replicas of the HPA for the execution
+// strategy. HPAs can't scale to zero, the idle object reaper does.
+func scaleBounds(execStrategy *fv1.ExecutionStrategy) (int32, int32) {
+	minRepl := int32(execStrategy.MinScale)
+	if minRepl == 0 {
+		minRepl = 1
+	}
+	maxRepl := int32(execStrategy.MaxScale)
+	if maxRepl == 0 {
+		maxRepl = minRepl
+	}
+	return minRepl, maxRepl
+}
+
 func (hpaops *HpaOperations) GetHpa(ctx context.Context, ns, name string) (*asv2.HorizontalPodAutoscaler, error) {
 	return hpaops.kubernetesClient.AutoscalingV2().HorizontalPodAutoscalers(ns).Get(ctx, name, metav1.GetOptions{})
 }
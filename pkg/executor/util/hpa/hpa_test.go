@@ -140,6 +140,20 @@ func TestHpaOps(t *testing.T) {
 		t.Errorf("Expected max replicas to be 10, got %v", hpa.Spec.MaxReplicas)
 	}
 
+	// Test UpdateHpaScale
+	err = hpaops.UpdateHpaScale(ctx, ns, "test-hpa", &fv1.ExecutionStrategy{MinScale: 0, MaxScale: 3})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	hpa, err = hpaops.GetHpa(ctx, ns, "test-hpa")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if *hpa.Spec.MinReplicas != 1 || hpa.Spec.MaxReplicas != 3 {
+		t.Errorf("Expected min and max replicas to be 1 and 3, got %v and %v", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+
 	// Test DeleteHPA
 	err = hpaops.DeleteHpa(ctx, ns, "test-hpa")
 	if err != nil {
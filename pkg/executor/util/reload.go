@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fetcher"
+	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
+)
+
+// ReloadFuncPods asks the fetchers of the running function pods matching the
+// selector to update the secrets and configmaps of the function in place,
+// instead of recycling the pods. Pods which are not running yet fetch the
+// current data when they are specialized.
+func ReloadFuncPods(ctx context.Context, logger *zap.Logger, kubernetesClient kubernetes.Interface,
+	namespace string, selector map[string]string, fn *fv1.Function) error {
+	podList, err := kubernetesClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(selector).AsSelector().String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	refreshReq := &fetcher.FunctionRefreshRequest{
+		Secrets:          fn.Spec.Secrets,
+		ConfigMaps:       fn.Spec.ConfigMaps,
+		FunctionMetadata: &fn.ObjectMeta,
+	}
+
+	var errs []error
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != apiv1.PodRunning || len(pod.Status.PodIP) == 0 {
+			continue
+		}
+		fetcherURL := "http://" + net.JoinHostPort(pod.Status.PodIP, "8000")
+		err := fetcherClient.MakeClient(logger, fetcherURL).Refresh(ctx, refreshReq)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reloading pod %s: %w", pod.ObjectMeta.Name, err))
+			continue
+		}
+		logger.Info("reloaded secrets and configmaps of function pod",
+			zap.String("function", fn.ObjectMeta.Name),
+			zap.String("namespace", fn.ObjectMeta.Namespace),
+			zap.String("pod", pod.ObjectMeta.Name))
+	}
+	return errors.Join(errs...)
+}
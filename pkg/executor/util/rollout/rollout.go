@@ -0,0 +1,372 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	k8s_err "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/generated/clientset/versioned"
+	"github.com/fission/fission/pkg/utils/maps"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// trackGreen is the ROLLOUT_TRACK label value of the pods serving a
+// blue/green rollout while the function deployment is updated.
+const trackGreen = "green"
+
+// RolloutOperations rolls out function updates to the deployments of
+// newdeploy and container functions.
+type RolloutOperations struct {
+	logger           *zap.Logger
+	kubernetesClient kubernetes.Interface
+	fissionClient    versioned.Interface
+
+	// rollouts of the same function are serialized
+	locks sync.Map // function UID -> *sync.Mutex
+}
+
+func NewRolloutOperations(logger *zap.Logger, kubernetesClient kubernetes.Interface, fissionClient versioned.Interface) *RolloutOperations {
+	return &RolloutOperations{
+		logger:           logger.Named("rollout"),
+		kubernetesClient: kubernetesClient,
+		fissionClient:    fissionClient,
+	}
+}
+
+// DeploymentStrategy returns the update strategy of the function deployment.
+func DeploymentStrategy(fn *fv1.Function) appsv1.DeploymentStrategy {
+	// Set maxUnavailable and maxSurge to 20% by default because we want
+	// fission to rollout newer function version gradually without
+	// affecting any online service. For example, if you set maxSurge
+	// to 100%, the new ReplicaSet scales up immediately and may
+	// consume all remaining compute resources which might be an
+	// issue if a cluster's resource is on a budget.
+	maxUnavailable := intstr.FromString("20%")
+	maxSurge := intstr.FromString("20%")
+
+	if us := fn.Spec.InvokeStrategy.ExecutionStrategy.UpdateStrategy; us != nil {
+		if us.MaxUnavailable != nil {
+			maxUnavailable = *us.MaxUnavailable
+		}
+		if us.MaxSurge != nil {
+			maxSurge = *us.MaxSurge
+		}
+	}
+
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		},
+	}
+}
+
+// GreenName returns the name of the deployment serving the new version of
+// the function during a blue/green rollout.
+func GreenName(name string) string {
+	return name + "-" + trackGreen
+}
+
+// Update replaces the current function deployment with the updated one
+// following the update strategy of the function. The service of the function
+// must have the name of the deployment.
+func (ops *RolloutOperations) Update(ctx context.Context, fn *fv1.Function, current *appsv1.Deployment, updated *appsv1.Deployment) error {
+	lock, _ := ops.locks.LoadOrStore(fn.ObjectMeta.UID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	us := fn.Spec.InvokeStrategy.ExecutionStrategy.UpdateStrategy
+	if us == nil {
+		us = &fv1.UpdateStrategy{}
+	}
+
+	if us.Type != fv1.UpdateStrategyBlueGreen {
+		// the service may still select the pods of a failed blue/green rollout
+		err := ops.pinService(ctx, updated.Namespace, updated.Name, false)
+		if err != nil {
+			return err
+		}
+		if us.RollbackTimeout <= 0 {
+			return ops.updateDeployment(ctx, updated)
+		}
+	}
+
+	ops.reportStatus(ctx, fn, fv1.RolloutProgressing, "")
+
+	var phase fv1.RolloutPhase
+	var err error
+	if us.Type == fv1.UpdateStrategyBlueGreen {
+		phase, err = ops.blueGreen(ctx, updated, rolloutTimeout(fn))
+	} else {
+		phase, err = ops.rolling(ctx, current, updated, rolloutTimeout(fn))
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	ops.reportStatus(ctx, fn, phase, message)
+	return err
+}
+
+// Cleanup removes the leftovers of failed blue/green rollouts.
+func (ops *RolloutOperations) Cleanup(ctx context.Context, ns string, name string) error {
+	err := ops.deleteDeployment(ctx, ns, GreenName(name))
+	if k8s_err.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// rolling updates the deployment in place and restores the pod template of
+// the current deployment if the rollout doesn't complete within the timeout.
+func (ops *RolloutOperations) rolling(ctx context.Context, current *appsv1.Deployment, updated *appsv1.Deployment, timeout time.Duration) (fv1.RolloutPhase, error) {
+	err := ops.updateDeployment(ctx, updated)
+	if err != nil {
+		return fv1.RolloutFailed, err
+	}
+
+	rolloutErr := ops.waitForRollout(ctx, updated.Namespace, updated.Name, timeout)
+	if rolloutErr == nil {
+		return fv1.RolloutSucceeded, nil
+	}
+
+	ops.logger.Warn("rolling back function deployment", zap.Error(rolloutErr),
+		zap.String("deployment", updated.Name), zap.String("namespace", updated.Namespace))
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := ops.kubernetesClient.AppsV1().Deployments(current.Namespace).Get(ctx, current.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		latest.Spec.Template = current.Spec.Template
+		return ops.updateDeployment(ctx, latest)
+	})
+	if err != nil {
+		return fv1.RolloutFailed, errors.Join(rolloutErr, fmt.Errorf("error rolling back deployment: %w", err))
+	}
+	return fv1.RolloutRolledBack, fmt.Errorf("%w, rolled back to the previous version", rolloutErr)
+}
+
+// blueGreen brings up the updated deployment next to the current one and
+// switches the service to it once it's ready. The function deployment is
+// updated while the service is pinned to the new pods, and the temporary
+// deployment is removed afterwards.
+func (ops *RolloutOperations) blueGreen(ctx context.Context, updated *appsv1.Deployment, timeout time.Duration) (fv1.RolloutPhase, error) {
+	green := greenDeployment(updated)
+	err := ops.applyDeployment(ctx, green)
+	if err != nil {
+		return fv1.RolloutFailed, err
+	}
+
+	err = ops.waitForRollout(ctx, green.Namespace, green.Name, timeout)
+	if err != nil {
+		// traffic never left the current pods
+		if cleanupErr := ops.Cleanup(ctx, updated.Namespace, updated.Name); cleanupErr != nil {
+			ops.logger.Error("error deleting blue/green deployment", zap.Error(cleanupErr), zap.String("deployment", green.Name))
+		}
+		return fv1.RolloutRolledBack, fmt.Errorf("%w, kept serving the previous version", err)
+	}
+
+	err = ops.pinService(ctx, updated.Namespace, updated.Name, true)
+	if err != nil {
+		return fv1.RolloutFailed, err
+	}
+
+	err = ops.updateDeployment(ctx, updated)
+	if err == nil {
+		err = ops.waitForRollout(ctx, updated.Namespace, updated.Name, timeout)
+	}
+	if err != nil {
+		return fv1.RolloutFailed, fmt.Errorf("%w, requests are served by deployment %s", err, green.Name)
+	}
+
+	err = ops.pinService(ctx, updated.Namespace, updated.Name, false)
+	if err != nil {
+		return fv1.RolloutFailed, err
+	}
+	err = ops.Cleanup(ctx, updated.Namespace, updated.Name)
+	if err != nil {
+		ops.logger.Error("error deleting blue/green deployment", zap.Error(err), zap.String("deployment", green.Name))
+	}
+	return fv1.RolloutSucceeded, nil
+}
+
+// greenDeployment returns a copy of the deployment which runs next to it
+// during a blue/green rollout. Its pods carry the labels of the function
+// pods, so they keep serving requests while the service is unpinned again.
+func greenDeployment(updated *appsv1.Deployment) *appsv1.Deployment {
+	green := updated.DeepCopy()
+	green.ObjectMeta = metav1.ObjectMeta{
+		Name:      GreenName(updated.Name),
+		Namespace: updated.Namespace,
+		// no executor labels, the executors must not adopt the deployment
+		Labels: map[string]string{
+			fv1.FUNCTION_UID:  updated.Labels[fv1.FUNCTION_UID],
+			fv1.ROLLOUT_TRACK: trackGreen,
+		},
+		Annotations:     updated.Annotations,
+		OwnerReferences: updated.OwnerReferences,
+	}
+	selector := maps.CopyStringMap(updated.Spec.Selector.MatchLabels)
+	selector[fv1.ROLLOUT_TRACK] = trackGreen
+	green.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	green.Spec.Template.Labels = maps.CopyStringMap(updated.Spec.Template.Labels)
+	green.Spec.Template.Labels[fv1.ROLLOUT_TRACK] = trackGreen
+	green.Status = appsv1.DeploymentStatus{}
+	return green
+}
+
+// pinService makes the service select only the pods of the green deployment,
+// or all pods of the function if pin is false.
+func (ops *RolloutOperations) pinService(ctx context.Context, ns string, name string, pin bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		svc, err := ops.kubernetesClient.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		_, pinned := svc.Spec.Selector[fv1.ROLLOUT_TRACK]
+		if pinned == pin {
+			return nil
+		}
+		selector := maps.CopyStringMap(svc.Spec.Selector)
+		if pin {
+			selector[fv1.ROLLOUT_TRACK] = trackGreen
+		} else {
+			delete(selector, fv1.ROLLOUT_TRACK)
+		}
+		svc.Spec.Selector = selector
+		_, err = ops.kubernetesClient.CoreV1().Services(ns).Update(ctx, svc, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// applyDeployment creates the deployment or updates the existing one.
+func (ops *RolloutOperations) applyDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
+	_, err := ops.kubernetesClient.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	if !k8s_err.IsAlreadyExists(err) {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := ops.kubernetesClient.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing.Spec.Replicas = deployment.Spec.Replicas
+		existing.Spec.Template = deployment.Spec.Template
+		existing.Spec.Strategy = deployment.Spec.Strategy
+		return ops.updateDeployment(ctx, existing)
+	})
+}
+
+func (ops *RolloutOperations) updateDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
+	_, err := ops.kubernetesClient.AppsV1().Deployments(deployment.Namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+	return err
+}
+
+func (ops *RolloutOperations) deleteDeployment(ctx context.Context, ns string, name string) error {
+	deletePropagation := metav1.DeletePropagationBackground
+	return ops.kubernetesClient.AppsV1().Deployments(ns).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &deletePropagation,
+	})
+}
+
+// waitForRollout waits until all pods of the deployment run its latest
+// pod template and are available.
+func (ops *RolloutOperations) waitForRollout(ctx context.Context, ns string, name string, timeout time.Duration) error {
+	var latest *appsv1.Deployment
+	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		latest, err = ops.kubernetesClient.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return rolledOut(latest), nil
+	})
+	if err == nil {
+		otelUtils.SpanTrackEvent(ctx, "deploymentRolledOut", otelUtils.GetAttributesForDeployment(latest)...)
+		return nil
+	}
+	if wait.Interrupted(err) {
+		return fmt.Errorf("new pods of deployment %s did not become ready within %v", name, timeout)
+	}
+	return err
+}
+
+// rolledOut reports whether the rollout of the deployment completed.
+func rolledOut(depl *appsv1.Deployment) bool {
+	if depl.Generation > depl.Status.ObservedGeneration {
+		return false
+	}
+	replicas := int32(1)
+	if depl.Spec.Replicas != nil {
+		replicas = *depl.Spec.Replicas
+	}
+	return depl.Status.UpdatedReplicas >= replicas &&
+		depl.Status.Replicas == depl.Status.UpdatedReplicas &&
+		depl.Status.AvailableReplicas >= replicas
+}
+
+// rolloutTimeout returns how long the new pods of the function have to
+// become ready.
+func rolloutTimeout(fn *fv1.Function) time.Duration {
+	es := fn.Spec.InvokeStrategy.ExecutionStrategy
+	if es.UpdateStrategy != nil && es.UpdateStrategy.RollbackTimeout > 0 {
+		return time.Duration(es.UpdateStrategy.RollbackTimeout) * time.Second
+	}
+	return time.Duration(max(es.SpecializationTimeout, fv1.DefaultSpecializationTimeOut)) * time.Second
+}
+
+// reportStatus records the rollout phase in the function status. Failures
+// are only logged, they must not fail the rollout.
+func (ops *RolloutOperations) reportStatus(ctx context.Context, fn *fv1.Function, phase fv1.RolloutPhase, message string) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := ops.fissionClient.CoreV1().Functions(fn.ObjectMeta.Namespace).Get(ctx, fn.ObjectMeta.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if latest.ObjectMeta.UID != fn.ObjectMeta.UID {
+			return nil
+		}
+		latest.Status.Rollout = &fv1.RolloutStatus{
+			Phase:                   phase,
+			FunctionResourceVersion: fn.ObjectMeta.ResourceVersion,
+			Message:                 message,
+			LastUpdateTimestamp:     metav1.Now(),
+		}
+		_, err = ops.fissionClient.CoreV1().Functions(fn.ObjectMeta.Namespace).UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		ops.logger.Warn("error updating function rollout status", zap.Error(err),
+			zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", fn.ObjectMeta.Namespace),
+			zap.String("phase", string(phase)))
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rollout
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8s_err "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fissionfake "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+const (
+	testNS   = "fission-function"
+	testName = "newdeploy-hello"
+)
+
+func testFunction(us *fv1.UpdateStrategy) *fv1.Function {
+	return &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "hello",
+			Namespace:       "default",
+			UID:             "f2c9b4a0-1d2e-4f3a-9b8c-7d6e5f4a3b2c",
+			ResourceVersion: "2",
+		},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:   fv1.ExecutorTypeNewdeploy,
+					UpdateStrategy: us,
+				},
+			},
+		},
+	}
+}
+
+func testDeployment(image string) *appsv1.Deployment {
+	labels := map[string]string{fv1.FUNCTION_UID: "f2c9b4a0-1d2e-4f3a-9b8c-7d6e5f4a3b2c"}
+	replicas := int32(2)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNS,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "hello", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+// makeReady marks the deployments written by the client as rolled out unless
+// their image is "broken".
+func makeReady(kubernetesClient *fake.Clientset) {
+	reactor := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		var obj runtime.Object
+		switch a := action.(type) {
+		case k8stesting.CreateAction:
+			obj = a.GetObject()
+		case k8stesting.UpdateAction:
+			obj = a.GetObject()
+		}
+		depl := obj.(*appsv1.Deployment)
+		if depl.Spec.Template.Spec.Containers[0].Image == "broken" {
+			depl.Status = appsv1.DeploymentStatus{}
+			return false, nil, nil
+		}
+		replicas := *depl.Spec.Replicas
+		depl.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: depl.Generation,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  replicas,
+		}
+		return false, nil, nil
+	}
+	kubernetesClient.PrependReactor("create", "deployments", reactor)
+	kubernetesClient.PrependReactor("update", "deployments", reactor)
+}
+
+func setup(t *testing.T, us *fv1.UpdateStrategy) (*RolloutOperations, *fake.Clientset, *fissionfake.Clientset, *fv1.Function, *appsv1.Deployment) {
+	fn := testFunction(us)
+	current := testDeployment("v1")
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNS},
+		Spec:       apiv1.ServiceSpec{Selector: current.Spec.Selector.MatchLabels},
+	}
+	kubernetesClient := fake.NewSimpleClientset(current, svc)
+	makeReady(kubernetesClient)
+	fissionClient := fissionfake.NewSimpleClientset(fn)
+	ops := NewRolloutOperations(loggerfactory.GetLogger(), kubernetesClient, fissionClient)
+	return ops, kubernetesClient, fissionClient, fn, current
+}
+
+func rolloutPhase(t *testing.T, fissionClient *fissionfake.Clientset, fn *fv1.Function) fv1.RolloutPhase {
+	latest, err := fissionClient.CoreV1().Functions(fn.Namespace).Get(t.Context(), fn.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting function: %v", err)
+	}
+	if latest.Status.Rollout == nil {
+		return ""
+	}
+	return latest.Status.Rollout.Phase
+}
+
+func TestDeploymentStrategy(t *testing.T) {
+	strategy := DeploymentStrategy(testFunction(nil))
+	if strategy.RollingUpdate.MaxSurge.String() != "20%" || strategy.RollingUpdate.MaxUnavailable.String() != "20%" {
+		t.Errorf("expected 20%% max surge and unavailable by default, got %v", strategy.RollingUpdate)
+	}
+
+	maxSurge := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromString("0%")
+	strategy = DeploymentStrategy(testFunction(&fv1.UpdateStrategy{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable}))
+	if *strategy.RollingUpdate.MaxSurge != maxSurge || *strategy.RollingUpdate.MaxUnavailable != maxUnavailable {
+		t.Errorf("expected configured max surge and unavailable, got %v", strategy.RollingUpdate)
+	}
+}
+
+func TestRollingRollback(t *testing.T) {
+	ops, kubernetesClient, fissionClient, fn, current := setup(t, &fv1.UpdateStrategy{RollbackTimeout: 1})
+	ctx := t.Context()
+
+	err := ops.Update(ctx, fn, current, testDeployment("broken"))
+	if err == nil {
+		t.Fatal("expected rollout of broken deployment to fail")
+	}
+	depl, err := kubernetesClient.AppsV1().Deployments(testNS).Get(ctx, testName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := depl.Spec.Template.Spec.Containers[0].Image; image != "v1" {
+		t.Errorf("expected deployment to be rolled back to v1, got %s", image)
+	}
+	if phase := rolloutPhase(t, fissionClient, fn); phase != fv1.RolloutRolledBack {
+		t.Errorf("expected rollout phase %s, got %s", fv1.RolloutRolledBack, phase)
+	}
+
+	err = ops.Update(ctx, fn, current, testDeployment("v2"))
+	if err != nil {
+		t.Fatalf("unexpected rollout error: %v", err)
+	}
+	if phase := rolloutPhase(t, fissionClient, fn); phase != fv1.RolloutSucceeded {
+		t.Errorf("expected rollout phase %s, got %s", fv1.RolloutSucceeded, phase)
+	}
+}
+
+func TestBlueGreen(t *testing.T) {
+	ops, kubernetesClient, fissionClient, fn, current := setup(t, &fv1.UpdateStrategy{
+		Type:            fv1.UpdateStrategyBlueGreen,
+		RollbackTimeout: 1,
+	})
+	ctx := t.Context()
+
+	// the new pods never become ready, the service stays on the current pods
+	err := ops.Update(ctx, fn, current, testDeployment("broken"))
+	if err == nil {
+		t.Fatal("expected rollout of broken deployment to fail")
+	}
+	depl, err := kubernetesClient.AppsV1().Deployments(testNS).Get(ctx, testName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := depl.Spec.Template.Spec.Containers[0].Image; image != "v1" {
+		t.Errorf("expected function deployment to be untouched, got image %s", image)
+	}
+	_, err = kubernetesClient.AppsV1().Deployments(testNS).Get(ctx, GreenName(testName), metav1.GetOptions{})
+	if !k8s_err.IsNotFound(err) {
+		t.Errorf("expected green deployment to be deleted, got %v", err)
+	}
+	if phase := rolloutPhase(t, fissionClient, fn); phase != fv1.RolloutRolledBack {
+		t.Errorf("expected rollout phase %s, got %s", fv1.RolloutRolledBack, phase)
+	}
+
+	err = ops.Update(ctx, fn, current, testDeployment("v2"))
+	if err != nil {
+		t.Fatalf("unexpected rollout error: %v", err)
+	}
+	depl, err = kubernetesClient.AppsV1().Deployments(testNS).Get(ctx, testName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := depl.Spec.Template.Spec.Containers[0].Image; image != "v2" {
+		t.Errorf("expected function deployment to run v2, got %s", image)
+	}
+	svc, err := kubernetesClient.CoreV1().Services(testNS).Get(ctx, testName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := svc.Spec.Selector[fv1.ROLLOUT_TRACK]; ok {
+		t.Errorf("expected service selector to be unpinned, got %v", svc.Spec.Selector)
+	}
+	_, err = kubernetesClient.AppsV1().Deployments(testNS).Get(ctx, GreenName(testName), metav1.GetOptions{})
+	if !k8s_err.IsNotFound(err) {
+		t.Errorf("expected green deployment to be deleted, got %v", err)
+	}
+	if phase := rolloutPhase(t, fissionClient, fn); phase != fv1.RolloutSucceeded {
+		t.Errorf("expected rollout phase %s, got %s", fv1.RolloutSucceeded, phase)
+	}
+}
@@ -18,9 +18,12 @@ package util
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -28,6 +31,7 @@ import (
 	"go.uber.org/zap"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 
@@ -36,9 +40,28 @@ import (
 )
 
 const (
-	dumpFileName string = "fission-dump"
+	// AccessTimeReportInterval is how often executor replicas record the
+	// last access time of the function services they serve, when running
+	// with more than one replica.
+	AccessTimeReportInterval = 30 * time.Second
+
+	// ActiveRequestsReportInterval is how often executor replicas which are
+	// not the leader record the in-flight requests of functions scaling on
+	// concurrency.
+	ActiveRequestsReportInterval = 5 * time.Second
+
+	// ScalingScheduleInterval is how often the leader applies the scaling
+	// schedules of functions.
+	ScalingScheduleInterval = 30 * time.Second
 )
 
+// ActiveRequests is the number of in-flight requests of a function observed
+// by an executor replica at a point in time.
+type ActiveRequests struct {
+	Count int
+	Time  time.Time
+}
+
 // ApplyImagePullSecret applies image pull secret to the give pod spec.
 // It's intentional not to check the existence of secret here.
 // First, Kubernetes will set Pod status to "ImagePullBackOff" once
@@ -66,8 +89,9 @@ func WaitTimeout(wg *sync.WaitGroup, timeout time.Duration) {
 	}
 }
 
-// ConvertConfigSecrets returns envFromSource which can be passed directly into the pod spec
-func ConvertConfigSecrets(ctx context.Context, fn *fv1.Function, kc kubernetes.Interface) ([]apiv1.EnvFromSource, error) {
+// ConvertConfigSecrets returns envFromSource and env vars which can be passed directly into the pod spec.
+// Secrets and configmaps without env projection are projected entirely.
+func ConvertConfigSecrets(ctx context.Context, fn *fv1.Function, kc kubernetes.Interface) ([]apiv1.EnvFromSource, []apiv1.EnvVar, error) {
 
 	cmList := fn.Spec.ConfigMaps
 	secList := fn.Spec.Secrets
@@ -75,11 +99,14 @@ func ConvertConfigSecrets(ctx context.Context, fn *fv1.Function, kc kubernetes.I
 	secEnvSources := make([]*apiv1.SecretEnvSource, 0)
 	for _, cm := range cmList {
 		if cm.Namespace != fn.Namespace {
-			return nil, errors.New("function should not reference config map of different namespace")
+			return nil, nil, errors.New("function should not reference config map of different namespace")
 		}
 		_, err := kc.CoreV1().ConfigMaps(cm.Namespace).Get(ctx, cm.Name, metav1.GetOptions{})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if cm.Env != nil {
+			continue
 		}
 
 		cmEnvSource := &apiv1.ConfigMapEnvSource{
@@ -91,11 +118,14 @@ func ConvertConfigSecrets(ctx context.Context, fn *fv1.Function, kc kubernetes.I
 
 	for _, sec := range secList {
 		if sec.Namespace != fn.Namespace {
-			return nil, errors.New("function should not reference secret of different namespace")
+			return nil, nil, errors.New("function should not reference secret of different namespace")
 		}
 		_, err := kc.CoreV1().Secrets(sec.Namespace).Get(ctx, sec.Name, metav1.GetOptions{})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if sec.Env != nil {
+			continue
 		}
 
 		secEnvSource := &apiv1.SecretEnvSource{
@@ -121,7 +151,64 @@ func ConvertConfigSecrets(ctx context.Context, fn *fv1.Function, kc kubernetes.I
 		}
 		envFromSources = append(envFromSources, envFromSource)
 	}
-	return envFromSources, nil
+
+	projectedEnvFrom, projectedEnv := ProjectConfigSecretsEnv(fn)
+	return append(envFromSources, projectedEnvFrom...), projectedEnv, nil
+}
+
+// ProjectConfigSecretsEnv returns envFromSource and env vars projecting the secrets
+// and configmaps of the function with env projection. Keys missing from a
+// secret or configmap are skipped, the same way as in the specialize request.
+func ProjectConfigSecretsEnv(fn *fv1.Function) ([]apiv1.EnvFromSource, []apiv1.EnvVar) {
+	var envFrom []apiv1.EnvFromSource
+	var env []apiv1.EnvVar
+	optional := true
+
+	for _, cm := range fn.Spec.ConfigMaps {
+		if cm.Env == nil {
+			continue
+		}
+		ref := apiv1.LocalObjectReference{Name: cm.Name}
+		if len(cm.Env.Items) == 0 {
+			envFrom = append(envFrom, apiv1.EnvFromSource{
+				Prefix:       cm.Env.Prefix,
+				ConfigMapRef: &apiv1.ConfigMapEnvSource{LocalObjectReference: ref},
+			})
+			continue
+		}
+		for _, item := range cm.Env.Items {
+			env = append(env, apiv1.EnvVar{
+				Name: cm.Env.EnvName(item),
+				ValueFrom: &apiv1.EnvVarSource{
+					ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{LocalObjectReference: ref, Key: item.Key, Optional: &optional},
+				},
+			})
+		}
+	}
+
+	for _, sec := range fn.Spec.Secrets {
+		if sec.Env == nil {
+			continue
+		}
+		ref := apiv1.LocalObjectReference{Name: sec.Name}
+		if len(sec.Env.Items) == 0 {
+			envFrom = append(envFrom, apiv1.EnvFromSource{
+				Prefix:    sec.Env.Prefix,
+				SecretRef: &apiv1.SecretEnvSource{LocalObjectReference: ref},
+			})
+			continue
+		}
+		for _, item := range sec.Env.Items {
+			env = append(env, apiv1.EnvVar{
+				Name: sec.Env.EnvName(item),
+				ValueFrom: &apiv1.EnvVarSource{
+					SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: ref, Key: item.Key, Optional: &optional},
+				},
+			})
+		}
+	}
+
+	return envFrom, env
 }
 
 func GetSpecFromConfigMap(filePath string) (*apiv1.PodSpec, error) {
@@ -161,14 +248,6 @@ func getExecutorEnvVarName(executor fv1.ExecutorType) string {
 	return strings.ToUpper(string(executor)) + "_OBJECT_REAPER_INTERVAL"
 }
 
-// CreateDumpFile => create dump file inside temp directory
-func CreateDumpFile(logger *zap.Logger) (*os.File, error) {
-	dumpPath := os.TempDir()
-	logger.Info("creating dump file", zap.String("dump_path", dumpPath))
-
-	return os.Create(fmt.Sprintf("%s/%s-%d.txt", dumpPath, dumpFileName, time.Now().Unix()))
-}
-
 // DoesContainerExistInPodSpec checks if the container with the given name exists in the pod spec
 func DoesContainerExistInPodSpec(containerName string, podSpec *apiv1.PodSpec) bool {
 	for _, container := range podSpec.Containers {
@@ -178,3 +257,115 @@ func DoesContainerExistInPodSpec(containerName string, podSpec *apiv1.PodSpec) b
 	}
 	return false
 }
+
+// GetLastAccessTime returns the last access time recorded in the object
+// annotations by an executor replica.
+func GetLastAccessTime(obj metav1.Object) (time.Time, bool) {
+	return getAnnotationTime(obj, fv1.ANNOTATION_LAST_ACCESS_TIME)
+}
+
+// GetSpecializationTime returns the specialization time recorded in the
+// pod annotations by the executor replica which specialized it.
+func GetSpecializationTime(obj metav1.Object) (time.Time, bool) {
+	return getAnnotationTime(obj, fv1.ANNOTATION_SPECIALIZATION_TIME)
+}
+
+// GetDrainDeadline returns the deadline of the drain of the pod recorded in
+// its annotations, if it's drained.
+func GetDrainDeadline(obj metav1.Object) (time.Time, bool) {
+	return getAnnotationTime(obj, fv1.ANNOTATION_DRAIN_DEADLINE)
+}
+
+func getAnnotationTime(obj metav1.Object, key string) (time.Time, bool) {
+	v, ok := obj.GetAnnotations()[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// PatchLastAccessTime records the last access time of a function service
+// in the annotations of the given pod or deployment, so that other
+// executor replicas learn about it.
+func PatchLastAccessTime(ctx context.Context, kubernetesClient kubernetes.Interface, obj *apiv1.ObjectReference, atime time.Time) error {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, fv1.ANNOTATION_LAST_ACCESS_TIME, atime.UTC().Format(time.RFC3339))
+	var err error
+	switch strings.ToLower(obj.Kind) {
+	case "pod":
+		_, err = kubernetesClient.CoreV1().Pods(obj.Namespace).Patch(ctx, obj.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	case "deployment":
+		_, err = kubernetesClient.AppsV1().Deployments(obj.Namespace).Patch(ctx, obj.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unsupported object kind '%s' for last access time", obj.Kind)
+	}
+	return err
+}
+
+// PatchDrainDeadline records in the annotations of the pod that it's drained,
+// so that all executor replicas stop assigning requests to it, and the
+// deadline of the drain.
+func PatchDrainDeadline(ctx context.Context, kubernetesClient kubernetes.Interface, obj *apiv1.ObjectReference, deadline time.Time) error {
+	if !strings.EqualFold(obj.Kind, "pod") {
+		return fmt.Errorf("unsupported object kind '%s' for drain deadline", obj.Kind)
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, fv1.ANNOTATION_DRAIN_DEADLINE, deadline.UTC().Format(time.RFC3339))
+	_, err := kubernetesClient.CoreV1().Pods(obj.Namespace).Patch(ctx, obj.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// ActiveRequestsReplicaKey returns the key identifying an executor replica in
+// the annotations recording in-flight requests. Replica identities are pod
+// hostnames, which are hashed to keep annotation names within 63 characters.
+func ActiveRequestsReplicaKey(replica string) string {
+	sum := sha256.Sum256([]byte(replica))
+	return hex.EncodeToString(sum[:8])
+}
+
+// GetActiveRequests returns the in-flight requests recorded in the object
+// annotations by each executor replica, by replica key.
+func GetActiveRequests(obj metav1.Object) map[string]ActiveRequests {
+	reports := make(map[string]ActiveRequests)
+	for k, v := range obj.GetAnnotations() {
+		replica, ok := strings.CutPrefix(k, fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX)
+		if !ok {
+			continue
+		}
+		count, ts, ok := strings.Cut(v, ",")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			continue
+		}
+		reports[replica] = ActiveRequests{Count: n, Time: t}
+	}
+	return reports
+}
+
+// PatchActiveRequests records the in-flight requests of a function observed
+// by an executor replica in the annotations of the function deployment or pod,
+// so that the leader scales the deployment, or recycles the pod, on the
+// requests of all replicas.
+func PatchActiveRequests(ctx context.Context, kubernetesClient kubernetes.Interface, obj *apiv1.ObjectReference, replica string, activeRequests ActiveRequests) error {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s%s":"%d,%s"}}}`, fv1.ANNOTATION_ACTIVE_REQUESTS_PREFIX, ActiveRequestsReplicaKey(replica),
+		activeRequests.Count, activeRequests.Time.UTC().Format(time.RFC3339Nano))
+	var err error
+	switch strings.ToLower(obj.Kind) {
+	case "pod":
+		_, err = kubernetesClient.CoreV1().Pods(obj.Namespace).Patch(ctx, obj.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	case "deployment":
+		_, err = kubernetesClient.AppsV1().Deployments(obj.Namespace).Patch(ctx, obj.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unsupported object kind '%s' for active requests", obj.Kind)
+	}
+	return err
+}
@@ -17,13 +17,20 @@ limitations under the License.
 package util
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/utils/loggerfactory"
@@ -155,3 +162,87 @@ func TestGetObjectReaperInterval(t *testing.T) {
 		t.Fatalf(`%d %d`, want, got)
 	}
 }
+
+func TestConvertConfigSecrets(t *testing.T) {
+	kc := fake.NewSimpleClientset(
+		&apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&apiv1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+		&apiv1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+	)
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"},
+		Spec: fv1.FunctionSpec{
+			ConfigMaps: []fv1.ConfigMapReference{
+				{Name: "settings", Namespace: "default", Env: &fv1.EnvProjection{Prefix: "APP_"}},
+			},
+			Secrets: []fv1.SecretReference{
+				{Name: "db", Namespace: "default"},
+				{Name: "api", Namespace: "default", Env: &fv1.EnvProjection{
+					Prefix: "API_",
+					Items:  []fv1.EnvKeyMapping{{Key: "token"}, {Key: "url", Name: "ENDPOINT"}},
+				}},
+			},
+		},
+	}
+
+	envFrom, env, err := ConvertConfigSecrets(context.Background(), fn, kc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	optional := true
+	wantEnvFrom := []apiv1.EnvFromSource{
+		{SecretRef: &apiv1.SecretEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "db"}}},
+		{Prefix: "APP_", ConfigMapRef: &apiv1.ConfigMapEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "settings"}}},
+	}
+	wantEnv := []apiv1.EnvVar{
+		{Name: "API_token", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: "api"}, Key: "token", Optional: &optional}}},
+		{Name: "API_ENDPOINT", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: "api"}, Key: "url", Optional: &optional}}},
+	}
+	if diff := cmp.Diff(wantEnvFrom, envFrom); diff != "" {
+		t.Errorf("unexpected envFrom (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantEnv, env); diff != "" {
+		t.Errorf("unexpected env (-want +got):\n%s", diff)
+	}
+
+	// newdeploy only projects the references with env projection
+	envFrom, env = ProjectConfigSecretsEnv(fn)
+	if diff := cmp.Diff(wantEnvFrom[1:], envFrom); diff != "" {
+		t.Errorf("unexpected projected envFrom (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantEnv, env); diff != "" {
+		t.Errorf("unexpected projected env (-want +got):\n%s", diff)
+	}
+}
+
+func TestActiveRequests(t *testing.T) {
+	ctx := context.Background()
+	kubernetesClient := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "fission-function"},
+	})
+	obj := &apiv1.ObjectReference{Kind: "deployment", Name: "hello", Namespace: "fission-function"}
+
+	// replica identities are pod hostnames, up to 63 characters long
+	replica := "executor-" + strings.Repeat("x", 54)
+	now := time.Now().UTC()
+	err := PatchActiveRequests(ctx, kubernetesClient, obj, replica, ActiveRequests{Count: 3, Time: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	depl, err := kubernetesClient.AppsV1().Deployments(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range depl.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			t.Errorf("invalid annotation name %q: %v", key, errs)
+		}
+	}
+	want := map[string]ActiveRequests{ActiveRequestsReplicaKey(replica): {Count: 3, Time: now}}
+	if diff := cmp.Diff(want, GetActiveRequests(depl)); diff != "" {
+		t.Errorf("unexpected active requests (-want +got):\n%s", diff)
+	}
+}
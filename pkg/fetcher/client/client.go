@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,8 +21,10 @@ import (
 
 type (
 	ClientInterface interface {
-		Specialize(context.Context, *fetcher.FunctionSpecializeRequest) error
-		Fetch(context.Context, *fetcher.FunctionFetchRequest) error
+		Specialize(context.Context, *fetcher.FunctionSpecializeRequest) (*fetcher.FunctionSpecializeResponse, error)
+		SpecializePhases(context.Context) (*fetcher.FunctionSpecializeResponse, error)
+		Fetch(context.Context, *fetcher.FunctionFetchRequest) (*fetcher.FunctionFetchResponse, error)
+		Refresh(context.Context, *fetcher.FunctionRefreshRequest) error
 		Upload(context.Context, *fetcher.ArchiveUploadRequest) (*fetcher.ArchiveUploadResponse, error)
 	}
 	client struct {
@@ -44,21 +47,86 @@ func (c *client) getSpecializeUrl() string {
 	return c.url + "/specialize"
 }
 
+func (c *client) getSpecializePhasesUrl() string {
+	return c.url + "/specialize/phases"
+}
+
 func (c *client) getFetchUrl() string {
 	return c.url + "/fetch"
 }
 
+func (c *client) getRefreshUrl() string {
+	return c.url + "/refresh"
+}
+
 func (c *client) getUploadUrl() string {
 	return c.url + "/upload"
 }
 
-func (c *client) Specialize(ctx context.Context, req *fetcher.FunctionSpecializeRequest) error {
-	_, err := sendRequest(c.logger, ctx, c.httpClient, req, c.getSpecializeUrl())
-	return err
+func (c *client) Specialize(ctx context.Context, req *fetcher.FunctionSpecializeRequest) (*fetcher.FunctionSpecializeResponse, error) {
+	body, err := sendRequest(c.logger, ctx, c.httpClient, req, c.getSpecializeUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	specializeResp := fetcher.FunctionSpecializeResponse{}
+	// fetchers of older releases reply without a body
+	if len(body) == 0 {
+		return &specializeResp, nil
+	}
+	err = json.Unmarshal(body, &specializeResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &specializeResp, nil
+}
+
+// SpecializePhases returns the phases of the specialization of a pod
+// specialized on startup. It returns no phases when they were already taken
+// or the fetcher predates them.
+func (c *client) SpecializePhases(ctx context.Context) (*fetcher.FunctionSpecializeResponse, error) {
+	resp, err := ctxhttp.Get(ctx, c.httpClient, c.getSpecializePhasesUrl())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	specializeResp := fetcher.FunctionSpecializeResponse{}
+	if resp.StatusCode == http.StatusNotFound {
+		return &specializeResp, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&specializeResp)
+	if err != nil {
+		return nil, err
+	}
+	return &specializeResp, nil
 }
 
-func (c *client) Fetch(ctx context.Context, fr *fetcher.FunctionFetchRequest) error {
-	_, err := sendRequest(c.logger, ctx, c.httpClient, fr, c.getFetchUrl())
+func (c *client) Fetch(ctx context.Context, fr *fetcher.FunctionFetchRequest) (*fetcher.FunctionFetchResponse, error) {
+	body, err := sendRequest(c.logger, ctx, c.httpClient, fr, c.getFetchUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	fetchResp := fetcher.FunctionFetchResponse{}
+	// fetchers of older releases reply without a body
+	if len(body) == 0 {
+		return &fetchResp, nil
+	}
+	err = json.Unmarshal(body, &fetchResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetchResp, nil
+}
+
+func (c *client) Refresh(ctx context.Context, rr *fetcher.FunctionRefreshRequest) error {
+	_, err := sendRequest(c.logger, ctx, c.httpClient, rr, c.getRefreshUrl())
 	return err
 }
 
@@ -108,6 +176,10 @@ func sendRequest(logger *zap.Logger, ctx context.Context, httpClient *http.Clien
 			logger.Error(msg, zap.Error(err), zap.String("url", url))
 			return nil, err
 		}
+		// nor when the request is canceled
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
 
 		if i < maxRetries-1 {
 			time.Sleep(50 * time.Duration(2*i) * time.Millisecond)
@@ -31,6 +31,14 @@ type Config struct {
 	sharedCfgMapPath string
 
 	serviceAccount string
+
+	// node-local package cache shared by fetchers on the same node,
+	// disabled when the host path is empty
+	packageCacheHostPath string
+	packageCacheSize     int64
+
+	// comma separated registries of OCI archives reached over plain HTTP
+	plainHTTPRegistries string
 }
 
 func getFetcherResources() (apiv1.ResourceRequirements, error) {
@@ -76,6 +84,15 @@ func MakeFetcherConfig(sharedMountPath string) (*Config, error) {
 		fetcherImagePullPolicy = "IfNotPresent"
 	}
 
+	var packageCacheSize int64
+	if val := os.Getenv("FETCHER_PACKAGE_CACHE_SIZE"); len(val) > 0 {
+		quantity, err := resource.ParseQuantity(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing FETCHER_PACKAGE_CACHE_SIZE: %w", err)
+		}
+		packageCacheSize = quantity.Value()
+	}
+
 	return &Config{
 		resourceRequirements:   resources,
 		fetcherImage:           fetcherImage,
@@ -84,6 +101,9 @@ func MakeFetcherConfig(sharedMountPath string) (*Config, error) {
 		sharedSecretPath:       "/secrets",
 		sharedCfgMapPath:       "/configs",
 		serviceAccount:         fv1.FissionFetcherSA,
+		packageCacheHostPath:   os.Getenv("FETCHER_PACKAGE_CACHE_DIR"),
+		packageCacheSize:       packageCacheSize,
+		plainHTTPRegistries:    os.Getenv("FETCHER_OCI_PLAIN_HTTP_REGISTRIES"),
 	}, nil
 }
 
@@ -91,6 +111,12 @@ func (cfg *Config) SharedMountPath() string {
 	return cfg.sharedMountPath
 }
 
+// PackageCacheEnabled returns true if fetchers keep packages in the
+// node-local package cache.
+func (cfg *Config) PackageCacheEnabled() bool {
+	return len(cfg.packageCacheHostPath) > 0
+}
+
 func (cfg *Config) NewSpecializeRequest(fn *fv1.Function, env *fv1.Environment) fetcher.FunctionSpecializeRequest {
 	targetFilename := "user"
 	if env.Spec.Version >= 2 {
@@ -118,6 +144,8 @@ func (cfg *Config) NewSpecializeRequest(fn *fv1.Function, env *fv1.Environment)
 			Secrets:     fn.Spec.Secrets,
 			ConfigMaps:  fn.Spec.ConfigMaps,
 			KeepArchive: env.Spec.KeepArchive,
+			ImagePullSecrets: fetcher.ImagePullSecrets(env.Spec.ImagePullSecret,
+				env.Spec.Runtime.PodSpec, fn.Spec.PodSpec),
 		},
 		LoadReq: fetcher.FunctionLoadRequest{
 			FilePath:         filepath.Join(cfg.sharedMountPath, targetFilename),
@@ -155,6 +183,17 @@ func (cfg *Config) fetcherCommand(extraArgs ...string) []string {
 		"-cfgmap-dir", cfg.sharedCfgMapPath,
 	}
 
+	if cfg.PackageCacheEnabled() {
+		command = append(command,
+			"-package-cache-dir", fv1.PackageCacheMount,
+			"-package-cache-size", fmt.Sprintf("%d", cfg.packageCacheSize),
+		)
+	}
+
+	if len(cfg.plainHTTPRegistries) > 0 {
+		command = append(command, "-oci-plain-http-registries", cfg.plainHTTPRegistries)
+	}
+
 	command = append(command, extraArgs...)
 	command = append(command, cfg.sharedMountPath)
 	return command
@@ -309,6 +348,25 @@ func (cfg *Config) addFetcherToPodSpecWithCommand(podSpec *apiv1.PodSpec, mainCo
 			existingContainerNames)
 	}
 
+	// the package cache is only mounted into the fetcher, so functions
+	// can't read or modify packages of other functions on the node.
+	if cfg.PackageCacheEnabled() {
+		hostPathType := apiv1.HostPathDirectoryOrCreate
+		volumes = append(volumes, apiv1.Volume{
+			Name: fv1.PackageCacheVolume,
+			VolumeSource: apiv1.VolumeSource{
+				HostPath: &apiv1.HostPathVolumeSource{
+					Path: cfg.packageCacheHostPath,
+					Type: &hostPathType,
+				},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      fv1.PackageCacheVolume,
+			MountPath: fv1.PackageCacheMount,
+		})
+	}
+
 	podSpec.Volumes = append(podSpec.Volumes, volumes...)
 	podSpec.Containers = append(podSpec.Containers, c)
 	if podSpec.ServiceAccountName == "" {
@@ -23,14 +23,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	"golang.org/x/net/context/ctxhttp"
 	corev1 "k8s.io/api/core/v1"
@@ -62,7 +65,15 @@ type (
 		fissionClient    versioned.Interface
 		kubeClient       kubernetes.Interface
 		httpClient       *http.Client
-		Info             PodInfo
+		pkgCache         *packageCache // nil when the node-local package cache is disabled
+		// registries of OCI archives reached over plain HTTP
+		plainHTTPRegistries []string
+		// base URL of the environment container
+		runtimeURL string
+		Info       PodInfo
+		// phases of the specialization on startup, until the executor
+		// takes them
+		startupPhases atomic.Pointer[FunctionSpecializeResponse]
 	}
 	PodInfo struct {
 		Name      string
@@ -122,9 +133,24 @@ func MakeFetcher(logger *zap.Logger, clientGen crd.ClientGeneratorInterface, sha
 			Namespace: string(namespace),
 		},
 		httpClient: hc,
+		// Instead of using "localhost", here we use "127.0.0.1" for
+		// inter-pod communication to prevent wrongly record returned from DNS.
+		runtimeURL: "http://127.0.0.1:8888",
 	}, nil
 }
 
+// EnablePackageCache keeps verified package archives in the node-local
+// directory, so that fetchers on the same node skip downloading them again.
+// maxSize limits the size of the cache in bytes, 0 means unlimited.
+func (fetcher *Fetcher) EnablePackageCache(dir string, maxSize int64) error {
+	pc, err := makePackageCache(fetcher.logger, dir, maxSize)
+	if err != nil {
+		return err
+	}
+	fetcher.pkgCache = pc
+	return nil
+}
+
 func verifyChecksum(fileChecksum, checksum *fv1.Checksum) error {
 	if checksum.Type != fv1.ChecksumTypeSHA256 {
 		return ferror.MakeError(ferror.ErrorInvalidArgument, "Unsupported checksum type")
@@ -135,17 +161,73 @@ func verifyChecksum(fileChecksum, checksum *fv1.Checksum) error {
 	return nil
 }
 
+// specializeResponseKey is the context key of the response of the pod
+// specialization in progress.
+type specializeResponseKey struct{}
+
+// startPhase starts a child span for a phase of fetching or specializing.
+// The returned function ends the span and, during a pod specialization,
+// records the phase duration in the specialize response.
+func startPhase(ctx context.Context, phase string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := otel.Tracer("fetcher").Start(ctx, "fetcher/"+phase)
+	return ctx, func() {
+		span.End()
+		if resp, ok := ctx.Value(specializeResponseKey{}).(*FunctionSpecializeResponse); ok {
+			resp.Phases = append(resp.Phases, SpecializePhase{Name: phase, Duration: time.Since(start)})
+		}
+	}
+}
+
+// writeSecretOrConfigMap writes each key of the data to a file of the
+// directory and removes the files of keys no longer present. Files are
+// replaced atomically, so that a function reading them while the data is
+// refreshed sees either the old or the new content.
 func writeSecretOrConfigMap(dataMap map[string][]byte, dirPath string) error {
 	for key, val := range dataMap {
 		writeFilePath := filepath.Join(dirPath, key)
-		err := os.WriteFile(writeFilePath, val, 0750)
+		err := writeFileAtomic(writeFilePath, val, 0750)
 		if err != nil {
 			return fmt.Errorf("failed to write file %s: %w", writeFilePath, err)
 		}
 	}
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dirPath, err)
+	}
+	for _, entry := range entries {
+		if _, ok := dataMap[entry.Name()]; ok || entry.IsDir() {
+			continue
+		}
+		removeFilePath := filepath.Join(dirPath, entry.Name())
+		if err := os.Remove(removeFilePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove file %s: %w", removeFilePath, err)
+		}
+	}
 	return nil
 }
 
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 func (fetcher *Fetcher) VersionHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	_, err := w.Write([]byte(info.BuildInfo().String()))
@@ -192,7 +274,7 @@ func (fetcher *Fetcher) FetchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code, err := fetcher.Fetch(ctx, pkg, req)
+	resp, code, err := fetcher.Fetch(ctx, pkg, req)
 	if err != nil {
 		logger.Error("error fetching", zap.Error(err))
 		http.Error(w, err.Error(), code)
@@ -207,8 +289,58 @@ func (fetcher *Fetcher) FetchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rBody, err := json.Marshal(resp)
+	if err != nil {
+		e := "error encoding fetch response"
+		logger.Error(e, zap.Error(err))
+		http.Error(w, fmt.Sprintf("%s: %v", e, err), http.StatusInternalServerError)
+		return
+	}
+
 	logger.Info("completed fetch request")
 	// all done
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(rBody)
+	if err != nil {
+		logger.Error("error writing response", zap.Error(err))
+	}
+}
+
+// RefreshHandler updates the secrets and configmaps of the specialized
+// function in place, for functions reloading them on change.
+func (fetcher *Fetcher) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != "POST" {
+		http.Error(w, "only POST is supported on this endpoint", http.StatusMethodNotAllowed)
+		return
+	}
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	// parse request
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("error reading request body", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var req FunctionRefreshRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		logger.Error("error parsing request body", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	code, err := fetcher.Refresh(ctx, req)
+	if err != nil {
+		logger.Error("error refreshing secrets and config maps", zap.Error(err))
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	logger.Info("completed refresh request")
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -236,26 +368,83 @@ func (fetcher *Fetcher) SpecializeHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = fetcher.SpecializePod(ctx, req.FetchReq, req.LoadReq)
+	resp, err := fetcher.SpecializePod(ctx, req.FetchReq, req.LoadReq)
 	if err != nil {
 		logger.Error("error specializing pod", zap.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	rBody, err := json.Marshal(resp)
+	if err != nil {
+		e := "error encoding specialize response"
+		logger.Error(e, zap.Error(err))
+		http.Error(w, fmt.Sprintf("%s: %v", e, err), http.StatusInternalServerError)
+		return
+	}
+
 	// all done
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(rBody)
+	if err != nil {
+		logger.Error("error writing response", zap.Error(err))
+	}
+}
+
+// SpecializeOnStartup specializes the pod with the request given at startup,
+// as newdeploy functions do, and keeps the durations of the specialization
+// phases until the executor takes them from SpecializePhasesHandler.
+func (fetcher *Fetcher) SpecializeOnStartup(ctx context.Context, req FunctionSpecializeRequest) error {
+	resp, err := fetcher.SpecializePod(ctx, req.FetchReq, req.LoadReq)
+	if err != nil {
+		return err
+	}
+	fetcher.startupPhases.Store(resp)
+	return nil
+}
+
+// SpecializePhasesHandler replies with the durations of the phases of the
+// specialization on startup. They are only replied once, so that the phases
+// of a pod are recorded once whichever executor asks for them.
+func (fetcher *Fetcher) SpecializePhasesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, fmt.Sprintf("only GET is supported on this endpoint, %v received", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	logger := otelUtils.LoggerWithTraceID(r.Context(), fetcher.logger)
+
+	resp := fetcher.startupPhases.Swap(nil)
+	if resp == nil {
+		http.Error(w, "no specialization phases to report", http.StatusNotFound)
+		return
+	}
+
+	rBody, err := json.Marshal(resp)
+	if err != nil {
+		e := "error encoding specialize response"
+		logger.Error(e, zap.Error(err))
+		http.Error(w, fmt.Sprintf("%s: %v", e, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(rBody)
+	if err != nil {
+		logger.Error("error writing response", zap.Error(err))
+	}
 }
 
 // Fetch takes FetchRequest and makes the fetch call
-// It returns the HTTP code and error if any
-func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req FunctionFetchRequest) (int, error) {
+// It returns the fetch response, the HTTP code and error if any
+func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req FunctionFetchRequest) (*FunctionFetchResponse, int, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
 
 	storePath, err := utils.SanitizeFilePath(filepath.Join(fetcher.sharedVolumePath, req.Filename), fetcher.sharedVolumePath)
 	if err != nil {
 		logger.Error(err.Error(), zap.String("filename", req.Filename))
-		return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
+		return nil, http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
 	}
 
 	// verify first if the file already exists.
@@ -264,15 +453,85 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 			zap.String("requested_file", req.Filename),
 			zap.String("shared_volume_path", fetcher.sharedVolumePath))
 		otelUtils.SpanTrackEvent(ctx, "packageAlreadyExists", otelUtils.GetAttributesForPackage(pkg)...)
-		return http.StatusOK, nil
+		resp := &FunctionFetchResponse{}
+		if req.FetchType == fv1.FETCH_SOURCE && pkg.Spec.Source.Type == fv1.ArchiveTypeGit {
+			resp.SourceCommit, err = readSourceCommit(storePath)
+			if err != nil {
+				logger.Error("error reading commit of checked out git source", zap.Error(err), zap.String("location", storePath))
+				return nil, http.StatusInternalServerError, err
+			}
+		}
+		return resp, http.StatusOK, nil
 	}
 
 	tmpPath, err := utils.SanitizeFilePath(storePath+".tmp", fetcher.sharedVolumePath)
 	if err != nil {
 		logger.Error(err.Error(), zap.String("filename", req.Filename))
-		return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
+		return nil, http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
+	}
+
+	resp := &FunctionFetchResponse{}
+	var code int
+	fetchCtx, endFetch := startPhase(ctx, PhaseFetch)
+	if req.FetchType == fv1.FETCH_SOURCE && pkg.Spec.Source.Type == fv1.ArchiveTypeGit {
+		// git sources are checked out as directories
+		resp.SourceCommit, code, err = fetcher.cloneSource(fetchCtx, pkg, tmpPath)
+	} else {
+		code, err = fetcher.download(fetchCtx, pkg, req, tmpPath)
+	}
+	endFetch()
+	if err != nil {
+		return nil, code, err
+	}
+
+	// checking if file is a zip, tar, tar.gz or tar.zst archive
+	if match, _ := utils.IsArchive(ctx, tmpPath); match && !req.KeepArchive {
+		// unarchive tmp file to a tmp unarchive path
+		tmpUnarchivePath := filepath.Join(fetcher.sharedVolumePath, uuid.NewString())
+		unarchiveCtx, endUnarchive := startPhase(ctx, PhaseUnarchive)
+		err := utils.Unarchive(unarchiveCtx, tmpPath, tmpUnarchivePath)
+		endUnarchive()
+		if err != nil {
+			logger.Error("error unarchive",
+				zap.Error(err),
+				zap.String("archive_location", tmpPath),
+				zap.String("target_location", tmpUnarchivePath))
+			return nil, http.StatusInternalServerError, err
+		}
+
+		tmpPath = tmpUnarchivePath
 	}
 
+	if len(resp.SourceCommit) > 0 {
+		// the commit is kept for the fetches of the checkout to come
+		err = writeSourceCommit(storePath, resp.SourceCommit)
+		if err != nil {
+			logger.Error("error writing commit of checked out git source", zap.Error(err), zap.String("location", storePath))
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	// move tmp file to requested filename
+	err = fetcher.rename(tmpPath, storePath)
+	if err != nil {
+		logger.Error("error renaming file",
+			zap.Error(err),
+			zap.String("original_path", tmpPath),
+			zap.String("rename_path", storePath))
+		return nil, http.StatusInternalServerError, fmt.Errorf("error renaming file: %w", err)
+	}
+
+	otelUtils.SpanTrackEvent(ctx, "packageFetched", otelUtils.GetAttributesForPackage(pkg)...)
+	logger.Info("successfully placed", zap.String("location", storePath))
+	return resp, http.StatusOK, nil
+}
+
+// download places the package archive of the fetch request at tmpPath, from
+// its URL, its OCI registry, its literal or the node-local package cache.
+// It returns the HTTP code and error if any
+func (fetcher *Fetcher) download(ctx context.Context, pkg *fv1.Package, req FunctionFetchRequest, tmpPath string) (int, error) {
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
 	if req.FetchType == fv1.FETCH_URL {
 		otelUtils.SpanTrackEvent(ctx, "fetch_url", otelUtils.MapToAttributes(map[string]string{
 			"package-name":      pkg.Name,
@@ -318,6 +577,13 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 				return http.StatusInternalServerError, fmt.Errorf("%s %s: %w", e, tmpPath, err)
 			}
 			otelUtils.SpanTrackEvent(ctx, "archiveLiteral", otelUtils.GetAttributesForPackage(pkg)...)
+		} else if fetcher.pkgCache.get(&archive.Checksum, tmpPath) {
+			logger.Info("using package from node-local cache", zap.String("checksum", archive.Checksum.Sum))
+			otelUtils.SpanTrackEvent(ctx, "packageCacheHit", otelUtils.GetAttributesForPackage(pkg)...)
+		} else if archive.Type == fv1.ArchiveTypeOCI {
+			return fetcher.pullArchive(ctx, pkg, req, archive, tmpPath)
+		} else if archive.Type == fv1.ArchiveTypeGit {
+			return http.StatusBadRequest, fmt.Errorf("package %s/%s has a git deployment archive, git archives are package sources only", pkg.Namespace, pkg.Name)
 		} else {
 			// download and verify
 			otelUtils.SpanTrackEvent(ctx, "dowloadArchieveLiteral", otelUtils.MapToAttributes(map[string]string{
@@ -346,45 +612,27 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 					logger.Error(e, zap.Error(err))
 					return http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
 				}
+				// only verified archives are cached
+				fetcher.pkgCache.put(&archive.Checksum, tmpPath)
 			}
 		}
 	}
-
-	// checking if file is a zip
-	if match, _ := utils.IsZip(ctx, tmpPath); match && !req.KeepArchive {
-		// unarchive tmp file to a tmp unarchive path
-		tmpUnarchivePath := filepath.Join(fetcher.sharedVolumePath, uuid.NewString())
-		err := utils.Unarchive(ctx, tmpPath, tmpUnarchivePath)
-		if err != nil {
-			logger.Error("error unarchive",
-				zap.Error(err),
-				zap.String("archive_location", tmpPath),
-				zap.String("target_location", tmpUnarchivePath))
-			return http.StatusInternalServerError, err
-		}
-
-		tmpPath = tmpUnarchivePath
-	}
-
-	// move tmp file to requested filename
-	err = fetcher.rename(tmpPath, storePath)
-	if err != nil {
-		logger.Error("error renaming file",
-			zap.Error(err),
-			zap.String("original_path", tmpPath),
-			zap.String("rename_path", storePath))
-		return http.StatusInternalServerError, fmt.Errorf("error renaming file: %w", err)
-	}
-
-	otelUtils.SpanTrackEvent(ctx, "packageFetched", otelUtils.GetAttributesForPackage(pkg)...)
-	logger.Info("successfully placed", zap.String("location", storePath))
 	return http.StatusOK, nil
 }
 
 // FetchSecretsAndCfgMaps fetches secrets and configmaps specified by user
 // It returns the HTTP code and error if any
 func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv1.SecretReference, cfgmaps []fv1.ConfigMapReference) (int, error) {
+	_, code, err := fetcher.fetchSecretsAndCfgMaps(ctx, secrets, cfgmaps)
+	return code, err
+}
+
+// fetchSecretsAndCfgMaps fetches secrets and configmaps specified by user and
+// returns the environment variables projected from them, the HTTP code and
+// error if any
+func (fetcher *Fetcher) fetchSecretsAndCfgMaps(ctx context.Context, secrets []fv1.SecretReference, cfgmaps []fv1.ConfigMapReference) (map[string]string, int, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+	envVars := make(map[string]string)
 
 	if len(secrets) > 0 {
 		for _, secret := range secrets {
@@ -403,13 +651,13 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					zap.String("secret_name", secret.Name),
 					zap.String("secret_namespace", secret.Namespace))
 
-				return httpCode, errors.New(e)
+				return nil, httpCode, errors.New(e)
 			}
 
 			secretDir, err := utils.SanitizeFilePath(filepath.Join(fetcher.sharedSecretPath, secret.Namespace, secret.Name), fetcher.sharedSecretPath)
 			if err != nil {
 				logger.Error(err.Error(), zap.String("directory", secretDir), zap.String("secret_name", secret.Name), zap.String("secret_namespace", secret.Namespace))
-				return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, secret)
+				return nil, http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, secret)
 			}
 
 			err = os.MkdirAll(secretDir, os.ModeDir|0750)
@@ -420,7 +668,7 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					zap.String("directory", secretDir),
 					zap.String("secret_name", secret.Name),
 					zap.String("secret_namespace", secret.Namespace))
-				return http.StatusInternalServerError, fmt.Errorf("%s: %s: %w", e, secretDir, err)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%s: %s: %w", e, secretDir, err)
 			}
 			err = writeSecretOrConfigMap(data.Data, secretDir)
 			if err != nil {
@@ -429,7 +677,10 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					zap.String("location", secretDir),
 					zap.String("secret_name", secret.Name),
 					zap.String("secret_namespace", secret.Namespace))
-				return http.StatusInternalServerError, err
+				return nil, http.StatusInternalServerError, err
+			}
+			if secret.Env != nil {
+				maps.Copy(envVars, secret.Env.Project(data.Data))
 			}
 			otelUtils.SpanTrackEvent(ctx, "storedSecret", otelUtils.MapToAttributes(map[string]string{
 				"secret-name":      secret.Name,
@@ -455,13 +706,13 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					zap.String("config_map_name", config.Name),
 					zap.String("config_map_namespace", config.Namespace))
 
-				return httpCode, errors.New(e)
+				return nil, httpCode, errors.New(e)
 			}
 
 			configDir, err := utils.SanitizeFilePath(filepath.Join(fetcher.sharedConfigPath, config.Namespace, config.Name), fetcher.sharedConfigPath)
 			if err != nil {
 				logger.Error(err.Error(), zap.String("directory", configDir), zap.String("config_map_name", config.Name), zap.String("config_map_namespace", config.Namespace))
-				return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err,
+				return nil, http.StatusBadRequest, fmt.Errorf("%s, request: %v", err,
 					config)
 			}
 
@@ -473,7 +724,7 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					zap.String("directory", configDir),
 					zap.String("config_map_name", config.Name),
 					zap.String("config_map_namespace", config.Namespace))
-				return http.StatusInternalServerError, fmt.Errorf("%s: %s: %w", e, configDir, err)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%s: %s: %w", e, configDir, err)
 			}
 			configMap := make(map[string][]byte)
 			for key, val := range data.Data {
@@ -486,7 +737,10 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					zap.String("location", configDir),
 					zap.String("config_map_name", config.Name),
 					zap.String("config_map_namespace", config.Namespace))
-				return http.StatusInternalServerError, err
+				return nil, http.StatusInternalServerError, err
+			}
+			if config.Env != nil {
+				maps.Copy(envVars, config.Env.Project(configMap))
 			}
 			otelUtils.SpanTrackEvent(ctx, "storedConfigmap", otelUtils.MapToAttributes(map[string]string{
 				"configmap-name":      config.Name,
@@ -495,9 +749,56 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 		}
 	}
 
+	return envVars, http.StatusOK, nil
+}
+
+// Refresh fetches the secrets and configmaps of the request again, then calls
+// the reload endpoint of the environment container so that the function
+// picks up the new data. Environments without a reload endpoint are expected
+// to read the files when they need them.
+// It returns the HTTP code and error if any
+func (fetcher *Fetcher) Refresh(ctx context.Context, req FunctionRefreshRequest) (int, error) {
+	envVars, code, err := fetcher.fetchSecretsAndCfgMaps(ctx, req.Secrets, req.ConfigMaps)
+	if err != nil {
+		return code, err
+	}
+	if len(envVars) > 0 {
+		req.EnvVars = envVars
+	}
+	err = fetcher.reload(ctx, req)
+	if err != nil {
+		return http.StatusBadGateway, err
+	}
 	return http.StatusOK, nil
 }
 
+// reload calls the optional reload endpoint of the environment container.
+func (fetcher *Fetcher) reload(ctx context.Context, req FunctionRefreshRequest) error {
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error encoding reload request: %w", err)
+	}
+
+	resp, err := ctxhttp.Post(ctx, fetcher.httpClient, fetcher.runtimeURL+"/v2/reload", "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error calling environment reload endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 300:
+		otelUtils.SpanTrackEvent(ctx, "reloadedFunction")
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		logger.Debug("environment has no reload endpoint, skipping reload")
+		return nil
+	default:
+		return fmt.Errorf("error reloading function: %w", ferror.MakeErrorFromHTTP(resp))
+	}
+}
+
 func (fetcher *Fetcher) UploadHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
@@ -663,7 +964,10 @@ func (fetcher *Fetcher) getPkgInformation(ctx context.Context, req FunctionFetch
 	return nil, err
 }
 
-func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetchRequest, loadReq FunctionLoadRequest) error {
+// SpecializePod fetches the function package, secrets and configmaps and
+// loads the function into the environment container. It returns the
+// durations of the specialization phases.
+func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetchRequest, loadReq FunctionLoadRequest) (*FunctionSpecializeResponse, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
 	startTime := time.Now()
 	defer func() {
@@ -671,22 +975,42 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 		logger.Info("specialize request done", zap.Duration("elapsed_time", elapsed))
 	}()
 
+	resp := &FunctionSpecializeResponse{}
+	ctx = context.WithValue(ctx, specializeResponseKey{}, resp)
+
 	pkg, err := fetcher.getPkgInformation(ctx, fetchReq)
 	if err != nil {
-		return fmt.Errorf("error getting package information: %w", err)
+		return nil, fmt.Errorf("error getting package information: %w", err)
 	}
 
-	_, err = fetcher.Fetch(ctx, pkg, fetchReq)
+	_, _, err = fetcher.Fetch(ctx, pkg, fetchReq)
 	if err != nil {
-		return fmt.Errorf("error fetching deploy package: %w", err)
+		return nil, fmt.Errorf("error fetching deploy package: %w", err)
 	}
 
-	_, err = fetcher.FetchSecretsAndCfgMaps(ctx, fetchReq.Secrets, fetchReq.ConfigMaps)
+	secretsCtx, endSecrets := startPhase(ctx, PhaseSecretsAndConfigMaps)
+	envVars, _, err := fetcher.fetchSecretsAndCfgMaps(secretsCtx, fetchReq.Secrets, fetchReq.ConfigMaps)
+	endSecrets()
 	if err != nil {
-		return fmt.Errorf("error fetching secrets/configs: %w", err)
+		return nil, fmt.Errorf("error fetching secrets/configs: %w", err)
 	}
+	if len(envVars) > 0 {
+		loadReq.EnvVars = envVars
+	}
+
+	loadCtx, endLoad := startPhase(ctx, PhaseLoad)
+	err = fetcher.load(loadCtx, loadReq)
+	endLoad()
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
 
-	// Specialize the pod
+// load calls the specialize endpoint of the environment container to load
+// the function.
+func (fetcher *Fetcher) load(ctx context.Context, loadReq FunctionLoadRequest) error {
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
 
 	maxRetries := 30
 	var contentType string
@@ -703,12 +1027,12 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 
 	if loadReq.EnvVersion >= 2 {
 		contentType = "application/json"
-		specializeURL = "http://127.0.0.1:8888/v2/specialize"
+		specializeURL = fetcher.runtimeURL + "/v2/specialize"
 		reader = bytes.NewReader(loadPayload)
 		logger.Info("calling environment v2 specialization endpoint")
 	} else {
 		contentType = "text/plain"
-		specializeURL = "http://127.0.0.1:8888/specialize"
+		specializeURL = fetcher.runtimeURL + "/specialize"
 		reader = bytes.NewReader([]byte{})
 		logger.Info("calling environment v1 specialization endpoint")
 	}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fissionfake "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+)
+
+func TestStartPhase(t *testing.T) {
+	resp := &FunctionSpecializeResponse{}
+	ctx := context.WithValue(t.Context(), specializeResponseKey{}, resp)
+
+	_, end := startPhase(ctx, PhaseFetch)
+	end()
+	_, end = startPhase(ctx, PhaseUnarchive)
+	end()
+	require.Len(t, resp.Phases, 2)
+	require.Equal(t, PhaseFetch, resp.Phases[0].Name)
+	require.Equal(t, PhaseUnarchive, resp.Phases[1].Name)
+
+	// phases outside of a pod specialization are only traced
+	_, end = startPhase(t.Context(), PhaseLoad)
+	end()
+	require.Len(t, resp.Phases, 2)
+}
+
+func TestSpecializeOnStartup(t *testing.T) {
+	ctx := t.Context()
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: fv1.PackageSpec{
+			Deployment: fv1.Archive{Type: fv1.ArchiveTypeLiteral, Literal: []byte("hello")},
+		},
+		Status: fv1.PackageStatus{BuildStatus: fv1.BuildStatusSucceeded},
+	}
+
+	var loads []FunctionLoadRequest
+	runtime := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/specialize", r.URL.Path)
+		var req FunctionLoadRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		loads = append(loads, req)
+	}))
+	defer runtime.Close()
+
+	fetcher := &Fetcher{
+		logger:           zap.NewNop(),
+		sharedVolumePath: t.TempDir(),
+		sharedSecretPath: t.TempDir(),
+		sharedConfigPath: t.TempDir(),
+		fissionClient:    fissionfake.NewSimpleClientset(pkg),
+		kubeClient:       fake.NewSimpleClientset(),
+		httpClient:       runtime.Client(),
+		runtimeURL:       runtime.URL,
+	}
+
+	// no phases before the specialization
+	w := httptest.NewRecorder()
+	fetcher.SpecializePhasesHandler(w, httptest.NewRequest(http.MethodGet, "/specialize/phases", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	err := fetcher.SpecializeOnStartup(ctx, FunctionSpecializeRequest{
+		FetchReq: FunctionFetchRequest{
+			FetchType: fv1.FETCH_DEPLOYMENT,
+			Package:   pkg.ObjectMeta,
+			Filename:  "user",
+		},
+		LoadReq: FunctionLoadRequest{
+			FilePath:   filepath.Join(fetcher.sharedVolumePath, "user"),
+			EnvVersion: 2,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, loads, 1)
+	content, err := os.ReadFile(filepath.Join(fetcher.sharedVolumePath, "user"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(content))
+
+	w = httptest.NewRecorder()
+	fetcher.SpecializePhasesHandler(w, httptest.NewRequest(http.MethodGet, "/specialize/phases", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp FunctionSpecializeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	var phases []string
+	for _, phase := range resp.Phases {
+		phases = append(phases, phase.Name)
+	}
+	require.Equal(t, []string{PhaseFetch, PhaseSecretsAndConfigMaps, PhaseLoad}, phases)
+
+	// the phases are reported once
+	w = httptest.NewRecorder()
+	fetcher.SpecializePhasesHandler(w, httptest.NewRequest(http.MethodGet, "/specialize/phases", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := t.Context()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"},
+		Data:       map[string][]byte{"user": []byte("alice"), "password": []byte("old")},
+	}
+	kubeClient := fake.NewSimpleClientset(secret)
+
+	var reloads []FunctionRefreshRequest
+	reloadStatus := http.StatusOK
+	runtime := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req FunctionRefreshRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		reloads = append(reloads, req)
+		w.WriteHeader(reloadStatus)
+	}))
+	defer runtime.Close()
+
+	fetcher := &Fetcher{
+		logger:           zap.NewNop(),
+		sharedSecretPath: t.TempDir(),
+		sharedConfigPath: t.TempDir(),
+		kubeClient:       kubeClient,
+		httpClient:       runtime.Client(),
+		runtimeURL:       runtime.URL,
+	}
+	req := FunctionRefreshRequest{
+		Secrets:          []fv1.SecretReference{{Name: "creds", Namespace: "default"}},
+		FunctionMetadata: &metav1.ObjectMeta{Name: "fn", Namespace: "default"},
+	}
+	secretDir := filepath.Join(fetcher.sharedSecretPath, "default", "creds")
+
+	code, err := fetcher.Refresh(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	password, err := os.ReadFile(filepath.Join(secretDir, "password"))
+	require.NoError(t, err)
+	require.Equal(t, "old", string(password))
+
+	// updated keys are replaced and removed keys deleted
+	secret.Data = map[string][]byte{"password": []byte("new")}
+	_, err = kubeClient.CoreV1().Secrets("default").Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = fetcher.Refresh(ctx, req)
+	require.NoError(t, err)
+	password, err = os.ReadFile(filepath.Join(secretDir, "password"))
+	require.NoError(t, err)
+	require.Equal(t, "new", string(password))
+	entries, err := os.ReadDir(secretDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Len(t, reloads, 2)
+	require.Equal(t, "fn", reloads[1].FunctionMetadata.Name)
+
+	// projected environment variables are passed to the reload endpoint
+	req.Secrets[0].Env = &fv1.EnvProjection{Prefix: "DB_"}
+	_, err = fetcher.Refresh(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"DB_password": "new"}, reloads[2].EnvVars)
+
+	// environments without a reload endpoint are not an error
+	reloadStatus = http.StatusNotFound
+	_, err = fetcher.Refresh(ctx, req)
+	require.NoError(t, err)
+
+	reloadStatus = http.StatusInternalServerError
+	code, err = fetcher.Refresh(ctx, req)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadGateway, code)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"go.uber.org/zap"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/gitrepo"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// cloneSource checks out the git source archive of the package as a
+// directory at tmpPath, with the credentials of the auth secret of the
+// archive, and returns the checked out commit.
+// It returns the HTTP code and error if any
+func (fetcher *Fetcher) cloneSource(ctx context.Context, pkg *fv1.Package, tmpPath string) (string, int, error) {
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	archive := &pkg.Spec.Source
+	var git fv1.GitSource
+	if archive.Git != nil {
+		git = *archive.Git
+	}
+	otelUtils.SpanTrackEvent(ctx, "cloneSource", otelUtils.MapToAttributes(map[string]string{
+		"package-name":      pkg.Name,
+		"package-namespace": pkg.Namespace,
+		"archive-url":       archive.URL,
+		"git-ref":           git.Ref,
+	})...)
+
+	var auth transport.AuthMethod
+	if len(git.AuthSecret) > 0 {
+		secret, err := fetcher.kubeClient.CoreV1().Secrets(pkg.Namespace).Get(ctx, git.AuthSecret, metav1.GetOptions{})
+		if err != nil {
+			code := http.StatusInternalServerError
+			if k8serr.IsNotFound(err) {
+				code = http.StatusNotFound
+			}
+			return "", code, fmt.Errorf("error getting git auth secret %s/%s of package: %w", pkg.Namespace, git.AuthSecret, err)
+		}
+		auth, err = gitrepo.AuthFromSecret(archive.URL, secret)
+		if err != nil {
+			return "", http.StatusBadRequest, err
+		}
+	}
+
+	cloneDir, err := utils.SanitizeFilePath(tmpPath+".clone", fetcher.sharedVolumePath)
+	if err != nil {
+		return "", http.StatusBadRequest, err
+	}
+	defer os.RemoveAll(cloneDir)
+
+	commit, err := gitrepo.Clone(ctx, archive.URL, git.Ref, auth, cloneDir)
+	if err != nil {
+		e := "failed to clone git repository"
+		logger.Error(e, zap.Error(err), zap.String("url", archive.URL), zap.String("ref", git.Ref))
+		return "", http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+	}
+	// the repository metadata isn't part of the source
+	err = os.RemoveAll(filepath.Join(cloneDir, ".git"))
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+
+	srcDir, code, err := resolveSubPath(cloneDir, git.SubPath)
+	if err != nil {
+		return "", code, err
+	}
+	err = fetcher.rename(srcDir, tmpPath)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+
+	logger.Info("checked out git source", zap.String("url", archive.URL), zap.String("ref", git.Ref), zap.String("commit", commit))
+	return commit, http.StatusOK, nil
+}
+
+// resolveSubPath returns the directory at subPath in the checked out
+// repository, which symbolic links of the repository can't take out of it.
+// It returns the HTTP code and error if any
+func resolveSubPath(cloneDir string, subPath string) (string, int, error) {
+	if len(subPath) == 0 {
+		return cloneDir, http.StatusOK, nil
+	}
+	root, err := filepath.EvalSymlinks(cloneDir)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Join(root, subPath))
+	if err != nil {
+		return "", http.StatusBadRequest, fmt.Errorf("sub path %q of git repository: %w", subPath, err)
+	}
+	if dir != root {
+		dir, err = utils.SanitizeFilePath(dir, root+string(filepath.Separator))
+		if err != nil {
+			return "", http.StatusBadRequest, fmt.Errorf("sub path %q is outside of git repository", subPath)
+		}
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", http.StatusBadRequest, fmt.Errorf("sub path %q of git repository: %w", subPath, err)
+	}
+	if !info.IsDir() {
+		return "", http.StatusBadRequest, fmt.Errorf("sub path %q of git repository is not a directory", subPath)
+	}
+	return dir, http.StatusOK, nil
+}
+
+// sourceCommitPath returns the path of the file keeping the commit of the
+// git source checked out at storePath. utils.DeleteOldPackages removes it
+// along with the source package.
+func sourceCommitPath(storePath string) string {
+	return storePath + ".commit"
+}
+
+// writeSourceCommit keeps the commit of the git source checked out at
+// storePath.
+func writeSourceCommit(storePath string, commit string) error {
+	return os.WriteFile(sourceCommitPath(storePath), []byte(commit), 0644)
+}
+
+// readSourceCommit returns the commit of the git source checked out at
+// storePath.
+func readSourceCommit(storePath string) (string, error) {
+	commit, err := os.ReadFile(sourceCommitPath(storePath))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(commit)), nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestFetchGitSource(t *testing.T) {
+	// a local repository stands in for the git server
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(repoDir, "hello"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "hello", "main.py"), []byte("print('hello')"), 0644))
+	require.NoError(t, os.Symlink(os.TempDir(), filepath.Join(repoDir, "escape")))
+	require.NoError(t, os.Symlink(".", filepath.Join(repoDir, "self")))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, worktree.AddGlob("."))
+	commit, err := worktree.Commit("hello", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@fission.io", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	sharedVolumePath := t.TempDir()
+	fetcher := &Fetcher{
+		logger:           zap.NewNop(),
+		kubeClient:       fake.NewSimpleClientset(),
+		sharedVolumePath: sharedVolumePath,
+	}
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: fv1.PackageSpec{
+			Source: fv1.Archive{Type: fv1.ArchiveTypeGit, URL: repoDir, Git: &fv1.GitSource{SubPath: "hello"}},
+		},
+	}
+
+	resp, code, err := fetcher.Fetch(t.Context(), pkg, FunctionFetchRequest{FetchType: fv1.FETCH_SOURCE, Filename: "src"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, commit.String(), resp.SourceCommit)
+	content, err := os.ReadFile(filepath.Join(sharedVolumePath, "src", "main.py"))
+	require.NoError(t, err)
+	require.Equal(t, "print('hello')", string(content))
+
+	// fetching the checked out source again gives the same commit
+	resp, code, err = fetcher.Fetch(t.Context(), pkg, FunctionFetchRequest{FetchType: fv1.FETCH_SOURCE, Filename: "src"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, commit.String(), resp.SourceCommit)
+
+	// without a sub path, the whole repository but its metadata
+	pkg.Spec.Source.Git.SubPath = ""
+	_, _, err = fetcher.Fetch(t.Context(), pkg, FunctionFetchRequest{FetchType: fv1.FETCH_SOURCE, Filename: "repo"})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(sharedVolumePath, "repo", "hello", "main.py"))
+	require.NoDirExists(t, filepath.Join(sharedVolumePath, "repo", ".git"))
+
+	// sub paths resolving to the repository root are the whole repository
+	for i, subPath := range []string{".", "hello/..", "self"} {
+		pkg.Spec.Source.Git.SubPath = subPath
+		filename := fmt.Sprintf("root-%d", i)
+		_, code, err = fetcher.Fetch(t.Context(), pkg, FunctionFetchRequest{FetchType: fv1.FETCH_SOURCE, Filename: filename})
+		require.NoError(t, err, subPath)
+		require.Equal(t, http.StatusOK, code)
+		require.FileExists(t, filepath.Join(sharedVolumePath, filename, "hello", "main.py"))
+	}
+
+	// symbolic links can't take the sub path out of the repository
+	pkg.Spec.Source.Git.SubPath = "escape"
+	_, code, err = fetcher.Fetch(t.Context(), pkg, FunctionFetchRequest{FetchType: fv1.FETCH_SOURCE, Filename: "escape"})
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	pkg.Spec.Source.Git = &fv1.GitSource{AuthSecret: "missing"}
+	_, code, err = fetcher.Fetch(t.Context(), pkg, FunctionFetchRequest{FetchType: fv1.FETCH_SOURCE, Filename: "auth"})
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, code)
+}
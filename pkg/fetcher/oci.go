@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/oci"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// SetPlainHTTPRegistries makes the fetcher pull OCI archives from the
+// registries over plain HTTP instead of HTTPS, like in-cluster registries
+// without TLS.
+func (fetcher *Fetcher) SetPlainHTTPRegistries(registries []string) {
+	fetcher.plainHTTPRegistries = registries
+}
+
+// ImagePullSecrets returns the names of the image pull secret of an
+// environment and of the image pull secrets of the pod specs, if any.
+func ImagePullSecrets(imagePullSecret string, podSpecs ...*apiv1.PodSpec) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if len(name) > 0 && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	add(imagePullSecret)
+	for _, podSpec := range podSpecs {
+		if podSpec == nil {
+			continue
+		}
+		for _, secret := range podSpec.ImagePullSecrets {
+			add(secret.Name)
+		}
+	}
+	return names
+}
+
+// pullArchive pulls the OCI archive of the package to tmpPath, with the
+// credentials of the pull secret of the archive and of the image pull
+// secrets of the pod.
+// It returns the HTTP code and error if any
+func (fetcher *Fetcher) pullArchive(ctx context.Context, pkg *fv1.Package, req FunctionFetchRequest, archive *fv1.Archive, tmpPath string) (int, error) {
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	ref, err := oci.ParsePinnedReference(archive.URL)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	otelUtils.SpanTrackEvent(ctx, "pullArchive", otelUtils.MapToAttributes(map[string]string{
+		"package-name":      pkg.Name,
+		"package-namespace": pkg.Namespace,
+		"archive-url":       archive.URL,
+	})...)
+
+	keychain, code, err := fetcher.getKeychain(ctx, pkg, archive, req.ImagePullSecrets)
+	if err != nil {
+		return code, err
+	}
+	layer, err := oci.MakeClient(fetcher.httpClient, keychain, fetcher.plainHTTPRegistries).Pull(ctx, ref, tmpPath)
+	if err != nil {
+		e := "failed to pull archive"
+		logger.Error(e, zap.Error(err), zap.String("url", archive.URL))
+		return http.StatusBadRequest, fmt.Errorf("%s %s: %w", e, archive.URL, err)
+	}
+
+	// the digest of the archive, verified by the pull, is its checksum
+	checksum := fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: strings.TrimPrefix(layer.Digest, "sha256:")}
+	if len(archive.Checksum.Sum) > 0 {
+		err = verifyChecksum(&checksum, &archive.Checksum)
+		if err != nil {
+			e := "failed to verify checksum"
+			logger.Error(e, zap.Error(err))
+			return http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+		}
+	}
+	fetcher.pkgCache.put(&checksum, tmpPath)
+	return http.StatusOK, nil
+}
+
+// getKeychain returns the registry credentials of the pull secret of the
+// archive, in the namespace of the package, and of the image pull secrets,
+// in the namespace of the fetcher pod.
+// It returns the HTTP code and error if any
+func (fetcher *Fetcher) getKeychain(ctx context.Context, pkg *fv1.Package, archive *fv1.Archive, imagePullSecrets []string) (oci.Keychain, int, error) {
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	var credentials []map[string]*oci.Credentials
+	if len(archive.PullSecret) > 0 {
+		secret, err := fetcher.kubeClient.CoreV1().Secrets(pkg.Namespace).Get(ctx, archive.PullSecret, metav1.GetOptions{})
+		if err != nil {
+			code := http.StatusInternalServerError
+			if k8serr.IsNotFound(err) {
+				code = http.StatusNotFound
+			}
+			return nil, code, fmt.Errorf("error getting pull secret %s/%s of package: %w", pkg.Namespace, archive.PullSecret, err)
+		}
+		creds, err := oci.ParsePullSecret(secret)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		credentials = append(credentials, creds)
+	}
+
+	// the image pull secrets are for the images of the pod, so those the
+	// fetcher can't use are skipped
+	for _, name := range imagePullSecrets {
+		secret, err := fetcher.kubeClient.CoreV1().Secrets(fetcher.Info.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			logger.Warn("error getting image pull secret", zap.Error(err),
+				zap.String("secret_name", name), zap.String("secret_namespace", fetcher.Info.Namespace))
+			continue
+		}
+		creds, err := oci.ParsePullSecret(secret)
+		if err != nil {
+			logger.Warn("error parsing image pull secret", zap.Error(err),
+				zap.String("secret_name", name), zap.String("secret_namespace", fetcher.Info.Namespace))
+			continue
+		}
+		credentials = append(credentials, creds)
+	}
+	return oci.MakeKeychain(credentials...), http.StatusOK, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func pullSecret(name, namespace, registry, username string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + registry + `":{"username":"` + username + `","password":"secret"}}}`),
+		},
+	}
+}
+
+func TestGetKeychain(t *testing.T) {
+	fetcher := &Fetcher{
+		logger: zap.NewNop(),
+		kubeClient: fake.NewSimpleClientset(
+			pullSecret("pkg-creds", "default", "registry.example.com", "pkg"),
+			pullSecret("pod-creds", "fission-function", "registry.example.com", "pod"),
+			pullSecret("other-creds", "fission-function", "ghcr.io", "other"),
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "fission-function"}},
+		),
+		Info: PodInfo{Name: "pod", Namespace: "fission-function"},
+	}
+	pkg := &fv1.Package{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"}}
+	archive := &fv1.Archive{Type: fv1.ArchiveTypeOCI, PullSecret: "pkg-creds"}
+
+	// the pull secret of the archive comes first, the image pull secrets
+	// the fetcher can't use are skipped
+	keychain, code, err := fetcher.getKeychain(t.Context(), pkg, archive, []string{"missing", "opaque", "pod-creds", "other-creds"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "pkg", keychain("registry.example.com").Username)
+	require.Equal(t, "other", keychain("ghcr.io").Username)
+	require.Nil(t, keychain("docker.io"))
+
+	archive.PullSecret = "missing"
+	_, code, err = fetcher.getKeychain(t.Context(), pkg, archive, nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestImagePullSecrets(t *testing.T) {
+	podSpec := &corev1.PodSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "env-creds"}, {Name: "fn-creds"}}}
+	require.Equal(t, []string{"env-creds", "fn-creds"}, ImagePullSecrets("env-creds", nil, podSpec))
+	require.Empty(t, ImagePullSecrets("", nil))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+)
+
+// packageCache is a content addressed store of verified package archives,
+// keyed by their sha256 checksum. The cache directory is a host path shared
+// by all fetchers on a node, so entries are written to a temporary file and
+// renamed into place, and read entries are verified again before use.
+type packageCache struct {
+	logger  *zap.Logger
+	dir     string
+	maxSize int64 // bytes, 0 means unlimited
+}
+
+func makePackageCache(logger *zap.Logger, dir string, maxSize int64) (*packageCache, error) {
+	err := makeVolumeDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error creating package cache directory %s: %w", dir, err)
+	}
+	return &packageCache{
+		logger:  logger.Named("package_cache"),
+		dir:     dir,
+		maxSize: maxSize,
+	}, nil
+}
+
+// path returns the cache entry of the checksum, or false if the checksum
+// can't be used as a cache key.
+func (pc *packageCache) path(checksum *fv1.Checksum) (string, bool) {
+	if checksum.Type != fv1.ChecksumTypeSHA256 {
+		return "", false
+	}
+	// the checksum comes from the package spec, make sure it can't
+	// point outside of the cache directory.
+	sum, err := hex.DecodeString(checksum.Sum)
+	if err != nil || len(sum) != 32 {
+		return "", false
+	}
+	return filepath.Join(pc.dir, checksum.Sum), true
+}
+
+// get copies the cached archive with the checksum to dst. It returns false if
+// the archive isn't cached.
+func (pc *packageCache) get(checksum *fv1.Checksum, dst string) bool {
+	if pc == nil {
+		return false
+	}
+	path, ok := pc.path(checksum)
+	if !ok {
+		return false
+	}
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+
+	err := copyFile(path, dst)
+	if err == nil {
+		var fileChecksum *fv1.Checksum
+		fileChecksum, err = utils.GetFileChecksum(dst)
+		if err == nil {
+			err = verifyChecksum(fileChecksum, checksum)
+		}
+	}
+	if err != nil {
+		pc.logger.Warn("dropping unusable package cache entry", zap.Error(err), zap.String("path", path))
+		os.Remove(path) // nolint errcheck
+		os.Remove(dst)  // nolint errcheck
+		return false
+	}
+
+	// entries are evicted least recently used first
+	now := time.Now()
+	os.Chtimes(path, now, now) // nolint errcheck
+	return true
+}
+
+// put adds the verified archive at src to the cache. Failures are only
+// logged, the cache is an optimization.
+func (pc *packageCache) put(checksum *fv1.Checksum, src string) {
+	if pc == nil {
+		return
+	}
+	path, ok := pc.path(checksum)
+	if !ok {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+
+	tmpPath := filepath.Join(pc.dir, "."+uuid.NewString()+".tmp")
+	err := copyFile(src, tmpPath)
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		pc.logger.Warn("error adding package to cache", zap.Error(err), zap.String("path", path))
+		os.Remove(tmpPath) // nolint errcheck
+		return
+	}
+	pc.evict()
+}
+
+// evict removes the least recently used entries until the cache fits into
+// its maximum size.
+func (pc *packageCache) evict() {
+	if pc.maxSize <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(pc.dir)
+	if err != nil {
+		pc.logger.Warn("error listing package cache", zap.Error(err))
+		return
+	}
+
+	var files []os.FileInfo
+	var size int64
+	for _, entry := range entries {
+		// skip entries being written by other fetchers
+		if strings.HasPrefix(entry.Name(), ".") || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		size += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, info := range files {
+		if size <= pc.maxSize {
+			return
+		}
+		err := os.Remove(filepath.Join(pc.dir, info.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			pc.logger.Warn("error evicting package from cache", zap.Error(err), zap.String("name", info.Name()))
+			continue
+		}
+		size -= info.Size()
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func writeArchive(t *testing.T, dir string, name string, data string) (string, *fv1.Checksum) {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+	checksum, err := utils.GetFileChecksum(path)
+	require.NoError(t, err)
+	return path, checksum
+}
+
+func TestPackageCache(t *testing.T) {
+	srcDir := t.TempDir()
+	pc, err := makePackageCache(loggerfactory.GetLogger(), filepath.Join(t.TempDir(), "cache"), 10)
+	require.NoError(t, err)
+
+	src, checksum := writeArchive(t, srcDir, "a", "aaaaaa")
+	dst := filepath.Join(srcDir, "dst")
+	require.False(t, pc.get(checksum, dst))
+
+	pc.put(checksum, src)
+	require.True(t, pc.get(checksum, dst))
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "aaaaaa", string(data))
+
+	// checksums which aren't sha256 sums are never cached
+	for _, sum := range []string{"", "../../etc/passwd", "abcd"} {
+		invalid := &fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: sum}
+		pc.put(invalid, src)
+		require.False(t, pc.get(invalid, dst))
+	}
+
+	// corrupted entries are dropped
+	path, _ := pc.path(checksum)
+	require.NoError(t, os.WriteFile(path, []byte("corrupted"), 0600))
+	require.False(t, pc.get(checksum, dst))
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	// least recently used entries are evicted first
+	pc.put(checksum, src)
+	old := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(path, old, old))
+	srcB, checksumB := writeArchive(t, srcDir, "b", "bbbbbb")
+	pc.put(checksumB, srcB)
+	require.False(t, pc.get(checksum, dst))
+	require.True(t, pc.get(checksumB, dst))
+
+	// a nil cache is disabled
+	var nilCache *packageCache
+	nilCache.put(checksumB, srcB)
+	require.False(t, nilCache.get(checksumB, dst))
+}
@@ -17,11 +17,21 @@ limitations under the License.
 package fetcher
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 )
 
+// Phases of a pod specialization, reported to the executor.
+const (
+	PhaseFetch                = "fetch"
+	PhaseUnarchive            = "unarchive"
+	PhaseSecretsAndConfigMaps = "secrets_configmaps"
+	PhaseLoad                 = "load"
+)
+
 // Fission-Environment interface. The following types are not
 // exposed in the Fission API, but rather used by Fission to
 // talk to environments.
@@ -33,6 +43,17 @@ type (
 		LoadReq  FunctionLoadRequest
 	}
 
+	// FunctionSpecializeResponse reports the phases of a pod specialization.
+	FunctionSpecializeResponse struct {
+		Phases []SpecializePhase `json:"phases"`
+	}
+
+	// SpecializePhase is the duration of a phase of a pod specialization.
+	SpecializePhase struct {
+		Name     string        `json:"name"`
+		Duration time.Duration `json:"duration"`
+	}
+
 	FunctionFetchRequest struct {
 		FetchType     FetchRequestType         `json:"fetchType"`
 		Package       metav1.ObjectMeta        `json:"package"`
@@ -42,6 +63,17 @@ type (
 		Secrets       []fv1.SecretReference    `json:"secretList"`
 		ConfigMaps    []fv1.ConfigMapReference `json:"configMapList"`
 		KeepArchive   bool                     `json:"keeparchive"`
+
+		// ImagePullSecrets are the names of the image pull secrets of the
+		// pod, in its namespace, used to pull OCI archives in addition to
+		// the pull secret of the archive. Optional.
+		ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+	}
+
+	// FunctionFetchResponse describes the fetched package archive.
+	FunctionFetchResponse struct {
+		// SourceCommit is the commit checked out for git source archives.
+		SourceCommit string `json:"sourceCommit,omitempty"`
 	}
 
 	FunctionLoadRequest struct {
@@ -64,6 +96,23 @@ type (
 		FunctionMetadata *metav1.ObjectMeta
 
 		EnvVersion int `json:"envVersion"`
+
+		// EnvVars are the environment variables projected from the secrets
+		// and configmaps of the function, for environments which support
+		// setting them at specialization. Optional.
+		EnvVars map[string]string `json:"envVars,omitempty"`
+	}
+
+	// FunctionRefreshRequest asks the fetcher of a specialized pod to update
+	// the secrets and configmaps of the function in place.
+	FunctionRefreshRequest struct {
+		Secrets          []fv1.SecretReference    `json:"secretList"`
+		ConfigMaps       []fv1.ConfigMapReference `json:"configMapList"`
+		FunctionMetadata *metav1.ObjectMeta       `json:"functionMetadata"`
+
+		// EnvVars are the updated environment variables projected from the
+		// secrets and configmaps, set by the fetcher for the reload endpoint.
+		EnvVars map[string]string `json:"envVars,omitempty"`
 	}
 
 	// ArchiveUploadRequest send from builder manager describes which
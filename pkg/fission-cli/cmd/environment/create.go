@@ -191,8 +191,10 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 				},
 			},
 			Builder: fv1.Builder{
-				Image:   envBuilderImg,
-				Command: envBuildCmd,
+				Image:             envBuilderImg,
+				Command:           envBuildCmd,
+				BuildTimeout:      input.Int(flagkey.EnvBuildTimeout),
+				MaxParallelBuilds: input.Int(flagkey.EnvMaxParallel),
 				Container: &apiv1.Container{
 					Name: fv1.BuilderContainerName,
 					Env:  builderEnvList,
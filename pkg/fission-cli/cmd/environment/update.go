@@ -137,6 +137,14 @@ func updateExistingEnvironmentWithCmd(env *fv1.Environment, input cli.Input) (*f
 		env.Spec.TerminationGracePeriod = input.Int64(flagkey.EnvGracePeriod)
 	}
 
+	if input.IsSet(flagkey.EnvBuildTimeout) {
+		env.Spec.Builder.BuildTimeout = input.Int(flagkey.EnvBuildTimeout)
+	}
+
+	if input.IsSet(flagkey.EnvMaxParallel) {
+		env.Spec.Builder.MaxParallelBuilds = input.Int(flagkey.EnvMaxParallel)
+	}
+
 	if input.IsSet(flagkey.EnvKeeparchive) {
 		env.Spec.KeepArchive = input.Bool(flagkey.EnvKeeparchive)
 	}
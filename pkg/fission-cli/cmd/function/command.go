@@ -37,10 +37,13 @@ func Commands() *cobra.Command {
 			flag.FnSpecializationTimeout, flag.FnExecutionTimeout,
 			flag.FnIdleTimeout, flag.FnConcurrency, flag.FnRequestsPerPod,
 			flag.FnOnceOnly, flag.Labels, flag.Annotation, flag.FnRetainPods,
+			flag.FnMaxPodLifetime, flag.FnMaxPodRequests, flag.FnPoolClass,
+			flag.FnConfigUpdatePolicy,
 
 			// TODO retired pkg & trigger related flags from function cmd
 			flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure,
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgArchiveFormat, flag.PkgPullSecret,
+			flag.PkgSrcGitRef, flag.PkgSrcGitSubPath, flag.PkgSrcGitSecret,
 			flag.FnBuildCmd,
 
 			flag.HtUrl, flag.HtPrefix, flag.HtMethod,
@@ -49,6 +52,8 @@ func Commands() *cobra.Command {
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin,
 			flag.ReplicasMax, flag.RunTimeTargetCPU,
+			flag.ReplicasScalingMode, flag.ReplicasTargetConcurrency,
+			flag.UpdateStrategyType, flag.UpdateMaxSurge, flag.UpdateMaxUnavailable, flag.UpdateRollbackTimeout,
 			flag.NamespaceFunction, flag.SpecSave, flag.SpecDry},
 	})
 
@@ -88,14 +93,18 @@ func Commands() *cobra.Command {
 			flag.FnSpecializationTimeout, flag.FnExecutionTimeout,
 			flag.FnIdleTimeout, flag.FnConcurrency, flag.FnRequestsPerPod,
 			flag.FnOnceOnly, flag.Labels, flag.Annotation, flag.FnRetainPods,
+			flag.FnMaxPodLifetime, flag.FnMaxPodRequests, flag.FnPoolClass,
+			flag.FnConfigUpdatePolicy,
 
 			flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure,
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgArchiveFormat, flag.PkgPullSecret,
+			flag.PkgSrcGitRef, flag.PkgSrcGitSubPath, flag.PkgSrcGitSecret,
 			flag.FnBuildCmd, flag.PkgForce,
 
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin, flag.ReplicasMax,
-			flag.RunTimeTargetCPU,
+			flag.RunTimeTargetCPU, flag.ReplicasScalingMode, flag.ReplicasTargetConcurrency,
+			flag.UpdateStrategyType, flag.UpdateMaxSurge, flag.UpdateMaxUnavailable, flag.UpdateRollbackTimeout,
 
 			flag.NamespaceFunction, flag.NamespaceEnvironment, flag.SpecSave,
 		},
@@ -174,6 +183,7 @@ func Commands() *cobra.Command {
 			flag.RunTimeMaxMemory, flag.ReplicasMin,
 			flag.ReplicasMax, flag.RunTimeTargetCPU,
 			flag.RunImagePullSecret,
+			flag.UpdateStrategyType, flag.UpdateMaxSurge, flag.UpdateMaxUnavailable, flag.UpdateRollbackTimeout,
 
 			flag.NamespaceFunction, flag.SpecSave, flag.SpecDry,
 		},
@@ -197,6 +207,7 @@ func Commands() *cobra.Command {
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin, flag.ReplicasMax,
 			flag.RunTimeTargetCPU,
+			flag.UpdateStrategyType, flag.UpdateMaxSurge, flag.UpdateMaxUnavailable, flag.UpdateRollbackTimeout,
 
 			flag.NamespaceFunction, flag.SpecSave,
 		},
@@ -214,13 +225,28 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceFunction},
 	})
 
+	servicesCmd := &cobra.Command{
+		Use:     "services",
+		Aliases: []string{"service", "svc"},
+		Short:   "List, evict and drain function services cached by the executor",
+		Long: "List function services cached by the executor, with their address, active requests and CPU usage. " +
+			"Use --evict to delete a specialized pod, or --drain to stop assigning requests to the function's pods " +
+			"and delete them once in-flight requests finish. " +
+			"With multiple executor replicas, only the state of the replica reached is shown.",
+		RunE: wrapper.Wrapper(Services),
+	}
+	wrapper.SetFlags(servicesCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.FnName, flag.FnEnvName, flag.NamespaceFunction, flag.AllNamespaces,
+			flag.FnServicesEvict, flag.FnServicesDrain, flag.FnServicesDrainTimeout, flag.FnServicesOutput},
+	})
+
 	command := &cobra.Command{
 		Use:     "function",
 		Aliases: []string{"fn"},
 		Short:   "Create, update and manage functions",
 	}
 	command.AddCommand(createCmd, getCmd, getmetaCmd, updateCmd, deleteCmd, listCmd, logsCmd, testCmd,
-		runContainerCmd, updateContainerCmd, listPodsCmd)
+		runContainerCmd, updateContainerCmd, listPodsCmd, servicesCmd)
 
 	return command
 }
@@ -24,6 +24,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	ferror "github.com/fission/fission/pkg/error"
@@ -110,6 +111,10 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 
 	requestsPerPod := input.Int(flagkey.FnRequestsPerPod)
 	retainPods := input.Int(flagkey.FnRetainPods)
+	maxPodLifetime := input.Int(flagkey.FnMaxPodLifetime)
+	maxPodRequests := input.Int(flagkey.FnMaxPodRequests)
+	poolClass := input.String(flagkey.FnPoolClass)
+	configUpdatePolicy := fv1.ConfigUpdatePolicy(input.String(flagkey.FnConfigUpdatePolicy))
 
 	fnOnceOnly := input.Bool(flagkey.FnOnceOnly)
 
@@ -300,16 +305,20 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 			Namespace: fnNamespace,
 		},
 		Spec: fv1.FunctionSpec{
-			Secrets:         secrets,
-			ConfigMaps:      cfgmaps,
-			Resources:       *resourceReq,
-			InvokeStrategy:  *invokeStrategy,
-			FunctionTimeout: fnTimeout,
-			IdleTimeout:     &fnIdleTimeout,
-			Concurrency:     fnConcurrency,
-			RequestsPerPod:  requestsPerPod,
-			RetainPods:      retainPods,
-			OnceOnly:        fnOnceOnly,
+			Secrets:            secrets,
+			ConfigMaps:         cfgmaps,
+			ConfigUpdatePolicy: configUpdatePolicy,
+			Resources:          *resourceReq,
+			InvokeStrategy:     *invokeStrategy,
+			FunctionTimeout:    fnTimeout,
+			IdleTimeout:        &fnIdleTimeout,
+			Concurrency:        fnConcurrency,
+			RequestsPerPod:     requestsPerPod,
+			RetainPods:         retainPods,
+			MaxPodLifetime:     maxPodLifetime,
+			MaxPodRequests:     maxPodRequests,
+			PoolClass:          poolClass,
+			OnceOnly:           fnOnceOnly,
 		},
 	}
 
@@ -452,8 +461,9 @@ func getInvokeStrategy(input cli.Input, existingInvokeStrategy *fv1.InvokeStrate
 	}, nil
 }
 
-// Show warning when --con, --rpp and --yolo flags are used with executortype other than `poolmgr`.
-// These flags are specifically introduced for executortype `poolmgr`.
+// Show warning when --con, --rpp and --yolo flags are used with executortype other than `poolmgr`,
+// and fail when --maxpodlifetime and --maxpodrequests are. These flags are specifically
+// introduced for executortype `poolmgr`.
 func checkExecutorPoolManager(input cli.Input, existingExecutorType fv1.ExecutorType) error {
 	var isNotPoolManager bool
 	if input.IsSet(flagkey.EnvExecutorType) {
@@ -475,6 +485,16 @@ func checkExecutorPoolManager(input cli.Input, existingExecutorType fv1.Executor
 	if input.IsSet(flagkey.FnOnceOnly) && isNotPoolManager {
 		console.Warn("--onceonly is only valid for executortype; `poolmgr`. Check `fission function create --help`")
 	}
+	// recycling and pool classes are rejected by the function validation for other executor types
+	if input.IsSet(flagkey.FnMaxPodLifetime) && isNotPoolManager {
+		return fmt.Errorf("--%v is only valid for executortype; `poolmgr`", flagkey.FnMaxPodLifetime)
+	}
+	if input.IsSet(flagkey.FnMaxPodRequests) && isNotPoolManager {
+		return fmt.Errorf("--%v is only valid for executortype; `poolmgr`", flagkey.FnMaxPodRequests)
+	}
+	if input.IsSet(flagkey.FnPoolClass) && isNotPoolManager {
+		return fmt.Errorf("--%v is only valid for executortype; `poolmgr`", flagkey.FnPoolClass)
+	}
 
 	return nil
 }
@@ -555,6 +575,16 @@ func getExecutionStrategy(fnExecutor fv1.ExecutorType, input cli.Input) (strateg
 		}
 	}
 
+	err = setScalingMode(input, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setUpdateStrategy(input, strategy)
+	if err != nil {
+		return nil, err
+	}
+
 	return strategy, nil
 }
 
@@ -647,11 +677,108 @@ func updateExecutionStrategy(input cli.Input, existingExecutionStrategy *fv1.Exe
 			strategy.Metrics = []asv2.MetricSpec{hpa.ConvertTargetCPUToCustomMetric(int32(targetCPU))}
 		}
 
+		if fnExecutor == oldExecutor {
+			strategy.ScalingMode = existingExecutionStrategy.ScalingMode
+			strategy.ConcurrencyScaling = existingExecutionStrategy.ConcurrencyScaling.DeepCopy()
+			strategy.UpdateStrategy = existingExecutionStrategy.UpdateStrategy.DeepCopy()
+		}
+	}
+
+	// scaling schedules are only kept as long as the executor type they
+	// were written for
+	if fnExecutor == oldExecutor {
+		for _, s := range existingExecutionStrategy.ScalingSchedules {
+			strategy.ScalingSchedules = append(strategy.ScalingSchedules, *s.DeepCopy())
+		}
+	}
+
+	err = setScalingMode(input, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setUpdateStrategy(input, strategy)
+	if err != nil {
+		return nil, err
 	}
 
 	return strategy, nil
 }
 
+// setScalingMode sets the scaling mode and target concurrency of newdeploy functions.
+func setScalingMode(input cli.Input, strategy *fv1.ExecutionStrategy) error {
+	if !input.IsSet(flagkey.ReplicasScalingMode) && !input.IsSet(flagkey.ReplicasTargetConcurrency) {
+		return nil
+	}
+	if strategy.ExecutorType != fv1.ExecutorTypeNewdeploy {
+		return errors.New("to set scaling mode or target concurrency for function, please specify \"--executortype newdeploy\"")
+	}
+
+	if input.IsSet(flagkey.ReplicasScalingMode) {
+		switch mode := fv1.ScalingMode(input.String(flagkey.ReplicasScalingMode)); mode {
+		case fv1.ScalingModeCPU, fv1.ScalingModeConcurrency:
+			strategy.ScalingMode = mode
+		default:
+			return fmt.Errorf("%v must be one of '%v' or '%v'", flagkey.ReplicasScalingMode, fv1.ScalingModeCPU, fv1.ScalingModeConcurrency)
+		}
+	}
+
+	if input.IsSet(flagkey.ReplicasTargetConcurrency) {
+		targetConcurrency := input.Int(flagkey.ReplicasTargetConcurrency)
+		if targetConcurrency <= 0 {
+			return fmt.Errorf("%v must be greater than 0", flagkey.ReplicasTargetConcurrency)
+		}
+		if strategy.ConcurrencyScaling == nil {
+			strategy.ConcurrencyScaling = &fv1.ConcurrencyScaling{}
+		}
+		strategy.ConcurrencyScaling.TargetConcurrency = targetConcurrency
+	}
+	return nil
+}
+
+// setUpdateStrategy sets the rollout strategy of newdeploy and container functions.
+func setUpdateStrategy(input cli.Input, strategy *fv1.ExecutionStrategy) error {
+	if !input.IsSet(flagkey.UpdateStrategyType) && !input.IsSet(flagkey.UpdateMaxSurge) &&
+		!input.IsSet(flagkey.UpdateMaxUnavailable) && !input.IsSet(flagkey.UpdateRollbackTimeout) {
+		return nil
+	}
+	if strategy.ExecutorType == fv1.ExecutorTypePoolmgr {
+		return errors.New("to set update strategy for function, please specify \"--executortype newdeploy\" or \"--executortype container\"")
+	}
+	if strategy.UpdateStrategy == nil {
+		strategy.UpdateStrategy = &fv1.UpdateStrategy{}
+	}
+
+	if input.IsSet(flagkey.UpdateStrategyType) {
+		switch typ := fv1.UpdateStrategyType(input.String(flagkey.UpdateStrategyType)); typ {
+		case fv1.UpdateStrategyRolling, fv1.UpdateStrategyBlueGreen:
+			strategy.UpdateStrategy.Type = typ
+		default:
+			return fmt.Errorf("%v must be one of '%v' or '%v'", flagkey.UpdateStrategyType, fv1.UpdateStrategyRolling, fv1.UpdateStrategyBlueGreen)
+		}
+	}
+
+	if input.IsSet(flagkey.UpdateMaxSurge) {
+		maxSurge := intstr.Parse(input.String(flagkey.UpdateMaxSurge))
+		strategy.UpdateStrategy.MaxSurge = &maxSurge
+	}
+
+	if input.IsSet(flagkey.UpdateMaxUnavailable) {
+		maxUnavailable := intstr.Parse(input.String(flagkey.UpdateMaxUnavailable))
+		strategy.UpdateStrategy.MaxUnavailable = &maxUnavailable
+	}
+
+	if input.IsSet(flagkey.UpdateRollbackTimeout) {
+		rollbackTimeout := input.Int(flagkey.UpdateRollbackTimeout)
+		if rollbackTimeout < 0 {
+			return fmt.Errorf("%v must be greater than or equal to 0", flagkey.UpdateRollbackTimeout)
+		}
+		strategy.UpdateStrategy.RollbackTimeout = rollbackTimeout
+	}
+
+	return fv1.AggregateValidationErrors("UpdateStrategy", strategy.UpdateStrategy.Validate())
+}
+
 func getTargetCPU(input cli.Input) (int, error) {
 	targetCPU := input.Int(flagkey.RuntimeTargetcpu)
 	if targetCPU <= 0 || targetCPU > 100 {
@@ -311,6 +311,75 @@ func TestGetInvokeStrategy(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "scale on concurrency",
+			testArgs: map[string]interface{}{
+				flagkey.FnExecutorType:            string(fv1.ExecutorTypeNewdeploy),
+				flagkey.ReplicasScalingMode:       string(fv1.ScalingModeConcurrency),
+				flagkey.ReplicasTargetConcurrency: 4,
+			},
+			existingInvokeStrategy: &fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypeNewdeploy,
+					MinScale:              0,
+					MaxScale:              5,
+					SpecializationTimeout: fv1.DefaultSpecializationTimeOut,
+				},
+			},
+			expectedResult: &fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypeNewdeploy,
+					MinScale:              0,
+					MaxScale:              5,
+					SpecializationTimeout: fv1.DefaultSpecializationTimeOut,
+					ScalingMode:           fv1.ScalingModeConcurrency,
+					ConcurrencyScaling:    &fv1.ConcurrencyScaling{TargetConcurrency: 4},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "scaling on concurrency is kept on update",
+			testArgs: map[string]interface{}{
+				flagkey.FnExecutorType:   string(fv1.ExecutorTypeNewdeploy),
+				flagkey.ReplicasMaxscale: 8,
+			},
+			existingInvokeStrategy: &fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypeNewdeploy,
+					MinScale:              0,
+					MaxScale:              5,
+					SpecializationTimeout: fv1.DefaultSpecializationTimeOut,
+					ScalingMode:           fv1.ScalingModeConcurrency,
+					ConcurrencyScaling:    &fv1.ConcurrencyScaling{TargetConcurrency: 4},
+				},
+			},
+			expectedResult: &fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypeNewdeploy,
+					MinScale:              0,
+					MaxScale:              8,
+					SpecializationTimeout: fv1.DefaultSpecializationTimeOut,
+					ScalingMode:           fv1.ScalingModeConcurrency,
+					ConcurrencyScaling:    &fv1.ConcurrencyScaling{TargetConcurrency: 4},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "scale on concurrency requires newdeploy",
+			testArgs: map[string]interface{}{
+				flagkey.FnExecutorType:      string(fv1.ExecutorTypePoolmgr),
+				flagkey.ReplicasScalingMode: string(fv1.ScalingModeConcurrency),
+			},
+			existingInvokeStrategy: nil,
+			expectedResult:         nil,
+			expectError:            true,
+		},
 		{
 			name: "change specializationtimeout",
 			testArgs: map[string]interface{}{
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type ServicesSubCommand struct {
+	cmd.CommandActioner
+	executor client.ClientInterface
+}
+
+func Services(input cli.Input) error {
+	return (&ServicesSubCommand{}).do(input)
+}
+
+func (opts *ServicesSubCommand) do(input cli.Input) error {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
+	if err != nil {
+		return fmt.Errorf("error in finding function services : %w", err)
+	}
+
+	output := input.String(flagkey.FnServicesOutput)
+	if output != "table" && output != "json" {
+		return fmt.Errorf("invalid output format '%s', must be one of 'table', 'json'", output)
+	}
+
+	executorURL, err := util.GetExecutorURL(input.Context(), opts.Client())
+	if err != nil {
+		return fmt.Errorf("error getting executor URL: %w", err)
+	}
+	opts.executor = client.MakeClient(zap.NewNop(), executorURL)
+
+	switch {
+	case input.IsSet(flagkey.FnServicesEvict):
+		return opts.evict(input, namespace)
+	case input.Bool(flagkey.FnServicesDrain):
+		return opts.drain(input, namespace, output)
+	default:
+		return opts.list(input, namespace, output)
+	}
+}
+
+func (opts *ServicesSubCommand) list(input cli.Input, namespace string, output string) error {
+	filter := client.FunctionServiceFilter{
+		Namespace:   namespace,
+		Function:    input.String(flagkey.FnName),
+		Environment: input.String(flagkey.FnEnvironmentName),
+	}
+	if input.Bool(flagkey.AllNamespaces) {
+		filter.Namespace = metav1.NamespaceAll
+	}
+
+	fsvcs, err := opts.executor.ListFunctionServices(input.Context(), filter)
+	if err != nil {
+		return fmt.Errorf("error listing function services: %w", err)
+	}
+
+	if output == "json" {
+		return printJSON(fsvcs)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "FUNCTION", "ENV", "EXECUTORTYPE", "ADDRESS", "ACTIVE", "CPU", "CPULIMIT", "DRAINING", "AGE", "NAMESPACE")
+	for _, fsvc := range fsvcs {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			fsvc.Name, fsvc.Function, fsvc.Environment, fsvc.Executor, fsvc.Address,
+			fsvc.ActiveRequests, valueOrNone(fsvc.CPUUsage), valueOrNone(fsvc.CPULimit), fsvc.Draining,
+			duration.HumanDuration(time.Since(fsvc.Ctime)), fsvc.Namespace)
+	}
+	return w.Flush()
+}
+
+func (opts *ServicesSubCommand) evict(input cli.Input, namespace string) error {
+	podName := input.String(flagkey.FnServicesEvict)
+	err := opts.executor.EvictFunctionService(input.Context(), client.EvictFunctionServiceRequest{
+		Namespace:      namespace,
+		PodName:        podName,
+		FnExecutorType: fv1.ExecutorTypePoolmgr,
+	})
+	if err != nil {
+		return fmt.Errorf("error evicting function pod '%s': %w", podName, err)
+	}
+	fmt.Printf("pod '%s' evicted\n", podName)
+	return nil
+}
+
+func (opts *ServicesSubCommand) drain(input cli.Input, namespace string, output string) error {
+	fnName := input.String(flagkey.FnName)
+	if len(fnName) == 0 {
+		return errors.New("need --name to drain a function")
+	}
+
+	fn, err := opts.Client().FissionClientSet.CoreV1().Functions(namespace).Get(input.Context(), fnName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting function: %w", err)
+	}
+
+	resp, err := opts.executor.DrainFunction(input.Context(), client.DrainFunctionRequest{
+		FnMetadata:     fn.ObjectMeta,
+		FnExecutorType: fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType,
+		Timeout:        input.Duration(flagkey.FnServicesDrainTimeout),
+	})
+	if err != nil {
+		return fmt.Errorf("error draining function '%s': %w", fnName, err)
+	}
+
+	if output == "json" {
+		return printJSON(resp)
+	}
+	fmt.Printf("function '%s' drained, %d pod(s) deleted after finishing requests, %d busy pod(s) deleted after timeout\n",
+		fnName, len(resp.Drained), len(resp.Forced))
+	if len(resp.Forced) > 0 {
+		fmt.Printf("busy pods: %s\n", strings.Join(resp.Forced, ", "))
+	}
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding output: %w", err)
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func valueOrNone(v string) string {
+	if len(v) == 0 {
+		return "<none>"
+	}
+	return v
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/dummy"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	fClient "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+)
+
+// fakeExecutor records the requests of the function services API.
+type fakeExecutor struct {
+	lock    sync.Mutex
+	queries []string
+	evicts  []client.EvictFunctionServiceRequest
+	drains  []client.DrainFunctionRequest
+}
+
+func (e *fakeExecutor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	switch r.URL.Path {
+	case "/v2/functionServices":
+		e.queries = append(e.queries, r.URL.RawQuery)
+		_ = json.NewEncoder(w).Encode([]client.FunctionService{{Name: "pod-1", Function: "hello", Namespace: "default"}})
+	case "/v2/evictFunctionService":
+		req := client.EvictFunctionServiceRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		e.evicts = append(e.evicts, req)
+	case "/v2/drainFunction":
+		req := client.DrainFunctionRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		e.drains = append(e.drains, req)
+		_ = json.NewEncoder(w).Encode(client.DrainFunctionResponse{Drained: []string{"pod-1"}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestServices(t *testing.T) {
+	executor := &fakeExecutor{}
+	server := httptest.NewServer(executor)
+	t.Cleanup(server.Close)
+	t.Setenv("FISSION_EXECUTOR_URL", server.URL)
+
+	cmd.SetClientset(cmd.Client{
+		FissionClientSet: fClient.NewSimpleClientset(&fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "uid-hello"},
+			Spec: fv1.FunctionSpec{
+				InvokeStrategy: fv1.InvokeStrategy{
+					ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypePoolmgr},
+				},
+			},
+		}),
+		Namespace: "default",
+	})
+
+	flags := dummy.TestFlagSet()
+	flags.Set(flagkey.FnServicesOutput, "json")
+	flags.Set(flagkey.FnName, "hello")
+	require.NoError(t, Services(flags))
+	require.Equal(t, []string{"function=hello&namespace=default"}, executor.queries)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.FnServicesOutput, "table")
+	flags.Set(flagkey.FnServicesEvict, "pod-1")
+	require.NoError(t, Services(flags))
+	require.Equal(t, []client.EvictFunctionServiceRequest{
+		{Namespace: "default", PodName: "pod-1", FnExecutorType: fv1.ExecutorTypePoolmgr},
+	}, executor.evicts)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.FnServicesOutput, "table")
+	flags.Set(flagkey.FnServicesDrain, true)
+	flags.Set(flagkey.FnServicesDrainTimeout, time.Minute)
+	require.Error(t, Services(flags), "draining needs the function name")
+	flags.Set(flagkey.FnName, "hello")
+	require.NoError(t, Services(flags))
+	require.Len(t, executor.drains, 1)
+	require.Equal(t, "hello", executor.drains[0].FnMetadata.Name)
+	require.Equal(t, fv1.ExecutorTypePoolmgr, executor.drains[0].FnExecutorType)
+	require.Equal(t, time.Minute, executor.drains[0].Timeout)
+
+	flags = dummy.TestFlagSet()
+	flags.Set(flagkey.FnServicesOutput, "yaml")
+	require.Error(t, Services(flags))
+}
@@ -161,6 +161,22 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		function.Spec.RetainPods = input.Int(flagkey.FnRetainPods)
 	}
 
+	if input.IsSet(flagkey.FnMaxPodLifetime) {
+		function.Spec.MaxPodLifetime = input.Int(flagkey.FnMaxPodLifetime)
+	}
+
+	if input.IsSet(flagkey.FnMaxPodRequests) {
+		function.Spec.MaxPodRequests = input.Int(flagkey.FnMaxPodRequests)
+	}
+
+	if input.IsSet(flagkey.FnPoolClass) {
+		function.Spec.PoolClass = input.String(flagkey.FnPoolClass)
+	}
+
+	if input.IsSet(flagkey.FnConfigUpdatePolicy) {
+		function.Spec.ConfigUpdatePolicy = fv1.ConfigUpdatePolicy(input.String(flagkey.FnConfigUpdatePolicy))
+	}
+
 	if input.IsSet(flagkey.FnOnceOnly) {
 		function.Spec.OnceOnly = input.Bool(flagkey.FnOnceOnly)
 	}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	bmClient "github.com/fission/fission/pkg/buildermgr/client"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type CancelSubCommand struct {
+	cmd.CommandActioner
+	name      string
+	namespace string
+}
+
+func Cancel(input cli.Input) error {
+	return (&CancelSubCommand{}).do(input)
+}
+
+func (opts *CancelSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CancelSubCommand) complete(input cli.Input) (err error) {
+	opts.name = input.String(flagkey.PkgName)
+	_, opts.namespace, err = opts.GetResourceNamespace(input, flagkey.NamespacePackage)
+	if err != nil {
+		return fv1.AggregateValidationErrors("Environment", err)
+	}
+	return nil
+}
+
+func (opts *CancelSubCommand) run(input cli.Input) error {
+	pkg, err := opts.Client().FissionClientSet.CoreV1().Packages(opts.namespace).Get(input.Context(), opts.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("package %s not found: %w", opts.name, err)
+	}
+
+	if pkg.Status.BuildStatus != fv1.BuildStatusPending && pkg.Status.BuildStatus != fv1.BuildStatusRunning {
+		return fmt.Errorf("package %v is not being built, its build status is %v",
+			pkg.ObjectMeta.Name, pkg.Status.BuildStatus)
+	}
+
+	builderMgrURL, err := util.GetBuilderMgrURL(input.Context(), opts.Client())
+	if err != nil {
+		return fmt.Errorf("error connecting to buildermgr: %w", err)
+	}
+
+	err = bmClient.MakeClient(zap.NewNop(), builderMgrURL).CancelBuild(input.Context(), pkg.ObjectMeta.Namespace, pkg.ObjectMeta.Name)
+	if ferror.IsNotFound(err) {
+		return fmt.Errorf("package %v is not being built", pkg.ObjectMeta.Name)
+	} else if err != nil {
+		return fmt.Errorf("error canceling build of package %v: %w", pkg.ObjectMeta.Name, err)
+	}
+
+	fmt.Printf("Canceling build for pkg %v. Use \"fission pkg info --name %v\" to view status\n", pkg.ObjectMeta.Name, pkg.ObjectMeta.Name)
+
+	return nil
+}
@@ -32,7 +32,8 @@ func Commands() *cobra.Command {
 	wrapper.SetFlags(createCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgEnvironment},
 		Optional: []flag.Flag{flag.PkgName, flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgBuildCmd,
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgArchiveFormat, flag.PkgPullSecret,
+			flag.PkgSrcGitRef, flag.PkgSrcGitSubPath, flag.PkgSrcGitSecret, flag.PkgBuildCmd, flag.PkgBuildTimeout,
 			flag.NamespacePackage, flag.SpecSave, flag.SpecDry},
 	})
 
@@ -43,7 +44,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(getSrcCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgName},
-		Optional: []flag.Flag{flag.NamespacePackage, flag.PkgOutput},
+		Optional: []flag.Flag{flag.NamespacePackage, flag.PkgOutput, flag.PkgPlainHTTP},
 	})
 
 	getDeployCmd := &cobra.Command{
@@ -52,10 +53,31 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(GetDeploy),
 	}
 	wrapper.SetFlags(getDeployCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.PkgName},
+		Optional: []flag.Flag{flag.NamespacePackage, flag.PkgOutput, flag.PkgPlainHTTP},
+	})
+
+	getSBOMCmd := &cobra.Command{
+		Use:   "getsbom",
+		Short: "Get the SBOM emitted by the build of a package",
+		RunE:  wrapper.Wrapper(GetSBOM),
+	}
+	wrapper.SetFlags(getSBOMCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgName},
 		Optional: []flag.Flag{flag.NamespacePackage, flag.PkgOutput},
 	})
 
+	pushCmd := &cobra.Command{
+		Use:   "push",
+		Short: "Push the archive of a package to an OCI registry",
+		Long:  "Push the deploy or source archive of a package to an OCI registry, with the credentials of the docker config, and print its reference by digest to use in other clusters.",
+		RunE:  wrapper.Wrapper(Push),
+	}
+	wrapper.SetFlags(pushCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.PkgName, flag.PkgRef},
+		Optional: []flag.Flag{flag.PkgPushSource, flag.PkgPlainHTTP, flag.NamespacePackage},
+	})
+
 	updateCmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update a package",
@@ -64,7 +86,8 @@ func Commands() *cobra.Command {
 	wrapper.SetFlags(updateCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgName},
 		Optional: []flag.Flag{flag.PkgEnvironment, flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgBuildCmd, flag.PkgForce,
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgArchiveFormat, flag.PkgPullSecret,
+			flag.PkgSrcGitRef, flag.PkgSrcGitSubPath, flag.PkgSrcGitSecret, flag.PkgBuildCmd, flag.PkgBuildTimeout, flag.PkgForce,
 			flag.NamespacePackage, flag.NamespaceEnvironment},
 	})
 
@@ -94,7 +117,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(infoCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgName},
-		Optional: []flag.Flag{flag.NamespacePackage},
+		Optional: []flag.Flag{flag.PkgFollow, flag.NamespacePackage},
 	})
 
 	rebuildCmd := &cobra.Command{
@@ -107,13 +130,23 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespacePackage},
 	})
 
+	cancelCmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel the running build of a package",
+		RunE:  wrapper.Wrapper(Cancel),
+	}
+	wrapper.SetFlags(cancelCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.PkgName},
+		Optional: []flag.Flag{flag.NamespacePackage},
+	})
+
 	command := &cobra.Command{
 		Use:     "package",
 		Aliases: []string{"pkg"},
 		Short:   "Create, update and manage packages",
 	}
 
-	command.AddCommand(createCmd, getSrcCmd, getDeployCmd, updateCmd, deleteCmd, listCmd, infoCmd, rebuildCmd)
+	command.AddCommand(createCmd, getSrcCmd, getDeployCmd, getSBOMCmd, pushCmd, updateCmd, deleteCmd, listCmd, infoCmd, rebuildCmd, cancelCmd)
 
 	return command
 }
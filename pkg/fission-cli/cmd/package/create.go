@@ -162,7 +162,7 @@ func CreatePackage(input cli.Input, client cmd.Client, pkgName string, pkgNamesp
 		}
 	}
 	if len(srcArchiveFiles) > 0 {
-		source, err := CreateArchive(client, input, srcArchiveFiles, false, insecure, srcChecksum, specDir, specFile)
+		source, err := createSourceArchive(client, input, srcArchiveFiles, false, insecure, srcChecksum, specDir, specFile)
 		if err != nil {
 			return nil, fmt.Errorf("error creating source archive: %w", err)
 		}
@@ -177,6 +177,10 @@ func CreatePackage(input cli.Input, client cmd.Client, pkgName string, pkgNamesp
 		pkgSpec.BuildCommand = buildcmd
 	}
 
+	if buildTimeout := input.Int(flagkey.PkgBuildTimeout); buildTimeout > 0 {
+		pkgSpec.BuildTimeout = buildTimeout
+	}
+
 	if len(pkgName) == 0 {
 		pkgName = strings.ToLower(uuid.NewString())
 	}
@@ -17,7 +17,6 @@ limitations under the License.
 package _package
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -52,6 +51,12 @@ func GetDeploy(input cli.Input) error {
 	return opts.do(input)
 }
 
+func GetSBOM(input cli.Input) error {
+	opts := &GetSubCommand{}
+	opts.archiveType = util.SBOM_ARCHIVE
+	return opts.do(input)
+}
+
 func (opts *GetSubCommand) do(input cli.Input) error {
 	err := opts.complete(input)
 	if err != nil {
@@ -76,22 +81,22 @@ func (opts *GetSubCommand) run(input cli.Input) error {
 		return err
 	}
 
-	var reader io.Reader
 	archive := pkg.Spec.Source
 	if (opts.archiveType == util.DEPLOY_ARCHIVE || archive.Type == "") && (pkg.Spec.Deployment.Type != "") {
 		archive = pkg.Spec.Deployment
 	}
-
-	if archive.Type == fv1.ArchiveTypeLiteral {
-		reader = bytes.NewReader(archive.Literal)
-	} else if archive.Type == fv1.ArchiveTypeUrl {
-		readCloser, err := pkgutil.DownloadStrorageURL(input.Context(), opts.Client(), archive.URL)
-		if err != nil {
-			return fmt.Errorf("error downloading from storage service url: %s: %w", archive.URL, err)
+	if opts.archiveType == util.SBOM_ARCHIVE {
+		if pkg.Status.Provenance == nil || pkg.Status.Provenance.SBOM == nil {
+			return fmt.Errorf("package %s has no SBOM, its build script didn't write one to $SBOM_FILE", opts.name)
 		}
-		defer readCloser.Close()
-		reader = readCloser
+		archive = *pkg.Status.Provenance.SBOM
+	}
+
+	reader, err := pkgutil.OpenArchive(input.Context(), opts.Client(), &archive, input.Bool(flagkey.PkgPlainHTTP))
+	if err != nil {
+		return err
 	}
+	defer reader.Close()
 
 	if len(opts.output) > 0 {
 		return pkgutil.WriteArchiveToFile(opts.output, reader)
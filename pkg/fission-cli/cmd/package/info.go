@@ -63,6 +63,24 @@ func (opts *InfoSubCommand) run(input cli.Input) error {
 		return fmt.Errorf("error finding package %s: %w", opts.name, err)
 	}
 
-	pkgutil.PrintPackageSummary(os.Stdout, pkg)
+	if !input.Bool(flagkey.PkgFollow) ||
+		(pkg.Status.BuildStatus != fv1.BuildStatusPending && pkg.Status.BuildStatus != fv1.BuildStatusRunning) {
+		pkgutil.LoadFullBuildLog(input.Context(), opts.Client(), pkg)
+		pkgutil.PrintPackageSummary(os.Stdout, pkg)
+		return nil
+	}
+
+	// stream the build logs until the build finishes
+	logClient := pkgutil.MakeBuildLogClient(input.Context(), opts.Client())
+	pkg, written, err := pkgutil.FollowBuildLog(input.Context(), opts.Client(), logClient, opts.namespace, opts.name, os.Stdout, "")
+	if err != nil {
+		return fmt.Errorf("error following build of package %s: %w", opts.name, err)
+	}
+	if written > 0 {
+		pkgutil.PrintPackageStatus(os.Stdout, pkg)
+	} else {
+		pkgutil.LoadFullBuildLog(input.Context(), opts.Client(), pkg)
+		pkgutil.PrintPackageSummary(os.Stdout, pkg)
+	}
 	return nil
 }
@@ -40,6 +40,7 @@ import (
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/oci"
 	"github.com/fission/fission/pkg/utils/uuid"
 )
 
@@ -58,11 +59,24 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 			return nil, fmt.Errorf("error getting root directory of spec directory: %w", err)
 		}
 	}
+	format, err := utils.ParseArchiveFormat(input.String(flagkey.PkgArchiveFormat))
+	if err != nil {
+		return nil, err
+	}
+
 	errs := utils.MultiErrorWithFormat()
 	fileURL := ""
 
 	// check files existence
 	for _, path := range includeFiles {
+		// OCI artifacts are pulled by the fetchers
+		if oci.IsReference(path) {
+			if len(includeFiles) > 1 {
+				return nil, errors.New("unable to create an archive that contains both file and OCI artifact")
+			}
+			return createOCIArchive(input, path, checksum)
+		}
+
 		// ignore http files
 		if utils.IsURL(path) {
 			if len(includeFiles) > 1 {
@@ -150,6 +164,9 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 			Name:         archiveName("", includeFiles),
 			IncludeGlobs: includeFiles,
 		}
+		if input.IsSet(flagkey.PkgArchiveFormat) {
+			aus.Format = string(format)
+		}
 
 		if input.Bool(flagkey.SpecDry) {
 			err := spec.SpecDry(*aus)
@@ -186,7 +203,7 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 		return &archive, nil
 	}
 
-	archivePath, err := makeArchiveFile(input.Context(), "", includeFiles, noZip)
+	archivePath, err := makeArchiveFile(input.Context(), "", includeFiles, noZip, format)
 	if err != nil {
 		return nil, err
 	}
@@ -194,17 +211,61 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 	return pkgutil.UploadArchiveFile(input.Context(), client, archivePath)
 }
 
-// makeArchiveFile creates a zip file from the given list of input files,
-// unless that list has only one item and that item is a zip file.
+// createSourceArchive returns the source archive of the package: a git
+// source if the input is the URL of a git repository or the git flags are
+// set, the archive of CreateArchive otherwise.
+func createSourceArchive(client cmd.Client, input cli.Input, includeFiles []string, noZip bool, insecure bool, checksum string, specDir string, specFile string) (*fv1.Archive, error) {
+	gitFlags := input.IsSet(flagkey.PkgSrcGitRef) || input.IsSet(flagkey.PkgSrcGitSubPath) || input.IsSet(flagkey.PkgSrcGitSecret)
+	if !gitFlags && (len(includeFiles) != 1 || !pkgutil.IsGitURL(includeFiles[0])) {
+		return CreateArchive(client, input, includeFiles, noZip, insecure, checksum, specDir, specFile)
+	}
+	if len(includeFiles) != 1 {
+		return nil, errors.New("the source of a package can be a single git repository only")
+	}
+	return &fv1.Archive{
+		Type: fv1.ArchiveTypeGit,
+		URL:  includeFiles[0],
+		Git: &fv1.GitSource{
+			Ref:        input.String(flagkey.PkgSrcGitRef),
+			SubPath:    input.String(flagkey.PkgSrcGitSubPath),
+			AuthSecret: input.String(flagkey.PkgSrcGitSecret),
+		},
+	}, nil
+}
+
+// createOCIArchive returns an archive referencing the OCI artifact, which
+// must be pinned by digest so that the archive is immutable.
+func createOCIArchive(input cli.Input, ref string, checksum string) (*fv1.Archive, error) {
+	_, err := oci.ParsePinnedReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 'fission package push' prints the digest of pushed archives", err)
+	}
+	archive := &fv1.Archive{
+		Type:       fv1.ArchiveTypeOCI,
+		URL:        ref,
+		PullSecret: input.String(flagkey.PkgPullSecret),
+	}
+	if len(checksum) > 0 {
+		archive.Checksum = fv1.Checksum{
+			Type: fv1.ChecksumTypeSHA256,
+			Sum:  checksum,
+		}
+	}
+	return archive, nil
+}
+
+// makeArchiveFile creates an archive of the format from the given list of
+// input files, unless that list has only one item and that item is a zip,
+// tar, tar.gz or tar.zst archive.
 //
 // If the inputs have only one file and noZip is true, the file is
 // returned as-is with no zipping.  (This is used for compatibility
 // with v1 envs.)  noZip is IGNORED if there is more than one input
 // file.
-func makeArchiveFile(ctx context.Context, archiveNameHint string, archiveInput []string, noZip bool) (string, error) {
+func makeArchiveFile(ctx context.Context, archiveNameHint string, archiveInput []string, noZip bool, format utils.ArchiveFormat) (string, error) {
 
 	// Unique name for the archive
-	archiveFileName := archiveName(archiveNameHint, archiveInput) + ".zip"
+	archiveFileName := archiveName(archiveNameHint, archiveInput) + format.Extension()
 
 	// Get files from inputs as number of files decide next steps
 	files, err := utils.FindAllGlobs(archiveInput...)
@@ -212,15 +273,15 @@ func makeArchiveFile(ctx context.Context, archiveNameHint string, archiveInput [
 		return "", fmt.Errorf("error finding all globs: %w", err)
 	}
 
-	// We have one file; if it's a zip file, no need to archive it
+	// We have one file; if it's an archive, no need to archive it
 	if len(files) == 1 {
 		// make sure it exists
 		if _, err := os.Stat(files[0]); err != nil {
 			return "", fmt.Errorf("open input file %v: %w", files[0], err)
 		}
 
-		// if it's an existing zip file OR we're not supposed to zip it, don't do anything
-		if match, _ := utils.IsZip(ctx, files[0]); match || noZip {
+		// if it's an existing archive OR we're not supposed to zip it, don't do anything
+		if match, _ := utils.IsArchive(ctx, files[0]); match || noZip {
 			return files[0], nil
 		}
 	}
@@ -231,7 +292,7 @@ func makeArchiveFile(ctx context.Context, archiveNameHint string, archiveInput [
 		return "", fmt.Errorf("error create temporary archive directory: %w", err)
 	}
 
-	archivePath, err := utils.MakeZipArchiveWithGlobs(ctx, filepath.Join(tmpDir, archiveFileName), archiveInput...)
+	archivePath, err := utils.MakeArchiveWithGlobs(ctx, filepath.Join(tmpDir, archiveFileName), format, archiveInput...)
 	if err != nil {
 		return "", fmt.Errorf("create archive file: %w", err)
 	}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/oci"
+)
+
+type PushSubCommand struct {
+	cmd.CommandActioner
+	name      string
+	namespace string
+	ref       *oci.Reference
+	source    bool
+	plainHTTP bool
+}
+
+// Push publishes the deploy or source archive of a package as an OCI
+// artifact, which packages of any cluster can then reference by digest.
+func Push(input cli.Input) error {
+	return (&PushSubCommand{}).do(input)
+}
+
+func (opts *PushSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *PushSubCommand) complete(input cli.Input) (err error) {
+	opts.name = input.String(flagkey.PkgName)
+	_, opts.namespace, err = opts.GetResourceNamespace(input, flagkey.NamespacePackage)
+	if err != nil {
+		return fv1.AggregateValidationErrors("Package", err)
+	}
+	opts.ref, err = oci.ParseReference(input.String(flagkey.PkgRef))
+	if err != nil {
+		return err
+	}
+	if len(opts.ref.Digest) > 0 {
+		return fmt.Errorf("--%v must reference a tag, the digest is given by the registry", flagkey.PkgRef)
+	}
+	opts.source = input.Bool(flagkey.PkgPushSource)
+	opts.plainHTTP = input.Bool(flagkey.PkgPlainHTTP)
+	return nil
+}
+
+func (opts *PushSubCommand) run(input cli.Input) error {
+	pkg, err := opts.Client().FissionClientSet.CoreV1().Packages(opts.namespace).Get(input.Context(), opts.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	archive, kind := pkg.Spec.Deployment, "deploy"
+	if opts.source {
+		archive, kind = pkg.Spec.Source, "source"
+	}
+	if len(archive.Type) == 0 {
+		return fmt.Errorf("package %s has no %s archive", opts.name, kind)
+	}
+
+	reader, err := pkgutil.OpenArchive(input.Context(), opts.Client(), &archive, opts.plainHTTP)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	tmpDir, err := utils.GetTempDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(tmpDir, fmt.Sprintf("%s-%s.zip", opts.name, kind))
+	err = pkgutil.WriteArchiveToFile(path, reader)
+	if err != nil {
+		return fmt.Errorf("error writing %s archive to file %v: %w", kind, path, err)
+	}
+	defer os.Remove(path)
+
+	ociClient, err := pkgutil.MakeOCIClient(opts.ref.Registry, opts.plainHTTP)
+	if err != nil {
+		return err
+	}
+	pushed, err := ociClient.Push(input.Context(), opts.ref, path)
+	if err != nil {
+		return fmt.Errorf("error pushing %s archive to %v: %w", kind, opts.ref, err)
+	}
+
+	fmt.Printf("Pushed %s archive of package '%v' to %v\n", kind, opts.name, pushed)
+	return nil
+}
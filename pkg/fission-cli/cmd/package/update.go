@@ -128,8 +128,13 @@ func UpdatePackage(input cli.Input, client cmd.Client, specFile string, pkg *fv1
 		needToUpdate = true
 	}
 
+	if input.IsSet(flagkey.PkgBuildTimeout) {
+		pkg.Spec.BuildTimeout = input.Int(flagkey.PkgBuildTimeout)
+		needToUpdate = true
+	}
+
 	if input.IsSet(flagkey.PkgSrcArchive) {
-		srcArchive, err := CreateArchive(client, input, srcArchiveFiles, noZip, insecure, srcChecksum, "", "")
+		srcArchive, err := createSourceArchive(client, input, srcArchiveFiles, noZip, insecure, srcChecksum, "", "")
 		if err != nil {
 			return nil, fmt.Errorf("error creating source archive: %w", err)
 		}
@@ -162,6 +167,39 @@ func UpdatePackage(input cli.Input, client cmd.Client, specFile string, pkg *fv1
 		needToUpdate = true
 	}
 
+	// the pull secret of archives that aren't replaced
+	if input.IsSet(flagkey.PkgPullSecret) && !input.IsSet(flagkey.PkgSrcArchive) &&
+		!input.IsSet(flagkey.PkgDeployArchive) && !input.IsSet(flagkey.PkgCode) {
+		for _, archive := range []*fv1.Archive{&pkg.Spec.Source, &pkg.Spec.Deployment} {
+			if archive.Type == fv1.ArchiveTypeOCI {
+				archive.PullSecret = input.String(flagkey.PkgPullSecret)
+				needToUpdate = true
+			}
+		}
+	}
+
+	// the git ref, sub path and secret of a git source that isn't replaced
+	if !input.IsSet(flagkey.PkgSrcArchive) && pkg.Spec.Source.Type == fv1.ArchiveTypeGit {
+		if pkg.Spec.Source.Git == nil {
+			pkg.Spec.Source.Git = &fv1.GitSource{}
+		}
+		git := pkg.Spec.Source.Git
+		for key, field := range map[string]*string{
+			flagkey.PkgSrcGitRef:     &git.Ref,
+			flagkey.PkgSrcGitSubPath: &git.SubPath,
+			flagkey.PkgSrcGitSecret:  &git.AuthSecret,
+		} {
+			if input.IsSet(key) {
+				*field = input.String(key)
+				needToRebuild = true
+				needToUpdate = true
+			}
+		}
+	} else if !input.IsSet(flagkey.PkgSrcArchive) &&
+		(input.IsSet(flagkey.PkgSrcGitRef) || input.IsSet(flagkey.PkgSrcGitSubPath) || input.IsSet(flagkey.PkgSrcGitSecret)) {
+		return nil, fmt.Errorf("package %s has no git source, use --%v to set one", pkg.Name, flagkey.PkgSrcArchive)
+	}
+
 	if !needToUpdate {
 		return &pkg.ObjectMeta, nil
 	}
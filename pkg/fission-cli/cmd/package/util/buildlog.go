@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/builder"
+	bmClient "github.com/fission/fission/pkg/buildermgr/client"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	storageSvcClient "github.com/fission/fission/pkg/storagesvc/client"
+)
+
+// buildPollInterval is how often the status of a building package is checked.
+const buildPollInterval = time.Second
+
+// MakeBuildLogClient returns a client to stream build logs from buildermgr,
+// or nil with a warning if buildermgr can't be reached.
+func MakeBuildLogClient(ctx context.Context, client cmd.Client) bmClient.ClientInterface {
+	builderMgrURL, err := util.GetBuilderMgrURL(ctx, client)
+	if err != nil {
+		console.Warn(fmt.Sprintf("Build logs will not be streamed, error connecting to buildermgr: %v", err))
+		return nil
+	}
+	return bmClient.MakeClient(zap.NewNop(), builderMgrURL)
+}
+
+// FollowBuildLog waits for the pending or running build of the package to
+// finish and returns the package. With a build log client, the output of the
+// build is written to w while it runs, each line prefixed with prefix. It
+// also returns the number of lines written.
+func FollowBuildLog(ctx context.Context, client cmd.Client, logClient bmClient.ClientInterface,
+	namespace, name string, w io.Writer, prefix string) (*fv1.Package, int, error) {
+	written := 0
+	streamed := false
+	for {
+		pkg, err := client.FissionClientSet.CoreV1().Packages(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, written, err
+		}
+		if pkg.Status.BuildStatus != fv1.BuildStatusPending && pkg.Status.BuildStatus != fv1.BuildStatusRunning {
+			return pkg, written, nil
+		}
+
+		if logClient != nil && !streamed && pkg.Status.BuildStatus == fv1.BuildStatusRunning {
+			// the output is streamed from the start of the build, so skip
+			// the lines already written if the stream is resumed
+			n, err := streamBuildLog(ctx, logClient, namespace, name, w, prefix, written)
+			written += n
+			if err == nil {
+				streamed = true
+			} else if !ferror.IsNotFound(err) {
+				console.Verbose(2, "Error streaming build logs of package %s: %v", name, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, written, ctx.Err()
+		case <-time.After(buildPollInterval):
+		}
+	}
+}
+
+// streamBuildLog writes the output of the running build of the package to w,
+// skipping the given number of lines. It returns the number of lines written.
+func streamBuildLog(ctx context.Context, logClient bmClient.ClientInterface,
+	namespace, name string, w io.Writer, prefix string, skip int) (int, error) {
+	logs, err := logClient.BuildLog(ctx, namespace, name, true)
+	if err != nil {
+		return 0, err
+	}
+	defer logs.Close()
+
+	written := 0
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), builder.MaxBuildLogLineSize)
+	for i := 0; scanner.Scan(); i++ {
+		if i < skip {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s%s\n", prefix, scanner.Text()); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, scanner.Err()
+}
+
+// LoadFullBuildLog replaces the tail of the build log kept in the package
+// with the full build log, if it's stored in storagesvc. On error, the tail
+// is kept with a warning.
+func LoadFullBuildLog(ctx context.Context, client cmd.Client, pkg *fv1.Package) {
+	if len(pkg.Status.BuildLogArchiveID) == 0 {
+		return
+	}
+	buildLog, err := downloadBuildLog(ctx, client, pkg.Status.BuildLogArchiveID)
+	if err != nil {
+		console.Warn(fmt.Sprintf("Showing the tail of the build log only, error downloading the full build log: %v", err))
+		return
+	}
+	pkg.Status.BuildLog = buildLog
+}
+
+func downloadBuildLog(ctx context.Context, client cmd.Client, archiveID string) (string, error) {
+	storagesvcURL, err := util.GetStorageURL(ctx, client)
+	if err != nil {
+		return "", fmt.Errorf("error getting fission storage service URL: %w", err)
+	}
+	resp, err := storageSvcClient.MakeClient(storagesvcURL.String()).GetFile(ctx, archiveID)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error getting build log archive %s: %s", archiveID, resp.Status)
+	}
+	buildLog, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(buildLog), nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/oci"
+	"github.com/fission/fission/pkg/utils/uuid"
+)
+
+// MakeOCIClient returns a client of the registry with the credentials of the
+// docker config of the user, reaching it over plain HTTP if plainHTTP is set.
+func MakeOCIClient(registry string, plainHTTP bool) (*oci.Client, error) {
+	keychain, err := dockerKeychain()
+	if err != nil {
+		return nil, err
+	}
+	var plainHTTPRegistries []string
+	if plainHTTP {
+		plainHTTPRegistries = []string{registry}
+	}
+	return oci.MakeClient(http.DefaultClient, keychain, plainHTTPRegistries), nil
+}
+
+// dockerKeychain returns the credentials of the docker config file at
+// $DOCKER_CONFIG/config.json or ~/.docker/config.json, if any. Credential
+// helpers aren't supported.
+func dockerKeychain() (oci.Keychain, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if len(dir) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return oci.MakeKeychain(), nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return oci.MakeKeychain(), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading docker config: %w", err)
+	}
+	credentials, err := oci.ParseDockerConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	return oci.MakeKeychain(credentials), nil
+}
+
+// OpenArchive returns a reader of the content of the archive: its literal,
+// the file at its URL or the OCI artifact it references.
+func OpenArchive(ctx context.Context, client cmd.Client, archive *fv1.Archive, plainHTTP bool) (io.ReadCloser, error) {
+	switch archive.Type {
+	case fv1.ArchiveTypeLiteral:
+		return io.NopCloser(bytes.NewReader(archive.Literal)), nil
+	case fv1.ArchiveTypeUrl:
+		readCloser, err := DownloadStrorageURL(ctx, client, archive.URL)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading from storage service url: %s: %w", archive.URL, err)
+		}
+		return readCloser, nil
+	case fv1.ArchiveTypeOCI:
+		ref, err := oci.ParsePinnedReference(archive.URL)
+		if err != nil {
+			return nil, err
+		}
+		ociClient, err := MakeOCIClient(ref.Registry, plainHTTP)
+		if err != nil {
+			return nil, err
+		}
+		tmpDir, err := utils.GetTempDir()
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(tmpDir, uuid.NewString())
+		_, err = ociClient.Pull(ctx, ref, path)
+		if err != nil {
+			return nil, fmt.Errorf("error pulling %s: %w", archive.URL, err)
+		}
+		return os.Open(path)
+	case fv1.ArchiveTypeGit:
+		return nil, fmt.Errorf("archive is the git repository %s, which can be cloned with git", archive.URL)
+	default:
+		return nil, fmt.Errorf("unsupported archive type %q", archive.Type)
+	}
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDockerKeychain(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+
+	// no docker config means no credentials
+	keychain, err := dockerKeychain()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds := keychain("ghcr.io"); creds != nil {
+		t.Errorf("unexpected credentials %v", creds)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"auths":{"ghcr.io":{"username":"user","password":"secret"}}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keychain, err = dockerKeychain()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds := keychain("ghcr.io"); creds == nil || creds.Username != "user" {
+		t.Errorf("unexpected credentials %v", creds)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
@@ -228,11 +229,78 @@ func PrintPackageSummary(writer io.Writer, pkg *fv1.Package) {
 	// replace escaped line breaker character
 	buildlog := strings.ReplaceAll(pkg.Status.BuildLog, `\n`, "\n")
 	w := tabwriter.NewWriter(writer, 0, 0, 1, ' ', 0)
+	printPackageStatus(w, pkg)
+	fmt.Fprintf(w, "%v\n%v", "Build Logs:", buildlog)
+	w.Flush()
+}
+
+// PrintPackageStatus prints the package summary without the build logs,
+// for packages whose build logs were streamed.
+func PrintPackageStatus(writer io.Writer, pkg *fv1.Package) {
+	w := tabwriter.NewWriter(writer, 0, 0, 1, ' ', 0)
+	printPackageStatus(w, pkg)
+	w.Flush()
+}
+
+func printPackageStatus(w io.Writer, pkg *fv1.Package) {
 	fmt.Fprintf(w, "%v\t%v\n", "Name:", pkg.ObjectMeta.Name)
 	fmt.Fprintf(w, "%v\t%v\n", "Environment:", pkg.Spec.Environment.Name)
 	fmt.Fprintf(w, "%v\t%v\n", "Status:", pkg.Status.BuildStatus)
-	fmt.Fprintf(w, "%v\n%v", "Build Logs:", buildlog)
-	w.Flush()
+	if pkg.Status.BuildStatus == fv1.BuildStatusPending && pkg.Status.QueuePosition > 0 {
+		fmt.Fprintf(w, "%v\t%v\n", "Queue position:", pkg.Status.QueuePosition)
+	}
+	if p := pkg.Status.Provenance; p != nil {
+		fmt.Fprintf(w, "%v\n", "Provenance:")
+		fmt.Fprintf(w, "  %v\t%v\n", "Source checksum:", formatChecksum(p.SourceChecksum))
+		if len(p.SourceCommit) > 0 {
+			fmt.Fprintf(w, "  %v\t%v\n", "Source commit:", p.SourceCommit)
+		}
+		fmt.Fprintf(w, "  %v\t%v\n", "Deployment checksum:", formatChecksum(p.DeploymentChecksum))
+		fmt.Fprintf(w, "  %v\t%v\n", "Builder image:", p.BuilderImage)
+		fmt.Fprintf(w, "  %v\t%v\n", "Builder image digest:", valueOrUnknown(p.BuilderImageDigest))
+		buildCmd := p.BuildCommand
+		if len(buildCmd) == 0 {
+			buildCmd = "default command of the builder"
+		}
+		fmt.Fprintf(w, "  %v\t%v\n", "Build command:", buildCmd)
+		fmt.Fprintf(w, "  %v\t%v (resource version %v)\n", "Environment version:", p.EnvironmentVersion, p.EnvironmentResourceVersion)
+		fmt.Fprintf(w, "  %v\t%v\n", "Build started:", p.StartTime.Format(time.RFC3339))
+		fmt.Fprintf(w, "  %v\t%v\n", "Build ended:", p.EndTime.Format(time.RFC3339))
+		sbom := "none"
+		if p.SBOM != nil {
+			sbom = fmt.Sprintf("%s, use \"fission package getsbom --name %s\" to download it", formatChecksum(p.SBOM.Checksum), pkg.ObjectMeta.Name)
+		}
+		fmt.Fprintf(w, "  %v\t%v\n", "SBOM:", sbom)
+	}
+}
+
+func formatChecksum(checksum fv1.Checksum) string {
+	if len(checksum.Sum) == 0 {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%s", checksum.Type, checksum.Sum)
+}
+
+func valueOrUnknown(value string) string {
+	if len(value) == 0 {
+		return "unknown"
+	}
+	return value
+}
+
+// IsGitURL returns whether the URL is the one of a git repository: an SSH
+// URL, in either the ssh:// or the scp-like user@host:path form, or a URL
+// ending with .git.
+func IsGitURL(urlStr string) bool {
+	if strings.HasPrefix(urlStr, "ssh://") || strings.HasPrefix(urlStr, "git://") {
+		return true
+	}
+	if utils.IsURL(urlStr) && strings.HasSuffix(strings.TrimSuffix(urlStr, "/"), ".git") {
+		return true
+	}
+	// user@host:path, without the scheme of other URLs
+	at, colon := strings.Index(urlStr, "@"), strings.Index(urlStr, ":")
+	return at > 0 && colon > at && !strings.Contains(urlStr[:colon], "/")
 }
 
 // validArchiveURL checks if the given URL is a valid archive URL
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -64,3 +65,59 @@ func TestValidArchiveURL(t *testing.T) {
 		})
 	}
 }
+
+func TestIsGitURL(t *testing.T) {
+	for url, expected := range map[string]bool{
+		"https://github.com/fission/examples.git":  true,
+		"https://github.com/fission/examples.git/": true,
+		"ssh://git@github.com/fission/examples":    true,
+		"git@github.com:fission/examples.git":      true,
+		"git://example.com/examples":               true,
+		"https://github.com/fission/examples":      false,
+		"https://example.com/src.zip":              false,
+		"src/main.git":                             false,
+		"oci://ghcr.io/fission/hello@sha256:0":     false,
+		"hello.py":                                 false,
+	} {
+		if got := IsGitURL(url); got != expected {
+			t.Errorf("IsGitURL(%q) = %t, expected %t", url, got, expected)
+		}
+	}
+}
+
+func TestPrintPackageProvenance(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "dummy"},
+		Status: fv1.PackageStatus{
+			BuildStatus: fv1.BuildStatusSucceeded,
+			Provenance: &fv1.BuildProvenance{
+				SourceChecksum:     fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "abc"},
+				SourceCommit:       "0123456789abcdef0123456789abcdef01234567",
+				BuilderImage:       "python-builder",
+				BuilderImageDigest: "sha256:def",
+				StartTime:          metav1.Time{Time: start},
+				EndTime:            metav1.Time{Time: start.Add(time.Minute)},
+				SBOM:               &fv1.Archive{Checksum: fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "123"}},
+			},
+		},
+	}
+
+	writer := &bytes.Buffer{}
+	PrintPackageStatus(writer, pkg)
+	for _, expected := range []string{
+		"Provenance:",
+		"Source checksum:      sha256:abc",
+		"Source commit:        0123456789abcdef0123456789abcdef01234567",
+		"Deployment checksum:  unknown",
+		"Builder image digest: sha256:def",
+		"Build command:        default command of the builder",
+		"Build started:        2026-01-02T03:04:05Z",
+		"Build ended:          2026-01-02T03:05:05Z",
+		"SBOM:                 sha256:123",
+	} {
+		if !strings.Contains(writer.String(), expected) {
+			t.Errorf("expected %q in package status, got:\n%s", expected, writer.String())
+		}
+	}
+}
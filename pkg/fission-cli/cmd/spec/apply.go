@@ -138,6 +138,9 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 	if watchResources || waitForBuild {
 		// init package build watcher
 		pbw = makePackageBuildWatcher(opts.Client())
+		if waitForBuild && !watchResources {
+			pbw.streamBuildLogs(input.Context())
+		}
 	}
 
 	if watchResources {
@@ -516,8 +519,13 @@ func localArchiveFromSpec(ctx context.Context, specDir string, aus *spectypes.Ar
 	// to do a filepath.Walk and call path.Match on each path...
 	files := make([]string, 0)
 
-	// checking if file is a zip
-	if match, _ := utils.IsZip(ctx, aus.IncludeGlobs[0]); match && len(aus.IncludeGlobs) == 1 {
+	format, err := utils.ParseArchiveFormat(aus.Format)
+	if err != nil {
+		return nil, fmt.Errorf("archive %v: %w", aus.Name, err)
+	}
+
+	// checking if file is an archive
+	if match, _ := utils.IsArchive(ctx, aus.IncludeGlobs[0]); match && len(aus.IncludeGlobs) == 1 {
 		files = append(files, aus.IncludeGlobs[0])
 	} else {
 		for _, relativeGlob := range aus.IncludeGlobs {
@@ -552,14 +560,14 @@ func localArchiveFromSpec(ctx context.Context, specDir string, aus *spectypes.Ar
 	}
 
 	if len(files) > 1 || !isSingleFile {
-		// Generate archive name with .zip extension and pack all files under it.
-		archiveFile, err := os.CreateTemp("", fmt.Sprintf("fission-archive-%v-*.zip", aus.Name))
+		// Generate archive name with the extension of its format and pack all files under it.
+		archiveFile, err := os.CreateTemp("", fmt.Sprintf("fission-archive-%v-*%v", aus.Name, format.Extension()))
 		if err != nil {
 			return nil, err
 		}
 		archiveFileName = archiveFile.Name()
 
-		_, err = utils.MakeZipArchiveWithGlobs(ctx, archiveFileName, files...)
+		_, err = utils.MakeArchiveWithGlobs(ctx, archiveFileName, format, files...)
 		if err != nil {
 			return nil, err
 		}
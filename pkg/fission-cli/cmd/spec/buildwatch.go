@@ -26,6 +26,7 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	bmClient "github.com/fission/fission/pkg/buildermgr/client"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	pkgUtil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
 )
@@ -40,6 +41,17 @@ type (
 
 		// set of metadata in the app spec.  packages outside this set should be ignored.
 		pkgMeta map[string]metav1.ObjectMeta
+
+		// client streaming the build logs, nil if they are not streamed
+		logClient bmClient.ClientInterface
+		// build log streams of the running builds, by package name and namespace
+		streams map[string]*buildLogStream
+	}
+
+	// buildLogStream streams the build logs of a package until its build finishes.
+	buildLogStream struct {
+		done    chan struct{}
+		written int
 	}
 )
 
@@ -48,9 +60,51 @@ func makePackageBuildWatcher(fclient cmd.Client) *packageBuildWatcher {
 		fclient:  fclient,
 		finished: make(map[string]bool),
 		pkgMeta:  make(map[string]metav1.ObjectMeta),
+		streams:  make(map[string]*buildLogStream),
 	}
 }
 
+// streamBuildLogs makes the watcher print the build logs of the packages
+// while they are built.
+func (w *packageBuildWatcher) streamBuildLogs(ctx context.Context) {
+	w.logClient = pkgUtil.MakeBuildLogClient(ctx, w.fclient)
+}
+
+// startBuildLogStream starts streaming the build logs of the package, each
+// line prefixed with the package name.
+func (w *packageBuildWatcher) startBuildLogStream(ctx context.Context, pkg *fv1.Package) {
+	k := k8sCache.MetaObjectToName(&pkg.ObjectMeta).String()
+	if _, ok := w.streams[k]; ok {
+		return
+	}
+	stream := &buildLogStream{done: make(chan struct{})}
+	w.streams[k] = stream
+	go func(namespace, name string) {
+		defer close(stream.done)
+		_, stream.written, _ = pkgUtil.FollowBuildLog(ctx, w.fclient, w.logClient, namespace, name,
+			os.Stdout, fmt.Sprintf("[%s] ", name))
+	}(pkg.ObjectMeta.Namespace, pkg.ObjectMeta.Name)
+}
+
+// printSummary prints the summary of a package whose build finished, once
+// its build logs are streamed.
+func (w *packageBuildWatcher) printSummary(ctx context.Context, pkg *fv1.Package) {
+	k := k8sCache.MetaObjectToName(&pkg.ObjectMeta).String()
+	stream, ok := w.streams[k]
+	if ok {
+		<-stream.done
+		delete(w.streams, k)
+	}
+	fmt.Printf("------\n")
+	if ok && stream.written > 0 {
+		pkgUtil.PrintPackageStatus(os.Stdout, pkg)
+	} else {
+		pkgUtil.LoadFullBuildLog(ctx, w.fclient, pkg)
+		pkgUtil.PrintPackageSummary(os.Stdout, pkg)
+	}
+	fmt.Printf("------\n")
+}
+
 func (w *packageBuildWatcher) addPackages(pkgMeta map[string]metav1.ObjectMeta) {
 	for k, v := range pkgMeta {
 		w.pkgMeta[k] = v
@@ -89,6 +143,9 @@ func (w *packageBuildWatcher) watch(ctx context.Context) {
 				pkg.Status.BuildStatus == fv1.BuildStatusRunning {
 				keepWaiting = true
 			}
+			if pkg.Status.BuildStatus == fv1.BuildStatusRunning && w.logClient != nil {
+				w.startBuildLogStream(ctx, &pkg)
+			}
 			buildpkgs = append(buildpkgs, pkg)
 		}
 
@@ -101,9 +158,7 @@ func (w *packageBuildWatcher) watch(ctx context.Context) {
 			if pkg.Status.BuildStatus == fv1.BuildStatusFailed ||
 				pkg.Status.BuildStatus == fv1.BuildStatusSucceeded {
 				w.finished[k] = true
-				fmt.Printf("------\n")
-				pkgUtil.PrintPackageSummary(os.Stdout, &pkg)
-				fmt.Printf("------\n")
+				w.printSummary(ctx, &pkg)
 			}
 			if pkg.Status.BuildStatus == fv1.BuildStatusFailed {
 				os.Exit(1)
@@ -356,6 +356,13 @@ func (fr *FissionResources) Validate(input cli.Input, client cmd.Client) ([]stri
 		result = multierror.Append(result, p.Validate())
 	}
 
+	for _, a := range fr.ArchiveUploadSpecs {
+		if _, err := utils.ParseArchiveFormat(a.Format); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%v: archive '%v': %w",
+				fr.SourceMap.Locations["ArchiveUploadSpec"][""][a.Name], a.Name, err))
+		}
+	}
+
 	// error on unreferenced archives
 	for name, referenced := range archives {
 		if !referenced {
@@ -677,7 +684,8 @@ func (fr *FissionResources) SpecExists(resource interface{}, compareMetadata boo
 			if compareSpec &&
 				!(reflect.DeepEqual(aus.RootDir, typedres.RootDir) &&
 					reflect.DeepEqual(aus.IncludeGlobs, typedres.IncludeGlobs) &&
-					reflect.DeepEqual(aus.ExcludeGlobs, typedres.ExcludeGlobs)) {
+					reflect.DeepEqual(aus.ExcludeGlobs, typedres.ExcludeGlobs) &&
+					aus.Format == typedres.Format) {
 				continue
 			}
 			return &aus
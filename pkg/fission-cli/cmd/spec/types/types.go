@@ -60,6 +60,11 @@ type (
 		// ExcludeGlobs is a list of globs to exclude from the set specified by
 		// IncludeGlobs.
 		ExcludeGlobs []string `json:"exclude,omitempty"`
+
+		// Format is the format of the archive: zip, tar, tar.gz or tar.zst.
+		// It is optional and defaults to zip, which doesn't keep file
+		// modes on all platforms.
+		Format string `json:"format,omitempty"`
 	}
 
 	// TypeMeta is the same as Kubernetes' TypeMeta, and allows us to version and
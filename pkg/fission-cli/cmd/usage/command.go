@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"github.com/spf13/cobra"
+
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+)
+
+func Commands() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "usage",
+		Short: "Show the platform usage of functions",
+		Long: `Show the pod-seconds, reserved CPU and memory, invocations and cold starts of functions, by namespace and function.
+The usage is accounted by the executor when usage accounting is enabled. It's kept in memory,
+so reports only cover the usage since the executor last restarted or changed leader.
+The usage metrics of the executor are the complete record of the usage.`,
+		RunE: wrapper.Wrapper(Usage),
+	}
+	wrapper.SetFlags(command, flag.FlagSet{
+		Optional: []flag.Flag{flag.AllNamespaces, flag.UsageSince, flag.UsageOutput},
+	})
+
+	return command
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type UsageSubCommand struct {
+	cmd.CommandActioner
+}
+
+func Usage(input cli.Input) error {
+	return (&UsageSubCommand{}).do(input)
+}
+
+func (opts *UsageSubCommand) do(input cli.Input) error {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.Namespace)
+	if err != nil {
+		return fmt.Errorf("error in finding usage: %w", err)
+	}
+	if input.Bool(flagkey.AllNamespaces) {
+		namespace = ""
+	}
+
+	output := input.String(flagkey.UsageOutput)
+	if output != "table" && output != "json" {
+		return fmt.Errorf("invalid output format '%s', must be one of 'table', 'json'", output)
+	}
+
+	executorURL, err := util.GetExecutorURL(input.Context(), opts.Client())
+	if err != nil {
+		return fmt.Errorf("error getting executor URL: %w", err)
+	}
+	report, err := client.MakeClient(zap.NewNop(), executorURL).GetUsage(input.Context(), client.UsageFilter{
+		Namespace: namespace,
+		Since:     time.Now().Add(-input.Duration(flagkey.UsageSince)),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting usage: %w", err)
+	}
+
+	if output == "json" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding output: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	return printReport(os.Stdout, report)
+}
+
+// printReport prints the usage of namespaces and functions, with pod, CPU and
+// memory usage in hours.
+func printReport(out io.Writer, report *client.UsageReport) error {
+	fmt.Fprintf(out, "Usage from %v to %v\n", report.Start.Format(time.RFC3339), report.End.Format(time.RFC3339))
+	if report.RecordedSince.After(report.Start) {
+		fmt.Fprintf(out, "The executor recorded the usage since %v only, after it restarted or changed leader\n",
+			report.RecordedSince.Format(time.RFC3339))
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAMESPACE", "FUNCTION", "POD-HOURS", "CPU-CORE-HOURS", "MEMORY-GIB-HOURS", "INVOCATIONS", "COLDSTARTS")
+	for _, ns := range report.Namespaces {
+		printUsage(w, ns.Namespace, "*", ns.Usage)
+		for _, fn := range report.Functions {
+			if fn.Namespace == ns.Namespace {
+				printUsage(w, fn.Namespace, fn.Function, fn.Usage)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+func printUsage(w io.Writer, namespace, function string, u client.Usage) {
+	fmt.Fprintf(w, "%v\t%v\t%.2f\t%.2f\t%.2f\t%v\t%v\n", namespace, function,
+		u.PodSeconds/3600, u.CPUCoreSeconds/3600, u.MemoryByteSeconds/(1<<30)/3600,
+		u.Invocations, u.ColdStarts)
+}
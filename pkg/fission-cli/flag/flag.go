@@ -100,6 +100,14 @@ var (
 	ReplicasMin = Flag{Type: Int, Name: flagkey.ReplicasMinscale, Usage: "Minimum number of pods (Uses resource inputs to configure HPA)", DefaultValue: 1}
 	ReplicasMax = Flag{Type: Int, Name: flagkey.ReplicasMaxscale, Usage: "Maximum number of pods (Uses resource inputs to configure HPA)", DefaultValue: 1}
 
+	ReplicasScalingMode       = Flag{Type: String, Name: flagkey.ReplicasScalingMode, Usage: "Scaling mode of newdeploy functions: cpu (HPA) or concurrency (in-flight requests per pod)"}
+	ReplicasTargetConcurrency = Flag{Type: Int, Name: flagkey.ReplicasTargetConcurrency, Usage: "Target in-flight requests per pod of functions scaling on concurrency", DefaultValue: 10}
+
+	UpdateStrategyType    = Flag{Type: String, Name: flagkey.UpdateStrategyType, Usage: "Rollout strategy of function updates for newdeploy and container functions: Rolling or BlueGreen"}
+	UpdateMaxSurge        = Flag{Type: String, Name: flagkey.UpdateMaxSurge, Usage: "Number or percentage of pods created above the desired replicas during a rolling update, e.g. 1 or 20%"}
+	UpdateMaxUnavailable  = Flag{Type: String, Name: flagkey.UpdateMaxUnavailable, Usage: "Number or percentage of pods which can be unavailable during a rolling update, e.g. 0 or 20%"}
+	UpdateRollbackTimeout = Flag{Type: Int, Name: flagkey.UpdateRollbackTimeout, Usage: "Seconds the new pods of an update have to become ready before the update is rolled back, 0 disables the rollback"}
+
 	FnName                  = Flag{Type: String, Name: flagkey.FnName, Usage: "Function name"}
 	FnSpecializationTimeout = Flag{Type: Int, Name: flagkey.FnSpecializationTimeout, Aliases: []string{"st"}, Usage: "Timeout for executor to wait for function pod creation", DefaultValue: fv1.DefaultSpecializationTimeOut}
 	FnEnvName               = Flag{Type: String, Name: flagkey.FnEnvironmentName, Usage: "Environment name for function"}
@@ -132,6 +140,14 @@ var (
 	FnSubPath               = Flag{Type: String, Name: flagkey.FnSubPath, Usage: "Sub Path to check if function internally supports routing"}
 	FnLogAllPods            = Flag{Type: Bool, Name: flagkey.FnLogAllPods, Usage: "Get all pod's logs in the function."}
 	FnRetainPods            = Flag{Type: Int, Name: flagkey.FnRetainPods, Usage: "Number of pods to retain after pods specialization.", DefaultValue: 0}
+	FnMaxPodLifetime        = Flag{Type: Int, Name: flagkey.FnMaxPodLifetime, Usage: "The length of time (in seconds) after which a specialized pod is replaced once its in-flight requests finish, 0 to disable (Only valid for executortype; `poolmgr`)", DefaultValue: 0}
+	FnMaxPodRequests        = Flag{Type: Int, Name: flagkey.FnMaxPodRequests, Usage: "Number of requests after which a specialized pod is replaced once its in-flight requests finish, 0 to disable (Only valid for executortype; `poolmgr`)", DefaultValue: 0}
+	FnPoolClass             = Flag{Type: String, Name: flagkey.FnPoolClass, Usage: "Name of the pool class of the environment to specialize pods from, empty for the default pool (Only valid for executortype; `poolmgr`)"}
+	FnConfigUpdatePolicy    = Flag{Type: String, Name: flagkey.FnConfigUpdatePolicy, Usage: "What happens to function pods when a referenced secret or configmap changes: Recycle replaces the pods, Reload updates the files in place and calls the reload endpoint of the environment (Not valid for executortype; `container`)"}
+	FnServicesEvict         = Flag{Type: String, Name: flagkey.FnServicesEvict, Usage: "Name of a specialized pod to remove from the executor cache and delete (Only valid for executortype; `poolmgr`)"}
+	FnServicesDrain         = Flag{Type: Bool, Name: flagkey.FnServicesDrain, Usage: "Stop assigning requests to the function's pods and delete them once in-flight requests finish (Only valid for executortype; `poolmgr`)"}
+	FnServicesDrainTimeout  = Flag{Type: Duration, Name: flagkey.FnServicesDrainTimeout, Usage: "Length of time to wait for in-flight requests when draining, busy pods are deleted afterwards", DefaultValue: 60 * time.Second}
+	FnServicesOutput        = Flag{Type: String, Name: flagkey.FnServicesOutput, Short: "o", Usage: "Output format; one of 'table', 'json'", DefaultValue: "table"}
 	// Termination Grace Period configurable at function creation/update only for container functions
 	FnTerminationGracePeriod = Flag{Type: Int64, Name: flagkey.FnGracePeriod, Usage: "Grace time (in seconds) for pod to perform connection draining before termination (only non-negative values considered)", DefaultValue: 360}
 
@@ -189,6 +205,8 @@ var (
 	EnvForce                  = Flag{Type: Bool, Name: flagkey.EnvForce, Short: "f", Usage: "Force delete env even if one or more functions exist", DefaultValue: false}
 	EnvBuilder                = Flag{Type: StringSlice, Name: flagkey.EnvBuilder, Usage: "Environment variable to be set in the builder container"}
 	EnvRuntime                = Flag{Type: StringSlice, Name: flagkey.EnvRuntime, Usage: "Environment variable to be set in the runtime container"}
+	EnvBuildTimeout           = Flag{Type: Int, Name: flagkey.EnvBuildTimeout, Usage: "Maximum time (in seconds) of a package build, 0 to use the default of buildermgr"}
+	EnvMaxParallelBuilds      = Flag{Type: Int, Name: flagkey.EnvMaxParallel, Usage: "Maximum number of package builds running at the same time, 0 to use the default of buildermgr"}
 
 	KwName      = Flag{Type: String, Name: flagkey.KwName, Usage: "Watch name"}
 	KwFnName    = Flag{Type: String, Name: flagkey.KwFnName, Usage: "Function name"}
@@ -197,9 +215,11 @@ var (
 	KwLabels    = Flag{Type: String, Name: flagkey.KwLabels, Usage: "Label selector of the form a=b,c=d"}
 
 	PkgName           = Flag{Type: String, Name: flagkey.PkgName, Usage: "Package name"}
+	PkgFollow         = Flag{Type: Bool, Name: flagkey.PkgFollow, Short: "f", Usage: "Stream the build logs of the package until its build finishes"}
 	PkgForce          = Flag{Type: Bool, Name: flagkey.PkgForce, Short: "f", Usage: "Force update a package even if it is used by one or more functions"}
 	PkgEnvironment    = Flag{Type: String, Name: flagkey.PkgEnvironment, Usage: "Environment name"}
 	PkgBuildCmd       = Flag{Type: String, Name: flagkey.PkgBuildCmd, Usage: "Build command for builder to run with"}
+	PkgBuildTimeout   = Flag{Type: Int, Name: flagkey.PkgBuildTimeout, Usage: "Maximum time (in seconds) of the package build, 0 to use the build timeout of the environment"}
 	PkgOutput         = Flag{Type: String, Name: flagkey.PkgOutput, Short: "o", Usage: "Output filename to save archive content"}
 	PkgStatus         = Flag{Type: String, Name: flagkey.PkgStatus, Usage: `Filter packages by status`}
 	PkgOrphan         = Flag{Type: Bool, Name: flagkey.PkgOrphan, Usage: "Orphan packages that are not referenced by any function"}
@@ -209,12 +229,20 @@ var (
 	PkgSrcArchive     = Flag{Type: StringSlice, Name: flagkey.PkgSrcArchive, Aliases: []string{"source", "src"}, Usage: "URL or local paths for source archive"}
 	PkgSrcChecksum    = Flag{Type: String, Name: flagkey.PkgSrcChecksum, Usage: "SHA256 checksum of source archive when providing URL"}
 	PkgInsecure       = Flag{Type: Bool, Name: flagkey.PkgInsecure, Usage: "Skip generating SHA256 checksum for file integrity validation"}
+	PkgArchiveFormat  = Flag{Type: String, Name: flagkey.PkgArchiveFormat, Usage: "Format of the archives made of local files: zip, tar, tar.gz or tar.zst; tar archives keep file modes on all platforms", DefaultValue: "zip"}
+	PkgPullSecret     = Flag{Type: String, Name: flagkey.PkgPullSecret, Usage: "Image pull secret with the registry credentials of OCI archives"}
+	PkgSrcGitRef      = Flag{Type: String, Name: flagkey.PkgSrcGitRef, Usage: "Branch, tag or commit of the git repository of the source, its default branch if empty"}
+	PkgSrcGitSubPath  = Flag{Type: String, Name: flagkey.PkgSrcGitSubPath, Usage: "Directory of the source in its git repository, its root if empty"}
+	PkgSrcGitSecret   = Flag{Type: String, Name: flagkey.PkgSrcGitSecret, Usage: "Secret with the credentials of the git repository of the source, a token or an SSH private key and known_hosts"}
+	PkgRef            = Flag{Type: String, Name: flagkey.PkgRef, Usage: "OCI reference to push the archive to, like oci://registry/repository:tag"}
+	PkgPushSource     = Flag{Type: Bool, Name: flagkey.PkgPushSource, Usage: "Push the source archive of the package instead of its deploy archive"}
+	PkgPlainHTTP      = Flag{Type: Bool, Name: flagkey.PkgPlainHTTP, Usage: "Reach the registry of OCI archives over plain HTTP instead of HTTPS"}
 
 	SpecSave             = Flag{Type: Bool, Name: flagkey.SpecSave, Usage: "Save to the spec directory instead of creating on cluster"}
 	SpecDir              = Flag{Type: String, Name: flagkey.SpecDir, Usage: "Directory to store specs, defaults to ./specs"}
 	SpecName             = Flag{Type: String, Name: flagkey.SpecName, Usage: "Name for the app, applied to resources as a Kubernetes annotation"}
 	SpecDeployID         = Flag{Type: String, Name: flagkey.SpecDeployID, Aliases: []string{"id"}, Usage: "Deployment ID for the spec deployment config"}
-	SpecWait             = Flag{Type: Bool, Name: flagkey.SpecWait, Usage: "Wait for package builds"}
+	SpecWait             = Flag{Type: Bool, Name: flagkey.SpecWait, Usage: "Wait for package builds, streaming their build logs"}
 	SpecWatch            = Flag{Type: Bool, Name: flagkey.SpecWatch, Usage: "Watch local files for change, and re-apply specs as necessary"}
 	SpecDelete           = Flag{Type: Bool, Name: flagkey.SpecDelete, Usage: "Allow apply to delete resources that no longer exist in the specification"}
 	SpecDry              = Flag{Type: Bool, Name: flagkey.SpecDry, Usage: "View the generated specs"}
@@ -237,4 +265,7 @@ var (
 	ArchiveName   = Flag{Type: String, Name: flagkey.ArchiveName, Usage: "Name of the archive file"}
 	ArchiveID     = Flag{Type: String, Name: flagkey.ArchiveID, Usage: "Id for the archive file"}
 	ArchiveOutput = Flag{Type: String, Name: flagkey.ArchiveOutput, Usage: "Download file with this name", Aliases: []string{"o"}, DefaultValue: ""}
+
+	UsageSince  = Flag{Type: Duration, Name: flagkey.UsageSince, Usage: "Report the usage of this length of time, rounded to whole hours and at most 7 days", DefaultValue: 24 * time.Hour}
+	UsageOutput = Flag{Type: String, Name: flagkey.UsageOutput, Short: "o", Usage: "Output format; one of 'table', 'json'", DefaultValue: "table"}
 )
@@ -54,6 +54,14 @@ const (
 	ReplicasMinscale = "minscale"
 	ReplicasMaxscale = "maxscale"
 
+	ReplicasScalingMode       = "scalingmode"
+	ReplicasTargetConcurrency = "targetconcurrency"
+
+	UpdateStrategyType    = "updatestrategy"
+	UpdateMaxSurge        = "maxsurge"
+	UpdateMaxUnavailable  = "maxunavailable"
+	UpdateRollbackTimeout = "rollbacktimeout"
+
 	FnName                  = resourceName
 	FnSpecializationTimeout = "specializationtimeout"
 	FnEnvironmentName       = "env"
@@ -87,6 +95,14 @@ const (
 	FnGracePeriod           = "graceperiod"
 	FnLogAllPods            = "all-pods"
 	FnRetainPods            = "retainpods"
+	FnMaxPodLifetime        = "maxpodlifetime"
+	FnMaxPodRequests        = "maxpodrequests"
+	FnPoolClass             = "poolclass"
+	FnConfigUpdatePolicy    = "configupdatepolicy"
+	FnServicesEvict         = "evict"
+	FnServicesDrain         = "drain"
+	FnServicesDrainTimeout  = "drain-timeout"
+	FnServicesOutput        = Output
 
 	HtName              = resourceName
 	HtMethod            = "method"
@@ -141,6 +157,8 @@ const (
 	EnvExecutorType    = "executortype"
 	EnvForce           = force
 	EnvBuilder         = "builder-env"
+	EnvBuildTimeout    = "buildtimeout"
+	EnvMaxParallel     = "maxparallelbuilds"
 	EnvRuntime         = "runtime-env"
 
 	KwName      = resourceName
@@ -150,6 +168,7 @@ const (
 	KwLabels    = "labels"
 
 	PkgName           = resourceName
+	PkgFollow         = "follow"
 	PkgForce          = force
 	PkgEnvironment    = "env"
 	PkgCode           = "code"
@@ -158,7 +177,16 @@ const (
 	PkgSrcChecksum    = "srcchecksum"
 	PkgDeployChecksum = "deploychecksum"
 	PkgInsecure       = "insecure"
+	PkgArchiveFormat  = "archiveformat"
+	PkgPullSecret     = "pullsecret"
+	PkgSrcGitRef      = "srcgitref"
+	PkgSrcGitSubPath  = "srcgitsubpath"
+	PkgSrcGitSecret   = "srcgitsecret"
+	PkgRef            = "ref"
+	PkgPushSource     = "pushsource"
+	PkgPlainHTTP      = "plainhttp"
 	PkgBuildCmd       = "buildcmd"
+	PkgBuildTimeout   = "buildtimeout"
 	PkgOutput         = Output
 	PkgStatus         = "status"
 	PkgOrphan         = "orphan"
@@ -191,5 +219,8 @@ const (
 	ArchiveID     = "id"
 	ArchiveOutput = Output
 
+	UsageSince  = "since"
+	UsageOutput = Output
+
 	DefaultSpecOutputDir = "fission-dump"
 )
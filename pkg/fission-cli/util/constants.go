@@ -26,6 +26,7 @@ const (
 	FISSION_DEFAULT_NAMESPACE = "fission"
 	SOURCE_ARCHIVE            = "source"
 	DEPLOY_ARCHIVE            = "deploy"
+	SBOM_ARCHIVE              = "sbom"
 )
 
 const (
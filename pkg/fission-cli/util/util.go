@@ -467,6 +467,34 @@ func GetStorageURL(ctx context.Context, client cmd.Client) (*url.URL, error) {
 	return serverURL, nil
 }
 
+// GetExecutorURL returns the executor URL, port-forwarding to an executor
+// pod unless FISSION_EXECUTOR_URL is set.
+func GetExecutorURL(ctx context.Context, client cmd.Client) (string, error) {
+	executorURL := os.Getenv("FISSION_EXECUTOR_URL")
+	if len(executorURL) > 0 {
+		return executorURL, nil
+	}
+	executorLocalPort, err := SetupPortForward(ctx, client, GetFissionNamespace(), "svc=executor")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s", localhostURL, executorLocalPort), nil
+}
+
+// GetBuilderMgrURL returns the buildermgr URL, port-forwarding to the
+// buildermgr pod unless FISSION_BUILDERMGR_URL is set.
+func GetBuilderMgrURL(ctx context.Context, client cmd.Client) (string, error) {
+	builderMgrURL := os.Getenv("FISSION_BUILDERMGR_URL")
+	if len(builderMgrURL) > 0 {
+		return builderMgrURL, nil
+	}
+	builderMgrLocalPort, err := SetupPortForward(ctx, client, GetFissionNamespace(), "svc=buildermgr")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s", localhostURL, builderMgrLocalPort), nil
+}
+
 // CheckHTTPTriggerDuplicates checks whether the tuple (Method, Host, URL) is duplicate or not.
 func CheckHTTPTriggerDuplicates(ctx context.Context, client cmd.Client, t *fv1.HTTPTrigger) error {
 	triggers, err := client.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
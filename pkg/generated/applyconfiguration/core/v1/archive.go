@@ -25,10 +25,12 @@ import (
 // ArchiveApplyConfiguration represents a declarative configuration of the Archive type for use
 // with apply.
 type ArchiveApplyConfiguration struct {
-	Type     *corev1.ArchiveType         `json:"type,omitempty"`
-	Literal  []byte                      `json:"literal,omitempty"`
-	URL      *string                     `json:"url,omitempty"`
-	Checksum *ChecksumApplyConfiguration `json:"checksum,omitempty"`
+	Type       *corev1.ArchiveType          `json:"type,omitempty"`
+	Literal    []byte                       `json:"literal,omitempty"`
+	URL        *string                      `json:"url,omitempty"`
+	Checksum   *ChecksumApplyConfiguration  `json:"checksum,omitempty"`
+	PullSecret *string                      `json:"pullSecret,omitempty"`
+	Git        *GitSourceApplyConfiguration `json:"git,omitempty"`
 }
 
 // ArchiveApplyConfiguration constructs a declarative configuration of the Archive type for use with
@@ -70,3 +72,19 @@ func (b *ArchiveApplyConfiguration) WithChecksum(value *ChecksumApplyConfigurati
 	b.Checksum = value
 	return b
 }
+
+// WithPullSecret sets the PullSecret field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullSecret field is set to the value of the last call.
+func (b *ArchiveApplyConfiguration) WithPullSecret(value string) *ArchiveApplyConfiguration {
+	b.PullSecret = &value
+	return b
+}
+
+// WithGit sets the Git field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Git field is set to the value of the last call.
+func (b *ArchiveApplyConfiguration) WithGit(value *GitSourceApplyConfiguration) *ArchiveApplyConfiguration {
+	b.Git = value
+	return b
+}
@@ -25,10 +25,13 @@ import (
 // BuilderApplyConfiguration represents a declarative configuration of the Builder type for use
 // with apply.
 type BuilderApplyConfiguration struct {
-	Image     *string           `json:"image,omitempty"`
-	Command   *string           `json:"command,omitempty"`
-	Container *corev1.Container `json:"container,omitempty"`
-	PodSpec   *corev1.PodSpec   `json:"podspec,omitempty"`
+	Image             *string                                   `json:"image,omitempty"`
+	Command           *string                                   `json:"command,omitempty"`
+	Container         *corev1.Container                         `json:"container,omitempty"`
+	PodSpec           *corev1.PodSpec                           `json:"podspec,omitempty"`
+	DependencyCache   *BuilderDependencyCacheApplyConfiguration `json:"dependencyCache,omitempty"`
+	BuildTimeout      *int                                      `json:"buildTimeout,omitempty"`
+	MaxParallelBuilds *int                                      `json:"maxParallelBuilds,omitempty"`
 }
 
 // BuilderApplyConfiguration constructs a declarative configuration of the Builder type for use with
@@ -68,3 +71,27 @@ func (b *BuilderApplyConfiguration) WithPodSpec(value corev1.PodSpec) *BuilderAp
 	b.PodSpec = &value
 	return b
 }
+
+// WithDependencyCache sets the DependencyCache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DependencyCache field is set to the value of the last call.
+func (b *BuilderApplyConfiguration) WithDependencyCache(value *BuilderDependencyCacheApplyConfiguration) *BuilderApplyConfiguration {
+	b.DependencyCache = value
+	return b
+}
+
+// WithBuildTimeout sets the BuildTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildTimeout field is set to the value of the last call.
+func (b *BuilderApplyConfiguration) WithBuildTimeout(value int) *BuilderApplyConfiguration {
+	b.BuildTimeout = &value
+	return b
+}
+
+// WithMaxParallelBuilds sets the MaxParallelBuilds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxParallelBuilds field is set to the value of the last call.
+func (b *BuilderApplyConfiguration) WithMaxParallelBuilds(value int) *BuilderApplyConfiguration {
+	b.MaxParallelBuilds = &value
+	return b
+}
@@ -21,8 +21,9 @@ package v1
 // ConfigMapReferenceApplyConfiguration represents a declarative configuration of the ConfigMapReference type for use
 // with apply.
 type ConfigMapReferenceApplyConfiguration struct {
-	Namespace *string `json:"namespace,omitempty"`
-	Name      *string `json:"name,omitempty"`
+	Namespace *string                          `json:"namespace,omitempty"`
+	Name      *string                          `json:"name,omitempty"`
+	Env       *EnvProjectionApplyConfiguration `json:"env,omitempty"`
 }
 
 // ConfigMapReferenceApplyConfiguration constructs a declarative configuration of the ConfigMapReference type for use with
@@ -46,3 +47,11 @@ func (b *ConfigMapReferenceApplyConfiguration) WithName(value string) *ConfigMap
 	b.Name = &value
 	return b
 }
+
+// WithEnv sets the Env field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Env field is set to the value of the last call.
+func (b *ConfigMapReferenceApplyConfiguration) WithEnv(value *EnvProjectionApplyConfiguration) *ConfigMapReferenceApplyConfiguration {
+	b.Env = value
+	return b
+}
@@ -33,6 +33,7 @@ type EnvironmentSpecApplyConfiguration struct {
 	AllowAccessToExternalNetwork *bool                                `json:"allowAccessToExternalNetwork,omitempty"`
 	Resources                    *apicorev1.ResourceRequirements      `json:"resources,omitempty"`
 	Poolsize                     *int                                 `json:"poolsize,omitempty"`
+	PoolClasses                  []PoolClassApplyConfiguration        `json:"poolClasses,omitempty"`
 	TerminationGracePeriod       *int64                               `json:"terminationGracePeriod,omitempty"`
 	KeepArchive                  *bool                                `json:"keeparchive,omitempty"`
 	ImagePullSecret              *string                              `json:"imagepullsecret,omitempty"`
@@ -100,6 +101,19 @@ func (b *EnvironmentSpecApplyConfiguration) WithPoolsize(value int) *Environment
 	return b
 }
 
+// WithPoolClasses adds the given value to the PoolClasses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PoolClasses field.
+func (b *EnvironmentSpecApplyConfiguration) WithPoolClasses(values ...*PoolClassApplyConfiguration) *EnvironmentSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPoolClasses")
+		}
+		b.PoolClasses = append(b.PoolClasses, *values[i])
+	}
+	return b
+}
+
 // WithTerminationGracePeriod sets the TerminationGracePeriod field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the TerminationGracePeriod field is set to the value of the last call.
@@ -26,13 +26,17 @@ import (
 // ExecutionStrategyApplyConfiguration represents a declarative configuration of the ExecutionStrategy type for use
 // with apply.
 type ExecutionStrategyApplyConfiguration struct {
-	ExecutorType          *corev1.ExecutorType                `json:"ExecutorType,omitempty"`
-	MinScale              *int                                `json:"MinScale,omitempty"`
-	MaxScale              *int                                `json:"MaxScale,omitempty"`
-	TargetCPUPercent      *int                                `json:"TargetCPUPercent,omitempty"`
-	SpecializationTimeout *int                                `json:"SpecializationTimeout,omitempty"`
-	Metrics               []v2.MetricSpec                     `json:"hpaMetrics,omitempty"`
-	Behavior              *v2.HorizontalPodAutoscalerBehavior `json:"hpaBehavior,omitempty"`
+	ExecutorType          *corev1.ExecutorType                  `json:"ExecutorType,omitempty"`
+	MinScale              *int                                  `json:"MinScale,omitempty"`
+	MaxScale              *int                                  `json:"MaxScale,omitempty"`
+	TargetCPUPercent      *int                                  `json:"TargetCPUPercent,omitempty"`
+	SpecializationTimeout *int                                  `json:"SpecializationTimeout,omitempty"`
+	Metrics               []v2.MetricSpec                       `json:"hpaMetrics,omitempty"`
+	Behavior              *v2.HorizontalPodAutoscalerBehavior   `json:"hpaBehavior,omitempty"`
+	ScalingMode           *corev1.ScalingMode                   `json:"scalingMode,omitempty"`
+	ConcurrencyScaling    *ConcurrencyScalingApplyConfiguration `json:"concurrencyScaling,omitempty"`
+	UpdateStrategy        *UpdateStrategyApplyConfiguration     `json:"updateStrategy,omitempty"`
+	ScalingSchedules      []ScalingScheduleApplyConfiguration   `json:"scalingSchedules,omitempty"`
 }
 
 // ExecutionStrategyApplyConfiguration constructs a declarative configuration of the ExecutionStrategy type for use with
@@ -98,3 +102,40 @@ func (b *ExecutionStrategyApplyConfiguration) WithBehavior(value v2.HorizontalPo
 	b.Behavior = &value
 	return b
 }
+
+// WithScalingMode sets the ScalingMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScalingMode field is set to the value of the last call.
+func (b *ExecutionStrategyApplyConfiguration) WithScalingMode(value corev1.ScalingMode) *ExecutionStrategyApplyConfiguration {
+	b.ScalingMode = &value
+	return b
+}
+
+// WithConcurrencyScaling sets the ConcurrencyScaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConcurrencyScaling field is set to the value of the last call.
+func (b *ExecutionStrategyApplyConfiguration) WithConcurrencyScaling(value *ConcurrencyScalingApplyConfiguration) *ExecutionStrategyApplyConfiguration {
+	b.ConcurrencyScaling = value
+	return b
+}
+
+// WithUpdateStrategy sets the UpdateStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateStrategy field is set to the value of the last call.
+func (b *ExecutionStrategyApplyConfiguration) WithUpdateStrategy(value *UpdateStrategyApplyConfiguration) *ExecutionStrategyApplyConfiguration {
+	b.UpdateStrategy = value
+	return b
+}
+
+// WithScalingSchedules adds the given value to the ScalingSchedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ScalingSchedules field.
+func (b *ExecutionStrategyApplyConfiguration) WithScalingSchedules(values ...*ScalingScheduleApplyConfiguration) *ExecutionStrategyApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithScalingSchedules")
+		}
+		b.ScalingSchedules = append(b.ScalingSchedules, *values[i])
+	}
+	return b
+}
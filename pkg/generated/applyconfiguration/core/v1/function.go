@@ -29,7 +29,8 @@ import (
 type FunctionApplyConfiguration struct {
 	metav1.TypeMetaApplyConfiguration    `json:",inline"`
 	*metav1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                                 *FunctionSpecApplyConfiguration `json:"spec,omitempty"`
+	Spec                                 *FunctionSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                               *FunctionStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // Function constructs a declarative configuration of the Function type for use with
@@ -209,6 +210,14 @@ func (b *FunctionApplyConfiguration) WithSpec(value *FunctionSpecApplyConfigurat
 	return b
 }
 
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *FunctionApplyConfiguration) WithStatus(value *FunctionStatusApplyConfiguration) *FunctionApplyConfiguration {
+	b.Status = value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *FunctionApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
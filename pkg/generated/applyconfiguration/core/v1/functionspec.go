@@ -19,25 +19,31 @@ limitations under the License.
 package v1
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	corev1 "github.com/fission/fission/pkg/apis/core/v1"
+	apicorev1 "k8s.io/api/core/v1"
 )
 
 // FunctionSpecApplyConfiguration represents a declarative configuration of the FunctionSpec type for use
 // with apply.
 type FunctionSpecApplyConfiguration struct {
-	Environment     *EnvironmentReferenceApplyConfiguration `json:"environment,omitempty"`
-	Package         *FunctionPackageRefApplyConfiguration   `json:"package,omitempty"`
-	Secrets         []SecretReferenceApplyConfiguration     `json:"secrets,omitempty"`
-	ConfigMaps      []ConfigMapReferenceApplyConfiguration  `json:"configmaps,omitempty"`
-	Resources       *corev1.ResourceRequirements            `json:"resources,omitempty"`
-	InvokeStrategy  *InvokeStrategyApplyConfiguration       `json:"InvokeStrategy,omitempty"`
-	FunctionTimeout *int                                    `json:"functionTimeout,omitempty"`
-	IdleTimeout     *int                                    `json:"idletimeout,omitempty"`
-	Concurrency     *int                                    `json:"concurrency,omitempty"`
-	RequestsPerPod  *int                                    `json:"requestsPerPod,omitempty"`
-	OnceOnly        *bool                                   `json:"onceOnly,omitempty"`
-	RetainPods      *int                                    `json:"retainPods,omitempty"`
-	PodSpec         *corev1.PodSpec                         `json:"podspec,omitempty"`
+	Environment        *EnvironmentReferenceApplyConfiguration `json:"environment,omitempty"`
+	Package            *FunctionPackageRefApplyConfiguration   `json:"package,omitempty"`
+	Secrets            []SecretReferenceApplyConfiguration     `json:"secrets,omitempty"`
+	ConfigMaps         []ConfigMapReferenceApplyConfiguration  `json:"configmaps,omitempty"`
+	ConfigUpdatePolicy *corev1.ConfigUpdatePolicy              `json:"configUpdatePolicy,omitempty"`
+	Resources          *apicorev1.ResourceRequirements         `json:"resources,omitempty"`
+	InvokeStrategy     *InvokeStrategyApplyConfiguration       `json:"InvokeStrategy,omitempty"`
+	FunctionTimeout    *int                                    `json:"functionTimeout,omitempty"`
+	IdleTimeout        *int                                    `json:"idletimeout,omitempty"`
+	IdleReapPolicy     *IdleReapPolicyApplyConfiguration       `json:"idleReapPolicy,omitempty"`
+	Concurrency        *int                                    `json:"concurrency,omitempty"`
+	RequestsPerPod     *int                                    `json:"requestsPerPod,omitempty"`
+	OnceOnly           *bool                                   `json:"onceOnly,omitempty"`
+	RetainPods         *int                                    `json:"retainPods,omitempty"`
+	MaxPodLifetime     *int                                    `json:"maxPodLifetime,omitempty"`
+	MaxPodRequests     *int                                    `json:"maxPodRequests,omitempty"`
+	PoolClass          *string                                 `json:"poolClass,omitempty"`
+	PodSpec            *apicorev1.PodSpec                      `json:"podspec,omitempty"`
 }
 
 // FunctionSpecApplyConfiguration constructs a declarative configuration of the FunctionSpec type for use with
@@ -88,10 +94,18 @@ func (b *FunctionSpecApplyConfiguration) WithConfigMaps(values ...*ConfigMapRefe
 	return b
 }
 
+// WithConfigUpdatePolicy sets the ConfigUpdatePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigUpdatePolicy field is set to the value of the last call.
+func (b *FunctionSpecApplyConfiguration) WithConfigUpdatePolicy(value corev1.ConfigUpdatePolicy) *FunctionSpecApplyConfiguration {
+	b.ConfigUpdatePolicy = &value
+	return b
+}
+
 // WithResources sets the Resources field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Resources field is set to the value of the last call.
-func (b *FunctionSpecApplyConfiguration) WithResources(value corev1.ResourceRequirements) *FunctionSpecApplyConfiguration {
+func (b *FunctionSpecApplyConfiguration) WithResources(value apicorev1.ResourceRequirements) *FunctionSpecApplyConfiguration {
 	b.Resources = &value
 	return b
 }
@@ -120,6 +134,14 @@ func (b *FunctionSpecApplyConfiguration) WithIdleTimeout(value int) *FunctionSpe
 	return b
 }
 
+// WithIdleReapPolicy sets the IdleReapPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleReapPolicy field is set to the value of the last call.
+func (b *FunctionSpecApplyConfiguration) WithIdleReapPolicy(value *IdleReapPolicyApplyConfiguration) *FunctionSpecApplyConfiguration {
+	b.IdleReapPolicy = value
+	return b
+}
+
 // WithConcurrency sets the Concurrency field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Concurrency field is set to the value of the last call.
@@ -152,10 +174,34 @@ func (b *FunctionSpecApplyConfiguration) WithRetainPods(value int) *FunctionSpec
 	return b
 }
 
+// WithMaxPodLifetime sets the MaxPodLifetime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxPodLifetime field is set to the value of the last call.
+func (b *FunctionSpecApplyConfiguration) WithMaxPodLifetime(value int) *FunctionSpecApplyConfiguration {
+	b.MaxPodLifetime = &value
+	return b
+}
+
+// WithMaxPodRequests sets the MaxPodRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxPodRequests field is set to the value of the last call.
+func (b *FunctionSpecApplyConfiguration) WithMaxPodRequests(value int) *FunctionSpecApplyConfiguration {
+	b.MaxPodRequests = &value
+	return b
+}
+
+// WithPoolClass sets the PoolClass field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PoolClass field is set to the value of the last call.
+func (b *FunctionSpecApplyConfiguration) WithPoolClass(value string) *FunctionSpecApplyConfiguration {
+	b.PoolClass = &value
+	return b
+}
+
 // WithPodSpec sets the PodSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PodSpec field is set to the value of the last call.
-func (b *FunctionSpecApplyConfiguration) WithPodSpec(value corev1.PodSpec) *FunctionSpecApplyConfiguration {
+func (b *FunctionSpecApplyConfiguration) WithPodSpec(value apicorev1.PodSpec) *FunctionSpecApplyConfiguration {
 	b.PodSpec = &value
 	return b
 }
@@ -25,6 +25,7 @@ type PackageSpecApplyConfiguration struct {
 	Source       *ArchiveApplyConfiguration              `json:"source,omitempty"`
 	Deployment   *ArchiveApplyConfiguration              `json:"deployment,omitempty"`
 	BuildCommand *string                                 `json:"buildcmd,omitempty"`
+	BuildTimeout *int                                    `json:"buildTimeout,omitempty"`
 }
 
 // PackageSpecApplyConfiguration constructs a declarative configuration of the PackageSpec type for use with
@@ -64,3 +65,11 @@ func (b *PackageSpecApplyConfiguration) WithBuildCommand(value string) *PackageS
 	b.BuildCommand = &value
 	return b
 }
+
+// WithBuildTimeout sets the BuildTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildTimeout field is set to the value of the last call.
+func (b *PackageSpecApplyConfiguration) WithBuildTimeout(value int) *PackageSpecApplyConfiguration {
+	b.BuildTimeout = &value
+	return b
+}
@@ -26,9 +26,13 @@ import (
 // PackageStatusApplyConfiguration represents a declarative configuration of the PackageStatus type for use
 // with apply.
 type PackageStatusApplyConfiguration struct {
-	BuildStatus         *corev1.BuildStatus `json:"buildstatus,omitempty"`
-	BuildLog            *string             `json:"buildlog,omitempty"`
-	LastUpdateTimestamp *metav1.Time        `json:"lastUpdateTimestamp,omitempty"`
+	BuildStatus         *corev1.BuildStatus                `json:"buildstatus,omitempty"`
+	BuildLog            *string                            `json:"buildlog,omitempty"`
+	BuildLogArchiveID   *string                            `json:"buildlogArchiveID,omitempty"`
+	BuildCacheKey       *string                            `json:"buildCacheKey,omitempty"`
+	QueuePosition       *int                               `json:"queuePosition,omitempty"`
+	Provenance          *BuildProvenanceApplyConfiguration `json:"provenance,omitempty"`
+	LastUpdateTimestamp *metav1.Time                       `json:"lastUpdateTimestamp,omitempty"`
 }
 
 // PackageStatusApplyConfiguration constructs a declarative configuration of the PackageStatus type for use with
@@ -53,6 +57,38 @@ func (b *PackageStatusApplyConfiguration) WithBuildLog(value string) *PackageSta
 	return b
 }
 
+// WithBuildLogArchiveID sets the BuildLogArchiveID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildLogArchiveID field is set to the value of the last call.
+func (b *PackageStatusApplyConfiguration) WithBuildLogArchiveID(value string) *PackageStatusApplyConfiguration {
+	b.BuildLogArchiveID = &value
+	return b
+}
+
+// WithBuildCacheKey sets the BuildCacheKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildCacheKey field is set to the value of the last call.
+func (b *PackageStatusApplyConfiguration) WithBuildCacheKey(value string) *PackageStatusApplyConfiguration {
+	b.BuildCacheKey = &value
+	return b
+}
+
+// WithQueuePosition sets the QueuePosition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QueuePosition field is set to the value of the last call.
+func (b *PackageStatusApplyConfiguration) WithQueuePosition(value int) *PackageStatusApplyConfiguration {
+	b.QueuePosition = &value
+	return b
+}
+
+// WithProvenance sets the Provenance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provenance field is set to the value of the last call.
+func (b *PackageStatusApplyConfiguration) WithProvenance(value *BuildProvenanceApplyConfiguration) *PackageStatusApplyConfiguration {
+	b.Provenance = value
+	return b
+}
+
 // WithLastUpdateTimestamp sets the LastUpdateTimestamp field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LastUpdateTimestamp field is set to the value of the last call.
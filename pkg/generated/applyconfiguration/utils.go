@@ -34,8 +34,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=fission.io, Version=v1
 	case v1.SchemeGroupVersion.WithKind("Archive"):
 		return &corev1.ArchiveApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BuildProvenance"):
+		return &corev1.BuildProvenanceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Builder"):
 		return &corev1.BuilderApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BuilderDependencyCache"):
+		return &corev1.BuilderDependencyCacheApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CanaryConfig"):
 		return &corev1.CanaryConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CanaryConfigSpec"):
@@ -44,8 +48,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1.CanaryConfigStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Checksum"):
 		return &corev1.ChecksumApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConcurrencyScaling"):
+		return &corev1.ConcurrencyScalingApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ConfigMapReference"):
 		return &corev1.ConfigMapReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EnvKeyMapping"):
+		return &corev1.EnvKeyMappingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EnvProjection"):
+		return &corev1.EnvProjectionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Environment"):
 		return &corev1.EnvironmentApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EnvironmentReference"):
@@ -62,14 +72,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1.FunctionReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FunctionSpec"):
 		return &corev1.FunctionSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("FunctionStatus"):
+		return &corev1.FunctionStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GitSource"):
+		return &corev1.GitSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HTTPTrigger"):
 		return &corev1.HTTPTriggerApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HTTPTriggerSpec"):
 		return &corev1.HTTPTriggerSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("IdleReapPolicy"):
+		return &corev1.IdleReapPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("IngressConfig"):
 		return &corev1.IngressConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("InvokeStrategy"):
 		return &corev1.InvokeStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("KeepAliveWindow"):
+		return &corev1.KeepAliveWindowApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KubernetesWatchTrigger"):
 		return &corev1.KubernetesWatchTriggerApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KubernetesWatchTriggerSpec"):
@@ -86,14 +104,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1.PackageSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PackageStatus"):
 		return &corev1.PackageStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PoolClass"):
+		return &corev1.PoolClassApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RolloutStatus"):
+		return &corev1.RolloutStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Runtime"):
 		return &corev1.RuntimeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ScalingSchedule"):
+		return &corev1.ScalingScheduleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SecretReference"):
 		return &corev1.SecretReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TimeTrigger"):
 		return &corev1.TimeTriggerApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TimeTriggerSpec"):
 		return &corev1.TimeTriggerSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("UpdateStrategy"):
+		return &corev1.UpdateStrategyApplyConfiguration{}
 
 	}
 	return nil
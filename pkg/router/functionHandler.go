@@ -225,7 +225,7 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 				// We might want a specific error code or header for fission failures as opposed to
 				// user function bugs.
 				statusCode, errMsg := ferror.GetHTTPError(err)
-				if statusCode == http.StatusTooManyRequests {
+				if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
 					return nil, err
 				}
 				if roundTripper.funcHandler.isDebugEnv {
@@ -436,6 +436,25 @@ func (fh *functionHandler) tapService(fn *fv1.Function, serviceURL *url.URL) {
 	fh.executor.TapService(fn.ObjectMeta, fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType, *serviceURL)
 }
 
+// trackRequest counts the request as in-flight for functions scaling on
+// concurrency, until the returned function is called.
+func (fh *functionHandler) trackRequest(fn *fv1.Function) func() {
+	if fh.executor == nil ||
+		fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy ||
+		fn.Spec.InvokeStrategy.ExecutionStrategy.ScalingMode != fv1.ScalingModeConcurrency {
+		return func() {}
+	}
+	return fh.executor.TrackRequest(fn.ObjectMeta, fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType)
+}
+
+// countInvocation counts the request for usage accounting.
+func (fh *functionHandler) countInvocation(fn *fv1.Function) {
+	if fh.executor == nil {
+		return
+	}
+	fh.executor.CountInvocation(fn.ObjectMeta, fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType)
+}
+
 func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *http.Request) {
 	if fh.httpTrigger != nil && fh.httpTrigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights {
 		// canary deployment. need to determine the function to send request to now
@@ -500,6 +519,12 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		rrt.closeContext()
 	}()
 
+	// the request is in-flight while waiting for a function service as well,
+	// so that functions scaled to zero are scaled up while the router holds it
+	done := fh.trackRequest(fh.function)
+	defer done()
+	fh.countInvocation(fh.function)
+
 	otelUtils.SpanTrackEvent(request.Context(), "functionRequestProxy", otelUtils.GetAttributesForFunction(fh.function)...)
 	proxy.ServeHTTP(responseWriter, request)
 }
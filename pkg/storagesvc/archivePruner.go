@@ -121,6 +121,21 @@ func (pruner *ArchivePruner) getOrphanArchives(ctx context.Context) {
 				}
 				archivesRefByPkgs = append(archivesRefByPkgs, archiveID)
 			}
+			// full build logs are stored as archives too
+			if pkg.Status.BuildLogArchiveID != "" {
+				archivesRefByPkgs = append(archivesRefByPkgs, pkg.Status.BuildLogArchiveID)
+			}
+			// so are the SBOMs of the builds
+			if pkg.Status.Provenance != nil && pkg.Status.Provenance.SBOM != nil && pkg.Status.Provenance.SBOM.URL != "" {
+				archiveID, err = getQueryParamValue(pkg.Status.Provenance.SBOM.URL, "id")
+				if err != nil {
+					pruner.logger.Error("error extracting value of archiveID from SBOM url",
+						zap.Error(err),
+						zap.String("url", pkg.Status.Provenance.SBOM.URL))
+					return
+				}
+				archivesRefByPkgs = append(archivesRefByPkgs, archiveID)
+			}
 		}
 	}
 
@@ -43,6 +43,7 @@ type (
 		List(ctx context.Context) ([]string, error)
 		Download(ctx context.Context, id string, filePath string) error
 		GetFile(ctx context.Context, id string) (*http.Response, error)
+		Exists(ctx context.Context, id string) (bool, error)
 		Delete(ctx context.Context, id string) error
 	}
 	client struct {
@@ -209,6 +210,30 @@ func (c *client) GetFile(ctx context.Context, id string) (resp *http.Response, e
 	return resp, err
 }
 
+// Exists checks whether the file identified by ID exists, without
+// downloading it.
+func (c *client) Exists(ctx context.Context, id string) (bool, error) {
+	req, err := http.NewRequest(http.MethodHead, c.GetUrl(id), nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("HTTP error %v", resp.StatusCode)
+	}
+}
+
 func (c *client) Delete(ctx context.Context, id string) error {
 	url := c.GetUrl(id)
 
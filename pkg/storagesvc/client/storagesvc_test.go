@@ -248,6 +248,12 @@ func TestLocalStorageService(t *testing.T) {
 		t.Fatalf("Expected 1 file, got %v", len(ids))
 	}
 
+	exists, err := client.Exists(ctx, fileID)
+	failTest(t, err)
+	if !exists {
+		t.Fatalf("Expected file %v to exist", fileID)
+	}
+
 	// make a temp file for verification
 	retrievedfile, err := os.CreateTemp("", "storagesvc_verify_")
 	failTest(t, err)
@@ -276,6 +282,11 @@ func TestLocalStorageService(t *testing.T) {
 	if err == nil {
 		log.Panic("Download succeeded but file isn't supposed to exist")
 	}
+	exists, err = client.Exists(ctx, fileID)
+	failTest(t, err)
+	if exists {
+		t.Fatalf("Expected file %v to be deleted", fileID)
+	}
 
 	// // cleanup /tmp
 	os.RemoveAll(fmt.Sprintf("/tmp/%v", testID))
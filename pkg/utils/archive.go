@@ -0,0 +1,420 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mholt/archives"
+)
+
+// ArchiveFormat is the format of package archives. Unlike zip archives,
+// tar archives keep the file modes of all platforms.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip    ArchiveFormat = "zip"
+	ArchiveFormatTar    ArchiveFormat = "tar"
+	ArchiveFormatTarGz  ArchiveFormat = "tar.gz"
+	ArchiveFormatTarZst ArchiveFormat = "tar.zst"
+)
+
+// ArchiveFormats are the supported archive formats.
+var ArchiveFormats = []ArchiveFormat{ArchiveFormatZip, ArchiveFormatTar, ArchiveFormatTarGz, ArchiveFormatTarZst}
+
+// ParseArchiveFormat returns the archive format of the name, zip if empty.
+func ParseArchiveFormat(name string) (ArchiveFormat, error) {
+	if len(name) == 0 {
+		return ArchiveFormatZip, nil
+	}
+	for _, format := range ArchiveFormats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported archive format %q, supported formats are %v", name, ArchiveFormats)
+}
+
+// Extension returns the file name extension of the format.
+func (format ArchiveFormat) Extension() string {
+	return "." + string(format)
+}
+
+func (format ArchiveFormat) archiver() archives.CompressedArchive {
+	switch format {
+	case ArchiveFormatTar:
+		return archives.CompressedArchive{Archival: archives.Tar{}, Extraction: archives.Tar{}}
+	case ArchiveFormatTarGz:
+		return archives.CompressedArchive{Archival: archives.Tar{}, Extraction: archives.Tar{}, Compression: archives.Gz{}}
+	case ArchiveFormatTarZst:
+		return archives.CompressedArchive{Archival: archives.Tar{}, Extraction: archives.Tar{}, Compression: archives.Zstd{}}
+	default:
+		return archives.CompressedArchive{Archival: archives.Zip{}, Extraction: archives.Zip{}}
+	}
+}
+
+// IsArchive checks whether the file is an archive of a supported format.
+func IsArchive(ctx context.Context, filename string) (bool, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return false, nil
+	}
+	defer f.Close()
+	format, err := identifyArchive(ctx, filename, f)
+	if err != nil {
+		return false, err
+	}
+	return len(format) > 0, nil
+}
+
+// identifyArchive returns the format of the archive, or an empty format if
+// it's not an archive of a supported format.
+func identifyArchive(ctx context.Context, filename string, f *os.File) (ArchiveFormat, error) {
+	format, _, err := archives.Identify(ctx, filename, f)
+	if errors.Is(err, archives.NoMatch) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	switch format := format.(type) {
+	case archives.Zip:
+		return ArchiveFormatZip, nil
+	case archives.Tar:
+		return ArchiveFormatTar, nil
+	case archives.CompressedArchive:
+		if _, ok := format.Extraction.(archives.Tar); !ok {
+			return "", nil
+		}
+		switch format.Compression.(type) {
+		case archives.Gz:
+			return ArchiveFormatTarGz, nil
+		case archives.Zstd:
+			return ArchiveFormatTarZst, nil
+		}
+	}
+	return "", nil
+}
+
+// MakeArchiveWithGlobs archives the files matching the globs into a new
+// archive of the format at targetName, keeping their modes, and returns its
+// absolute path. Symbolic links are archived as links.
+func MakeArchiveWithGlobs(ctx context.Context, targetName string, format ArchiveFormat, globs ...string) (string, error) {
+	globFiles, err := FindAllGlobs(globs...)
+	if err != nil {
+		return "", err
+	}
+	if len(globFiles) == 0 {
+		return "", fmt.Errorf("no files found for globs: %v", globs)
+	}
+	files := make(map[string]string, len(globFiles))
+	for _, file := range globFiles {
+		files[file] = ""
+	}
+
+	archiveFiles, err := archives.FilesFromDisk(ctx, nil, files)
+	if err != nil {
+		return "", fmt.Errorf("failed to read files from disk: %w", err)
+	}
+	if format == ArchiveFormatZip {
+		zipSymlinks(archiveFiles)
+	}
+	out, err := os.Create(targetName)
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer out.Close()
+	if err := format.archiver().Archive(ctx, out, archiveFiles); err != nil {
+		return "", fmt.Errorf("failed to create archive: %w", err)
+	}
+	return filepath.Abs(targetName)
+}
+
+// zipSymlinks makes the symbolic links of the files archived as links in zip
+// archives, which have the targets of links as contents, instead of the files
+// they point to.
+func zipSymlinks(files []archives.FileInfo) {
+	for i := range files {
+		if len(files[i].LinkTarget) == 0 {
+			continue
+		}
+		info, target := files[i].FileInfo, files[i].LinkTarget
+		files[i].Open = func() (fs.File, error) {
+			return &linkFile{Reader: strings.NewReader(target), info: info}, nil
+		}
+	}
+}
+
+// linkFile is a symbolic link opened as a file with its target as content.
+type linkFile struct {
+	*strings.Reader
+	info fs.FileInfo
+}
+
+func (f *linkFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *linkFile) Close() error {
+	return nil
+}
+
+// Unarchive extracts the zip, tar, tar.gz or tar.zst archive at src to the
+// directory dst, keeping the permissions of the files and the symbolic links
+// which stay inside dst. Files are only extracted inside dst: paths of the
+// archive out of it, or through its symbolic links, are rejected.
+func Unarchive(ctx context.Context, src string, dst string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	format, err := identifyArchive(ctx, src, file)
+	if err != nil {
+		return fmt.Errorf("failed to identify archive format: %w", err)
+	}
+	if len(format) == 0 {
+		return fmt.Errorf("file %s is not an archive of a supported format %v", src, ArchiveFormats)
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dst, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	root, err := os.OpenRoot(dst)
+	if err != nil {
+		return fmt.Errorf("failed to open destination directory: %w", err)
+	}
+	defer root.Close()
+
+	x := &extractor{root: root, dst: dst, dirModes: make(map[string]fs.FileMode)}
+	err = format.archiver().Extract(ctx, file, x.extract)
+	if err != nil {
+		return err
+	}
+	// directories are made writable until all their files are extracted
+	for name, mode := range x.dirModes {
+		err = os.Chmod(filepath.Join(dst, name), mode)
+		if err != nil {
+			return fmt.Errorf("failed to set directory permissions: %w", err)
+		}
+	}
+	return nil
+}
+
+// extractor extracts the files of an archive into root, which is at dst.
+// os.Root keeps the files opened in root, while the parents of symbolic
+// links are checked to be directories so that their targets are resolved
+// from where they're checked.
+type extractor struct {
+	root     *os.Root
+	dst      string
+	dirModes map[string]fs.FileMode
+}
+
+func (x *extractor) extract(ctx context.Context, f archives.FileInfo) error {
+	name := filepath.Clean(filepath.FromSlash(f.NameInArchive))
+	if name == "." {
+		return nil
+	}
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("invalid path %q in archive", f.NameInArchive)
+	}
+	err := x.mkdirAll(filepath.Dir(name))
+	if err != nil {
+		return err
+	}
+
+	hdr, _ := f.Header.(*tar.Header)
+	switch {
+	case f.IsDir():
+		err = x.mkdirAll(name)
+		if err != nil {
+			return err
+		}
+		x.dirModes[name] = f.Mode().Perm()
+		return nil
+	case f.Mode()&fs.ModeSymlink != 0:
+		return x.symlink(f, name)
+	case hdr != nil && hdr.Typeflag == tar.TypeLink:
+		return x.hardlink(f, name)
+	case f.Mode().IsRegular():
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open file in archive: %w", err)
+		}
+		defer rc.Close()
+		return x.writeFile(name, rc, f.Mode().Perm())
+	default:
+		// special files, like devices, aren't extracted
+		return nil
+	}
+}
+
+// mkdirAll creates the directory at name and its parents, which must be
+// directories and not symbolic links.
+func (x *extractor) mkdirAll(name string) error {
+	if name == "." {
+		return nil
+	}
+	var dir string
+	for _, elem := range strings.Split(name, string(filepath.Separator)) {
+		dir = filepath.Join(dir, elem)
+		info, err := x.root.Lstat(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = x.root.Mkdir(dir, 0755)
+			if err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("path %q in archive is not under a directory", name)
+		}
+	}
+	return nil
+}
+
+// remove removes the file the archive has again at name, if any.
+func (x *extractor) remove(name string) error {
+	info, err := x.root.Lstat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("path %q in archive is a directory and a file", name)
+	}
+	return x.root.Remove(name)
+}
+
+func (x *extractor) writeFile(name string, r io.Reader, perm fs.FileMode) error {
+	err := x.remove(name)
+	if err != nil {
+		return err
+	}
+	destFile, err := x.root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create file in destination: %w", err)
+	}
+	defer destFile.Close()
+	_, err = io.Copy(destFile, r)
+	if err != nil {
+		return fmt.Errorf("failed to copy file contents: %w", err)
+	}
+	// the mode of the file isn't masked by the umask
+	err = destFile.Chmod(perm)
+	if err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	return destFile.Close()
+}
+
+func (x *extractor) symlink(f archives.FileInfo, name string) error {
+	target := f.LinkTarget
+	if len(target) == 0 {
+		// zip archives have the targets of symbolic links as contents
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open file in archive: %w", err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(io.LimitReader(rc, 4096))
+		if err != nil {
+			return fmt.Errorf("failed to read symbolic link in archive: %w", err)
+		}
+		target = string(data)
+	}
+	target = filepath.FromSlash(target)
+	if !x.isLocalLinkTarget(name, target) {
+		return fmt.Errorf("symbolic link %q in archive points outside of the archive to %q", f.NameInArchive, target)
+	}
+	err := x.remove(name)
+	if err != nil {
+		return err
+	}
+	// the parents of the link are directories of root, checked by mkdirAll
+	return os.Symlink(target, filepath.Join(x.dst, name))
+}
+
+// isLocalLinkTarget returns true if the target of the symbolic link at name
+// resolves inside root. The parents of the link are directories, checked by
+// mkdirAll. Following the target, ".." must go up from a directory of root,
+// not from a symbolic link which could point anywhere inside root, so that
+// chains of links, including links extracted later, stay inside root.
+func (x *extractor) isLocalLinkTarget(name string, target string) bool {
+	if filepath.IsAbs(target) {
+		return false
+	}
+	var dir []string
+	if parent := filepath.Dir(name); parent != "." {
+		dir = strings.Split(parent, string(filepath.Separator))
+	}
+	for _, elem := range strings.Split(target, string(filepath.Separator)) {
+		switch elem {
+		case "", ".":
+		case "..":
+			if len(dir) == 0 {
+				return false
+			}
+			info, err := x.root.Lstat(filepath.Join(dir...))
+			if err != nil || !info.IsDir() {
+				return false
+			}
+			dir = dir[:len(dir)-1]
+		default:
+			dir = append(dir, elem)
+		}
+	}
+	return true
+}
+
+func (x *extractor) hardlink(f archives.FileInfo, name string) error {
+	target := filepath.Clean(filepath.FromSlash(f.LinkTarget))
+	if !filepath.IsLocal(target) {
+		return fmt.Errorf("hard link %q in archive points outside of the archive to %q", f.NameInArchive, f.LinkTarget)
+	}
+	// the content of the linked file is copied, os.Root keeps it in root
+	src, err := x.root.Open(target)
+	if err != nil {
+		return fmt.Errorf("failed to open target of hard link %q in archive: %w", f.NameInArchive, err)
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("hard link %q in archive doesn't point to a file", f.NameInArchive)
+	}
+	return x.writeFile(name, src, info.Mode().Perm())
+}
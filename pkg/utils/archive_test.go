@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArchiveFormat(t *testing.T) {
+	format, err := ParseArchiveFormat("")
+	require.NoError(t, err)
+	require.Equal(t, ArchiveFormatZip, format)
+	format, err = ParseArchiveFormat("tar.zst")
+	require.NoError(t, err)
+	require.Equal(t, ArchiveFormatTarZst, format)
+	require.Equal(t, ".tar.zst", format.Extension())
+	_, err = ParseArchiveFormat("rar")
+	require.Error(t, err)
+}
+
+func TestArchiveFormats(t *testing.T) {
+	ctx := context.Background()
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "bin", "handler"), []byte("#!/bin/sh\necho hello"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "config.json"), []byte("{}"), 0600))
+	require.NoError(t, os.Symlink(filepath.Join("bin", "handler"), filepath.Join(src, "run")))
+	// links to directories and dangling links, like editor locks, are kept
+	require.NoError(t, os.Symlink("bin", filepath.Join(src, "lib")))
+	require.NoError(t, os.Symlink("user@host.1234", filepath.Join(src, ".#config.json")))
+
+	for _, format := range ArchiveFormats {
+		t.Run(string(format), func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "archive"+format.Extension())
+			_, err := MakeArchiveWithGlobs(ctx, archivePath, format, src+"/*")
+			require.NoError(t, err)
+
+			// the format is identified by content, not by name
+			unnamed := filepath.Join(t.TempDir(), "archive")
+			require.NoError(t, os.Rename(archivePath, unnamed))
+			isArchive, err := IsArchive(ctx, unnamed)
+			require.NoError(t, err)
+			require.True(t, isArchive)
+
+			dst := filepath.Join(t.TempDir(), "dst")
+			require.NoError(t, Unarchive(ctx, unnamed, dst))
+
+			info, err := os.Stat(filepath.Join(dst, "bin", "handler"))
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+			info, err = os.Stat(filepath.Join(dst, "config.json"))
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			target, err := os.Readlink(filepath.Join(dst, "run"))
+			require.NoError(t, err)
+			require.Equal(t, filepath.Join("bin", "handler"), target)
+			target, err = os.Readlink(filepath.Join(dst, "lib"))
+			require.NoError(t, err)
+			require.Equal(t, "bin", target)
+			_, err = os.Lstat(filepath.Join(dst, ".#config.json"))
+			require.NoError(t, err)
+		})
+	}
+
+	isArchive, err := IsArchive(ctx, filepath.Join(src, "config.json"))
+	require.NoError(t, err)
+	require.False(t, isArchive)
+}
+
+// writeTar writes a tar archive of the headers, with the content of the
+// regular files.
+func writeTar(t *testing.T, headers ...*tar.Header) string {
+	path := filepath.Join(t.TempDir(), "archive.tar")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for _, hdr := range headers {
+		content := "content of " + hdr.Name
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(content))
+		}
+		if hdr.Mode == 0 {
+			hdr.Mode = 0644
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Typeflag == tar.TypeReg {
+			_, err = tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	return path
+}
+
+func TestUnarchiveUnsafePaths(t *testing.T) {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		name    string
+		headers []*tar.Header
+	}{
+		{
+			name:    "parent path",
+			headers: []*tar.Header{{Name: "../evil", Typeflag: tar.TypeReg}},
+		},
+		{
+			name:    "absolute path",
+			headers: []*tar.Header{{Name: "/tmp/evil", Typeflag: tar.TypeReg}},
+		},
+		{
+			name:    "symbolic link out of the archive",
+			headers: []*tar.Header{{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"}},
+		},
+		{
+			name:    "absolute symbolic link",
+			headers: []*tar.Header{{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc"}},
+		},
+		{
+			name: "file through symbolic link",
+			headers: []*tar.Header{
+				{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755},
+				{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "dir"},
+				{Name: "link/evil", Typeflag: tar.TypeReg},
+			},
+		},
+		{
+			name:    "hard link out of the archive",
+			headers: []*tar.Header{{Name: "link", Typeflag: tar.TypeLink, Linkname: "../evil"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dst := filepath.Join(dir, "dst")
+			require.Error(t, Unarchive(ctx, writeTar(t, test.headers...), dst))
+			require.NoFileExists(t, filepath.Join(dir, "evil"))
+			require.NoFileExists(t, filepath.Join(dst, "dir", "evil"))
+		})
+	}
+}
+
+func TestUnarchiveLinks(t *testing.T) {
+	ctx := context.Background()
+	dst := filepath.Join(t.TempDir(), "dst")
+	archive := writeTar(t,
+		&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0555},
+		&tar.Header{Name: "dir/file", Typeflag: tar.TypeReg, Mode: 0755},
+		&tar.Header{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "../file"},
+		&tar.Header{Name: "file", Typeflag: tar.TypeLink, Linkname: "dir/file"},
+		// links may point to links, and go up from directories
+		&tar.Header{Name: "chain", Typeflag: tar.TypeSymlink, Linkname: "dir/link"},
+		&tar.Header{Name: "up", Typeflag: tar.TypeSymlink, Linkname: "dir/../file"},
+	)
+	require.NoError(t, Unarchive(ctx, archive, dst))
+	t.Cleanup(func() {
+		_ = os.Chmod(filepath.Join(dst, "dir"), 0755)
+	})
+
+	// hard links are extracted as copies
+	content, err := os.ReadFile(filepath.Join(dst, "dir", "link"))
+	require.NoError(t, err)
+	require.Equal(t, "content of dir/file", string(content))
+	info, err := os.Stat(filepath.Join(dst, "file"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	content, err = os.ReadFile(filepath.Join(dst, "chain"))
+	require.NoError(t, err)
+	require.Equal(t, "content of dir/file", string(content))
+	content, err = os.ReadFile(filepath.Join(dst, "up"))
+	require.NoError(t, err)
+	require.Equal(t, "content of dir/file", string(content))
+	// links going up from links could get out of dst
+	dir := t.TempDir()
+	err = Unarchive(ctx, writeTar(t,
+		&tar.Header{Name: "x/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "x/l1", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		&tar.Header{Name: "l2", Typeflag: tar.TypeSymlink, Linkname: "x/l1/../.."},
+	), filepath.Join(dir, "dst"))
+	require.ErrorContains(t, err, "points outside of the archive")
+	_, err = os.Lstat(filepath.Join(dir, "dst", "l2"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	// the modes of directories are set once their files are extracted
+	info, err = os.Stat(filepath.Join(dst, "dir"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0555), info.Mode().Perm())
+}
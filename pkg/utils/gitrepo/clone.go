@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// KnownHostsKey is the key of the host keys of SSH servers in the auth
+// secrets of repositories, in the known_hosts format.
+const KnownHostsKey = "known_hosts"
+
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsCommit returns whether the ref is a full commit hash, which always
+// checks out the same tree unlike branches and tags.
+func IsCommit(ref string) bool {
+	return commitRegexp.MatchString(ref)
+}
+
+// Clone checks out the ref of the repository at url into dir, which must not
+// exist or be empty, and returns the checked out commit. The ref is a
+// branch, a tag, a full reference name or a full commit hash, the default
+// branch if empty. auth may be nil for public repositories.
+func Clone(ctx context.Context, url string, ref string, auth transport.AuthMethod, dir string) (string, error) {
+	opts := &git.CloneOptions{
+		URL:          url,
+		Auth:         auth,
+		SingleBranch: true,
+		Depth:        1,
+	}
+
+	var repo *git.Repository
+	var err error
+	switch {
+	case len(ref) == 0:
+		repo, err = git.PlainCloneContext(ctx, dir, false, opts)
+	case IsCommit(ref):
+		// servers don't have to serve commits which aren't the tip of a
+		// reference, so the whole history is cloned
+		opts.SingleBranch, opts.Depth, opts.NoCheckout = false, 0, true
+		repo, err = git.PlainCloneContext(ctx, dir, false, opts)
+		if err == nil {
+			err = checkout(repo, plumbing.NewHash(ref))
+		}
+	case strings.HasPrefix(ref, "refs/"):
+		opts.ReferenceName = plumbing.ReferenceName(ref)
+		repo, err = git.PlainCloneContext(ctx, dir, false, opts)
+	default:
+		opts.ReferenceName = plumbing.NewBranchReferenceName(ref)
+		repo, err = git.PlainCloneContext(ctx, dir, false, opts)
+		if errors.Is(err, git.NoMatchingRefSpecError{}) {
+			// the partial clone of the branch is removed to clone the tag
+			err = removeContents(dir)
+			if err == nil {
+				opts.ReferenceName = plumbing.NewTagReferenceName(ref)
+				repo, err = git.PlainCloneContext(ctx, dir, false, opts)
+			}
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("error checking out %q of git repository %s: %w", ref, url, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("error getting checked out commit of git repository %s: %w", url, err)
+	}
+	return head.Hash().String(), nil
+}
+
+func checkout(repo *git.Repository, commit plumbing.Hash) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return worktree.Checkout(&git.CheckoutOptions{Hash: commit})
+}
+
+// removeContents removes the contents of the directory, but not the
+// directory itself. Failed clones remove the directories they created.
+func removeContents(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		err = os.RemoveAll(dir + string(os.PathSeparator) + entry.Name())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AuthFromSecret returns the credentials of the repository at url in the
+// secret: a token or password, with an optional username, as in secrets of
+// type kubernetes.io/basic-auth, or a private key and the known host keys,
+// as in secrets of type kubernetes.io/ssh-auth.
+func AuthFromSecret(url string, secret *apiv1.Secret) (transport.AuthMethod, error) {
+	if key, ok := secret.Data[apiv1.SSHAuthPrivateKey]; ok {
+		knownHosts, ok := secret.Data[KnownHostsKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s has an SSH private key and no %s key with the host keys of the server",
+				secret.Namespace, secret.Name, KnownHostsKey)
+		}
+		user := "git"
+		if ep, err := transport.NewEndpoint(url); err == nil && len(ep.User) > 0 {
+			user = ep.User
+		}
+		auth, err := gitssh.NewPublicKeys(user, key, "")
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SSH private key of secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		// the known hosts are parsed from files only
+		knownHostsFile, err := writeTempFile(knownHosts)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(knownHostsFile)
+		auth.HostKeyCallback, err = gitssh.NewKnownHostsCallback(knownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s of secret %s/%s: %w", KnownHostsKey, secret.Namespace, secret.Name, err)
+		}
+		return auth, nil
+	}
+
+	if password, ok := secret.Data[apiv1.BasicAuthPasswordKey]; ok {
+		// hosting services accept tokens with any username
+		username := string(secret.Data[apiv1.BasicAuthUsernameKey])
+		if len(username) == 0 {
+			username = "git"
+		}
+		return &githttp.BasicAuth{Username: username, Password: string(password)}, nil
+	}
+
+	return nil, fmt.Errorf("secret %s/%s has no %s nor %s key with the credentials of the repository",
+		secret.Namespace, secret.Name, apiv1.BasicAuthPasswordKey, apiv1.SSHAuthPrivateKey)
+}
+
+func writeTempFile(data []byte) (string, error) {
+	f, err := os.CreateTemp("", "known_hosts-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// makeRepo makes a repository with two commits on master, the first one
+// tagged v1, and a dev branch from the first one with a third commit.
+func makeRepo(t *testing.T) (dir string, commits []string) {
+	dir = t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(file string, content string) plumbing.Hash {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+		_, err := worktree.Add(file)
+		require.NoError(t, err)
+		hash, err := worktree.Commit(content, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@fission.io", When: time.Now()},
+		})
+		require.NoError(t, err)
+		commits = append(commits, hash.String())
+		return hash
+	}
+
+	first := commit("main.py", "v1")
+	_, err = repo.CreateTag("v1", first, nil)
+	require.NoError(t, err)
+	commit("main.py", "v2")
+
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Hash: first, Branch: plumbing.NewBranchReferenceName("dev"), Create: true}))
+	commit("dev.py", "dev")
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.Master}))
+	return dir, commits
+}
+
+func TestClone(t *testing.T) {
+	url, commits := makeRepo(t)
+
+	for _, test := range []struct {
+		name    string
+		ref     string
+		commit  string
+		content string
+	}{
+		{name: "default branch", ref: "", commit: commits[1], content: "v2"},
+		{name: "branch", ref: "dev", commit: commits[2], content: "v1"},
+		{name: "tag", ref: "v1", commit: commits[0], content: "v1"},
+		{name: "reference", ref: "refs/heads/master", commit: commits[1], content: "v2"},
+		{name: "commit", ref: commits[0], commit: commits[0], content: "v1"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "clone")
+			commit, err := Clone(context.Background(), url, test.ref, nil, dir)
+			require.NoError(t, err)
+			require.Equal(t, test.commit, commit)
+			content, err := os.ReadFile(filepath.Join(dir, "main.py"))
+			require.NoError(t, err)
+			require.Equal(t, test.content, string(content))
+		})
+	}
+
+	t.Run("missing ref", func(t *testing.T) {
+		_, err := Clone(context.Background(), url, "missing", nil, filepath.Join(t.TempDir(), "clone"))
+		require.Error(t, err)
+	})
+}
+
+func TestAuthFromSecret(t *testing.T) {
+	secret := func(data map[string]string) *apiv1.Secret {
+		s := &apiv1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"}, Data: map[string][]byte{}}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+
+	auth, err := AuthFromSecret("https://github.com/fission/fission.git", secret(map[string]string{apiv1.BasicAuthPasswordKey: "token"}))
+	require.NoError(t, err)
+	require.Equal(t, &githttp.BasicAuth{Username: "git", Password: "token"}, auth)
+
+	auth, err = AuthFromSecret("https://github.com/fission/fission.git", secret(map[string]string{
+		apiv1.BasicAuthUsernameKey: "user", apiv1.BasicAuthPasswordKey: "password",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, &githttp.BasicAuth{Username: "user", Password: "password"}, auth)
+
+	_, err = AuthFromSecret("git@github.com:fission/fission.git", secret(map[string]string{apiv1.SSHAuthPrivateKey: "key"}))
+	require.ErrorContains(t, err, KnownHostsKey)
+
+	_, err = AuthFromSecret("https://github.com/fission/fission.git", secret(nil))
+	require.Error(t, err)
+}
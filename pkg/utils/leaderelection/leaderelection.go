@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// ENV_LEADER_ELECTION_ENABLED enables leader election for components
+	// which may run with more than one replica.
+	ENV_LEADER_ELECTION_ENABLED = "LEADER_ELECTION_ENABLED"
+	// ENV_LEADER_ELECTION_NAMESPACE is the namespace of the Lease object.
+	// It defaults to POD_NAMESPACE.
+	ENV_LEADER_ELECTION_NAMESPACE = "LEADER_ELECTION_NAMESPACE"
+
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// Elector keeps track of whether this replica holds the component Lease.
+// Work which must only happen on a single replica, such as reconcilers
+// and reapers, should check IsLeader before acting.
+//
+// A nil *Elector behaves as a permanent leader, so single replica
+// deployments don't need to special case it.
+type Elector struct {
+	logger   *zap.Logger
+	identity string
+	elector  *leaderelection.LeaderElector
+	isLeader atomic.Bool
+
+	lock             sync.Mutex
+	onStartedLeading []func(context.Context)
+}
+
+// Enabled returns true if leader election is enabled via environment.
+func Enabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(ENV_LEADER_ELECTION_ENABLED))
+	return enabled
+}
+
+// New returns an Elector competing for the Lease with the given name.
+func New(logger *zap.Logger, kubernetesClient kubernetes.Interface, name, identity string) (*Elector, error) {
+	namespace := os.Getenv(ENV_LEADER_ELECTION_NAMESPACE)
+	if namespace == "" {
+		namespace = os.Getenv("POD_NAMESPACE")
+	}
+	if namespace == "" {
+		return nil, errors.New("leader election namespace not set, set LEADER_ELECTION_NAMESPACE or POD_NAMESPACE")
+	}
+
+	e := &Elector{
+		logger:   logger.Named("leader_election").With(zap.String("lease", name), zap.String("identity", identity)),
+		identity: identity,
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Client: kubernetesClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            name,
+		LeaseDuration:   defaultLeaseDuration,
+		RenewDeadline:   defaultRenewDeadline,
+		RetryPeriod:     defaultRetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: e.startedLeading,
+			OnStoppedLeading: e.stoppedLeading,
+			OnNewLeader: func(identity string) {
+				e.logger.Info("observed new leader", zap.String("leader", identity))
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.elector = elector
+	return e, nil
+}
+
+// OnStartedLeading registers a function called each time this replica
+// acquires the lease. The context is canceled once the lease is lost.
+func (e *Elector) OnStartedLeading(f func(context.Context)) {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.onStartedLeading = append(e.onStartedLeading, f)
+}
+
+// Identity returns the identity of this replica in the election.
+func (e *Elector) Identity() string {
+	if e == nil {
+		return ""
+	}
+	return e.identity
+}
+
+// IsLeader returns true if this replica currently holds the lease.
+func (e *Elector) IsLeader() bool {
+	if e == nil {
+		return true
+	}
+	return e.isLeader.Load()
+}
+
+// Run campaigns for the lease until ctx is done. Losing the lease
+// doesn't stop the replica, it keeps serving and campaigns again.
+func (e *Elector) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		e.elector.Run(ctx)
+	}
+}
+
+func (e *Elector) startedLeading(ctx context.Context) {
+	e.isLeader.Store(true)
+	e.logger.Info("started leading")
+
+	e.lock.Lock()
+	callbacks := append([]func(context.Context){}, e.onStartedLeading...)
+	e.lock.Unlock()
+
+	for _, f := range callbacks {
+		go f(ctx)
+	}
+}
+
+func (e *Elector) stoppedLeading() {
+	e.isLeader.Store(false)
+	e.logger.Info("stopped leading")
+}
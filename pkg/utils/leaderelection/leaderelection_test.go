@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNilElector(t *testing.T) {
+	var e *Elector
+	require.True(t, e.IsLeader())
+	require.Empty(t, e.Identity())
+	// callbacks of a nil elector are never called
+	e.OnStartedLeading(func(context.Context) {
+		t.Error("callback of nil elector called")
+	})
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv(ENV_LEADER_ELECTION_ENABLED, "")
+	require.False(t, Enabled())
+	t.Setenv(ENV_LEADER_ELECTION_ENABLED, "true")
+	require.True(t, Enabled())
+}
+
+func TestNewNamespace(t *testing.T) {
+	t.Setenv(ENV_LEADER_ELECTION_NAMESPACE, "")
+	t.Setenv("POD_NAMESPACE", "")
+	_, err := New(zap.NewNop(), fake.NewSimpleClientset(), "executor", "replica-a")
+	require.Error(t, err)
+}
+
+func TestElector(t *testing.T) {
+	t.Setenv(ENV_LEADER_ELECTION_NAMESPACE, "fission")
+	kubernetesClient := fake.NewSimpleClientset()
+	e, err := New(zap.NewNop(), kubernetesClient, "executor", "replica-a")
+	require.NoError(t, err)
+	require.Equal(t, "replica-a", e.Identity())
+	require.False(t, e.IsLeader())
+
+	leading := make(chan context.Context, 1)
+	e.OnStartedLeading(func(ctx context.Context) {
+		leading <- ctx
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx)
+	}()
+
+	var leaderCtx context.Context
+	select {
+	case leaderCtx = <-leading:
+	case <-time.After(10 * time.Second):
+		t.Fatal("replica didn't acquire the lease")
+	}
+	require.True(t, e.IsLeader())
+	lease, err := kubernetesClient.CoordinationV1().Leases("fission").Get(t.Context(), "executor", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "replica-a", *lease.Spec.HolderIdentity)
+
+	// leadership ends with the context of the callbacks
+	cancel()
+	<-done
+	require.False(t, e.IsLeader())
+	require.Error(t, leaderCtx.Err())
+}
@@ -0,0 +1,510 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// ArtifactType is the artifact type of the manifests of package archives.
+	ArtifactType = "application/vnd.fission.package.v1"
+
+	// ArchiveMediaType is the media type of the layer holding the archive.
+	ArchiveMediaType = "application/vnd.fission.package.archive.v1"
+
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	emptyMediaType    = "application/vnd.oci.empty.v1+json"
+	titleAnnotation   = "org.opencontainers.image.title"
+
+	// maxManifestSize is the size of the largest manifest read from a
+	// registry.
+	maxManifestSize = 4 * 1024 * 1024
+
+	// maxErrorSize is how much of the body of an error response is read.
+	maxErrorSize = 4 * 1024
+)
+
+// emptyConfig is the config of the package artifacts, which have none.
+var emptyConfig = []byte("{}")
+
+type (
+	// Descriptor describes the content of a blob.
+	Descriptor struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Size        int64             `json:"size"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+
+	manifest struct {
+		SchemaVersion int          `json:"schemaVersion"`
+		MediaType     string       `json:"mediaType,omitempty"`
+		ArtifactType  string       `json:"artifactType,omitempty"`
+		Config        Descriptor   `json:"config"`
+		Layers        []Descriptor `json:"layers"`
+	}
+
+	// Client pulls and pushes package archives from and to registries.
+	Client struct {
+		httpClient *http.Client
+		keychain   Keychain
+		plainHTTP  map[string]bool
+
+		lock sync.Mutex
+		// authorization headers by registry and scope
+		auths map[string]string
+	}
+)
+
+// MakeClient returns a client authenticating to registries with the
+// credentials of the keychain, if any. The registries of plainHTTPRegistries
+// are reached over plain HTTP instead of HTTPS.
+func MakeClient(httpClient *http.Client, keychain Keychain, plainHTTPRegistries []string) *Client {
+	plainHTTP := make(map[string]bool, len(plainHTTPRegistries))
+	for _, registry := range plainHTTPRegistries {
+		plainHTTP[registry] = true
+	}
+	if keychain == nil {
+		keychain = MakeKeychain()
+	}
+	return &Client{
+		httpClient: httpClient,
+		keychain:   keychain,
+		plainHTTP:  plainHTTP,
+		auths:      make(map[string]string),
+	}
+}
+
+// Pull downloads the archive of the package artifact to path. The reference
+// must be pinned by digest, the manifest is verified against it and the
+// archive against the digest of the manifest. It returns the descriptor of
+// the archive.
+func (c *Client) Pull(ctx context.Context, ref *Reference, path string) (*Descriptor, error) {
+	if !ref.Pinned() {
+		return nil, fmt.Errorf("error pulling %v: the reference has no digest", ref)
+	}
+	m, err := c.getManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := getArchiveLayer(m)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", ref, err)
+	}
+
+	resp, err := c.do(ctx, ref, "pull", func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, c.url(ref, "blobs", layer.Digest), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), resp.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error downloading archive of %v: %w", ref, err)
+	}
+	if digest := "sha256:" + hex.EncodeToString(h.Sum(nil)); digest != layer.Digest || n != layer.Size {
+		return nil, fmt.Errorf("archive of %v doesn't match its manifest: got %s of %d bytes, expected %s of %d bytes",
+			ref, digest, n, layer.Digest, layer.Size)
+	}
+	return layer, nil
+}
+
+// Push uploads the archive at path as a package artifact with the tag of
+// the reference. It returns the reference to the artifact by digest.
+func (c *Client) Push(ctx context.Context, ref *Reference, path string) (*Reference, error) {
+	if len(ref.Digest) > 0 {
+		return nil, fmt.Errorf("can't push to %v: the reference must have a tag, not a digest", ref)
+	}
+
+	layer, err := describeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := describeBytes(emptyMediaType, emptyConfig)
+
+	err = c.pushBlob(ctx, ref, config, func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(emptyConfig)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = c.pushBlob(ctx, ref, layer, func() (io.ReadCloser, error) {
+		return os.Open(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(manifest{
+		SchemaVersion: 2,
+		MediaType:     manifestMediaType,
+		ArtifactType:  ArtifactType,
+		Config:        *config,
+		Layers:        []Descriptor{*layer},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, ref, "pull,push", func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url(ref, "manifests", ref.Tag), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", manifestMediaType)
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, responseError(resp)
+	}
+
+	return &Reference{
+		Registry:   ref.Registry,
+		Repository: ref.Repository,
+		Digest:     describeBytes(manifestMediaType, body).Digest,
+	}, nil
+}
+
+// getManifest returns the manifest of the artifact.
+func (c *Client) getManifest(ctx context.Context, ref *Reference) (*manifest, error) {
+	resp, err := c.do(ctx, ref, "pull", func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(ref, "manifests", ref.ref()), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", manifestMediaType)
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest of %v: %w", ref, err)
+	}
+	if len(body) > maxManifestSize {
+		return nil, fmt.Errorf("manifest of %v is larger than %d bytes", ref, maxManifestSize)
+	}
+	if len(ref.Digest) > 0 {
+		if digest := describeBytes(manifestMediaType, body).Digest; digest != ref.Digest {
+			return nil, fmt.Errorf("manifest of %v has digest %s", ref, digest)
+		}
+	}
+
+	var m manifest
+	err = json.Unmarshal(body, &m)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing manifest of %v: %w", ref, err)
+	}
+	if m.SchemaVersion != 2 || (len(m.MediaType) > 0 && m.MediaType != manifestMediaType) {
+		return nil, fmt.Errorf("%v is not an OCI image manifest, its media type is %q", ref, m.MediaType)
+	}
+	return &m, nil
+}
+
+// getArchiveLayer returns the layer of the manifest holding the archive: the
+// one with the archive media type, or the only layer of artifacts pushed by
+// other tools.
+func getArchiveLayer(m *manifest) (*Descriptor, error) {
+	for i := range m.Layers {
+		if m.Layers[i].MediaType == ArchiveMediaType {
+			return &m.Layers[i], nil
+		}
+	}
+	if len(m.Layers) == 1 {
+		return &m.Layers[0], nil
+	}
+	return nil, fmt.Errorf("artifact has %d layers and none of media type %s", len(m.Layers), ArchiveMediaType)
+}
+
+// pushBlob uploads the blob unless the repository already has it.
+func (c *Client) pushBlob(ctx context.Context, ref *Reference, desc *Descriptor, open func() (io.ReadCloser, error)) error {
+	resp, err := c.do(ctx, ref, "pull,push", func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodHead, c.url(ref, "blobs", desc.Digest), nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = c.do(ctx, ref, "pull,push", func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPost, c.url(ref, "blobs", "uploads")+"/", nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return responseError(resp)
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("invalid blob upload location: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", desc.Digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, ref, "pull,push", func() (*http.Request, error) {
+		body, err := open()
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, location.String(), body)
+		if err != nil {
+			body.Close()
+			return nil, err
+		}
+		req.ContentLength = desc.Size
+		req.Header.Set("Content-Type", "application/octet-stream")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// url returns the URL of an endpoint of the repository of the reference.
+func (c *Client) url(ref *Reference, kind string, name string) string {
+	scheme := "https"
+	if c.plainHTTP[ref.Registry] {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, ref.Registry, ref.Repository, kind, name)
+}
+
+// do sends the request made by newReq with the authorization to the
+// repository of the reference for the actions, authenticating and sending it
+// again if the registry requires it.
+func (c *Client) do(ctx context.Context, ref *Reference, actions string, newReq func() (*http.Request, error)) (*http.Response, error) {
+	scope := fmt.Sprintf("repository:%s:%s", ref.Repository, actions)
+	key := ref.Registry + " " + scope
+
+	req, err := newReq()
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	auth := c.auths[key]
+	c.lock.Unlock()
+	if len(auth) > 0 {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	auth, err = c.authenticate(ctx, ref.Registry, scope, challenge)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	c.auths[key] = auth
+	c.lock.Unlock()
+
+	req, err = newReq()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", auth)
+	return c.httpClient.Do(req)
+}
+
+// authenticate returns the authorization header answering the challenge of
+// the registry, with the credentials of the registry if any.
+func (c *Client) authenticate(ctx context.Context, registry string, scope string, challenge string) (string, error) {
+	creds := c.keychain(registry)
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if creds == nil {
+			return "", fmt.Errorf("registry %s requires authentication and has no credentials", registry)
+		}
+		return "Basic " + basicAuth(creds), nil
+	case "bearer":
+		return c.getToken(ctx, registry, creds, params, scope)
+	default:
+		return "", fmt.Errorf("registry %s requires unsupported authentication %q", registry, challenge)
+	}
+}
+
+// getToken gets a bearer token from the token server of the challenge.
+func (c *Client) getToken(ctx context.Context, registry string, creds *Credentials, params map[string]string, scope string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || len(realm.Host) == 0 {
+		return "", fmt.Errorf("registry %s has an invalid token realm %q", registry, params["realm"])
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	if s, ok := params["scope"]; ok {
+		scope = s
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if creds != nil {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error getting token of registry %s: %w", registry, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error getting token of registry %s: %w", registry, responseError(resp))
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token)
+	if err != nil {
+		return "", fmt.Errorf("error parsing token of registry %s: %w", registry, err)
+	}
+	if len(token.Token) == 0 {
+		token.Token = token.AccessToken
+	}
+	if len(token.Token) == 0 {
+		return "", fmt.Errorf("registry %s returned no token", registry)
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like
+// Bearer realm="https://auth.example.com/token",service="registry".
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+	for {
+		rest = strings.TrimLeft(rest, ", ")
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			return scheme, params
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				return scheme, params
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(value)
+		}
+	}
+}
+
+func basicAuth(creds *Credentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))
+}
+
+// responseError returns an error describing the failed response.
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+	msg := strings.TrimSpace(string(body))
+	if len(msg) == 0 {
+		return fmt.Errorf("%s %s: %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status)
+	}
+	return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status, msg)
+}
+
+func describeBytes(mediaType string, data []byte) *Descriptor {
+	sum := sha256.Sum256(data)
+	return &Descriptor{
+		MediaType: mediaType,
+		Digest:    "sha256:" + hex.EncodeToString(sum[:]),
+		Size:      int64(len(data)),
+	}
+}
+
+func describeFile(path string) (*Descriptor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("archive is empty")
+	}
+	return &Descriptor{
+		MediaType:   ArchiveMediaType,
+		Digest:      "sha256:" + hex.EncodeToString(h.Sum(nil)),
+		Size:        n,
+		Annotations: map[string]string{titleAnnotation: filepath.Base(path)},
+	}, nil
+}
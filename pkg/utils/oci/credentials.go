@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+type (
+	// Credentials authenticate to a registry.
+	Credentials struct {
+		Username string
+		Password string
+	}
+
+	// Keychain returns the credentials for a registry host, or nil if it
+	// has none.
+	Keychain func(registry string) *Credentials
+
+	// dockerConfig is the format of docker config files and of the image
+	// pull secrets of Kubernetes.
+	dockerConfig struct {
+		Auths map[string]dockerAuth `json:"auths"`
+	}
+
+	dockerAuth struct {
+		Auth     string `json:"auth,omitempty"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+	}
+)
+
+// ParseDockerConfig returns the credentials of the docker config file, or
+// of the legacy .dockercfg format, by registry host.
+func ParseDockerConfig(data []byte) (map[string]*Credentials, error) {
+	var config dockerConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing docker config: %w", err)
+	}
+	auths := config.Auths
+	if auths == nil {
+		// the legacy format has the auths at the top level
+		err = json.Unmarshal(data, &auths)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing docker config: %w", err)
+		}
+	}
+
+	credentials := make(map[string]*Credentials, len(auths))
+	for server, auth := range auths {
+		creds := &Credentials{Username: auth.Username, Password: auth.Password}
+		if len(auth.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding auth of registry %s: %w", server, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth of registry %s", server)
+			}
+			creds.Username, creds.Password = username, password
+		}
+		credentials[registryHost(server)] = creds
+	}
+	return credentials, nil
+}
+
+// ParsePullSecret returns the credentials of an image pull secret, of type
+// kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg.
+func ParsePullSecret(secret *apiv1.Secret) (map[string]*Credentials, error) {
+	data, ok := secret.Data[apiv1.DockerConfigJsonKey]
+	if !ok {
+		data, ok = secret.Data[apiv1.DockerConfigKey]
+	}
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s is not an image pull secret, it has no %s or %s key",
+			secret.Namespace, secret.Name, apiv1.DockerConfigJsonKey, apiv1.DockerConfigKey)
+	}
+	return ParseDockerConfig(data)
+}
+
+// MakeKeychain returns a keychain looking up the credentials of a registry
+// in the given credentials in order.
+func MakeKeychain(credentials ...map[string]*Credentials) Keychain {
+	return func(registry string) *Credentials {
+		for _, creds := range credentials {
+			if c, ok := creds[registry]; ok {
+				return c
+			}
+		}
+		return nil
+	}
+}
+
+// registryHost returns the host of a registry server of a docker config,
+// which may be a URL like https://index.docker.io/v1/.
+func registryHost(server string) string {
+	host := server
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	host, _, _ = strings.Cut(host, "/")
+	return host
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// testRegistry is a minimal registry requiring bearer tokens.
+type testRegistry struct {
+	lock      sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != "user" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "t0k3n"})
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer t0k3n" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	path := strings.TrimPrefix(req.URL.Path, "/v2/functions/hello/")
+	switch {
+	case strings.HasPrefix(path, "blobs/uploads/") && req.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/functions/hello/blobs/uploads/1?state=x")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(path, "blobs/uploads/") && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		r.blobs[req.URL.Query().Get("digest")] = data
+		r.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "blobs/"):
+		data, ok := r.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case strings.HasPrefix(path, "manifests/") && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		sum := sha256.Sum256(data)
+		r.manifests[strings.TrimPrefix(path, "manifests/")] = data
+		r.manifests["sha256:"+hex.EncodeToString(sum[:])] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "manifests/"):
+		data, ok := r.manifests[strings.TrimPrefix(path, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPushPull(t *testing.T) {
+	registry := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "deploy.zip")
+	require.NoError(t, os.WriteFile(src, []byte("archive content"), 0644))
+
+	ref, err := ParseReference(Scheme + host + "/functions/hello:v1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// without credentials the token server rejects the client
+	client := MakeClient(server.Client(), nil, []string{host})
+	_, err = client.Push(ctx, ref, src)
+	require.Error(t, err)
+
+	keychain := MakeKeychain(map[string]*Credentials{host: {Username: "user", Password: "secret"}})
+	client = MakeClient(server.Client(), keychain, []string{host})
+	pushed, err := client.Push(ctx, ref, src)
+	require.NoError(t, err)
+	require.Empty(t, pushed.Tag)
+	require.True(t, digestRegexp.MatchString(pushed.Digest))
+	require.Equal(t, 2, registry.uploads)
+
+	// blobs the registry has aren't uploaded again
+	_, err = client.Push(ctx, ref, src)
+	require.NoError(t, err)
+	require.Equal(t, 2, registry.uploads)
+
+	dst := filepath.Join(dir, "pulled.zip")
+	layer, err := client.Pull(ctx, pushed, dst)
+	require.NoError(t, err)
+	require.Equal(t, ArchiveMediaType, layer.MediaType)
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "archive content", string(data))
+
+	// tags can be moved to other artifacts, so archives are pulled by digest only
+	_, err = client.Pull(ctx, ref, filepath.Join(dir, "tagged.zip"))
+	require.ErrorContains(t, err, "no digest")
+
+	// a tampered archive doesn't match the manifest
+	blobs := make(map[string][]byte)
+	for digest, blob := range registry.blobs {
+		blobs[digest] = blob
+		if digest != describeBytes(emptyMediaType, emptyConfig).Digest {
+			registry.blobs[digest] = []byte("tampered content")
+		}
+	}
+	_, err = client.Pull(ctx, pushed, filepath.Join(dir, "tampered.zip"))
+	require.ErrorContains(t, err, "doesn't match its manifest")
+	registry.blobs = blobs
+
+	// a tampered manifest doesn't match the digest of the reference
+	registry.manifests[pushed.Digest] = append(registry.manifests[pushed.Digest], ' ')
+	_, err = client.Pull(ctx, pushed, filepath.Join(dir, "tampered.zip"))
+	require.ErrorContains(t, err, "has digest")
+
+	_, err = client.Push(ctx, pushed, src)
+	require.Error(t, err)
+}
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	for s, expected := range map[string]*Reference{
+		"oci://ghcr.io/fission/hello":                  {Registry: "ghcr.io", Repository: "fission/hello", Tag: "latest"},
+		"oci://localhost:5000/hello:v1":                {Registry: "localhost:5000", Repository: "hello", Tag: "v1"},
+		"oci://localhost:5000/fission/hello@" + digest: {Registry: "localhost:5000", Repository: "fission/hello", Digest: digest},
+		"oci://ghcr.io/hello:v1@" + digest:             {Registry: "ghcr.io", Repository: "hello", Tag: "v1", Digest: digest},
+	} {
+		ref, err := ParseReference(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, ref, s)
+	}
+	require.Equal(t, "oci://ghcr.io/hello:v1@"+digest, (&Reference{Registry: "ghcr.io", Repository: "hello", Tag: "v1", Digest: digest}).String())
+
+	for _, s := range []string{
+		"ghcr.io/hello",
+		"oci://ghcr.io",
+		"oci:///hello",
+		"oci://ghcr.io/Hello",
+		"oci://ghcr.io/hello@sha256:abc",
+		"oci://ghcr.io/hello:-v1",
+	} {
+		_, err := ParseReference(s)
+		require.Error(t, err, s)
+	}
+
+	pinned, err := ParsePinnedReference("oci://ghcr.io/hello@" + digest)
+	require.NoError(t, err)
+	require.True(t, pinned.Pinned())
+	for _, s := range []string{"oci://ghcr.io/hello", "oci://ghcr.io/hello:v1"} {
+		_, err := ParsePinnedReference(s)
+		require.ErrorContains(t, err, "digest is required", s)
+	}
+}
+
+func TestParsePullSecret(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pa:ss"))
+	secret := &apiv1.Secret{
+		Data: map[string][]byte{
+			apiv1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"` + auth + `"},"ghcr.io":{"username":"u","password":"p"}}}`),
+		},
+	}
+	creds, err := ParsePullSecret(secret)
+	require.NoError(t, err)
+	require.Equal(t, &Credentials{Username: "user", Password: "pa:ss"}, creds["index.docker.io"])
+	require.Equal(t, &Credentials{Username: "u", Password: "p"}, creds["ghcr.io"])
+
+	// the legacy format
+	secret.Data = map[string][]byte{apiv1.DockerConfigKey: []byte(`{"quay.io":{"auth":"` + auth + `"}}`)}
+	legacy, err := ParsePullSecret(secret)
+	require.NoError(t, err)
+	require.Equal(t, "user", legacy["quay.io"].Username)
+
+	keychain := MakeKeychain(legacy, creds)
+	require.Equal(t, "u", keychain("ghcr.io").Username)
+	require.Nil(t, keychain("example.com"))
+
+	_, err = ParsePullSecret(&apiv1.Secret{Data: map[string][]byte{"token": nil}})
+	require.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:fission/hello:pull,push"`)
+	require.Equal(t, "Bearer", scheme)
+	require.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:fission/hello:pull,push",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	require.Equal(t, "Basic", scheme)
+	require.Equal(t, "registry", params["realm"])
+}
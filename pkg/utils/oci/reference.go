@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oci pulls and pushes package archives as OCI artifacts, following
+// the OCI distribution specification.
+package oci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Scheme is the URL scheme of the references to OCI artifacts.
+const Scheme = "oci://"
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	digestRegexp     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference references an OCI artifact by tag or digest, like
+// oci://registry.example.com/functions/hello@sha256:...
+type Reference struct {
+	// Registry is the host, and port if any, of the registry.
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// IsReference returns whether the URL references an OCI artifact.
+func IsReference(url string) bool {
+	return strings.HasPrefix(url, Scheme)
+}
+
+// ParseReference parses a reference to an OCI artifact, with the oci://
+// scheme. A reference without tag nor digest references the latest tag.
+func ParseReference(ref string) (*Reference, error) {
+	if !IsReference(ref) {
+		return nil, fmt.Errorf("invalid OCI reference %q: scheme must be %s", ref, Scheme)
+	}
+	rest := strings.TrimPrefix(ref, Scheme)
+
+	registry, name, ok := strings.Cut(rest, "/")
+	if !ok || len(registry) == 0 || strings.ContainsAny(registry, "@ ") {
+		return nil, fmt.Errorf("invalid OCI reference %q: registry and repository are required", ref)
+	}
+
+	r := &Reference{Registry: registry}
+	if i := strings.Index(name, "@"); i >= 0 {
+		r.Digest = name[i+1:]
+		name = name[:i]
+		if !digestRegexp.MatchString(r.Digest) {
+			return nil, fmt.Errorf("invalid OCI reference %q: digest must be sha256:<64 hex characters>", ref)
+		}
+	}
+	// the tag follows the last path segment, as the registry may have a port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		r.Tag = name[i+1:]
+		name = name[:i]
+		if !tagRegexp.MatchString(r.Tag) {
+			return nil, fmt.Errorf("invalid OCI reference %q: invalid tag %q", ref, r.Tag)
+		}
+	}
+	if !repositoryRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid OCI reference %q: invalid repository %q", ref, name)
+	}
+	r.Repository = name
+	if len(r.Tag) == 0 && len(r.Digest) == 0 {
+		r.Tag = "latest"
+	}
+	return r, nil
+}
+
+// ParsePinnedReference parses a reference to an OCI artifact pinned by
+// digest. Package archives are referenced this way, as the artifacts tags
+// reference can be replaced.
+func ParsePinnedReference(ref string) (*Reference, error) {
+	r, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !r.Pinned() {
+		return nil, fmt.Errorf("invalid OCI reference %q: digest is required, like %s@sha256:<digest>", ref, ref)
+	}
+	return r, nil
+}
+
+// Pinned returns whether the reference is pinned by digest.
+func (r Reference) Pinned() bool {
+	return len(r.Digest) > 0
+}
+
+// ref returns the digest of the artifact, or its tag if it has no digest,
+// to get its manifest.
+func (r Reference) ref() string {
+	if len(r.Digest) > 0 {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// String returns the reference with the oci:// scheme.
+func (r Reference) String() string {
+	s := Scheme + r.Registry + "/" + r.Repository
+	if len(r.Tag) > 0 {
+		s += ":" + r.Tag
+	}
+	if len(r.Digest) > 0 {
+		s += "@" + r.Digest
+	}
+	return s
+}
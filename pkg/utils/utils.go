@@ -254,28 +254,30 @@ func FindFreePort() (int, error) {
 
 // DeleteOldPackages deletes src and built deployment packages from builder's storage.
 // The function also verifies that sharedVolumePath for builder and fetcher containers
-// is /packages. A source_package contains a directory and a .tmp file while a deployment
-// package contains a directory and a .zip file.
+// is /packages. A source_package contains a directory, a .tmp file and, for git sources,
+// a .commit file while a deployment package contains a directory and a .zip file.
 func DeleteOldPackages(pkgPath, pkgType string) error {
 	sharedVolumePath := "/packages"
 	if !strings.HasPrefix(pkgPath, sharedVolumePath) {
 		return fmt.Errorf("invalid shared volume path: %s", pkgPath)
 	}
 
-	var file string
+	var files []string
 	if pkgType == "DEPLOY_PKG" {
-		file = pkgPath + ".zip"
+		files = []string{pkgPath + ".zip"}
 	} else if pkgType == "SRC_PKG" {
-		file = pkgPath + ".tmp"
+		files = []string{pkgPath + ".tmp", pkgPath + ".commit"}
 	}
 
 	err := os.RemoveAll(pkgPath)
 	if err != nil {
 		return err
 	}
-	err = os.RemoveAll(file)
-	if err != nil {
-		return err
+	for _, file := range files {
+		err = os.RemoveAll(file)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
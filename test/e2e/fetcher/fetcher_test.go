@@ -213,7 +213,7 @@ func (f *FetcherTestSuite) TestFetcherDeploymentType() {
 	_, err = cli.ExecCommand(f.framework, f.ctx, "function", "create", "--name", testDeployFuncName, "--pkg", testDeployPkg, "--entrypoint", "hello.main")
 	require.NoError(f.T(), err)
 
-	err = f.fetcherClient.Fetch(f.ctx, &fetcher.FunctionFetchRequest{
+	_, err = f.fetcherClient.Fetch(f.ctx, &fetcher.FunctionFetchRequest{
 		Filename:      "hello.py",
 		StorageSvcUrl: f.storagesvcURL,
 		KeepArchive:   true,
@@ -264,7 +264,7 @@ func (f *FetcherTestSuite) TestFetcherURLType() {
 	_, err = cli.ExecCommand(f.framework, f.ctx, "function", "create", "--name", testDeployFuncName, "--pkg", testDeployPkg, "--entrypoint", "hello.main")
 	require.NoError(f.T(), err)
 
-	err = f.fetcherClient.Fetch(f.ctx, &fetcher.FunctionFetchRequest{
+	_, err = f.fetcherClient.Fetch(f.ctx, &fetcher.FunctionFetchRequest{
 		Filename:      "new.py",
 		StorageSvcUrl: f.storagesvcURL,
 		KeepArchive:   true,
@@ -386,7 +386,7 @@ func (f *FetcherTestSuite) TestFetcherSpecialize() {
 	require.NoError(f.T(), err)
 
 	// test with EnvVersion v2
-	err = f.fetcherClient.Specialize(f.ctx, &fetcher.FunctionSpecializeRequest{
+	_, err = f.fetcherClient.Specialize(f.ctx, &fetcher.FunctionSpecializeRequest{
 		FetchReq: fetcher.FunctionFetchRequest{
 			Filename:      "hi.py",
 			StorageSvcUrl: f.storagesvcURL,
@@ -422,7 +422,7 @@ func (f *FetcherTestSuite) TestFetcherSpecialize() {
 	defer file.Close()
 
 	// test with no EnvVersion
-	err = f.fetcherClient.Specialize(f.ctx, &fetcher.FunctionSpecializeRequest{
+	_, err = f.fetcherClient.Specialize(f.ctx, &fetcher.FunctionSpecializeRequest{
 		FetchReq: fetcher.FunctionFetchRequest{
 			Filename:      "hi.py",
 			StorageSvcUrl: f.storagesvcURL,
@@ -447,7 +447,7 @@ func (f *FetcherTestSuite) TestFetcherSpecialize() {
 	// set throwError to true to test for error case
 	f.specTestData.throwError = true
 
-	err = f.fetcherClient.Specialize(f.ctx, &fetcher.FunctionSpecializeRequest{
+	_, err = f.fetcherClient.Specialize(f.ctx, &fetcher.FunctionSpecializeRequest{
 		FetchReq: fetcher.FunctionFetchRequest{
 			Filename:      "hi.py",
 			StorageSvcUrl: f.storagesvcURL,